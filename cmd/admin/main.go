@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+
+	"github.com/estrys/estrys/cmd"
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/domain"
+	"github.com/estrys/estrys/internal/logger"
+)
+
+const usage = `Usage:
+  admin follows list [-user username]   list the follow requests waiting for approval
+  admin follows approve <follow id>     accept a pending follow request
+  admin follows reject <follow id>      reject a pending follow request
+  admin follows expire                  reject the follow requests that have not been approved in time
+  admin users migrate <user> <actor>    move the followers of a bridged user to its fediverse account
+  admin users candidates [-user username]
+                                        list the fediverse accounts found in the twitter profile of bridged users
+  admin users scan                      look for fediverse accounts in the twitter profile of bridged users
+  admin users opt-out <user>            stop bridging a twitter user and delete its actor from its followers' servers
+  admin users opt-in <user>             remove a twitter user from the opt-out registry
+  admin users opt-outs                  list the twitter users who refused to be bridged
+`
+
+var errUsage = errors.New("invalid command")
+
+func main() {
+	if len(os.Args) < 3 || (os.Args[1] != "follows" && os.Args[1] != "users") {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	globalContext, _, err := cmd.Bootstrap()
+	log := dic.GetService[logger.Logger]()
+	if err != nil {
+		if log != nil {
+			log.WithError(err).Error("unable to start application")
+			os.Exit(1)
+		}
+		panic(err)
+	}
+
+	if os.Args[1] == "users" {
+		err = users(globalContext, os.Args[2], os.Args[3:])
+	} else {
+		err = follows(globalContext, os.Args[2], os.Args[3:])
+	}
+	if errors.Is(err, errUsage) {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.WithError(err).Error("command failed")
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func follows(ctx context.Context, command string, args []string) error {
+	followRequestService := dic.GetService[domain.FollowRequestService]()
+
+	switch command {
+	case "list":
+		flags := flag.NewFlagSet("list", flag.ExitOnError)
+		username := flags.String("user", "", "only list the follow requests of this user")
+		_ = flags.Parse(args)
+		followers, err := followRequestService.ListPending(ctx, *username)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "USER\tACTOR\tFOLLOW ID\tREQUESTED AT")
+		for _, follower := range followers {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
+				follower.User,
+				follower.R.FollowerActor.URL,
+				follower.FollowID.String,
+				follower.CreatedAt.Format("2006-01-02 15:04:05"),
+			)
+		}
+		return writer.Flush() //nolint:wrapcheck
+	case "approve", "reject":
+		if len(args) != 1 {
+			return errUsage
+		}
+		if command == "approve" {
+			return followRequestService.Approve(ctx, args[0]) //nolint:wrapcheck
+		}
+		return followRequestService.Reject(ctx, args[0]) //nolint:wrapcheck
+	case "expire":
+		expired, err := followRequestService.ExpirePending(ctx)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		fmt.Printf("%d follow requests expired\n", expired)
+		return nil
+	default:
+		return errUsage
+	}
+}
+
+func users(ctx context.Context, command string, args []string) error {
+	fediverseLinkService := dic.GetService[domain.FediverseLinkService]()
+	consentService := dic.GetService[domain.ConsentService]()
+
+	switch command {
+	case "migrate":
+		if len(args) != 2 {
+			return errUsage
+		}
+		return migrate(ctx, args[0], args[1])
+	case "candidates":
+		flags := flag.NewFlagSet("candidates", flag.ExitOnError)
+		username := flags.String("user", "", "only list the fediverse accounts of this user")
+		_ = flags.Parse(args)
+		links, err := fediverseLinkService.ListCandidates(ctx, *username)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "USER\tHANDLE\tACTOR\tVERIFIED AT\tMIGRATION")
+		for _, link := range links {
+			fmt.Fprintf(writer, "%s\t@%s\t%s\t%s\tadmin users migrate %s %s\n",
+				link.User,
+				link.Handle,
+				link.ActorURL,
+				link.VerifiedAt.Format("2006-01-02 15:04:05"),
+				link.User,
+				link.ActorURL,
+			)
+		}
+		return writer.Flush() //nolint:wrapcheck
+	case "scan":
+		verified, err := fediverseLinkService.Scan(ctx)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		fmt.Printf("%d fediverse accounts verified\n", verified)
+		return nil
+	case "opt-out", "opt-in":
+		if len(args) != 1 {
+			return errUsage
+		}
+		if command == "opt-in" {
+			return consentService.OptIn(ctx, args[0]) //nolint:wrapcheck
+		}
+		deliveries, err := consentService.OptOut(ctx, args[0])
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		fmt.Printf("%s opted out, delete scheduled for %d inboxes\n", args[0], deliveries)
+		return nil
+	case "opt-outs":
+		optOuts, err := consentService.List(ctx)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "USER\tREASON\tOPTED OUT AT")
+		for _, optOut := range optOuts {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n",
+				optOut.Username,
+				optOut.Reason,
+				optOut.CreatedAt.Format("2006-01-02 15:04:05"),
+			)
+		}
+		return writer.Flush() //nolint:wrapcheck
+	default:
+		return errUsage
+	}
+}
+
+func migrate(ctx context.Context, username string, actor string) error {
+	target, err := url.Parse(actor)
+	if err != nil || (target.Scheme != "https" && target.Scheme != "http") {
+		return errors.Errorf("invalid actor url '%s'", actor)
+	}
+	deliveries, err := dic.GetService[domain.MigrationService]().Migrate(ctx, username, target)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	fmt.Printf("%s moved to %s, move scheduled for %d inboxes\n", username, target, deliveries)
+	return nil
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -21,6 +23,8 @@ import (
 	"github.com/estrys/estrys/migrations"
 )
 
+const followRequestExpirationInterval = time.Hour
+
 func main() {
 	appContext, _, err := cmd.Bootstrap()
 	if err != nil {
@@ -70,6 +74,9 @@ func main() {
 		}
 	}()
 
+	go expireFollowRequests(appContext, log, dic.GetService[domain.FollowRequestService]())
+	go scanFediverseLinks(appContext, log, dic.GetService[domain.FediverseLinkService](), conf.FediverseScanInterval)
+
 	err = internal.StartServer(appContext, internal.Config{Address: conf.Address})
 	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.WithError(err).Error("server failed")
@@ -78,3 +85,46 @@ func main() {
 	log.Info("http server stopped")
 	os.Exit(0)
 }
+
+// expireFollowRequests periodically rejects the follow requests which have not been approved in time.
+func expireFollowRequests(ctx context.Context, log logger.Logger, followRequestService domain.FollowRequestService) {
+	ticker := time.NewTicker(followRequestExpirationInterval)
+	defer ticker.Stop()
+	for {
+		expired, err := followRequestService.ExpirePending(ctx)
+		if err != nil {
+			log.WithError(err).Error("unable to expire follow requests")
+		} else if expired > 0 {
+			log.WithField("count", expired).Info("expired follow requests")
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// scanFediverseLinks periodically looks for the fediverse accounts of the bridged users in their twitter profile.
+func scanFediverseLinks(
+	ctx context.Context,
+	log logger.Logger,
+	fediverseLinkService domain.FediverseLinkService,
+	interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		verified, err := fediverseLinkService.Scan(ctx)
+		if err != nil {
+			log.WithError(err).Error("unable to scan profiles for fediverse accounts")
+		} else if verified > 0 {
+			log.WithField("count", verified).Info("fediverse accounts verified")
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -6,6 +6,8 @@ go 1.19
 replace github.com/go-fed/activity => github.com/birdlephant/activity v0.0.0-20221204152203-733d1fd88157
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.4.1
+	github.com/buckket/go-blurhash v1.1.0
 	github.com/friendsofgo/errors v0.9.2
 	github.com/g8rswimmer/go-twitter/v2 v2.1.4
 	github.com/getsentry/sentry-go v0.16.0
@@ -15,7 +17,6 @@ require (
 	github.com/gofrs/uuid v4.0.0+incompatible
 	github.com/golang-migrate/migrate/v4 v4.15.2
 	github.com/gorilla/mux v1.8.0
-	github.com/hashicorp/golang-lru/v2 v2.0.1
 	github.com/hibiken/asynq v0.23.0
 	github.com/jackc/pgx/v5 v5.1.1
 	github.com/pkg/errors v0.9.1
@@ -23,6 +24,7 @@ require (
 	github.com/spf13/viper v1.14.0
 	github.com/stretchr/testify v1.8.1
 	github.com/vmihailenco/msgpack/v5 v5.3.5
+	github.com/volatiletech/null/v8 v8.1.2
 	github.com/volatiletech/sqlboiler/v4 v4.13.0
 	github.com/volatiletech/strmangle v0.0.4
 )
@@ -31,6 +33,7 @@ require (
 	github.com/cespare/xxhash/v2 v2.1.2 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/ericlagergren/decimal v0.0.0-20181231230500-73749d4874d5 // indirect
 	github.com/fsnotify/fsnotify v1.6.0 // indirect
 	github.com/go-redis/redis/v8 v8.11.5 // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
@@ -55,6 +58,7 @@ require (
 	github.com/subosito/gotenv v1.4.1 // indirect
 	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
 	github.com/volatiletech/inflect v0.0.1 // indirect
+	github.com/volatiletech/randomize v0.0.1 // indirect
 	go.uber.org/atomic v1.10.0 // indirect
 	golang.org/x/crypto v0.0.0-20220926161630-eccd6366d1be // indirect
 	golang.org/x/sys v0.2.0 // indirect
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/estrys/estrys/internal/authorization"
+	"github.com/estrys/estrys/internal/authorization/attributes"
+	"github.com/estrys/estrys/internal/config"
+	"github.com/estrys/estrys/internal/dic"
+	internalerrors "github.com/estrys/estrys/internal/errors"
+)
+
+// AuthorizedFetchMiddleware verifies the signature of GET requests when authorized fetch is enabled,
+// handlers are then responsible to call CheckAuthorizedFetch before serving activitypub documents.
+func AuthorizedFetchMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		if !IsAuthorizedFetchEnabled() {
+			next.ServeHTTP(responseWriter, request)
+			return
+		}
+		HTTPSigMiddleware(next).ServeHTTP(responseWriter, request)
+	})
+}
+
+// IsAuthorizedFetchEnabled tells if activitypub documents should only be served to signed requests.
+func IsAuthorizedFetchEnabled() bool {
+	return dic.GetService[config.Config]().AuthorizedFetch
+}
+
+// CheckAuthorizedFetch returns an error when authorized fetch is enabled and
+// the request is not signed, or signed by a blocked domain.
+func CheckAuthorizedFetch(request *http.Request) error {
+	if !IsAuthorizedFetchEnabled() {
+		return nil
+	}
+	if !IsRequestSigned(request) {
+		return internalerrors.New("request signature required", http.StatusUnauthorized).SkipCapture()
+	}
+	return CheckSignerAllowed(request)
+}
+
+// CheckSignerAllowed returns an error if the request is signed by a blocked domain.
+func CheckSignerAllowed(request *http.Request) error {
+	signer := RequestSigner(request)
+	if signer == nil {
+		return nil
+	}
+	authorizationChecker := dic.GetService[authorization.AuthorizationChecker]()
+	if !authorizationChecker.IsGranted(signer, attributes.CanFetch) {
+		return internalerrors.New("domain is blocked", http.StatusForbidden).
+			WithContext("signer", signer.String()).
+			SkipCapture()
+	}
+	return nil
+}
@@ -5,23 +5,40 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rsa"
+	"crypto/x509"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/getsentry/sentry-go"
 	"github.com/go-fed/httpsig"
+	"github.com/pkg/errors"
 
 	"github.com/estrys/estrys/internal/config"
 	"github.com/estrys/estrys/internal/crypto"
+	"github.com/estrys/estrys/internal/crypto/rfc9421"
 	"github.com/estrys/estrys/internal/dic"
+	internalerrors "github.com/estrys/estrys/internal/errors"
 	"github.com/estrys/estrys/internal/logger"
+	"github.com/estrys/estrys/internal/repository"
 )
 
 type contextKey int
 
 const (
 	HTTPSignature contextKey = iota
+	HTTPSignatureKeyID
+	HTTPSignatureKeyOwner
 )
 
+// maxBodySize is the largest request body accepted, bodies are read to check their digest before
+// the signature is verified.
+const maxBodySize = 1 << 20
+
+// keyRefreshCooldown is the minimum delay between two refreshes of the same key
+// after a signature failed to verify.
+const keyRefreshCooldown = time.Minute
+
 func IsRequestSigned(req *http.Request) bool {
 	if isSigned, isBool := req.Context().Value(HTTPSignature).(*bool); isBool && isSigned != nil && *isSigned {
 		return true
@@ -29,6 +46,32 @@ func IsRequestSigned(req *http.Request) bool {
 	return false
 }
 
+// RequestSigner returns the id of the key which signed the request, nil if the signature was not verified.
+func RequestSigner(req *http.Request) *url.URL {
+	keyID, isString := req.Context().Value(HTTPSignatureKeyID).(*string)
+	if !isString || keyID == nil || *keyID == "" {
+		return nil
+	}
+	signer, err := url.Parse(*keyID)
+	if err != nil {
+		return nil
+	}
+	return signer
+}
+
+// KeyOwner returns the actor owning the key which signed the request, nil if unknown.
+func KeyOwner(ctx context.Context) *url.URL {
+	owner, isString := ctx.Value(HTTPSignatureKeyOwner).(*string)
+	if !isString || owner == nil || *owner == "" {
+		return nil
+	}
+	ownerURL, err := url.Parse(*owner)
+	if err != nil {
+		return nil
+	}
+	return ownerURL
+}
+
 func setSentryContext(request *http.Request, context map[string]any) {
 	sentry.GetHubFromContext(request.Context()).ConfigureScope(func(scope *sentry.Scope) {
 		scope.SetContext("httpsig", context)
@@ -39,17 +82,27 @@ func HTTPSigMiddleware(next http.Handler) http.Handler {
 	log := dic.GetService[logger.Logger]()
 	conf := dic.GetService[config.Config]()
 	keyManager := dic.GetService[crypto.KeyManager]()
+	replayCache := dic.GetService[SignatureReplayCache]()
+	actorRepo := dic.GetService[repository.ActorRepository]()
+	refreshLimiter := dic.GetService[KeyRefreshLimiter]()
 
 	return http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
 		var signed bool
+		var keyID string
+		var keyOwner string
 		sentryContext := map[string]any{
 			"signed": &signed,
 		}
 		signedContext := context.WithValue(request.Context(), HTTPSignature, &signed)
+		signedContext = context.WithValue(signedContext, HTTPSignatureKeyID, &keyID)
+		signedContext = context.WithValue(signedContext, HTTPSignatureKeyOwner, &keyOwner)
 		request = request.WithContext(signedContext)
 		if conf.DisableHTTPSignatureVerify {
 			signed = true
 		}
+		if request.Body != nil {
+			request.Body = http.MaxBytesReader(responseWriter, request.Body, maxBodySize)
+		}
 
 		sig := request.Header.Get("signature")
 		sentryContext["signature"] = sig
@@ -59,54 +112,189 @@ func HTTPSigMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		verifier, err := httpsig.NewVerifier(request)
+		verifier := &signatureVerifier{
+			conf:           conf,
+			keyManager:     keyManager,
+			actorRepo:      actorRepo,
+			refreshLimiter: refreshLimiter,
+			sentryContext:  sentryContext,
+		}
+		var signerKeyID string
+		var err error
+		if rfc9421.IsSigned(request) {
+			sentryContext["format"] = "rfc9421"
+			signerKeyID, err = verifier.verifyMessageSignature(request)
+		} else {
+			sentryContext["format"] = "draft-cavage"
+			signerKeyID, err = verifier.verifyCavageSignature(request, sig)
+		}
 		if err != nil {
 			sentryContext["error"] = err.Error()
-			log.Error(err)
 			setSentryContext(request, sentryContext)
+			var tooLargeErr *http.MaxBytesError
+			if errors.As(err, &tooLargeErr) {
+				internalerrors.HTTPErrorHandler(func(http.ResponseWriter, *http.Request) error {
+					return internalerrors.Wrap(err, http.StatusRequestEntityTooLarge).
+						WithUserMessage("request body too large").
+						SkipCapture()
+				})(responseWriter, request)
+				return
+			}
+			log.WithField("keyId", signerKeyID).WithError(err).Warn("unable to verify http signature")
 			next.ServeHTTP(responseWriter, request)
 			return
 		}
 
-		sentryContext["key_id"] = verifier.KeyId()
-		log.WithField("keyId", verifier.KeyId()).Trace("request signature check starting")
-		pubKey, err := keyManager.FetchKey(signedContext, verifier.KeyId())
-		if err != nil {
+		// Signatures are valid for twice the clock skew since dates are accepted in both directions.
+		// Only activities posted to inboxes are rejected on replay, remote servers may repeat a signed fetch.
+		firstSeen := true
+		if request.Method == http.MethodPost {
+			firstSeen, err = replayCache.MarkSeen(signedContext, sig, 2*conf.SignatureClockSkew)
+		}
+		if err != nil || !firstSeen {
+			if err == nil {
+				err = errors.New("signature already used")
+			}
 			sentryContext["error"] = err.Error()
 			setSentryContext(request, sentryContext)
-			log.WithError(err).Error("unable to fetch keyManager")
+			log.WithField("keyId", signerKeyID).WithError(err).Warn("signature replay check failed")
 			next.ServeHTTP(responseWriter, request)
 			return
 		}
 
+		log.WithField("keyId", signerKeyID).Debug("http signature valid")
+		signed = true
+		keyID = signerKeyID
+		keyOwner = verifier.owner
+		sentryContext["key_owner"] = keyOwner
+
+		setSentryContext(request, sentryContext)
+		next.ServeHTTP(responseWriter, request)
+	})
+}
+
+type signatureVerifier struct {
+	conf           config.Config
+	keyManager     crypto.KeyManager
+	actorRepo      repository.ActorRepository
+	refreshLimiter KeyRefreshLimiter
+	sentryContext  map[string]any
+	// owner is the actor owning the key, known once the key is fetched
+	owner string
+}
+
+func (v *signatureVerifier) verifyCavageSignature(request *http.Request, sig string) (string, error) {
+	verifier, err := httpsig.NewVerifier(request)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid signature header")
+	}
+	v.sentryContext["key_id"] = verifier.KeyId()
+
+	err = checkRequestIntegrity(request, sig, time.Now(), v.conf.SignatureClockSkew)
+	if err != nil {
+		return verifier.KeyId(), err
+	}
+
+	return verifier.KeyId(), v.verifyWithKey(request.Context(), verifier.KeyId(), func(publicKey *crypto.PublicKey) error {
 		// Algorithm should not be retrieved from the signature header
 		// https://datatracker.ietf.org/doc/html/draft-cavage-http-signatures-10#section-2.5
 		var algo httpsig.Algorithm
-		if _, isEcDsa := pubKey.(*ecdsa.PublicKey); isEcDsa {
+		if _, isEcDsa := publicKey.Key.(*ecdsa.PublicKey); isEcDsa {
 			algo = httpsig.ECDSA_SHA256
 		}
-		if _, isEd := pubKey.(*ed25519.PublicKey); isEd {
+		if _, isEd := publicKey.Key.(*ed25519.PublicKey); isEd {
 			algo = httpsig.ED25519
 		}
-		if _, isRsa := pubKey.(*rsa.PublicKey); isRsa {
+		if _, isRsa := publicKey.Key.(*rsa.PublicKey); isRsa {
 			algo = httpsig.RSA_SHA256
 		}
 
-		sentryContext["algo"] = algo
-		err = verifier.Verify(pubKey, algo)
+		v.sentryContext["algo"] = algo
+		return errors.Wrap(verifier.Verify(publicKey.Key, algo), "invalid signature")
+	})
+}
 
-		if err != nil {
-			sentryContext["error"] = err.Error()
-			setSentryContext(request, sentryContext)
-			log.WithField("algo", algo).WithError(err).Warn("unable to verify http signature")
-			next.ServeHTTP(responseWriter, request)
-			return
-		}
+func (v *signatureVerifier) verifyMessageSignature(request *http.Request) (string, error) {
+	signature, err := rfc9421.Parse(request)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid signature headers")
+	}
+	v.sentryContext["key_id"] = signature.KeyID
 
-		log.WithField("keyId", verifier.KeyId()).Debug("http signature valid")
-		signed = true
+	err = checkMessageSignatureIntegrity(request, signature, time.Now(), v.conf.SignatureClockSkew)
+	if err != nil {
+		return signature.KeyID, err
+	}
 
-		setSentryContext(request, sentryContext)
-		next.ServeHTTP(responseWriter, request)
+	// Requests reach us through a reverse proxy, the target is rebuilt from the public domain
+	targetURI, err := url.Parse(v.conf.Domain.Scheme + "://" + v.conf.Domain.Host + request.URL.RequestURI())
+	if err != nil {
+		return signature.KeyID, errors.Wrap(err, "unable to build target uri")
+	}
+	v.sentryContext["algo"] = signature.Algorithm
+	return signature.KeyID, v.verifyWithKey(request.Context(), signature.KeyID, func(publicKey *crypto.PublicKey) error {
+		return signature.Verify(request, targetURI, publicKey.Key) //nolint:wrapcheck
 	})
 }
+
+// verifyWithKey runs the verification with the signer key. When it fails, the key is fetched
+// again once, without cache, since the remote actor may have rotated it. Keys are refreshed
+// at most once per keyRefreshCooldown.
+func (v *signatureVerifier) verifyWithKey(
+	ctx context.Context,
+	keyID string,
+	verify func(publicKey *crypto.PublicKey) error,
+) error {
+	publicKey, err := v.keyManager.FetchKey(ctx, keyID)
+	if err != nil {
+		// Deleted actors are gone by the time their Delete is delivered,
+		// the key we stored for a known actor is the only one left to check it.
+		storedKey, storedErr := v.storedKey(ctx, keyID)
+		if storedErr != nil {
+			return errors.Wrap(err, "unable to fetch key")
+		}
+		v.sentryContext["stored_key"] = true
+		err = verify(storedKey)
+		if err != nil {
+			return err
+		}
+		v.owner = storedKey.Owner
+		return nil
+	}
+	err = verify(publicKey)
+	if err != nil {
+		allowed, limitErr := v.refreshLimiter.Allow(ctx, keyID, keyRefreshCooldown)
+		if limitErr != nil || !allowed {
+			v.sentryContext["key_refresh_limited"] = true
+			return err
+		}
+		var refreshErr error
+		publicKey, refreshErr = v.keyManager.RefreshKey(ctx, keyID)
+		if refreshErr != nil {
+			return err
+		}
+		v.sentryContext["key_refreshed"] = true
+		err = verify(publicKey)
+		if err != nil {
+			return err
+		}
+	}
+	v.owner = publicKey.Owner
+	return nil
+}
+
+// storedKey returns the key saved along with a known actor.
+func (v *signatureVerifier) storedKey(ctx context.Context, keyID string) (*crypto.PublicKey, error) {
+	actor, err := v.actorRepo.GetByKeyID(ctx, keyID)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	key, err := x509.ParsePKIXPublicKey(actor.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse stored key")
+	}
+	return &crypto.PublicKey{
+		Key:   key,
+		Owner: actor.URL,
+	}, nil
+}
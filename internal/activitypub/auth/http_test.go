@@ -0,0 +1,336 @@
+package auth_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/go-fed/httpsig"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/estrys/estrys/internal/activitypub/auth"
+	authmocks "github.com/estrys/estrys/internal/activitypub/auth/mocks"
+	"github.com/estrys/estrys/internal/crypto"
+	cryptomocks "github.com/estrys/estrys/internal/crypto/mocks"
+	"github.com/estrys/estrys/internal/crypto/rfc9421"
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	repositorymocks "github.com/estrys/estrys/internal/repository/mocks"
+	dic_test "github.com/estrys/estrys/tests/dic"
+)
+
+const (
+	fakeKeyID    = "https://another-instance.example.com/users/foobar#main-key"
+	fakeKeyOwner = "https://another-instance.example.com/users/foobar"
+)
+
+func signedInboxRequest(t *testing.T, body []byte) *http.Request {
+	t.Helper()
+	return signedRequest(t, http.MethodPost, "https://example.com/users/foobar/inbox", body)
+}
+
+func signedRequest(t *testing.T, method string, target string, body []byte) *http.Request {
+	t.Helper()
+	keyPem, err := os.ReadFile(path.Join("testdata", "key.pem"))
+	require.NoError(t, err)
+	pemBlock, _ := pem.Decode(keyPem)
+	privateKey, err := x509.ParsePKCS1PrivateKey(pemBlock.Bytes)
+	require.NoError(t, err)
+
+	headers := []string{httpsig.RequestTarget, "date", "host"}
+	if body != nil {
+		headers = append(headers, "digest")
+	}
+	signer, _, err := httpsig.NewSigner(
+		[]httpsig.Algorithm{httpsig.RSA_SHA256},
+		httpsig.DigestSha256,
+		headers,
+		httpsig.Signature,
+		30,
+	)
+	require.NoError(t, err)
+	request := httptest.NewRequest(method, target, bytes.NewReader(body))
+	request.Header.Set("date", time.Now().UTC().Format(http.TimeFormat))
+	request.Header.Set("host", "example.com")
+	require.NoError(t, signer.SignRequest(privateKey, fakeKeyID, request, body))
+	// The sentry middleware always runs before in the real router
+	return request.WithContext(sentry.SetHubOnContext(request.Context(), sentry.NewHub(nil, sentry.NewScope())))
+}
+
+func messageSignedInboxRequest(t *testing.T, body []byte) *http.Request {
+	t.Helper()
+	keyPem, err := os.ReadFile(path.Join("testdata", "key.pem"))
+	require.NoError(t, err)
+	pemBlock, _ := pem.Decode(keyPem)
+	privateKey, err := x509.ParsePKCS1PrivateKey(pemBlock.Bytes)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "https://example.com/users/foobar/inbox", bytes.NewReader(body))
+	rfc9421.SetContentDigest(request, body)
+	require.NoError(t, rfc9421.Sign(
+		request,
+		privateKey,
+		fakeKeyID,
+		[]string{"@method", "@target-uri", "content-digest"},
+		time.Now(),
+	))
+	// Requests are received with a relative url, as behind the reverse proxy
+	request.URL.Scheme = ""
+	request.URL.Host = ""
+	return request.WithContext(sentry.SetHubOnContext(request.Context(), sentry.NewHub(nil, sentry.NewScope())))
+}
+
+type httpSigMocks struct {
+	keyManager     *cryptomocks.KeyManager
+	replayCache    *authmocks.SignatureReplayCache
+	actorRepo      *repositorymocks.ActorRepository
+	refreshLimiter *authmocks.KeyRefreshLimiter
+}
+
+func TestHTTPSigMiddleware(t *testing.T) {
+	body := []byte(`{"type":"Follow"}`)
+
+	tests := []struct {
+		name    string
+		request func(t *testing.T) *http.Request
+		mock    func(t *testing.T, mocks *httpSigMocks)
+		signed  bool
+		// statusCode is set when the request must not reach the handler
+		statusCode int
+	}{
+		{
+			name: "valid signature",
+			request: func(t *testing.T) *http.Request {
+				return signedInboxRequest(t, body)
+			},
+			mock: func(t *testing.T, mocks *httpSigMocks) {
+				mocks.keyManager.EXPECT().FetchKey(mock.Anything, fakeKeyID).Return(publicKey(t), nil)
+				mocks.replayCache.EXPECT().MarkSeen(mock.Anything, mock.Anything, 2*time.Hour).Return(true, nil)
+			},
+			signed: true,
+		},
+		{
+			name: "replayed signature",
+			request: func(t *testing.T) *http.Request {
+				return signedInboxRequest(t, body)
+			},
+			mock: func(t *testing.T, mocks *httpSigMocks) {
+				mocks.keyManager.EXPECT().FetchKey(mock.Anything, fakeKeyID).Return(publicKey(t), nil)
+				mocks.replayCache.EXPECT().MarkSeen(mock.Anything, mock.Anything, 2*time.Hour).Return(false, nil)
+			},
+		},
+		{
+			name: "signed fetch done again",
+			request: func(t *testing.T) *http.Request {
+				return signedRequest(t, http.MethodGet, "https://example.com/users/foobar", nil)
+			},
+			mock: func(t *testing.T, mocks *httpSigMocks) {
+				mocks.keyManager.EXPECT().FetchKey(mock.Anything, fakeKeyID).Return(publicKey(t), nil)
+			},
+			signed: true,
+		},
+		{
+			name: "body replaced",
+			request: func(t *testing.T) *http.Request {
+				request := signedInboxRequest(t, body)
+				request.Body = io.NopCloser(bytes.NewReader([]byte(`{"type":"Undo"}`)))
+				return request
+			},
+		},
+		{
+			name: "valid rfc9421 signature",
+			request: func(t *testing.T) *http.Request {
+				return messageSignedInboxRequest(t, body)
+			},
+			mock: func(t *testing.T, mocks *httpSigMocks) {
+				mocks.keyManager.EXPECT().FetchKey(mock.Anything, fakeKeyID).Return(publicKey(t), nil)
+				mocks.replayCache.EXPECT().MarkSeen(mock.Anything, mock.Anything, 2*time.Hour).Return(true, nil)
+			},
+			signed: true,
+		},
+		{
+			name: "rfc9421 body replaced",
+			request: func(t *testing.T) *http.Request {
+				request := messageSignedInboxRequest(t, body)
+				request.Body = io.NopCloser(bytes.NewReader([]byte(`{"type":"Undo"}`)))
+				return request
+			},
+		},
+		{
+			name: "rfc9421 signature of another request",
+			request: func(t *testing.T) *http.Request {
+				request := messageSignedInboxRequest(t, body)
+				request.URL.Path = "/users/another/inbox"
+				request.RequestURI = request.URL.Path
+				return request
+			},
+			mock: func(t *testing.T, mocks *httpSigMocks) {
+				mocks.keyManager.EXPECT().FetchKey(mock.Anything, fakeKeyID).Return(publicKey(t), nil)
+				mocks.refreshLimiter.EXPECT().Allow(mock.Anything, fakeKeyID, time.Minute).Return(true, nil)
+				mocks.keyManager.EXPECT().RefreshKey(mock.Anything, fakeKeyID).Return(publicKey(t), nil)
+			},
+		},
+		{
+			name: "rotated key",
+			request: func(t *testing.T) *http.Request {
+				return signedInboxRequest(t, body)
+			},
+			mock: func(t *testing.T, mocks *httpSigMocks) {
+				oldKey, err := rsa.GenerateKey(rand.Reader, 1024)
+				require.NoError(t, err)
+				mocks.keyManager.EXPECT().FetchKey(mock.Anything, fakeKeyID).Return(&crypto.PublicKey{
+					Key:   oldKey.Public(),
+					Owner: fakeKeyOwner,
+				}, nil)
+				mocks.refreshLimiter.EXPECT().Allow(mock.Anything, fakeKeyID, time.Minute).Return(true, nil)
+				mocks.keyManager.EXPECT().RefreshKey(mock.Anything, fakeKeyID).Return(publicKey(t), nil)
+				mocks.replayCache.EXPECT().MarkSeen(mock.Anything, mock.Anything, 2*time.Hour).Return(true, nil)
+			},
+			signed: true,
+		},
+		{
+			name: "key refreshed recently",
+			request: func(t *testing.T) *http.Request {
+				return signedInboxRequest(t, body)
+			},
+			mock: func(t *testing.T, mocks *httpSigMocks) {
+				oldKey, err := rsa.GenerateKey(rand.Reader, 1024)
+				require.NoError(t, err)
+				mocks.keyManager.EXPECT().FetchKey(mock.Anything, fakeKeyID).Return(&crypto.PublicKey{
+					Key:   oldKey.Public(),
+					Owner: fakeKeyOwner,
+				}, nil)
+				mocks.refreshLimiter.EXPECT().Allow(mock.Anything, fakeKeyID, time.Minute).Return(false, nil)
+			},
+		},
+		{
+			name: "deleted actor signs with its stored key",
+			request: func(t *testing.T) *http.Request {
+				return signedInboxRequest(t, []byte(`{"type":"Delete"}`))
+			},
+			mock: func(t *testing.T, mocks *httpSigMocks) {
+				mocks.keyManager.EXPECT().FetchKey(mock.Anything, fakeKeyID).Return(nil, errors.New("410 gone"))
+				storedKey, err := x509.MarshalPKIXPublicKey(publicKey(t).Key)
+				require.NoError(t, err)
+				mocks.actorRepo.EXPECT().GetByKeyID(mock.Anything, fakeKeyID).Return(&models.Actor{
+					URL:         fakeKeyOwner,
+					PublicKey:   storedKey,
+					PublicKeyID: null.StringFrom(fakeKeyID),
+				}, nil)
+				mocks.replayCache.EXPECT().MarkSeen(mock.Anything, mock.Anything, 2*time.Hour).Return(true, nil)
+			},
+			signed: true,
+		},
+		{
+			name: "unknown actor with unreachable key",
+			request: func(t *testing.T) *http.Request {
+				return signedInboxRequest(t, []byte(`{"type":"Delete"}`))
+			},
+			mock: func(t *testing.T, mocks *httpSigMocks) {
+				mocks.keyManager.EXPECT().FetchKey(mock.Anything, fakeKeyID).Return(nil, errors.New("410 gone"))
+				mocks.actorRepo.EXPECT().GetByKeyID(mock.Anything, fakeKeyID).Return(nil, sql.ErrNoRows)
+			},
+		},
+		{
+			name: "body too large",
+			request: func(t *testing.T) *http.Request {
+				return signedInboxRequest(t, bytes.Repeat([]byte(" "), 1<<20+1))
+			},
+			statusCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "rfc9421 body too large",
+			request: func(t *testing.T) *http.Request {
+				return messageSignedInboxRequest(t, bytes.Repeat([]byte(" "), 1<<20+1))
+			},
+			statusCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "expired date",
+			request: func(t *testing.T) *http.Request {
+				request := signedInboxRequest(t, body)
+				request.Header.Set("date", time.Now().Add(-2*time.Hour).UTC().Format(http.TimeFormat))
+				return request
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := &httpSigMocks{
+				keyManager:     cryptomocks.NewKeyManager(t),
+				replayCache:    authmocks.NewSignatureReplayCache(t),
+				actorRepo:      repositorymocks.NewActorRepository(t),
+				refreshLimiter: authmocks.NewKeyRefreshLimiter(t),
+			}
+			if tt.mock != nil {
+				tt.mock(t, mocks)
+			}
+			_ = dic.Register[crypto.KeyManager](mocks.keyManager)
+			_ = dic.Register[auth.SignatureReplayCache](mocks.replayCache)
+			_ = dic.Register[repository.ActorRepository](mocks.actorRepo)
+			_ = dic.Register[auth.KeyRefreshLimiter](mocks.refreshLimiter)
+			dic_test.BuildTestContainer(t)
+			defer dic.ResetContainer()
+
+			var handled bool
+			var signed bool
+			var signer string
+			var owner string
+			handler := auth.HTTPSigMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, request *http.Request) {
+				handled = true
+				signed = auth.IsRequestSigned(request)
+				if keyID := auth.RequestSigner(request); keyID != nil {
+					signer = keyID.String()
+				}
+				if keyOwner := auth.KeyOwner(request.Context()); keyOwner != nil {
+					owner = keyOwner.String()
+				}
+			}))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, tt.request(t))
+
+			if tt.statusCode != 0 {
+				require.False(t, handled)
+				require.Equal(t, tt.statusCode, recorder.Code)
+				return
+			}
+			require.True(t, handled)
+			require.Equal(t, tt.signed, signed)
+			if tt.signed {
+				require.Equal(t, fakeKeyID, signer)
+				require.Equal(t, fakeKeyOwner, owner)
+			} else {
+				require.Empty(t, signer)
+				require.Empty(t, owner)
+			}
+		})
+	}
+}
+
+func publicKey(t *testing.T) *crypto.PublicKey {
+	t.Helper()
+	keyPem, err := os.ReadFile(path.Join("testdata", "key.pem"))
+	require.NoError(t, err)
+	pemBlock, _ := pem.Decode(keyPem)
+	privateKey, err := x509.ParsePKCS1PrivateKey(pemBlock.Bytes)
+	require.NoError(t, err)
+	return &crypto.PublicKey{
+		Key:   privateKey.Public(),
+		Owner: fakeKeyOwner,
+	}
+}
@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"hash"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-fed/httpsig"
+	"github.com/pkg/errors"
+
+	"github.com/estrys/estrys/internal/crypto/rfc9421"
+)
+
+var signatureParamRegexp = regexp.MustCompile(`([a-zA-Z]+)="([^"]*)"`)
+
+// parseSignatureParams extracts the parameters of a draft-cavage Signature header.
+func parseSignatureParams(signature string) map[string]string {
+	params := make(map[string]string)
+	for _, match := range signatureParamRegexp.FindAllStringSubmatch(signature, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+	return params
+}
+
+// requiredSignedHeaders returns the headers which must be covered by the signature,
+// the body of a request can only be trusted if its digest is signed.
+func requiredSignedHeaders(request *http.Request) []string {
+	required := []string{httpsig.RequestTarget, "date"}
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		required = append(required, "digest")
+	}
+	return required
+}
+
+// checkRequestIntegrity makes sure the signature covers the request target, date and body,
+// and that it has been issued recently.
+func checkRequestIntegrity(request *http.Request, signature string, now time.Time, clockSkew time.Duration) error {
+	params := parseSignatureParams(signature)
+	signedHeaders := map[string]bool{}
+	// As per the spec, only the date is signed when the headers parameter is missing
+	headers := params["headers"]
+	if headers == "" {
+		headers = "date"
+	}
+	for _, header := range strings.Fields(strings.ToLower(headers)) {
+		signedHeaders[header] = true
+	}
+	for _, header := range requiredSignedHeaders(request) {
+		if !signedHeaders[header] {
+			return errors.Errorf("header %s is not signed", header)
+		}
+	}
+
+	date, err := http.ParseTime(request.Header.Get("date"))
+	if err != nil {
+		return errors.Wrap(err, "invalid date header")
+	}
+	if date.Before(now.Add(-clockSkew)) || date.After(now.Add(clockSkew)) {
+		return errors.Errorf("date %s is outside of the allowed clock skew", date.Format(time.RFC3339))
+	}
+
+	if signedHeaders["digest"] {
+		return checkDigest(request)
+	}
+	return nil
+}
+
+// checkMessageSignatureIntegrity is the rfc9421 counterpart of checkRequestIntegrity.
+func checkMessageSignatureIntegrity(
+	request *http.Request,
+	signature *rfc9421.Signature,
+	now time.Time,
+	clockSkew time.Duration,
+) error {
+	if !signature.HasComponent("@method") {
+		return errors.New("component @method is not signed")
+	}
+	coversTarget := signature.HasComponent("@target-uri") ||
+		((signature.HasComponent("@path") || signature.HasComponent("@request-target")) &&
+			(signature.HasComponent("@authority") || signature.HasComponent("host")))
+	if !coversTarget {
+		return errors.New("request target is not signed")
+	}
+	hasBody := request.Method != http.MethodGet && request.Method != http.MethodHead
+	if hasBody && !signature.HasComponent("content-digest") {
+		return errors.New("component content-digest is not signed")
+	}
+
+	if signature.Created.IsZero() {
+		return errors.New("signature has no creation date")
+	}
+	if signature.Created.Before(now.Add(-clockSkew)) || signature.Created.After(now.Add(clockSkew)) {
+		return errors.Errorf(
+			"creation date %s is outside of the allowed clock skew",
+			signature.Created.UTC().Format(time.RFC3339),
+		)
+	}
+	if !signature.Expires.IsZero() && signature.Expires.Before(now) {
+		return errors.New("signature has expired")
+	}
+
+	if signature.HasComponent("content-digest") {
+		body, err := readBody(request)
+		if err != nil {
+			return err
+		}
+		return rfc9421.VerifyContentDigest(request, body) //nolint:wrapcheck
+	}
+	return nil
+}
+
+// readBody returns the request body, the body is restored to be read again.
+func readBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read request body")
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// checkDigest compares the request body with the Digest header, the body is restored to be read again.
+func checkDigest(request *http.Request) error {
+	digestHeader := request.Header.Get("digest")
+	if digestHeader == "" {
+		return errors.New("missing digest header")
+	}
+
+	body, err := readBody(request)
+	if err != nil {
+		return err
+	}
+
+	for _, digest := range strings.Split(digestHeader, ",") {
+		algorithm, value, found := strings.Cut(strings.TrimSpace(digest), "=")
+		if !found {
+			continue
+		}
+		var hasher hash.Hash
+		switch strings.ToUpper(algorithm) {
+		case "SHA-256":
+			hasher = sha256.New()
+		case "SHA-512":
+			hasher = sha512.New()
+		default:
+			continue
+		}
+		hasher.Write(body)
+		if base64.StdEncoding.EncodeToString(hasher.Sum(nil)) != value {
+			return errors.New("digest does not match request body")
+		}
+		return nil
+	}
+	return errors.Errorf("unsupported digest '%s'", digestHeader)
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkRequestIntegrity(t *testing.T) {
+	now := time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC)
+	body := `{"type":"Follow"}`
+	// echo -n '{"type":"Follow"}' | openssl dgst -sha256 -binary | base64
+	bodyDigest := "SHA-256=GYwYnH3BiO6aICFt0ThC5bUIJ4byvqdpWtR8m5fNkww="
+
+	tests := []struct {
+		name      string
+		method    string
+		headers   map[string]string
+		signature string
+		err       string
+	}{
+		{
+			name:   "valid post",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"date":   now.Add(-time.Minute).Format(http.TimeFormat),
+				"digest": bodyDigest,
+			},
+			signature: `keyId="https://example.com/actor#main-key",headers="(request-target) host date digest",signature="xx"`,
+		},
+		{
+			name:   "valid get without digest",
+			method: http.MethodGet,
+			headers: map[string]string{
+				"date": now.Format(http.TimeFormat),
+			},
+			signature: `keyId="https://example.com/actor#main-key",headers="(request-target) host date",signature="xx"`,
+		},
+		{
+			name:   "digest not signed",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"date":   now.Format(http.TimeFormat),
+				"digest": bodyDigest,
+			},
+			signature: `keyId="https://example.com/actor#main-key",headers="(request-target) host date",signature="xx"`,
+			err:       "header digest is not signed",
+		},
+		{
+			name:   "request target not signed",
+			method: http.MethodGet,
+			headers: map[string]string{
+				"date": now.Format(http.TimeFormat),
+			},
+			signature: `keyId="https://example.com/actor#main-key",signature="xx"`,
+			err:       "header (request-target) is not signed",
+		},
+		{
+			name:   "date too old",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"date":   now.Add(-2 * time.Hour).Format(http.TimeFormat),
+				"digest": bodyDigest,
+			},
+			signature: `keyId="https://example.com/actor#main-key",headers="(request-target) host date digest",signature="xx"`,
+			err:       "date 2022-12-01T10:00:00Z is outside of the allowed clock skew",
+		},
+		{
+			name:   "date in the future",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"date":   now.Add(2 * time.Hour).Format(http.TimeFormat),
+				"digest": bodyDigest,
+			},
+			signature: `keyId="https://example.com/actor#main-key",headers="(request-target) host date digest",signature="xx"`,
+			err:       "date 2022-12-01T14:00:00Z is outside of the allowed clock skew",
+		},
+		{
+			name:   "invalid date",
+			method: http.MethodGet,
+			headers: map[string]string{
+				"date": "yesterday",
+			},
+			signature: `keyId="https://example.com/actor#main-key",headers="(request-target) host date",signature="xx"`,
+			err:       `invalid date header: parsing time "yesterday" as "Mon Jan _2 15:04:05 2006": cannot parse "yesterday" as "Mon"`,
+		},
+		{
+			name:   "digest mismatch",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"date":   now.Format(http.TimeFormat),
+				"digest": "SHA-256=47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=",
+			},
+			signature: `keyId="https://example.com/actor#main-key",headers="(request-target) host date digest",signature="xx"`,
+			err:       "digest does not match request body",
+		},
+		{
+			name:   "unsupported digest",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"date":   now.Format(http.TimeFormat),
+				"digest": "MD5=foobar",
+			},
+			signature: `keyId="https://example.com/actor#main-key",headers="(request-target) host date digest",signature="xx"`,
+			err:       "unsupported digest 'MD5=foobar'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := http.NewRequest(tt.method, "https://example.com/users/foobar/inbox", strings.NewReader(body))
+			require.NoError(t, err)
+			for header, value := range tt.headers {
+				request.Header.Set(header, value)
+			}
+
+			err = checkRequestIntegrity(request, tt.signature, now, time.Hour)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// KeyRefreshLimiter is an autogenerated mock type for the KeyRefreshLimiter type
+type KeyRefreshLimiter struct {
+	mock.Mock
+}
+
+type KeyRefreshLimiter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *KeyRefreshLimiter) EXPECT() *KeyRefreshLimiter_Expecter {
+	return &KeyRefreshLimiter_Expecter{mock: &_m.Mock}
+}
+
+// Allow provides a mock function with given fields: ctx, keyID, cooldown
+func (_m *KeyRefreshLimiter) Allow(ctx context.Context, keyID string, cooldown time.Duration) (bool, error) {
+	ret := _m.Called(ctx, keyID, cooldown)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) bool); ok {
+		r0 = rf(ctx, keyID, cooldown)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, keyID, cooldown)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KeyRefreshLimiter_Allow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Allow'
+type KeyRefreshLimiter_Allow_Call struct {
+	*mock.Call
+}
+
+// Allow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keyID string
+//   - cooldown time.Duration
+func (_e *KeyRefreshLimiter_Expecter) Allow(ctx interface{}, keyID interface{}, cooldown interface{}) *KeyRefreshLimiter_Allow_Call {
+	return &KeyRefreshLimiter_Allow_Call{Call: _e.mock.On("Allow", ctx, keyID, cooldown)}
+}
+
+func (_c *KeyRefreshLimiter_Allow_Call) Run(run func(ctx context.Context, keyID string, cooldown time.Duration)) *KeyRefreshLimiter_Allow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *KeyRefreshLimiter_Allow_Call) Return(_a0 bool, _a1 error) *KeyRefreshLimiter_Allow_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewKeyRefreshLimiter interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewKeyRefreshLimiter creates a new instance of KeyRefreshLimiter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewKeyRefreshLimiter(t mockConstructorTestingTNewKeyRefreshLimiter) *KeyRefreshLimiter {
+	mock := &KeyRefreshLimiter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
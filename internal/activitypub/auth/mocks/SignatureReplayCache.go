@@ -0,0 +1,84 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SignatureReplayCache is an autogenerated mock type for the SignatureReplayCache type
+type SignatureReplayCache struct {
+	mock.Mock
+}
+
+type SignatureReplayCache_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SignatureReplayCache) EXPECT() *SignatureReplayCache_Expecter {
+	return &SignatureReplayCache_Expecter{mock: &_m.Mock}
+}
+
+// MarkSeen provides a mock function with given fields: ctx, signature, ttl
+func (_m *SignatureReplayCache) MarkSeen(ctx context.Context, signature string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, signature, ttl)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) bool); ok {
+		r0 = rf(ctx, signature, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, signature, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SignatureReplayCache_MarkSeen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkSeen'
+type SignatureReplayCache_MarkSeen_Call struct {
+	*mock.Call
+}
+
+// MarkSeen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - signature string
+//   - ttl time.Duration
+func (_e *SignatureReplayCache_Expecter) MarkSeen(ctx interface{}, signature interface{}, ttl interface{}) *SignatureReplayCache_MarkSeen_Call {
+	return &SignatureReplayCache_MarkSeen_Call{Call: _e.mock.On("MarkSeen", ctx, signature, ttl)}
+}
+
+func (_c *SignatureReplayCache_MarkSeen_Call) Run(run func(ctx context.Context, signature string, ttl time.Duration)) *SignatureReplayCache_MarkSeen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *SignatureReplayCache_MarkSeen_Call) Return(_a0 bool, _a1 error) *SignatureReplayCache_MarkSeen_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewSignatureReplayCache interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSignatureReplayCache creates a new instance of SignatureReplayCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSignatureReplayCache(t mockConstructorTestingTNewSignatureReplayCache) *SignatureReplayCache {
+	mock := &SignatureReplayCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/go-redis/redis/v9"
+	"github.com/pkg/errors"
+)
+
+// KeyRefreshLimiter spaces out the refreshes of remote keys, requests with an invalid signature
+// must not make us fetch the key of the actor they pretend to come from every time.
+//
+//go:generate mockery --with-expecter --name=KeyRefreshLimiter
+type KeyRefreshLimiter interface {
+	// Allow tells if the key can be refreshed, it returns true at most once per cooldown.
+	Allow(ctx context.Context, keyID string, cooldown time.Duration) (bool, error)
+}
+
+type redisKeyRefreshLimiter struct {
+	redis redis.Cmdable
+}
+
+func NewRedisKeyRefreshLimiter(redis redis.Cmdable) *redisKeyRefreshLimiter {
+	return &redisKeyRefreshLimiter{redis: redis}
+}
+
+func (r *redisKeyRefreshLimiter) Allow(ctx context.Context, keyID string, cooldown time.Duration) (bool, error) {
+	hash := sha256.Sum256([]byte(keyID))
+	key := "httpsig/refresh/" + hex.EncodeToString(hash[:])
+	allowed, err := r.redis.SetNX(ctx, key, 1, cooldown).Result()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to record key refresh")
+	}
+	return allowed, nil
+}
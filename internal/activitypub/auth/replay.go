@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/go-redis/redis/v9"
+	"github.com/pkg/errors"
+)
+
+// SignatureReplayCache remembers the signatures recently accepted,
+// a valid signature captured by a third party must not be accepted twice.
+//
+//go:generate mockery --with-expecter --name=SignatureReplayCache
+type SignatureReplayCache interface {
+	// MarkSeen records the signature, it returns false if it was already recorded.
+	MarkSeen(ctx context.Context, signature string, ttl time.Duration) (bool, error)
+}
+
+type redisSignatureReplayCache struct {
+	redis redis.Cmdable
+}
+
+func NewRedisSignatureReplayCache(redis redis.Cmdable) *redisSignatureReplayCache {
+	return &redisSignatureReplayCache{redis: redis}
+}
+
+func (r *redisSignatureReplayCache) MarkSeen(ctx context.Context, signature string, ttl time.Duration) (bool, error) {
+	hash := sha256.Sum256([]byte(signature))
+	key := "httpsig/seen/" + hex.EncodeToString(hash[:])
+	firstSeen, err := r.redis.SetNX(ctx, key, 1, ttl).Result()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to record signature")
+	}
+	return firstSeen, nil
+}
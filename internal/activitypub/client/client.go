@@ -3,18 +3,17 @@ package activitypubclient
 import (
 	"bytes"
 	"context"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"sync"
 	"time"
 
 	"github.com/go-fed/activity/pub"
 	"github.com/go-fed/activity/streams"
-	"github.com/go-fed/httpsig"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
@@ -42,47 +41,45 @@ type activityContext struct {
 	user *models.User
 }
 
-type httpSigRoundTripper struct {
-	mu           *sync.Mutex
-	signer       httpsig.Signer
-	urlGenerator urlgenerator.URLGenerator
+// userSigningKey signs with the key of the user the activity is sent from.
+func userSigningKey(urlGenerator urlgenerator.URLGenerator) signingKey {
+	return func(request *http.Request) (*rsa.PrivateKey, string, error) {
+		activityContext, ok := request.Context().Value(contextActivity).(activityContext)
+		if !ok {
+			return nil, "", errors.New("no context set for the request")
+		}
+
+		userPrivateKey, err := x509.ParsePKCS1PrivateKey(activityContext.user.PrivateKey)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "unable to parse user private key")
+		}
+		pubKeyID, err := urlGenerator.URL(
+			routes.UserRoute,
+			[]string{"username", activityContext.user.Username},
+			urlgenerator.OptionAbsoluteURL,
+		)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "unable to generate user route")
+		}
+		return userPrivateKey, pubKeyID.String(), nil
+	}
 }
 
-func (t *httpSigRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
-	activityContext, ok := request.Context().Value(contextActivity).(activityContext)
-	if !ok {
-		return nil, errors.New("no context set for the request")
-	}
+//go:generate mockery --with-expecter --name=ActivityPubClient
+type ActivityPubClient interface {
+	PostInbox(ctx context.Context, inbox *url.URL, from *models.User, act pub.Activity) error
+}
 
-	userPrivateKey, err := x509.ParsePKCS1PrivateKey(activityContext.user.PrivateKey)
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to parse user private key")
-	}
-	pubKeyID, err := t.urlGenerator.URL(
-		routes.UserRoute,
-		[]string{"username", activityContext.user.Username},
-		urlgenerator.OptionAbsoluteURL,
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to generate user route")
+// ActorInbox returns the resolved personal inbox of an actor.
+func ActorInbox(actor *models.Actor) (*url.URL, error) {
+	if !actor.Inbox.Valid {
+		return nil, errors.Errorf("no inbox known for actor %s", actor.URL)
 	}
-
-	buf, _ := io.ReadAll(request.Body)
-	rdr2 := io.NopCloser(bytes.NewBuffer(buf))
-	t.mu.Lock()
-	err = t.signer.SignRequest(userPrivateKey, pubKeyID.String(), request, buf)
-	t.mu.Unlock()
+	inboxURL, err := url.Parse(actor.Inbox.String)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to sign http request")
+		return nil, errors.Wrap(err, "unable to decode actor inbox url")
 	}
-	request.Body = rdr2
-
-	return http.DefaultTransport.RoundTrip(request) //nolint:wrapcheck
-}
-
-//go:generate mockery --with-expecter --name=ActivityPubClient
-type ActivityPubClient interface {
-	PostInbox(ctx context.Context, to *models.Actor, from *models.User, act pub.Activity) error
+	return inboxURL, nil
 }
 
 type activityPubClient struct {
@@ -94,24 +91,15 @@ func NewActivityPubClient(
 	client *http.Client,
 	log logger.Logger,
 	urlGenerator urlgenerator.URLGenerator,
+	formats SignatureFormatStore,
 ) (*activityPubClient, error) {
-	signer, _, err := httpsig.NewSigner(
-		[]httpsig.Algorithm{httpsig.RSA_SHA256},
-		httpsig.DigestSha256,
-		[]string{httpsig.RequestTarget, "date", "host", "digest"},
-		httpsig.Signature,
-		30, // seconds
-	)
+	signingTransport, err := newSigningRoundTripper(formats, userSigningKey(urlGenerator), client.Transport, log)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to create an http request signer")
+		return nil, err
 	}
 	client.Transport = &logger.HTTPLoggerRoundTripper{
-		RoundTripper: &httpSigRoundTripper{
-			signer:       signer,
-			urlGenerator: urlGenerator,
-			mu:           &sync.Mutex{},
-		},
-		Log: log,
+		RoundTripper: signingTransport,
+		Log:          log,
 	}
 	return &activityPubClient{
 		client: client,
@@ -121,14 +109,10 @@ func NewActivityPubClient(
 
 func (c *activityPubClient) PostInbox(
 	ctx context.Context,
-	to *models.Actor,
+	inboxURL *url.URL,
 	from *models.User,
 	act pub.Activity,
 ) error {
-	actorURL, err := url.Parse(to.URL)
-	if err != nil {
-		return errors.Wrap(err, "unable to decode actor url")
-	}
 
 	serializedActivity, err := streams.Serialize(act)
 	if err != nil {
@@ -140,14 +124,13 @@ func (c *activityPubClient) PostInbox(
 		return errors.Wrap(err, "unable to encode serialized activity")
 	}
 	requestContext := context.WithValue(ctx, contextActivity, activityContext{from})
-	inboxPath, _ := url.JoinPath(actorURL.String(), "/inbox")
-	request, err := http.NewRequestWithContext(requestContext, http.MethodPost, inboxPath, bodyBuffer)
+	request, err := http.NewRequestWithContext(requestContext, http.MethodPost, inboxURL.String(), bodyBuffer)
 	if err != nil {
 		return errors.Wrap(err, "unable to create inbox request")
 	}
 
 	request.Header.Add("date", time.Now().Format(http.TimeFormat))
-	request.Header.Add("host", actorURL.Host)
+	request.Header.Add("host", inboxURL.Host)
 	request.Header.Add("content-type", "application/activity+json")
 
 	response, err := c.client.Do(request)
@@ -156,7 +139,8 @@ func (c *activityPubClient) PostInbox(
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusAccepted {
+	// Servers answer with 202 when they process the activity later, but 200 and 204 are also common
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
 		respBody, _ := io.ReadAll(response.Body)
 		logrus.WithField("response", string(respBody)).Trace("unable to post to inbox")
 		return &InboxNotAcceptedError{StatusCode: response.StatusCode}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path"
 	"testing"
@@ -16,9 +17,14 @@ import (
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/httpsig"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
 
 	activitypubclient "github.com/estrys/estrys/internal/activitypub/client"
+	activitypubclientmocks "github.com/estrys/estrys/internal/activitypub/client/mocks"
+	instancemocks "github.com/estrys/estrys/internal/activitypub/instance/mocks"
+	"github.com/estrys/estrys/internal/crypto/rfc9421"
 	"github.com/estrys/estrys/internal/dic"
 	"github.com/estrys/estrys/internal/logger/mocks"
 	"github.com/estrys/estrys/internal/models"
@@ -38,7 +44,7 @@ func Test_activityPubClient_PostInbox(t *testing.T) {
 
 	tests := []struct {
 		name        string
-		to          func() *models.Actor
+		to          func() *url.URL
 		from        *models.User
 		act         pub.Activity
 		handlerFunc func(*testing.T) http.HandlerFunc
@@ -46,10 +52,9 @@ func Test_activityPubClient_PostInbox(t *testing.T) {
 	}{
 		{
 			name: "ok",
-			to: func() *models.Actor {
-				return &models.Actor{
-					URL: fakeServerURL + "/users/fake-actor",
-				}
+			to: func() *url.URL {
+				inbox, _ := url.Parse(fakeServerURL + "/inbox/fake-actor")
+				return inbox
 			},
 			from: &models.User{
 				Username:   "fake-username",
@@ -59,7 +64,7 @@ func Test_activityPubClient_PostInbox(t *testing.T) {
 			handlerFunc: func(t *testing.T) http.HandlerFunc {
 				return func(writer http.ResponseWriter, request *http.Request) {
 					// Check that request sounds ok
-					assert.Equal(t, "/users/fake-actor/inbox", request.URL.String())
+					assert.Equal(t, "/inbox/fake-actor", request.URL.String())
 					assert.Equal(t, http.MethodPost, request.Method)
 					assert.Equal(t, "application/activity+json", request.Header.Get("content-type"))
 
@@ -83,12 +88,28 @@ func Test_activityPubClient_PostInbox(t *testing.T) {
 			},
 		},
 		{
-			name: "401",
-			to: func() *models.Actor {
-				return &models.Actor{
-					URL: fakeServerURL + "/users/fake-actor",
+			name: "no content",
+			to: func() *url.URL {
+				inbox, _ := url.Parse(fakeServerURL + "/inbox/fake-actor")
+				return inbox
+			},
+			from: &models.User{
+				Username:   "fake-username",
+				PrivateKey: decodedPrivKeyPem.Bytes,
+			},
+			act: streams.NewActivityStreamsAccept(),
+			handlerFunc: func(t *testing.T) http.HandlerFunc {
+				return func(writer http.ResponseWriter, request *http.Request) {
+					writer.WriteHeader(http.StatusNoContent)
 				}
 			},
+		},
+		{
+			name: "401",
+			to: func() *url.URL {
+				inbox, _ := url.Parse(fakeServerURL + "/inbox/fake-actor")
+				return inbox
+			},
 			from: &models.User{
 				Username:   "fake-username",
 				PrivateKey: decodedPrivKeyPem.Bytes,
@@ -112,10 +133,16 @@ func Test_activityPubClient_PostInbox(t *testing.T) {
 			dic_test.BuildTestContainer(t)
 			defer dic.ResetContainer()
 
+			fakeFormatStore := activitypubclientmocks.NewSignatureFormatStore(t)
+			fakeFormatStore.EXPECT().
+				Get(mock.Anything, mock.Anything).
+				Return(activitypubclient.SignatureFormatCavage, nil)
+
 			c, err := activitypubclient.NewActivityPubClient(
 				fakeServer.Client(),
 				mocks.NewNullLogger(),
 				dic.GetService[urlgenerator.URLGenerator](),
+				fakeFormatStore,
 			)
 			require.NoError(t, err)
 
@@ -133,3 +160,166 @@ func Test_activityPubClient_PostInbox(t *testing.T) {
 		})
 	}
 }
+
+func TestActorInbox(t *testing.T) {
+	inbox, err := activitypubclient.ActorInbox(&models.Actor{
+		URL:   "https://another-instance.example.com/users/fake-actor",
+		Inbox: null.StringFrom("https://another-instance.example.com/inbox/fake-actor"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "https://another-instance.example.com/inbox/fake-actor", inbox.String())
+
+	inbox, err = activitypubclient.ActorInbox(&models.Actor{
+		URL: "https://another-instance.example.com/users/fake-actor",
+	})
+	require.EqualError(t, err, "no inbox known for actor https://another-instance.example.com/users/fake-actor")
+	require.Nil(t, inbox)
+}
+
+func TestNewSignedClient(t *testing.T) {
+	fakeServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, http.MethodGet, request.Method)
+		assert.NotEmpty(t, request.Header.Get("date"))
+
+		verifier, err := httpsig.NewVerifier(request)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/actor#main-key", verifier.KeyId())
+		assert.NoError(t, verifier.Verify(pubKey.Public(), httpsig.RSA_SHA256))
+
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer fakeServer.Close()
+
+	dic_test.BuildTestContainer(t)
+	defer dic.ResetContainer()
+
+	fakeInstanceActor := instancemocks.NewInstanceActor(t)
+	fakeInstanceActor.EXPECT().PrivateKey(mock.Anything).Return(pubKey, nil)
+
+	fakeFormatStore := activitypubclientmocks.NewSignatureFormatStore(t)
+	fakeFormatStore.EXPECT().
+		Get(mock.Anything, mock.Anything).
+		Return(activitypubclient.SignatureFormatCavage, nil)
+
+	client, err := activitypubclient.NewSignedClient(
+		fakeServer.Client(),
+		mocks.NewNullLogger(),
+		dic.GetService[urlgenerator.URLGenerator](),
+		fakeInstanceActor,
+		fakeFormatStore,
+	)
+	require.NoError(t, err)
+
+	request, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, fakeServer.URL+"/users/fake-actor", nil)
+	require.NoError(t, err)
+	response, err := client.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Empty(t, request.Header.Get("signature"))
+}
+
+func Test_activityPubClient_PostInbox_DoubleKnocking(t *testing.T) {
+	tests := []struct {
+		name            string
+		preferredFormat activitypubclient.SignatureFormat
+		acceptedFormat  activitypubclient.SignatureFormat
+		learnedFormat   activitypubclient.SignatureFormat
+		requests        int
+		err             string
+	}{
+		{
+			name:            "preferred format accepted",
+			preferredFormat: activitypubclient.SignatureFormatRFC9421,
+			acceptedFormat:  activitypubclient.SignatureFormatRFC9421,
+			requests:        1,
+		},
+		{
+			name:            "fallback to rfc9421",
+			preferredFormat: activitypubclient.SignatureFormatCavage,
+			acceptedFormat:  activitypubclient.SignatureFormatRFC9421,
+			learnedFormat:   activitypubclient.SignatureFormatRFC9421,
+			requests:        2,
+		},
+		{
+			name:            "fallback to draft-cavage",
+			preferredFormat: activitypubclient.SignatureFormatRFC9421,
+			acceptedFormat:  activitypubclient.SignatureFormatCavage,
+			learnedFormat:   activitypubclient.SignatureFormatCavage,
+			requests:        2,
+		},
+		{
+			name:            "no format accepted",
+			preferredFormat: activitypubclient.SignatureFormatCavage,
+			requests:        2,
+			err:             "error posting to inbox 401",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			fakeServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				requests++
+				body, err := io.ReadAll(request.Body)
+				assert.NoError(t, err)
+				assert.NotEmpty(t, body)
+
+				format := activitypubclient.SignatureFormatCavage
+				if rfc9421.IsSigned(request) {
+					format = activitypubclient.SignatureFormatRFC9421
+					assert.NoError(t, rfc9421.VerifyContentDigest(request, body))
+					signature, err := rfc9421.Parse(request)
+					assert.NoError(t, err)
+					assert.Equal(t, []string{"@method", "@target-uri", "content-digest"}, signature.Components)
+					request.URL.Scheme = "http"
+					request.URL.Host = request.Host
+					assert.NoError(t, signature.Verify(request, nil, pubKey.Public()))
+				} else {
+					verifier, err := httpsig.NewVerifier(request)
+					assert.NoError(t, err)
+					assert.NoError(t, verifier.Verify(pubKey.Public(), httpsig.RSA_SHA256))
+				}
+				if format != tt.acceptedFormat {
+					writer.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				writer.WriteHeader(http.StatusAccepted)
+			}))
+			defer fakeServer.Close()
+
+			dic_test.BuildTestContainer(t)
+			defer dic.ResetContainer()
+
+			inbox, _ := url.Parse(fakeServer.URL + "/inbox/fake-actor")
+			fakeFormatStore := activitypubclientmocks.NewSignatureFormatStore(t)
+			fakeFormatStore.EXPECT().Get(mock.Anything, inbox.Host).Return(tt.preferredFormat, nil)
+			if tt.learnedFormat != "" {
+				fakeFormatStore.EXPECT().Set(mock.Anything, inbox.Host, tt.learnedFormat).Return(nil)
+			}
+
+			c, err := activitypubclient.NewActivityPubClient(
+				fakeServer.Client(),
+				mocks.NewNullLogger(),
+				dic.GetService[urlgenerator.URLGenerator](),
+				fakeFormatStore,
+			)
+			require.NoError(t, err)
+
+			err = c.PostInbox(
+				context.TODO(),
+				inbox,
+				&models.User{
+					Username:   "fake-username",
+					PrivateKey: decodedPrivKeyPem.Bytes,
+				},
+				streams.NewActivityStreamsAccept(),
+			)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.requests, requests)
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	pub "github.com/go-fed/activity/pub"
+
+	url "net/url"
 )
 
 // ActivityPubClient is an autogenerated mock type for the ActivityPubClient type
@@ -24,13 +26,13 @@ func (_m *ActivityPubClient) EXPECT() *ActivityPubClient_Expecter {
 	return &ActivityPubClient_Expecter{mock: &_m.Mock}
 }
 
-// PostInbox provides a mock function with given fields: ctx, to, from, act
-func (_m *ActivityPubClient) PostInbox(ctx context.Context, to *models.Actor, from *models.User, act pub.Activity) error {
-	ret := _m.Called(ctx, to, from, act)
+// PostInbox provides a mock function with given fields: ctx, inbox, from, act
+func (_m *ActivityPubClient) PostInbox(ctx context.Context, inbox *url.URL, from *models.User, act pub.Activity) error {
+	ret := _m.Called(ctx, inbox, from, act)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Actor, *models.User, pub.Activity) error); ok {
-		r0 = rf(ctx, to, from, act)
+	if rf, ok := ret.Get(0).(func(context.Context, *url.URL, *models.User, pub.Activity) error); ok {
+		r0 = rf(ctx, inbox, from, act)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -45,16 +47,16 @@ type ActivityPubClient_PostInbox_Call struct {
 
 // PostInbox is a helper method to define mock.On call
 //   - ctx context.Context
-//   - to *models.Actor
+//   - inbox *url.URL
 //   - from *models.User
 //   - act pub.Activity
-func (_e *ActivityPubClient_Expecter) PostInbox(ctx interface{}, to interface{}, from interface{}, act interface{}) *ActivityPubClient_PostInbox_Call {
-	return &ActivityPubClient_PostInbox_Call{Call: _e.mock.On("PostInbox", ctx, to, from, act)}
+func (_e *ActivityPubClient_Expecter) PostInbox(ctx interface{}, inbox interface{}, from interface{}, act interface{}) *ActivityPubClient_PostInbox_Call {
+	return &ActivityPubClient_PostInbox_Call{Call: _e.mock.On("PostInbox", ctx, inbox, from, act)}
 }
 
-func (_c *ActivityPubClient_PostInbox_Call) Run(run func(ctx context.Context, to *models.Actor, from *models.User, act pub.Activity)) *ActivityPubClient_PostInbox_Call {
+func (_c *ActivityPubClient_PostInbox_Call) Run(run func(ctx context.Context, inbox *url.URL, from *models.User, act pub.Activity)) *ActivityPubClient_PostInbox_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*models.Actor), args[2].(*models.User), args[3].(pub.Activity))
+		run(args[0].(context.Context), args[1].(*url.URL), args[2].(*models.User), args[3].(pub.Activity))
 	})
 	return _c
 }
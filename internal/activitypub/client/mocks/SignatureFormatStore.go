@@ -0,0 +1,123 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	activitypubclient "github.com/estrys/estrys/internal/activitypub/client"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SignatureFormatStore is an autogenerated mock type for the SignatureFormatStore type
+type SignatureFormatStore struct {
+	mock.Mock
+}
+
+type SignatureFormatStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SignatureFormatStore) EXPECT() *SignatureFormatStore_Expecter {
+	return &SignatureFormatStore_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, host
+func (_m *SignatureFormatStore) Get(ctx context.Context, host string) (activitypubclient.SignatureFormat, error) {
+	ret := _m.Called(ctx, host)
+
+	var r0 activitypubclient.SignatureFormat
+	if rf, ok := ret.Get(0).(func(context.Context, string) activitypubclient.SignatureFormat); ok {
+		r0 = rf(ctx, host)
+	} else {
+		r0 = ret.Get(0).(activitypubclient.SignatureFormat)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, host)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SignatureFormatStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type SignatureFormatStore_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - host string
+func (_e *SignatureFormatStore_Expecter) Get(ctx interface{}, host interface{}) *SignatureFormatStore_Get_Call {
+	return &SignatureFormatStore_Get_Call{Call: _e.mock.On("Get", ctx, host)}
+}
+
+func (_c *SignatureFormatStore_Get_Call) Run(run func(ctx context.Context, host string)) *SignatureFormatStore_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SignatureFormatStore_Get_Call) Return(_a0 activitypubclient.SignatureFormat, _a1 error) *SignatureFormatStore_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Set provides a mock function with given fields: ctx, host, format
+func (_m *SignatureFormatStore) Set(ctx context.Context, host string, format activitypubclient.SignatureFormat) error {
+	ret := _m.Called(ctx, host, format)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, activitypubclient.SignatureFormat) error); ok {
+		r0 = rf(ctx, host, format)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SignatureFormatStore_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type SignatureFormatStore_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - ctx context.Context
+//   - host string
+//   - format activitypubclient.SignatureFormat
+func (_e *SignatureFormatStore_Expecter) Set(ctx interface{}, host interface{}, format interface{}) *SignatureFormatStore_Set_Call {
+	return &SignatureFormatStore_Set_Call{Call: _e.mock.On("Set", ctx, host, format)}
+}
+
+func (_c *SignatureFormatStore_Set_Call) Run(run func(ctx context.Context, host string, format activitypubclient.SignatureFormat)) *SignatureFormatStore_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(activitypubclient.SignatureFormat))
+	})
+	return _c
+}
+
+func (_c *SignatureFormatStore_Set_Call) Return(_a0 error) *SignatureFormatStore_Set_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+type mockConstructorTestingTNewSignatureFormatStore interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSignatureFormatStore creates a new instance of SignatureFormatStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSignatureFormatStore(t mockConstructorTestingTNewSignatureFormatStore) *SignatureFormatStore {
+	mock := &SignatureFormatStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,55 @@
+package activitypubclient
+
+import (
+	"crypto/rsa"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/estrys/estrys/internal/activitypub/instance"
+	"github.com/estrys/estrys/internal/logger"
+	"github.com/estrys/estrys/internal/router/routes"
+	"github.com/estrys/estrys/internal/router/urlgenerator"
+)
+
+// instanceSigningKey signs with the key of the instance actor.
+func instanceSigningKey(urlGenerator urlgenerator.URLGenerator, instanceActor instance.InstanceActor) signingKey {
+	return func(request *http.Request) (*rsa.PrivateKey, string, error) {
+		privateKey, err := instanceActor.PrivateKey(request.Context())
+		if err != nil {
+			return nil, "", errors.Wrap(err, "unable to get instance actor key")
+		}
+		pubKeyID, err := urlGenerator.URL(routes.InstanceActorRoute, nil, urlgenerator.OptionAbsoluteURL)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "unable to generate instance actor route")
+		}
+		pubKeyID.Fragment = instance.KeyFragment
+		return privateKey, pubKeyID.String(), nil
+	}
+}
+
+// NewSignedClient configures the client to sign its requests with the instance actor key.
+// Servers running in authorized fetch mode refuse to serve unsigned requests,
+// it is meant to be used to dereference remote objects, not to post activities.
+func NewSignedClient(
+	client *http.Client,
+	log logger.Logger,
+	urlGenerator urlgenerator.URLGenerator,
+	instanceActor instance.InstanceActor,
+	formats SignatureFormatStore,
+) (*http.Client, error) {
+	signingTransport, err := newSigningRoundTripper(
+		formats,
+		instanceSigningKey(urlGenerator, instanceActor),
+		client.Transport,
+		log,
+	)
+	if err != nil {
+		return nil, err
+	}
+	client.Transport = &logger.HTTPLoggerRoundTripper{
+		RoundTripper: signingTransport,
+		Log:          log,
+	}
+	return client, nil
+}
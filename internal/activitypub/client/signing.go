@@ -0,0 +1,203 @@
+package activitypubclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-fed/httpsig"
+	"github.com/go-redis/redis/v9"
+	"github.com/pkg/errors"
+
+	"github.com/estrys/estrys/internal/crypto/rfc9421"
+	"github.com/estrys/estrys/internal/logger"
+)
+
+type SignatureFormat string
+
+const (
+	SignatureFormatCavage  SignatureFormat = "draft-cavage"
+	SignatureFormatRFC9421 SignatureFormat = "rfc9421"
+
+	signatureFormatTTL = 30 * 24 * time.Hour
+)
+
+func (f SignatureFormat) other() SignatureFormat {
+	if f == SignatureFormatRFC9421 {
+		return SignatureFormatCavage
+	}
+	return SignatureFormatRFC9421
+}
+
+// SignatureFormatStore remembers which signature format remote hosts accept.
+//
+//go:generate mockery --with-expecter --name=SignatureFormatStore
+type SignatureFormatStore interface {
+	// Get returns the format accepted by the host, draft-cavage when it is not known.
+	Get(ctx context.Context, host string) (SignatureFormat, error)
+	Set(ctx context.Context, host string, format SignatureFormat) error
+}
+
+type redisSignatureFormatStore struct {
+	redis redis.Cmdable
+}
+
+func NewRedisSignatureFormatStore(redis redis.Cmdable) *redisSignatureFormatStore {
+	return &redisSignatureFormatStore{redis: redis}
+}
+
+func (r *redisSignatureFormatStore) Get(ctx context.Context, host string) (SignatureFormat, error) {
+	format, err := r.redis.Get(ctx, "httpsig/format/"+host).Result()
+	if errors.Is(err, redis.Nil) {
+		return SignatureFormatCavage, nil
+	}
+	if err != nil {
+		return SignatureFormatCavage, errors.Wrap(err, "unable to get signature format")
+	}
+	return SignatureFormat(format), nil
+}
+
+func (r *redisSignatureFormatStore) Set(ctx context.Context, host string, format SignatureFormat) error {
+	err := r.redis.Set(ctx, "httpsig/format/"+host, string(format), signatureFormatTTL).Err()
+	return errors.Wrap(err, "unable to save signature format")
+}
+
+// signingKey returns the key to sign the request with and its id.
+type signingKey func(request *http.Request) (*rsa.PrivateKey, string, error)
+
+// signingRoundTripper signs requests with the preferred format of the remote host.
+// When the signature is refused, the other format is tried and remembered if accepted.
+type signingRoundTripper struct {
+	mu           *sync.Mutex
+	cavageSigner httpsig.Signer
+	cavageGetter httpsig.Signer
+	formats      SignatureFormatStore
+	key          signingKey
+	next         http.RoundTripper
+	log          logger.Logger
+}
+
+func newSigningRoundTripper(
+	formats SignatureFormatStore,
+	key signingKey,
+	next http.RoundTripper,
+	log logger.Logger,
+) (*signingRoundTripper, error) {
+	cavageSigner, _, err := httpsig.NewSigner(
+		[]httpsig.Algorithm{httpsig.RSA_SHA256},
+		httpsig.DigestSha256,
+		[]string{httpsig.RequestTarget, "date", "host", "digest"},
+		httpsig.Signature,
+		30, // seconds
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create an http request signer")
+	}
+	cavageGetter, _, err := httpsig.NewSigner(
+		[]httpsig.Algorithm{httpsig.RSA_SHA256},
+		httpsig.DigestSha256,
+		[]string{httpsig.RequestTarget, "date", "host"},
+		httpsig.Signature,
+		30, // seconds
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create an http request signer")
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &signingRoundTripper{
+		mu:           &sync.Mutex{},
+		cavageSigner: cavageSigner,
+		cavageGetter: cavageGetter,
+		formats:      formats,
+		key:          key,
+		next:         next,
+		log:          log,
+	}, nil
+}
+
+func (t *signingRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	privateKey, keyID, err := t.key(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if request.Body != nil && request.Body != http.NoBody {
+		body, err = io.ReadAll(request.Body)
+		_ = request.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read request body")
+		}
+	}
+
+	host := request.URL.Host
+	format, err := t.formats.Get(request.Context(), host)
+	if err != nil {
+		t.log.WithError(err).WithField("host", host).Warn("unable to get signature format")
+	}
+
+	response, err := t.send(request, body, privateKey, keyID, format)
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		return response, err
+	}
+
+	// Double knocking, the remote may only understand the other format
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+	retryResponse, err := t.send(request, body, privateKey, keyID, format.other())
+	if err != nil || retryResponse.StatusCode == http.StatusUnauthorized {
+		return retryResponse, err
+	}
+	t.log.WithField("host", host).WithField("format", format.other()).Debug("signature format learned")
+	err = t.formats.Set(request.Context(), host, format.other())
+	if err != nil {
+		t.log.WithError(err).WithField("host", host).Warn("unable to save signature format")
+	}
+	return retryResponse, nil
+}
+
+func (t *signingRoundTripper) send(
+	original *http.Request,
+	body []byte,
+	privateKey *rsa.PrivateKey,
+	keyID string,
+	format SignatureFormat,
+) (*http.Response, error) {
+	request := original.Clone(original.Context())
+	if body != nil {
+		request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if request.Header.Get("date") == "" {
+		request.Header.Set("date", time.Now().UTC().Format(http.TimeFormat))
+	}
+	request.Header.Set("host", request.URL.Host)
+
+	var err error
+	if format == SignatureFormatRFC9421 {
+		components := []string{"@method", "@target-uri"}
+		if body != nil {
+			rfc9421.SetContentDigest(request, body)
+			components = append(components, "content-digest")
+		}
+		err = rfc9421.Sign(request, privateKey, keyID, components, time.Now())
+	} else {
+		signer := t.cavageGetter
+		if body != nil {
+			signer = t.cavageSigner
+		}
+		t.mu.Lock()
+		err = signer.SignRequest(privateKey, keyID, request, body)
+		t.mu.Unlock()
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to sign http request")
+	}
+
+	return t.next.RoundTrip(request) //nolint:wrapcheck
+}
@@ -0,0 +1,20 @@
+package activitypub
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	lineBreakRegexp = regexp.MustCompile(`(?i)<br\s*/?>|</p>\s*<p[^>]*>`)
+	tagRegexp       = regexp.MustCompile(`<[^>]*>`)
+)
+
+// HTMLToText turns the html content of a remote object into plain text,
+// remote servers send whatever markup they want so nothing of it is kept.
+func HTMLToText(content string) string {
+	content = lineBreakRegexp.ReplaceAllString(content, "\n")
+	content = tagRegexp.ReplaceAllString(content, "")
+	return strings.TrimSpace(html.UnescapeString(content))
+}
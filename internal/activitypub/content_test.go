@@ -0,0 +1,39 @@
+package activitypub_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/estrys/estrys/internal/activitypub"
+)
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "plain text",
+			content:  "Hello world",
+			expected: "Hello world",
+		},
+		{
+			name: "mastodon reply",
+			content: `<p><span class="h-card"><a href="https://example.com/@foobar" class="u-url mention">` +
+				`@<span>foobar</span></a></span> Nice one &amp; thanks</p><p>Second<br />line</p>`,
+			expected: "@foobar Nice one & thanks\nSecond\nline",
+		},
+		{
+			name:     "script",
+			content:  `<script>alert("hello")</script>&lt;b&gt;`,
+			expected: `alert("hello")<b>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, activitypub.HTMLToText(tt.content))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/estrys/estrys/internal/activitypub"
+	"github.com/estrys/estrys/internal/activitypub/instance"
+	"github.com/estrys/estrys/internal/crypto"
+	"github.com/estrys/estrys/internal/dic"
+	internalerrors "github.com/estrys/estrys/internal/errors"
+)
+
+func HandleInstanceActor(responseWriter http.ResponseWriter, request *http.Request) error {
+	instanceActor := dic.GetService[instance.InstanceActor]()
+	privateKey, err := instanceActor.PrivateKey(request.Context())
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+
+	keyManager := dic.GetService[crypto.KeyManager]()
+	vocabService := dic.GetService[activitypub.VocabService]()
+	actor, err := vocabService.GetInstanceActor(keyManager.FormatPubKey(privateKey.Public()))
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+
+	responseWriter.Header().Add("content-type", "application/activity+json")
+	err = json.NewEncoder(responseWriter).Encode(actor)
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+	return nil
+}
+
+// HandleInstanceInbox accepts and drops activities sent to the instance actor,
+// it does not follow anyone so nothing addressed to it needs to be processed.
+func HandleInstanceInbox(responseWriter http.ResponseWriter, _ *http.Request) {
+	responseWriter.WriteHeader(http.StatusAccepted)
+}
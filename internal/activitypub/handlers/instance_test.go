@@ -0,0 +1,79 @@
+package handlers_test
+
+import (
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/estrys/estrys/internal/activitypub/handlers"
+	"github.com/estrys/estrys/internal/activitypub/instance"
+	instancemocks "github.com/estrys/estrys/internal/activitypub/instance/mocks"
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/tests"
+)
+
+type InstanceHandlerTestSuite struct {
+	suite.Suite
+	tests.HTTPTestSuite
+}
+
+func TestInstanceHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(InstanceHandlerTestSuite))
+}
+
+func (suite *InstanceHandlerTestSuite) TestHandleInstanceActor() {
+	cases := []tests.HTTPTestCase{
+		{
+			Name: "ok",
+			Mock: func(t *testing.T) {
+				key, err := x509.ParsePKCS1PrivateKey(privKey.Bytes)
+				if err != nil {
+					t.Fatal(err)
+				}
+				fakeInstanceActor := instancemocks.NewInstanceActor(t)
+				fakeInstanceActor.On("PrivateKey", mock.Anything).Return(key, nil)
+				_ = dic.Register[instance.InstanceActor](fakeInstanceActor)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "instance/actor.json",
+		},
+		{
+			Name: "key error",
+			Mock: func(t *testing.T) {
+				fakeInstanceActor := instancemocks.NewInstanceActor(t)
+				fakeInstanceActor.On("PrivateKey", mock.Anything).Return(nil, errors.New("database down"))
+				_ = dic.Register[instance.InstanceActor](fakeInstanceActor)
+			},
+			StatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	suite.RunHTTPCases(suite.T(), handlers.HandleInstanceActor, cases)
+}
+
+func (suite *InstanceHandlerTestSuite) TestHandleWebFinger() {
+	cases := []tests.HTTPTestCase{
+		{
+			Name: "instance actor",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestQuery{Query: url.Values{"resource": []string{"acct:example.com@example.com"}}},
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "instance/webfinger.json",
+		},
+		{
+			Name: "other instance",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestQuery{Query: url.Values{"resource": []string{"acct:example.org@example.org"}}},
+			},
+			StatusCode: http.StatusNotFound,
+		},
+	}
+
+	suite.RunHTTPCases(suite.T(), handlers.HandleWebFinger, cases)
+}
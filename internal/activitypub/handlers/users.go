@@ -1,24 +1,33 @@
 package handlers
 
 import (
-	"context"
+	"database/sql"
 	"encoding/json"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/go-fed/activity/streams"
-	"github.com/go-fed/activity/streams/vocab"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 
 	"github.com/estrys/estrys/internal/activitypub"
 	"github.com/estrys/estrys/internal/activitypub/auth"
+	"github.com/estrys/estrys/internal/config"
 	"github.com/estrys/estrys/internal/dic"
 	"github.com/estrys/estrys/internal/domain"
+	"github.com/estrys/estrys/internal/domain/domainmodels"
 	internalerrors "github.com/estrys/estrys/internal/errors"
-	"github.com/estrys/estrys/internal/twitter"
+	"github.com/estrys/estrys/internal/repository"
+	twitterrepository "github.com/estrys/estrys/internal/twitter/repository"
 )
 
 func HandleUser(responseWriter http.ResponseWriter, request *http.Request) error {
+	err := auth.CheckSignerAllowed(request)
+	if err != nil {
+		return err
+	}
+
 	vars := mux.Vars(request)
 	// TODO Validate username input
 
@@ -26,16 +35,17 @@ func HandleUser(responseWriter http.ResponseWriter, request *http.Request) error
 	user, err := userService.GetFullUser(request.Context(), vars["username"])
 
 	if err != nil {
-		var twitterUserNotFound twitter.UserNotFoundError
-		if errors.As(err, &twitterUserNotFound) || errors.Is(err, domain.ErrUserDoesNotExist) {
-			return internalerrors.Wrap(err, http.StatusNotFound).
-				WithUserMessage("user not found")
-		}
-		return internalerrors.Wrap(err, http.StatusInternalServerError)
+		return domain.UserHTTPError(err)
 	}
 
 	vocabService := dic.GetService[activitypub.VocabService]()
-	actor, err := vocabService.GetActor(user)
+	var actor map[string]any
+	if auth.IsAuthorizedFetchEnabled() && !auth.IsRequestSigned(request) {
+		// Remote servers fetch keys without signing, they should still be able to verify our signatures
+		actor, err = vocabService.GetMinimalActor(user)
+	} else {
+		actor, err = vocabService.GetActor(user)
+	}
 	if err != nil {
 		return internalerrors.Wrap(err, http.StatusInternalServerError)
 	}
@@ -49,17 +59,16 @@ func HandleUser(responseWriter http.ResponseWriter, request *http.Request) error
 }
 
 func HandleFollowing(responseWriter http.ResponseWriter, request *http.Request) error {
+	err := auth.CheckAuthorizedFetch(request)
+	if err != nil {
+		return err
+	}
 	vars := mux.Vars(request)
 	userService := dic.GetService[domain.UserService]()
 	user, err := userService.GetFullUser(request.Context(), vars["username"])
 
 	if err != nil {
-		var twitterUserNotFound twitter.UserNotFoundError
-		if errors.As(err, &twitterUserNotFound) || errors.Is(err, domain.ErrUserDoesNotExist) {
-			return internalerrors.Wrap(err, http.StatusNotFound).
-				WithUserMessage("user not found")
-		}
-		return internalerrors.Wrap(err, http.StatusInternalServerError)
+		return domain.UserHTTPError(err)
 	}
 
 	vocabService := dic.GetService[activitypub.VocabService]()
@@ -77,24 +86,71 @@ func HandleFollowing(responseWriter http.ResponseWriter, request *http.Request)
 }
 
 func HandleFollowers(responseWriter http.ResponseWriter, request *http.Request) error {
+	err := auth.CheckAuthorizedFetch(request)
+	if err != nil {
+		return err
+	}
 	vars := mux.Vars(request)
 	userService := dic.GetService[domain.UserService]()
 	user, err := userService.GetFullUser(request.Context(), vars["username"])
 
 	if err != nil {
-		var twitterUserNotFound twitter.UserNotFoundError
-		if errors.As(err, &twitterUserNotFound) || errors.Is(err, domain.ErrUserDoesNotExist) {
-			return internalerrors.Wrap(err, http.StatusNotFound).
-				WithUserMessage("user not found")
-		}
-		return internalerrors.Wrap(err, http.StatusInternalServerError)
+		return domain.UserHTTPError(err)
 	}
 
+	conf := dic.GetService[config.Config]()
+	userRepo := dic.GetService[repository.UserRepository]()
 	vocabService := dic.GetService[activitypub.VocabService]()
-	followers, err := vocabService.GetFollowers(user)
+
+	dbUser, err := userRepo.Get(request.Context(), user.Username)
 	if err != nil {
 		return internalerrors.Wrap(err, http.StatusInternalServerError)
 	}
+	count, err := userRepo.CountFollowers(request.Context(), dbUser)
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+
+	var followers map[string]any
+	pageParam := request.URL.Query().Get("page")
+	if pageParam == "" || conf.HideFollowers {
+		followers, err = vocabService.GetFollowers(user, int(count), !conf.HideFollowers)
+		if err != nil {
+			return internalerrors.Wrap(err, http.StatusInternalServerError)
+		}
+	} else {
+		pageNumber, err := strconv.Atoi(pageParam)
+		page := activitypub.CollectionPage{
+			Number:     pageNumber,
+			Size:       conf.FollowersPageSize,
+			TotalItems: int(count),
+		}
+		if err != nil || page.Number < 1 || page.Number > page.LastPage() {
+			return internalerrors.New("invalid page number", http.StatusBadRequest).
+				WithUserMessage("invalid page number")
+		}
+		actors, err := userRepo.GetFollowersPage(
+			request.Context(),
+			dbUser,
+			(page.Number-1)*page.Size,
+			page.Size,
+		)
+		if err != nil {
+			return internalerrors.Wrap(err, http.StatusInternalServerError)
+		}
+		followerURLs := make([]*url.URL, 0, len(actors))
+		for _, actor := range actors {
+			actorURL, err := url.Parse(actor.URL)
+			if err != nil {
+				continue
+			}
+			followerURLs = append(followerURLs, actorURL)
+		}
+		followers, err = vocabService.GetFollowersPage(user, page, followerURLs)
+		if err != nil {
+			return internalerrors.Wrap(err, http.StatusInternalServerError)
+		}
+	}
 
 	responseWriter.Header().Add("content-type", "application/activity+json")
 	err = json.NewEncoder(responseWriter).Encode(followers)
@@ -106,23 +162,30 @@ func HandleFollowers(responseWriter http.ResponseWriter, request *http.Request)
 }
 
 func HandleOutbox(responseWriter http.ResponseWriter, request *http.Request) error {
+	err := auth.CheckAuthorizedFetch(request)
+	if err != nil {
+		return err
+	}
 	vars := mux.Vars(request)
 	userService := dic.GetService[domain.UserService]()
 	user, err := userService.GetFullUser(request.Context(), vars["username"])
 
 	if err != nil {
-		var twitterUserNotFound twitter.UserNotFoundError
-		if errors.As(err, &twitterUserNotFound) || errors.Is(err, domain.ErrUserDoesNotExist) {
-			return internalerrors.Wrap(err, http.StatusNotFound).
-				WithUserMessage("user not found")
-		}
-		return internalerrors.Wrap(err, http.StatusInternalServerError)
+		return domain.UserHTTPError(err)
 	}
 
 	vocabService := dic.GetService[activitypub.VocabService]()
-	outbox, err := vocabService.GetOutbox(user)
-	if err != nil {
-		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	var outbox map[string]any
+	if request.URL.Query().Get("page") == "true" {
+		outbox, err = getOutboxPage(request, user)
+		if err != nil {
+			return err
+		}
+	} else {
+		outbox, err = vocabService.GetOutbox(user)
+		if err != nil {
+			return internalerrors.Wrap(err, http.StatusInternalServerError)
+		}
 	}
 
 	responseWriter.Header().Add("content-type", "application/activity+json")
@@ -133,77 +196,82 @@ func HandleOutbox(responseWriter http.ResponseWriter, request *http.Request) err
 	return nil
 }
 
+func getOutboxPage(request *http.Request, user *domainmodels.User) (map[string]any, error) {
+	conf := dic.GetService[config.Config]()
+	tweetRepo := dic.GetService[twitterrepository.TweetRepository]()
+	vocabService := dic.GetService[activitypub.VocabService]()
+
+	query := twitterrepository.TimelineQuery{
+		MaxID: request.URL.Query().Get("max_id"),
+		MinID: request.URL.Query().Get("min_id"),
+		Limit: conf.OutboxPageSize,
+	}
+	for _, cursor := range []string{query.MaxID, query.MinID} {
+		if cursor == "" {
+			continue
+		}
+		if _, err := strconv.ParseUint(cursor, 10, 64); err != nil {
+			return nil, internalerrors.Wrap(err, http.StatusBadRequest).
+				WithUserMessage("invalid page cursor")
+		}
+	}
+
+	tweets, err := tweetRepo.GetTimeline(request.Context(), user.Username, query)
+	if err != nil {
+		return nil, internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+	page, err := vocabService.GetOutboxPage(user, query, tweets)
+	if err != nil {
+		return nil, internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+	return page, nil
+}
+
 func HandleInbox(responseWriter http.ResponseWriter, request *http.Request) error {
 	if !auth.IsRequestSigned(request) {
 		return internalerrors.New("request signature failed", http.StatusForbidden).SkipCapture()
 	}
 
 	vars := mux.Vars(request)
-
-	// TODO See if we can move this check later in the code, so we could avoid
-	// hitting the cache for unsupported activities
-	twitterClient := dic.GetService[twitter.TwitterClient]()
-	_, err := twitterClient.GetUser(request.Context(), vars["username"])
-
+	userRepo := dic.GetService[repository.UserRepository]()
+	user, err := userRepo.Get(request.Context(), vars["username"])
 	if err != nil {
-		var twitterUserNotFound twitter.UserNotFoundError
-		if errors.As(err, &twitterUserNotFound) {
+		if errors.Is(err, sql.ErrNoRows) {
 			return internalerrors.Wrap(err, http.StatusNotFound).
+				SkipCapture().
 				WithUserMessage("user not found")
 		}
 		return internalerrors.Wrap(err, http.StatusInternalServerError)
 	}
 
-	inboxService := dic.GetService[domain.InboxService]()
 	var jsonMap map[string]interface{}
-	jsonResolver, err := streams.NewJSONResolver(
-		inboxService.Follow,
-		inboxService.UnFollow,
-		func(_ context.Context, _ vocab.ActivityStreamsDelete) error {
-			// TODO Handle delete operations
-			//   this method is just implemented to limit noise in logs
-			return nil
-		},
-	)
-	if err != nil {
-		return internalerrors.Wrap(
-			err,
-			http.StatusInternalServerError,
-		)
-	}
 	err = json.NewDecoder(request.Body).Decode(&jsonMap)
 	if err != nil {
-		return internalerrors.Wrap(
-			err,
-			http.StatusInternalServerError,
-		)
+		return internalerrors.Wrap(err, http.StatusBadRequest).
+			SkipCapture().
+			WithUserMessage("invalid json document")
 	}
-	err = jsonResolver.Resolve(request.Context(), jsonMap)
+
+	// Activities are processed in background, so remote servers do not wait for us to handle them
+	inboxService := dic.GetService[domain.InboxService]()
+	err = inboxService.Receive(request.Context(), user, jsonMap)
 	if err != nil {
-		var notAllowedErr *domain.ActorNotAllowedError
-		var notAllowedUndo *domain.UnsuportedUndoObjectError
+		var signerMismatch *domain.SignerMismatchError
 		switch {
-		case errors.As(err, &notAllowedErr):
+		case errors.As(err, &signerMismatch):
 			return internalerrors.Wrap(err, http.StatusForbidden).
 				SkipCapture().
-				WithUserMessage("not allowed to follow this user")
+				WithUserMessage("activity actor does not match the request signature")
 		case errors.Is(err, streams.ErrNoCallbackMatch):
 			return internalerrors.Wrap(err, http.StatusBadRequest).
 				SkipCapture().
 				WithUserMessage("unsupported activity")
-		case errors.Is(err, domain.ErrFollowMismatchDomain):
+		case errors.Is(err, domain.ErrMissingActivityID),
+			errors.Is(err, domain.ErrActivityIDMismatch),
+			errors.Is(err, activitypub.ErrMissingActor):
 			return internalerrors.Wrap(err, http.StatusBadRequest).
-				WithUserMessage("cannot follow a user that is not on this instance")
-		case errors.As(err, &notAllowedUndo):
-			err := internalerrors.Wrap(err, http.StatusBadRequest).
-				WithUserMessage("can only undo Follow activities")
-			if notAllowedUndo.VocabType != nil {
-				return err.WithContext("activity_type", notAllowedUndo.VocabType.GetTypeName())
-			}
-			return err
-		case errors.Is(err, domain.ErrUserDoesNotExist):
-			return internalerrors.Wrap(err, http.StatusBadRequest).
-				WithUserMessage("user not found")
+				SkipCapture().
+				WithUserMessage("invalid activity")
 		}
 		return internalerrors.Wrap(
 			err,
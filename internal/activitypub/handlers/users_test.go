@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"github.com/volatiletech/null/v8"
 
 	"github.com/estrys/estrys/internal/activitypub/auth"
 	"github.com/estrys/estrys/internal/activitypub/handlers"
@@ -30,6 +31,9 @@ import (
 	mocksuser "github.com/estrys/estrys/internal/repository/mocks"
 	"github.com/estrys/estrys/internal/twitter"
 	mockstwitter "github.com/estrys/estrys/internal/twitter/mocks"
+	twittermodels "github.com/estrys/estrys/internal/twitter/models"
+	twitterrepository "github.com/estrys/estrys/internal/twitter/repository"
+	mockstwitterrepo "github.com/estrys/estrys/internal/twitter/repository/mocks"
 	"github.com/estrys/estrys/internal/worker/client"
 	"github.com/estrys/estrys/internal/worker/client/mocks"
 	"github.com/estrys/estrys/internal/worker/tasks"
@@ -56,8 +60,65 @@ var (
 		auth.HTTPSignature,
 		func() *bool { v := true; return &v }(),
 	)
+	blockedSignerHTTPContext = context.WithValue(authenticatedHTTPContext,
+		auth.HTTPSignatureKeyID,
+		func() *string { v := "https://blocked.example.org/users/foobar#main-key"; return &v }(),
+	)
 )
 
+// signedByHTTPContext is a request context signed by a key of the actor.
+func signedByHTTPContext(actor string) context.Context {
+	ctx := context.WithValue(authenticatedHTTPContext,
+		auth.HTTPSignatureKeyID,
+		func() *string { v := actor + "#main-key"; return &v }(),
+	)
+	return context.WithValue(ctx,
+		auth.HTTPSignatureKeyOwner,
+		func() *string { v := actor; return &v }(),
+	)
+}
+
+// registerFediverseLinks registers the fediverse accounts found in the twitter profile of the user.
+func registerFediverseLinks(t *testing.T, links ...*models.FediverseLink) {
+	t.Helper()
+	fakeLinkRepo := mocksuser.NewFediverseLinkRepository(t)
+	fakeLinkRepo.EXPECT().List(mock.Anything, fakeUserName).Return(links, nil)
+	_ = dic.Register[repository.FediverseLinkRepository](fakeLinkRepo)
+}
+
+// registerUserProfile registers the cached twitter profile of the user, without banner nor links when profile is nil.
+func registerUserProfile(t *testing.T, profile *twitter.UserProfile) {
+	t.Helper()
+	if profile == nil {
+		profile = &twitter.UserProfile{}
+	}
+	fakeProfileCache := mockscache.NewCache[twitter.UserProfile](t)
+	fakeProfileCache.EXPECT().Get(mock.Anything, "twitter/profile/by-username/"+fakeUserName).Return(profile, nil)
+	_ = dic.Register[cache.Cache[twitter.UserProfile]](fakeProfileCache)
+}
+
+// registerOptOut registers the opt-out registry, the user did not opt out when optOut is nil.
+func registerOptOut(t *testing.T, optOut *models.OptOut) {
+	t.Helper()
+	fakeOptOutRepo := mocksuser.NewOptOutRepository(t)
+	if optOut == nil {
+		fakeOptOutRepo.EXPECT().Get(mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+	} else {
+		fakeOptOutRepo.EXPECT().Get(mock.Anything, mock.Anything).Return(optOut, nil)
+	}
+	_ = dic.Register[repository.OptOutRepository](fakeOptOutRepo)
+}
+
+func enableAuthorizedFetch(t *testing.T) {
+	t.Helper()
+	viper.Set("authorized_fetch", true)
+	viper.Set("blocked_domains", "example.org")
+	t.Cleanup(func() {
+		viper.Set("authorized_fetch", false)
+		viper.Set("blocked_domains", "")
+	})
+}
+
 func (suite *UserHandlerTestSuite) TestHandleUser() {
 	cases := []tests.HTTPTestCase{
 		{
@@ -101,6 +162,8 @@ func (suite *UserHandlerTestSuite) TestHandleUser() {
 							gotwitter.UserFieldProfileImageURL,
 							gotwitter.UserFieldCreatedAt,
 							gotwitter.UserFieldPublicMetrics,
+							gotwitter.UserFieldURL,
+							gotwitter.UserFieldLocation,
 						},
 					},
 				).Return(&gotwitter.UserLookupResponse{
@@ -131,6 +194,9 @@ func (suite *UserHandlerTestSuite) TestHandleUser() {
 						CreatedAt:  fakeUserCreatedAt,
 					}, nil)
 				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
 			},
 			StatusCode: http.StatusOK,
 			GoldenFile: "user/ok.json",
@@ -178,6 +244,8 @@ func (suite *UserHandlerTestSuite) TestHandleUser() {
 							gotwitter.UserFieldProfileImageURL,
 							gotwitter.UserFieldCreatedAt,
 							gotwitter.UserFieldPublicMetrics,
+							gotwitter.UserFieldURL,
+							gotwitter.UserFieldLocation,
 						},
 					},
 				).Return(&gotwitter.UserLookupResponse{
@@ -224,6 +292,8 @@ func (suite *UserHandlerTestSuite) TestHandleUser() {
 							gotwitter.UserFieldProfileImageURL,
 							gotwitter.UserFieldCreatedAt,
 							gotwitter.UserFieldPublicMetrics,
+							gotwitter.UserFieldURL,
+							gotwitter.UserFieldLocation,
 						},
 					},
 				).Return(nil, errors.New("unexpected error"))
@@ -231,12 +301,206 @@ func (suite *UserHandlerTestSuite) TestHandleUser() {
 			},
 			StatusCode: http.StatusInternalServerError,
 		},
+		{
+			Name: "moved user",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+			},
+			Mock: func(t *testing.T) {
+				fakeCache := mockscache.NewCache[gotwitter.UserObj](t)
+				fakeCache.On(
+					"Get", mock.Anything, mock.Anything).Return(&gotwitter.UserObj{
+					ID:              "12345",
+					Name:            "Foo Bar",
+					CreatedAt:       fakeUserCreatedAtStr,
+					Description:     "This is a fake twitter user",
+					ProfileImageURL: fakeUserProfileUrl.String(),
+					PublicMetrics:   &gotwitter.UserMetricsObj{Followers: 13, Following: 37, Tweets: 42},
+				}, nil)
+				_ = dic.Register[cache.Cache[gotwitter.UserObj]](fakeCache)
+
+				fakeUserRepo := mocksuser.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(
+					&models.User{
+						Username:   fakeUserName,
+						PrivateKey: privKey.Bytes,
+						CreatedAt:  fakeUserCreatedAt,
+						MovedTo:    null.StringFrom("https://another-instance.example.com/users/foobar"),
+					}, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "user/moved.json",
+		},
+		{
+			Name: "user with profile fields and fediverse links",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+			},
+			Mock: func(t *testing.T) {
+				fakeCache := mockscache.NewCache[gotwitter.UserObj](t)
+				fakeCache.On(
+					"Get", mock.Anything, mock.Anything).Return(&gotwitter.UserObj{
+					ID:              "12345",
+					Name:            "Foo Bar",
+					CreatedAt:       fakeUserCreatedAtStr,
+					Description:     "This is a fake twitter user, now at @foobar@another-instance.example.com",
+					URL:             "https://t.co/website",
+					Location:        "Paris & Lyon",
+					ProfileImageURL: fakeUserProfileUrl.String(),
+					PublicMetrics:   &gotwitter.UserMetricsObj{Followers: 13, Following: 37, Tweets: 42},
+				}, nil)
+				_ = dic.Register[cache.Cache[gotwitter.UserObj]](fakeCache)
+
+				fakeUserRepo := mocksuser.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(
+					&models.User{
+						Username:   fakeUserName,
+						PrivateKey: privKey.Bytes,
+						CreatedAt:  fakeUserCreatedAt,
+					}, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t, &models.FediverseLink{
+					User:     fakeUserName,
+					Handle:   "foobar@another-instance.example.com",
+					ActorURL: "https://another-instance.example.com/users/foobar",
+				})
+				registerUserProfile(t, &twitter.UserProfile{
+					BannerURL: "https://pbs.twimg.com/profile_banners/12345/1669000000",
+					Website:   "https://example.org/foobar",
+				})
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "user/fediverse_links.json",
+		},
+		{
+			Name: "user not flagged as a bot",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+			},
+			Mock: func(t *testing.T) {
+				viper.Set("bot_actors", false)
+				viper.Set("actor_name_suffix", "(bridged)")
+				viper.Set("actor_bio_suffix", "")
+				t.Cleanup(func() {
+					viper.Set("bot_actors", true)
+					viper.Set("actor_name_suffix", "")
+					viper.Set("actor_bio_suffix", "Unofficial mirror of a Twitter account, posts are copied automatically.")
+				})
+				fakeCache := mockscache.NewCache[gotwitter.UserObj](t)
+				fakeCache.On(
+					"Get", mock.Anything, mock.Anything).Return(&gotwitter.UserObj{
+					ID:              "12345",
+					Name:            "Foo Bar",
+					CreatedAt:       fakeUserCreatedAtStr,
+					Description:     "This is a fake twitter user",
+					ProfileImageURL: fakeUserProfileUrl.String(),
+					PublicMetrics:   &gotwitter.UserMetricsObj{Followers: 13, Following: 37, Tweets: 42},
+				}, nil)
+				_ = dic.Register[cache.Cache[gotwitter.UserObj]](fakeCache)
+
+				fakeUserRepo := mocksuser.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(
+					&models.User{
+						Username:   fakeUserName,
+						PrivateKey: privKey.Bytes,
+						CreatedAt:  fakeUserCreatedAt,
+					}, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "user/not_bot.json",
+		},
+		{
+			Name: "user opted out",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+			},
+			Mock: func(t *testing.T) {
+				fakeCache := mockscache.NewCache[gotwitter.UserObj](t)
+				fakeCache.On("Get", mock.Anything, mock.Anything).Return(&gotwitter.UserObj{
+					ID:          "12345",
+					UserName:    fakeUserName,
+					Name:        "Foo Bar",
+					CreatedAt:   fakeUserCreatedAtStr,
+					Description: "This is a fake twitter user",
+				}, nil)
+				_ = dic.Register[cache.Cache[gotwitter.UserObj]](fakeCache)
+
+				fakeUserRepo := mocksuser.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(
+					&models.User{
+						Username:   fakeUserName,
+						PrivateKey: privKey.Bytes,
+						CreatedAt:  fakeUserCreatedAt,
+					}, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, &models.OptOut{Username: fakeUserName, Reason: string(repository.OptOutReasonAdmin)})
+			},
+			StatusCode: http.StatusGone,
+		},
+		{
+			Name: "authorized fetch with unsigned request",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+			},
+			Mock: func(t *testing.T) {
+				enableAuthorizedFetch(t)
+				fakeCache := mockscache.NewCache[gotwitter.UserObj](t)
+				fakeCache.On(
+					"Get", mock.Anything, mock.Anything).Return(&gotwitter.UserObj{
+					ID:              "12345",
+					Name:            "Foo Bar",
+					CreatedAt:       fakeUserCreatedAtStr,
+					ProfileImageURL: fakeUserProfileUrl.String(),
+					PublicMetrics:   &gotwitter.UserMetricsObj{},
+				}, nil)
+				_ = dic.Register[cache.Cache[gotwitter.UserObj]](fakeCache)
+
+				fakeUserRepo := mocksuser.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(
+					&models.User{
+						Username:   fakeUserName,
+						PrivateKey: privKey.Bytes,
+						CreatedAt:  fakeUserCreatedAt,
+					}, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "user/minimal.json",
+		},
+		{
+			Name: "authorized fetch with blocked signer",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+				tests.RequestContext{Context: blockedSignerHTTPContext},
+			},
+			Mock: func(t *testing.T) {
+				enableAuthorizedFetch(t)
+			},
+			StatusCode: http.StatusForbidden,
+		},
 	}
 
 	suite.RunHTTPCases(suite.T(), handlers.HandleUser, cases)
 }
 
 func (suite *UserHandlerTestSuite) TestHandleFollowers() {
+	fakeDBUser := &models.User{
+		Username:   fakeUserName,
+		PrivateKey: privKey.Bytes,
+		CreatedAt:  fakeUserCreatedAt,
+	}
 	cases := []tests.HTTPTestCase{
 		{
 			Name: "user found",
@@ -255,17 +519,123 @@ func (suite *UserHandlerTestSuite) TestHandleFollowers() {
 				_ = dic.Register[cache.Cache[gotwitter.UserObj]](fakeCache)
 
 				fakeUserRepo := mocksuser.NewUserRepository(t)
-				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(
-					&models.User{
-						Username:   fakeUserName,
-						PrivateKey: privKey.Bytes,
-						CreatedAt:  fakeUserCreatedAt,
-					}, nil)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(fakeDBUser, nil)
+				fakeUserRepo.On("CountFollowers", mock.Anything, fakeDBUser).Return(int64(3), nil)
 				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
 			},
 			StatusCode: http.StatusOK,
 			GoldenFile: "followers/ok.json",
 		},
+		{
+			Name: "followers page",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+				tests.RequestQuery{Query: url.Values{"page": []string{"1"}}},
+			},
+			Mock: func(t *testing.T) {
+				viper.Set("followers_page_size", 2)
+				fakeCache := mockscache.NewCache[gotwitter.UserObj](t)
+				fakeCache.On("Get", mock.Anything, mock.Anything).Return(&gotwitter.UserObj{
+					ProfileImageURL: fakeUserProfileUrl.String(),
+					PublicMetrics:   &gotwitter.UserMetricsObj{},
+				}, nil)
+				_ = dic.Register[cache.Cache[gotwitter.UserObj]](fakeCache)
+
+				fakeUserRepo := mocksuser.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(fakeDBUser, nil)
+				fakeUserRepo.On("CountFollowers", mock.Anything, fakeDBUser).Return(int64(3), nil)
+				fakeUserRepo.On("GetFollowersPage", mock.Anything, fakeDBUser, 0, 2).Return(models.ActorSlice{
+					{URL: "https://another-instance.example.com/users/alice"},
+					{URL: "https://another-instance.example.com/users/bob"},
+				}, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "followers/page.json",
+		},
+		{
+			Name: "invalid page",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+				tests.RequestQuery{Query: url.Values{"page": []string{"0"}}},
+			},
+			Mock: func(t *testing.T) {
+				fakeCache := mockscache.NewCache[gotwitter.UserObj](t)
+				fakeCache.On("Get", mock.Anything, mock.Anything).Return(&gotwitter.UserObj{
+					ProfileImageURL: fakeUserProfileUrl.String(),
+					PublicMetrics:   &gotwitter.UserMetricsObj{},
+				}, nil)
+				_ = dic.Register[cache.Cache[gotwitter.UserObj]](fakeCache)
+
+				fakeUserRepo := mocksuser.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(fakeDBUser, nil)
+				fakeUserRepo.On("CountFollowers", mock.Anything, fakeDBUser).Return(int64(3), nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
+			},
+			StatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "page after the last one",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+				tests.RequestQuery{Query: url.Values{"page": []string{"3"}}},
+			},
+			Mock: func(t *testing.T) {
+				viper.Set("followers_page_size", 2)
+				t.Cleanup(func() { viper.Set("followers_page_size", 0) })
+				fakeCache := mockscache.NewCache[gotwitter.UserObj](t)
+				fakeCache.On("Get", mock.Anything, mock.Anything).Return(&gotwitter.UserObj{
+					ProfileImageURL: fakeUserProfileUrl.String(),
+					PublicMetrics:   &gotwitter.UserMetricsObj{},
+				}, nil)
+				_ = dic.Register[cache.Cache[gotwitter.UserObj]](fakeCache)
+
+				fakeUserRepo := mocksuser.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(fakeDBUser, nil)
+				fakeUserRepo.On("CountFollowers", mock.Anything, fakeDBUser).Return(int64(3), nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
+			},
+			StatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "hidden followers",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+				tests.RequestQuery{Query: url.Values{"page": []string{"1"}}},
+			},
+			Mock: func(t *testing.T) {
+				viper.Set("hide_followers", true)
+				t.Cleanup(func() { viper.Set("hide_followers", false) })
+				fakeCache := mockscache.NewCache[gotwitter.UserObj](t)
+				fakeCache.On("Get", mock.Anything, mock.Anything).Return(&gotwitter.UserObj{
+					ProfileImageURL: fakeUserProfileUrl.String(),
+					PublicMetrics:   &gotwitter.UserMetricsObj{},
+				}, nil)
+				_ = dic.Register[cache.Cache[gotwitter.UserObj]](fakeCache)
+
+				fakeUserRepo := mocksuser.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(fakeDBUser, nil)
+				fakeUserRepo.On("CountFollowers", mock.Anything, fakeDBUser).Return(int64(3), nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "followers/hidden.json",
+		},
 		{
 			Name: "user no found",
 			RequestOptions: []tests.RequestOption{
@@ -310,6 +680,8 @@ func (suite *UserHandlerTestSuite) TestHandleFollowers() {
 							gotwitter.UserFieldProfileImageURL,
 							gotwitter.UserFieldCreatedAt,
 							gotwitter.UserFieldPublicMetrics,
+							gotwitter.UserFieldURL,
+							gotwitter.UserFieldLocation,
 						},
 					},
 				).Return(nil, errors.New("unexpected error"))
@@ -348,6 +720,9 @@ func (suite *UserHandlerTestSuite) TestHandleFollowing() {
 						CreatedAt:  fakeUserCreatedAt,
 					}, nil)
 				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
 			},
 			StatusCode: http.StatusOK,
 			GoldenFile: "following/ok.json",
@@ -396,6 +771,8 @@ func (suite *UserHandlerTestSuite) TestHandleFollowing() {
 							gotwitter.UserFieldProfileImageURL,
 							gotwitter.UserFieldCreatedAt,
 							gotwitter.UserFieldPublicMetrics,
+							gotwitter.UserFieldURL,
+							gotwitter.UserFieldLocation,
 						},
 					},
 				).Return(nil, errors.New("unexpected error"))
@@ -434,6 +811,9 @@ func (suite *UserHandlerTestSuite) TestHandleOutbox() {
 						CreatedAt:  fakeUserCreatedAt,
 					}, nil)
 				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
 			},
 			StatusCode: http.StatusOK,
 			GoldenFile: "outbox/ok.json",
@@ -482,6 +862,8 @@ func (suite *UserHandlerTestSuite) TestHandleOutbox() {
 							gotwitter.UserFieldProfileImageURL,
 							gotwitter.UserFieldCreatedAt,
 							gotwitter.UserFieldPublicMetrics,
+							gotwitter.UserFieldURL,
+							gotwitter.UserFieldLocation,
 						},
 					},
 				).Return(nil, errors.New("unexpected error"))
@@ -489,11 +871,173 @@ func (suite *UserHandlerTestSuite) TestHandleOutbox() {
 			},
 			StatusCode: http.StatusInternalServerError,
 		},
+		{
+			Name: "first page",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+				tests.RequestQuery{Query: url.Values{"page": []string{"true"}}},
+			},
+			Mock: func(t *testing.T) {
+				viper.Set("outbox_page_size", 2)
+				fakeCache := mockscache.NewCache[gotwitter.UserObj](t)
+				fakeCache.On("Get", mock.Anything, mock.Anything).Return(&gotwitter.UserObj{
+					ProfileImageURL: fakeUserProfileUrl.String(),
+					PublicMetrics:   &gotwitter.UserMetricsObj{},
+				}, nil)
+				_ = dic.Register[cache.Cache[gotwitter.UserObj]](fakeCache)
+
+				fakeUserRepo := mocksuser.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(
+					&models.User{
+						Username:   fakeUserName,
+						PrivateKey: privKey.Bytes,
+						CreatedAt:  fakeUserCreatedAt,
+					}, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
+
+				fakeTweetRepo := mockstwitterrepo.NewTweetRepository(t)
+				fakeTweetRepo.EXPECT().GetTimeline(mock.Anything, fakeUserName, twitterrepository.TimelineQuery{
+					Limit: 2,
+				}).Return([]twittermodels.Tweet{
+					{
+						ID:             "1002",
+						AuthorUsername: fakeUserName,
+						Text:           "tweet content",
+						Published:      fakeUserCreatedAt,
+					},
+					{
+						ID:             "1001",
+						AuthorUsername: fakeUserName,
+						Text:           "RT @someone: retweeted content",
+						Published:      fakeUserCreatedAt,
+						ReferencedTweets: []twittermodels.Tweet{
+							{
+								ID:             "42",
+								AuthorUsername: "someone",
+								ReferencedType: twittermodels.ReferenceTypeRetweet,
+							},
+						},
+					},
+				}, nil)
+				_ = dic.Register[twitterrepository.TweetRepository](fakeTweetRepo)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "outbox/first_page.json",
+		},
+		{
+			Name: "last page",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+				tests.RequestQuery{Query: url.Values{"page": []string{"true"}, "max_id": []string{"1001"}}},
+			},
+			Mock: func(t *testing.T) {
+				viper.Set("outbox_page_size", 2)
+				fakeCache := mockscache.NewCache[gotwitter.UserObj](t)
+				fakeCache.On("Get", mock.Anything, mock.Anything).Return(&gotwitter.UserObj{
+					ProfileImageURL: fakeUserProfileUrl.String(),
+					PublicMetrics:   &gotwitter.UserMetricsObj{},
+				}, nil)
+				_ = dic.Register[cache.Cache[gotwitter.UserObj]](fakeCache)
+
+				fakeUserRepo := mocksuser.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(
+					&models.User{
+						Username:   fakeUserName,
+						PrivateKey: privKey.Bytes,
+						CreatedAt:  fakeUserCreatedAt,
+					}, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
+
+				fakeTweetRepo := mockstwitterrepo.NewTweetRepository(t)
+				fakeTweetRepo.EXPECT().GetTimeline(mock.Anything, fakeUserName, twitterrepository.TimelineQuery{
+					MaxID: "1001",
+					Limit: 2,
+				}).Return([]twittermodels.Tweet{
+					{
+						ID:             "1000",
+						AuthorUsername: fakeUserName,
+						Text:           "first tweet",
+						Published:      fakeUserCreatedAt,
+					},
+				}, nil)
+				_ = dic.Register[twitterrepository.TweetRepository](fakeTweetRepo)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "outbox/last_page.json",
+		},
+		{
+			Name: "invalid cursor",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+				tests.RequestQuery{Query: url.Values{"page": []string{"true"}, "min_id": []string{"abc"}}},
+			},
+			Mock: func(t *testing.T) {
+				fakeCache := mockscache.NewCache[gotwitter.UserObj](t)
+				fakeCache.On("Get", mock.Anything, mock.Anything).Return(&gotwitter.UserObj{
+					ProfileImageURL: fakeUserProfileUrl.String(),
+					PublicMetrics:   &gotwitter.UserMetricsObj{},
+				}, nil)
+				_ = dic.Register[cache.Cache[gotwitter.UserObj]](fakeCache)
+
+				fakeUserRepo := mocksuser.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(
+					&models.User{
+						Username:   fakeUserName,
+						PrivateKey: privKey.Bytes,
+						CreatedAt:  fakeUserCreatedAt,
+					}, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerOptOut(t, nil)
+				registerFediverseLinks(t)
+				registerUserProfile(t, nil)
+			},
+			StatusCode: http.StatusBadRequest,
+		},
+		{
+			Name: "authorized fetch with unsigned request",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+			},
+			Mock: func(t *testing.T) {
+				enableAuthorizedFetch(t)
+			},
+			StatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name: "authorized fetch with blocked signer",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+				tests.RequestContext{Context: blockedSignerHTTPContext},
+			},
+			Mock: func(t *testing.T) {
+				enableAuthorizedFetch(t)
+			},
+			StatusCode: http.StatusForbidden,
+		},
 	}
 
 	suite.RunHTTPCases(suite.T(), handlers.HandleOutbox, cases)
 }
 
+func registerInboxUser(t *testing.T) *models.User {
+	t.Helper()
+	fakeUser := &models.User{
+		Username:   fakeUserName,
+		PrivateKey: privKey.Bytes,
+		CreatedAt:  fakeUserCreatedAt,
+	}
+	fakeUserRepo := mocksuser.NewUserRepository(t)
+	fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(fakeUser, nil)
+	_ = dic.Register[repository.UserRepository](fakeUserRepo)
+	return fakeUser
+}
+
 func (suite *UserHandlerTestSuite) TestHandleInbox() {
 	cases := []tests.HTTPTestCase{
 		{
@@ -501,21 +1045,21 @@ func (suite *UserHandlerTestSuite) TestHandleInbox() {
 			StatusCode: http.StatusForbidden,
 		},
 		{
-			Name: "twitter user no found",
+			Name: "user not found",
 			RequestOptions: []tests.RequestOption{
 				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
 				tests.RequestContext{Context: authenticatedHTTPContext},
 			},
 			Mock: func(t *testing.T) {
-				fakeTwitterClient := mockstwitter.NewTwitterClient(t)
-				fakeTwitterClient.On("GetUser", mock.Anything, fakeUserName).Return(
-					nil, twitter.UserNotFoundError{fakeUserName},
+				fakeUserRepo := mocksuser.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, fakeUserName).Return(
+					nil, errors.Wrap(sql.ErrNoRows, "unable to fetch user from database"),
 				)
-				_ = dic.Register[twitter.TwitterClient](fakeTwitterClient)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
 			},
+			GoldenFile: "errors/user_not_found.json",
 			StatusCode: http.StatusNotFound,
 		},
-		//TODO add more error cases here
 		{
 			Name: "invalid activity",
 			RequestOptions: []tests.RequestOption{
@@ -524,250 +1068,206 @@ func (suite *UserHandlerTestSuite) TestHandleInbox() {
 				tests.RequestBodyFromFile{FilePath: "inbox/input/unsupported_activity.json"},
 			},
 			Mock: func(t *testing.T) {
-				fakeTwitterClient := mockstwitter.NewTwitterClient(t)
-				fakeTwitterClient.On("GetUser", mock.Anything, fakeUserName).Return(
-					nil, nil,
-				)
-				_ = dic.Register[twitter.TwitterClient](fakeTwitterClient)
+				registerInboxUser(t)
 			},
 			GoldenFile: "errors/invalid_activity.json",
 			StatusCode: http.StatusBadRequest,
 		},
-	}
-
-	suite.RunHTTPCases(suite.T(), handlers.HandleInbox, cases)
-}
-
-func (suite *UserHandlerTestSuite) TestHandleInbox_Follow() {
-	cases := []tests.HTTPTestCase{
-		//TODO add more error cases here
 		{
-			Name: "object domain mismatch",
+			Name: "activity without id",
 			RequestOptions: []tests.RequestOption{
 				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
-				tests.RequestContext{Context: authenticatedHTTPContext},
-				tests.RequestBodyFromFile{FilePath: "inbox/input/domain_mismatch.json"},
+				tests.RequestContext{Context: signedByHTTPContext("https://another-instance.example.com/users/validactor")},
+				tests.RequestBodyFromFile{FilePath: "inbox/input/follow_without_id.json"},
 			},
 			Mock: func(t *testing.T) {
-				fakeTwitterClient := mockstwitter.NewTwitterClient(t)
-				fakeTwitterClient.On("GetUser", mock.Anything, fakeUserName).Return(
-					nil, nil,
-				)
-				_ = dic.Register[twitter.TwitterClient](fakeTwitterClient)
-
+				registerInboxUser(t)
 			},
-			GoldenFile: "errors/follow_domain_mismatch.json",
+			GoldenFile: "errors/missing_activity_id.json",
 			StatusCode: http.StatusBadRequest,
 		},
 		{
-			Name: "no user in db",
+			Name: "activity id of another instance",
 			RequestOptions: []tests.RequestOption{
 				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
-				tests.RequestContext{Context: authenticatedHTTPContext},
-				tests.RequestBodyFromFile{FilePath: "inbox/input/no_user_in_db.json"},
+				tests.RequestContext{Context: signedByHTTPContext("https://another-instance.example.com/users/validactor")},
+				tests.RequestBodyFromFile{FilePath: "inbox/input/follow_foreign_id.json"},
 			},
 			Mock: func(t *testing.T) {
-				fakeTwitterClient := mockstwitter.NewTwitterClient(t)
-				fakeTwitterClient.On("GetUser", mock.Anything, fakeUserName).Return(
-					nil, nil,
-				)
-				_ = dic.Register[twitter.TwitterClient](fakeTwitterClient)
-
-				fakeUserRepo := mocksuser.NewUserRepository(t)
-				fakeUserRepo.On("Get", mock.Anything, "notfound").Return(
-					nil, sql.ErrNoRows)
-				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerInboxUser(t)
 			},
-			GoldenFile: "errors/user_not_found.json",
+			GoldenFile: "errors/missing_activity_id.json",
 			StatusCode: http.StatusBadRequest,
 		},
 		{
-			Name: "actor not allowed",
+			Name: "follow signed by another actor",
 			RequestOptions: []tests.RequestOption{
 				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
-				tests.RequestContext{Context: authenticatedHTTPContext},
+				tests.RequestContext{Context: signedByHTTPContext("https://another-instance.example.com/users/mallory")},
 				tests.RequestBodyFromFile{FilePath: "inbox/input/valid_follow.json"},
 			},
 			Mock: func(t *testing.T) {
-				fakeTwitterClient := mockstwitter.NewTwitterClient(t)
-				fakeTwitterClient.On("GetUser", mock.Anything, fakeUserName).Return(
-					nil, nil,
-				)
-				_ = dic.Register[twitter.TwitterClient](fakeTwitterClient)
-
-				fakeUserRepo := mocksuser.NewUserRepository(t)
-				fakeUserRepo.On("Get", mock.Anything, "validuser").Return(
-					&models.User{
-						Username:   "validuser",
-						PrivateKey: privKey.Bytes,
-						CreatedAt:  fakeUserCreatedAt,
-					}, nil)
-				_ = dic.Register[repository.UserRepository](fakeUserRepo)
-
-				fakeActor := &models.Actor{}
-				fakeActorUrl, _ := url.Parse("https://another-instance.example.com/users/validactor")
-				fakeActorRepo := mocksuser.NewActorRepository(t)
-				fakeActorRepo.On("Get", mock.Anything, fakeActorUrl).Return(fakeActor, nil)
-				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
-
-				fakeWorker := mocks.NewBackgroundWorkerClient(t)
-				fakeWorker.On("Enqueue", mock.MatchedBy(func(task *asynq.Task) bool {
-					expectedAccept := tasks.RejectFollowInput{
-						TraceID:  "",
-						Username: "validuser",
-						Activity: map[string]interface{}{
-							"@context": "https://www.w3.org/ns/activitystreams",
-							"type":     "Follow",
-							"actor":    "https://another-instance.example.com/users/validactor",
-							"id":       "https://another-instance.example.com/bdd01ced-d657-4847-a266-2c43e1cd8dc5",
-							"object":   "https://example.com/users/validuser",
-						},
-					}
-					accept := tasks.RejectFollowInput{}
-					err := json.Unmarshal(task.Payload(), &accept)
-					if err != nil {
-						return assert.NoError(t, err)
-					}
-					return assert.Equal(t, expectedAccept, accept)
-				})).Return(nil, nil)
-				_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
+				registerInboxUser(t)
 			},
-			GoldenFile: "errors/actor_not_allowed_to_follow.json",
+			GoldenFile: "errors/signer_mismatch.json",
 			StatusCode: http.StatusForbidden,
 		},
 		{
-			Name: "valid follow",
+			Name: "follow signed by another instance",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+				tests.RequestContext{Context: signedByHTTPContext("https://evil.example.org/users/validactor")},
+				tests.RequestBodyFromFile{FilePath: "inbox/input/valid_follow.json"},
+			},
+			Mock: func(t *testing.T) {
+				registerInboxUser(t)
+			},
+			GoldenFile: "errors/signer_mismatch.json",
+			StatusCode: http.StatusForbidden,
+		},
+		{
+			Name: "unknown key owner",
 			RequestOptions: []tests.RequestOption{
 				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
 				tests.RequestContext{Context: authenticatedHTTPContext},
 				tests.RequestBodyFromFile{FilePath: "inbox/input/valid_follow.json"},
 			},
 			Mock: func(t *testing.T) {
-				fakeTwitterClient := mockstwitter.NewTwitterClient(t)
-				fakeTwitterClient.On("GetUser", mock.Anything, fakeUserName).Return(
-					nil, nil,
-				)
-				_ = dic.Register[twitter.TwitterClient](fakeTwitterClient)
-
-				fakeUser := &models.User{
-					Username:   "validuser",
-					PrivateKey: privKey.Bytes,
-					CreatedAt:  fakeUserCreatedAt,
-				}
-				fakeActor := &models.Actor{}
-				fakeUserRepo := mocksuser.NewUserRepository(t)
-				fakeUserRepo.On("Get", mock.Anything, "validuser").Return(fakeUser, nil)
-				fakeUserRepo.On("Follow", mock.Anything, fakeUser, fakeActor).Return(nil)
-				_ = dic.Register[repository.UserRepository](fakeUserRepo)
-
-				fakeActorUrl, _ := url.Parse("https://another-instance.example.com/users/validactor")
-				fakeActorRepo := mocksuser.NewActorRepository(t)
-				fakeActorRepo.On("Get", mock.Anything, fakeActorUrl).Return(fakeActor, nil)
-				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
-
-				fakeWorker := mocks.NewBackgroundWorkerClient(t)
-				fakeWorker.On("Enqueue", mock.MatchedBy(func(task *asynq.Task) bool {
-					expectedAccept := tasks.AcceptFollowInput{
-						TraceID:  "",
-						Username: "validuser",
-						Activity: map[string]interface{}{
-							"@context": "https://www.w3.org/ns/activitystreams",
-							"type":     "Follow",
-							"actor":    "https://another-instance.example.com/users/validactor",
-							"id":       "https://another-instance.example.com/bdd01ced-d657-4847-a266-2c43e1cd8dc5",
-							"object":   "https://example.com/users/validuser",
-						},
-					}
-					accept := tasks.AcceptFollowInput{}
-					err := json.Unmarshal(task.Payload(), &accept)
-					if err != nil {
-						return assert.NoError(t, err)
-					}
-					return assert.Equal(t, expectedAccept, accept)
-				})).Return(nil, nil)
-				_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
+				registerInboxUser(t)
+			},
+			GoldenFile: "errors/signer_mismatch.json",
+			StatusCode: http.StatusForbidden,
+		},
+		{
+			Name: "undo signed by another actor",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+				tests.RequestContext{Context: signedByHTTPContext("https://another-instance.example.com/users/mallory")},
+				tests.RequestBodyFromFile{FilePath: "inbox/input/valid_undo_follow.json"},
+			},
+			Mock: func(t *testing.T) {
+				registerInboxUser(t)
+			},
+			GoldenFile: "errors/signer_mismatch.json",
+			StatusCode: http.StatusForbidden,
+		},
+		{
+			Name: "update signed by another actor",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+				tests.RequestContext{Context: signedByHTTPContext("https://another-instance.example.com/users/mallory")},
+				tests.RequestBodyFromFile{FilePath: "inbox/input/update_actor.json"},
+			},
+			Mock: func(t *testing.T) {
+				registerInboxUser(t)
+			},
+			GoldenFile: "errors/signer_mismatch.json",
+			StatusCode: http.StatusForbidden,
+		},
+		{
+			Name: "already processed activity",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
+				tests.RequestContext{Context: signedByHTTPContext("https://another-instance.example.com/users/validactor")},
+				tests.RequestBodyFromFile{FilePath: "inbox/input/valid_follow.json"},
+			},
+			Mock: func(t *testing.T) {
+				registerInboxUser(t)
 
-				viper.Set("allowed_users", "validactor@another-instance.example.com")
+				fakeInboundActivityRepo := mocksuser.NewInboundActivityRepository(t)
+				fakeInboundActivityRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(
+					&models.InboundActivity{
+						ID:          "0b9c1d7e-4a5f-4c3e-9b2a-8d7e6f5a4b3c",
+						ProcessedAt: null.TimeFrom(time.Now()),
+					}, false, nil,
+				)
+				_ = dic.Register[repository.InboundActivityRepository](fakeInboundActivityRepo)
 			},
 			StatusCode: http.StatusAccepted,
 		},
-	}
-
-	suite.RunHTTPCases(suite.T(), handlers.HandleInbox, cases)
-}
-
-func (suite *UserHandlerTestSuite) TestHandleInbox_UnFollow() {
-	cases := []tests.HTTPTestCase{
 		{
-			Name: "no user in db",
+			Name: "already received activity which failed to be scheduled",
 			RequestOptions: []tests.RequestOption{
 				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
-				tests.RequestContext{Context: authenticatedHTTPContext},
-				tests.RequestBodyFromFile{FilePath: "inbox/input/no_user_in_db.json"},
+				tests.RequestContext{Context: signedByHTTPContext("https://another-instance.example.com/users/validactor")},
+				tests.RequestBodyFromFile{FilePath: "inbox/input/valid_follow.json"},
 			},
 			Mock: func(t *testing.T) {
-				fakeTwitterClient := mockstwitter.NewTwitterClient(t)
-				fakeTwitterClient.On("GetUser", mock.Anything, fakeUserName).Return(
-					nil, nil,
+				registerInboxUser(t)
+
+				fakeInboundActivityRepo := mocksuser.NewInboundActivityRepository(t)
+				fakeInboundActivityRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(
+					&models.InboundActivity{ID: "0b9c1d7e-4a5f-4c3e-9b2a-8d7e6f5a4b3c"}, false, nil,
 				)
-				_ = dic.Register[twitter.TwitterClient](fakeTwitterClient)
+				_ = dic.Register[repository.InboundActivityRepository](fakeInboundActivityRepo)
 
-				fakeUserRepo := mocksuser.NewUserRepository(t)
-				fakeUserRepo.On("Get", mock.Anything, "notfound").Return(
-					nil, sql.ErrNoRows)
-				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				fakeWorker := mocks.NewBackgroundWorkerClient(t)
+				fakeWorker.On("Enqueue", mock.MatchedBy(func(task *asynq.Task) bool {
+					return task.Type() == tasks.TypeProcessInboundActivity
+				})).Return(nil, nil)
+				_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
 			},
-			GoldenFile: "errors/user_not_found.json",
-			StatusCode: http.StatusBadRequest,
+			StatusCode: http.StatusAccepted,
 		},
 		{
-			Name: "can only undo follow",
+			Name: "already received activity waiting to be processed",
 			RequestOptions: []tests.RequestOption{
 				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
-				tests.RequestContext{Context: authenticatedHTTPContext},
-				tests.RequestBodyFromFile{FilePath: "inbox/input/invalid_undo_reject.json"},
+				tests.RequestContext{Context: signedByHTTPContext("https://another-instance.example.com/users/validactor")},
+				tests.RequestBodyFromFile{FilePath: "inbox/input/valid_follow.json"},
 			},
 			Mock: func(t *testing.T) {
-				fakeTwitterClient := mockstwitter.NewTwitterClient(t)
-				fakeTwitterClient.On("GetUser", mock.Anything, fakeUserName).Return(
-					nil, nil,
+				registerInboxUser(t)
+
+				fakeInboundActivityRepo := mocksuser.NewInboundActivityRepository(t)
+				fakeInboundActivityRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(
+					&models.InboundActivity{ID: "0b9c1d7e-4a5f-4c3e-9b2a-8d7e6f5a4b3c"}, false, nil,
 				)
-				_ = dic.Register[twitter.TwitterClient](fakeTwitterClient)
+				_ = dic.Register[repository.InboundActivityRepository](fakeInboundActivityRepo)
+
+				fakeWorker := mocks.NewBackgroundWorkerClient(t)
+				fakeWorker.On("Enqueue", mock.Anything).Return(nil, asynq.ErrTaskIDConflict)
+				_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
 			},
-			GoldenFile: "errors/cannot_undo.json",
-			StatusCode: http.StatusBadRequest,
+			StatusCode: http.StatusAccepted,
 		},
 		{
-			Name: "valid undo follow",
+			Name: "valid follow",
 			RequestOptions: []tests.RequestOption{
 				tests.RequestParams{Params: map[string]string{"username": fakeUserName}},
-				tests.RequestContext{Context: authenticatedHTTPContext},
-				tests.RequestBodyFromFile{FilePath: "inbox/input/valid_undo_follow.json"},
+				tests.RequestContext{Context: signedByHTTPContext("https://another-instance.example.com/users/validactor")},
+				tests.RequestBodyFromFile{FilePath: "inbox/input/valid_follow.json"},
 			},
 			Mock: func(t *testing.T) {
-				fakeTwitterClient := mockstwitter.NewTwitterClient(t)
-				fakeTwitterClient.On("GetUser", mock.Anything, fakeUserName).Return(
-					nil, nil,
-				)
-				_ = dic.Register[twitter.TwitterClient](fakeTwitterClient)
-
-				fakeUser := &models.User{
-					Username:   "validuser",
-					PrivateKey: privKey.Bytes,
-					CreatedAt:  fakeUserCreatedAt,
-				}
-				fakeActor := &models.Actor{}
-				fakeUserRepo := mocksuser.NewUserRepository(t)
-				fakeUserRepo.On("Get", mock.Anything, "validuser").Return(fakeUser, nil)
-				fakeUserRepo.On("UnFollow", mock.Anything, fakeUser, fakeActor).Return(nil)
-				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+				registerInboxUser(t)
 
-				fakeActorUrl, _ := url.Parse("https://another-instance.example.com/users/validactor")
-				fakeActorRepo := mocksuser.NewActorRepository(t)
-				fakeActorRepo.On("Get", mock.Anything, fakeActorUrl).Return(fakeActor, nil)
-				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+				fakeInboundActivityRepo := mocksuser.NewInboundActivityRepository(t)
+				fakeInboundActivityRepo.EXPECT().Create(mock.Anything, repository.CreateInboundActivityRequest{
+					ActivityID: "https://another-instance.example.com/bdd01ced-d657-4847-a266-2c43e1cd8dc5",
+					Username:   fakeUserName,
+					Payload: map[string]any{
+						"@context": "https://www.w3.org/ns/activitystreams",
+						"type":     "Follow",
+						"actor":    "https://another-instance.example.com/users/validactor",
+						"id":       "https://another-instance.example.com/bdd01ced-d657-4847-a266-2c43e1cd8dc5",
+						"object":   "https://example.com/users/validuser",
+					},
+				}).Return(&models.InboundActivity{ID: "0b9c1d7e-4a5f-4c3e-9b2a-8d7e6f5a4b3c"}, true, nil)
+				_ = dic.Register[repository.InboundActivityRepository](fakeInboundActivityRepo)
 
-				viper.Set("allowed_users", "validactor@another-instance.example.com")
+				fakeWorker := mocks.NewBackgroundWorkerClient(t)
+				fakeWorker.On("Enqueue", mock.MatchedBy(func(task *asynq.Task) bool {
+					input := tasks.ProcessInboundActivityInput{}
+					err := json.Unmarshal(task.Payload(), &input)
+					if err != nil {
+						return assert.NoError(t, err)
+					}
+					return assert.Equal(t, tasks.TypeProcessInboundActivity, task.Type()) &&
+						assert.Equal(t, tasks.ProcessInboundActivityInput{
+							ActivityID: "0b9c1d7e-4a5f-4c3e-9b2a-8d7e6f5a4b3c",
+						}, input)
+				})).Return(nil, nil)
+				_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
 			},
 			StatusCode: http.StatusAccepted,
 		},
@@ -30,11 +30,20 @@ func HandleWebFinger(responseWriter http.ResponseWriter, request *http.Request)
 		return internalerrors.New("requested user is not on this instance", http.StatusNotFound)
 	}
 
+	if username == conf.Domain.Host {
+		templateContent, _ := views.Views.ReadFile("well_known/webfinger_instance.json.tmpl")
+		t := template.Must(template.New("webfinger").Parse(string(templateContent)))
+		responseWriter.Header().Add("content-type", "application/json")
+		_ = t.Execute(responseWriter, map[string]interface{}{
+			"domain": conf.Domain,
+		})
+		return nil
+	}
+
 	userService := dic.GetService[domain.UserService]()
 	user, err := userService.GetFullUser(request.Context(), username)
 	if err != nil {
-		return internalerrors.Wrap(err, http.StatusNotFound).
-			WithUserMessage("user not found")
+		return domain.UserHTTPError(err)
 	}
 
 	templateContent, _ := views.Views.ReadFile("well_known/webfinger.json.tmpl")
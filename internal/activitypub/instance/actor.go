@@ -0,0 +1,73 @@
+package instance
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/estrys/estrys/internal/crypto"
+	"github.com/estrys/estrys/internal/logger"
+	"github.com/estrys/estrys/internal/repository"
+)
+
+const (
+	mainKeyName = "main"
+	// KeyFragment identifies the instance actor key in its actor document.
+	KeyFragment = "main-key"
+)
+
+// InstanceActor is the application actor representing the whole instance,
+// it signs the requests which are not made on behalf of a user.
+//
+//go:generate mockery --with-expecter --name=InstanceActor
+type InstanceActor interface {
+	// PrivateKey returns the instance actor key, generating it the first time it is needed.
+	PrivateKey(ctx context.Context) (*rsa.PrivateKey, error)
+}
+
+type instanceActor struct {
+	log  logger.Logger
+	repo repository.InstanceKeyRepository
+	mu   sync.Mutex
+	key  *rsa.PrivateKey
+}
+
+func NewInstanceActor(log logger.Logger, repo repository.InstanceKeyRepository) *instanceActor {
+	return &instanceActor{
+		log:  log,
+		repo: repo,
+	}
+}
+
+func (i *instanceActor) PrivateKey(ctx context.Context) (*rsa.PrivateKey, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.key != nil {
+		return i.key, nil
+	}
+
+	storedKey, err := i.repo.Get(ctx, mainKeyName)
+	if errors.Is(err, sql.ErrNoRows) {
+		i.log.Info("generating instance actor key")
+		var privateKey *rsa.PrivateKey
+		privateKey, err = crypto.GenerateKey()
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to generate instance actor key")
+		}
+		storedKey, err = i.repo.Create(ctx, mainKeyName, privateKey)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load instance actor key")
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(storedKey.PrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse instance actor private key")
+	}
+	i.key = key
+	return key, nil
+}
@@ -0,0 +1,65 @@
+package instance_test
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/estrys/estrys/internal/activitypub/instance"
+	"github.com/estrys/estrys/internal/crypto"
+	loggermock "github.com/estrys/estrys/internal/logger/mocks"
+	"github.com/estrys/estrys/internal/models"
+	repositorymocks "github.com/estrys/estrys/internal/repository/mocks"
+)
+
+func TestInstanceActor_PrivateKey(t *testing.T) {
+	storedKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	t.Run("stored key is loaded once", func(t *testing.T) {
+		repo := repositorymocks.NewInstanceKeyRepository(t)
+		repo.EXPECT().Get(mock.Anything, "main").Once().Return(&models.InstanceKey{
+			Name:       "main",
+			PrivateKey: x509.MarshalPKCS1PrivateKey(storedKey),
+		}, nil)
+
+		actor := instance.NewInstanceActor(loggermock.NewNullLogger(), repo)
+		key, err := actor.PrivateKey(context.Background())
+		require.NoError(t, err)
+		require.True(t, storedKey.Equal(key))
+
+		key, err = actor.PrivateKey(context.Background())
+		require.NoError(t, err)
+		require.True(t, storedKey.Equal(key))
+	})
+
+	t.Run("key is generated when missing", func(t *testing.T) {
+		repo := repositorymocks.NewInstanceKeyRepository(t)
+		repo.EXPECT().Get(mock.Anything, "main").Return(nil, errors.WithStack(sql.ErrNoRows))
+		repo.EXPECT().Create(mock.Anything, "main", mock.AnythingOfType("*rsa.PrivateKey")).
+			Return(&models.InstanceKey{
+				Name:       "main",
+				PrivateKey: x509.MarshalPKCS1PrivateKey(storedKey),
+			}, nil)
+
+		actor := instance.NewInstanceActor(loggermock.NewNullLogger(), repo)
+		key, err := actor.PrivateKey(context.Background())
+		require.NoError(t, err)
+		require.True(t, storedKey.Equal(key))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		repo := repositorymocks.NewInstanceKeyRepository(t)
+		repo.EXPECT().Get(mock.Anything, "main").Return(nil, errors.New("connection refused"))
+
+		actor := instance.NewInstanceActor(loggermock.NewNullLogger(), repo)
+		key, err := actor.PrivateKey(context.Background())
+		require.EqualError(t, err, "unable to load instance actor key: connection refused")
+		require.Nil(t, key)
+	})
+}
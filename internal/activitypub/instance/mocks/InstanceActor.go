@@ -0,0 +1,85 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	rsa "crypto/rsa"
+)
+
+// InstanceActor is an autogenerated mock type for the InstanceActor type
+type InstanceActor struct {
+	mock.Mock
+}
+
+type InstanceActor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InstanceActor) EXPECT() *InstanceActor_Expecter {
+	return &InstanceActor_Expecter{mock: &_m.Mock}
+}
+
+// PrivateKey provides a mock function with given fields: ctx
+func (_m *InstanceActor) PrivateKey(ctx context.Context) (*rsa.PrivateKey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *rsa.PrivateKey
+	if rf, ok := ret.Get(0).(func(context.Context) *rsa.PrivateKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rsa.PrivateKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InstanceActor_PrivateKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PrivateKey'
+type InstanceActor_PrivateKey_Call struct {
+	*mock.Call
+}
+
+// PrivateKey is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *InstanceActor_Expecter) PrivateKey(ctx interface{}) *InstanceActor_PrivateKey_Call {
+	return &InstanceActor_PrivateKey_Call{Call: _e.mock.On("PrivateKey", ctx)}
+}
+
+func (_c *InstanceActor_PrivateKey_Call) Run(run func(ctx context.Context)) *InstanceActor_PrivateKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *InstanceActor_PrivateKey_Call) Return(_a0 *rsa.PrivateKey, _a1 error) *InstanceActor_PrivateKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewInstanceActor interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewInstanceActor creates a new instance of InstanceActor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewInstanceActor(t mockConstructorTestingTNewInstanceActor) *InstanceActor {
+	mock := &InstanceActor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,26 @@
+package activitypub
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+var activityJSONMediaTypes = []string{"application/activity+json", "application/ld+json"}
+
+// AcceptsActivityJSON tells if the client asked for the activitypub representation
+// of a resource, browsers do not and should get the html page.
+func AcceptsActivityJSON(request *http.Request) bool {
+	for _, accept := range strings.Split(request.Header.Get("accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+		for _, activityJSONMediaType := range activityJSONMediaTypes {
+			if mediaType == activityJSONMediaType {
+				return true
+			}
+		}
+	}
+	return false
+}
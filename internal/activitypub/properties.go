@@ -0,0 +1,220 @@
+package activitypub
+
+import (
+	"net/url"
+	"regexp"
+
+	"github.com/go-fed/activity/pub"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/pkg/errors"
+)
+
+// hrefRegexp matches the links of the html values of profile fields.
+var hrefRegexp = regexp.MustCompile(`href="([^"]+)"`)
+
+var (
+	ErrMissingActor  = errors.New("activity has no actor")
+	ErrMissingObject = errors.New("activity has no object")
+	ErrMissingTarget = errors.New("activity has no target")
+	ErrNotAnActor    = errors.New("object is not an actor")
+)
+
+type withActor interface {
+	GetActivityStreamsActor() vocab.ActivityStreamsActorProperty
+}
+
+type withObject interface {
+	GetActivityStreamsObject() vocab.ActivityStreamsObjectProperty
+}
+
+type withInReplyTo interface {
+	GetActivityStreamsInReplyTo() vocab.ActivityStreamsInReplyToProperty
+}
+
+type withAttributedTo interface {
+	GetActivityStreamsAttributedTo() vocab.ActivityStreamsAttributedToProperty
+}
+
+type withTarget interface {
+	GetActivityStreamsTarget() vocab.ActivityStreamsTargetProperty
+}
+
+type withPreferredUsername interface {
+	GetActivityStreamsPreferredUsername() vocab.ActivityStreamsPreferredUsernameProperty
+}
+
+// GetActorURL returns the id of the activity actor,
+// servers either send it as a bare IRI or embed the whole actor.
+func GetActorURL(act withActor) (*url.URL, error) {
+	actors := act.GetActivityStreamsActor()
+	if actors == nil || actors.Len() == 0 {
+		return nil, errors.WithStack(ErrMissingActor)
+	}
+	if actors.Len() > 1 {
+		return nil, errors.New("activities with multiple actors are not supported")
+	}
+	actorURL, err := propertyURL(actors.Begin())
+	return actorURL, errors.Wrap(err, "unable to read activity actor")
+}
+
+// GetObjectURL returns the id of the activity object, as a bare IRI or an embedded object.
+func GetObjectURL(act withObject) (*url.URL, error) {
+	objects := act.GetActivityStreamsObject()
+	if objects == nil || objects.Len() == 0 {
+		return nil, errors.WithStack(ErrMissingObject)
+	}
+	if objects.Len() > 1 {
+		return nil, errors.New("activities with multiple objects are not supported")
+	}
+	objectURL, err := propertyURL(objects.Begin())
+	return objectURL, errors.Wrap(err, "unable to read activity object")
+}
+
+// GetObject returns the single object of the activity, nil if there is none or more than one.
+// Its value is either an IRI which needs to be dereferenced or an embedded object.
+func GetObject(act withObject) vocab.ActivityStreamsObjectPropertyIterator {
+	objects := act.GetActivityStreamsObject()
+	if objects == nil || objects.Len() != 1 {
+		return nil
+	}
+	return objects.Begin()
+}
+
+// GetTargetURL returns the id of the activity target, as a bare IRI or an embedded object.
+func GetTargetURL(act withTarget) (*url.URL, error) {
+	targets := act.GetActivityStreamsTarget()
+	if targets == nil || targets.Len() == 0 {
+		return nil, errors.WithStack(ErrMissingTarget)
+	}
+	if targets.Len() > 1 {
+		return nil, errors.New("activities with multiple targets are not supported")
+	}
+	targetURL, err := propertyURL(targets.Begin())
+	return targetURL, errors.Wrap(err, "unable to read activity target")
+}
+
+// GetInReplyToURL returns the id of the object replied to, nil if the object is not a reply.
+func GetInReplyToURL(object withInReplyTo) (*url.URL, error) {
+	inReplyTo := object.GetActivityStreamsInReplyTo()
+	if inReplyTo == nil || inReplyTo.Len() == 0 {
+		return nil, nil
+	}
+	if inReplyTo.Len() > 1 {
+		return nil, errors.New("replies to multiple objects are not supported")
+	}
+	inReplyToURL, err := propertyURL(inReplyTo.Begin())
+	return inReplyToURL, errors.Wrap(err, "unable to read object inReplyTo")
+}
+
+// GetAttributedToURL returns the id of the single author of the object.
+func GetAttributedToURL(object withAttributedTo) (*url.URL, error) {
+	attributedTo := object.GetActivityStreamsAttributedTo()
+	if attributedTo == nil || attributedTo.Len() != 1 {
+		return nil, errors.New("object must have a single author")
+	}
+	attributedToURL, err := propertyURL(attributedTo.Begin())
+	return attributedToURL, errors.Wrap(err, "unable to read object attributedTo")
+}
+
+// GetAlsoKnownAs returns the aliases declared by an actor, which are sent either as a single IRI or a list.
+// The property is not part of the activitystreams vocabulary, so it is read from the serialized actor.
+func GetAlsoKnownAs(actor vocab.Type) ([]*url.URL, error) {
+	document, err := actor.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to serialize actor")
+	}
+	var values []any
+	switch aliases := document["alsoKnownAs"].(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		values = aliases
+	default:
+		values = []any{aliases}
+	}
+	aliases := make([]*url.URL, 0, len(values))
+	for _, value := range values {
+		alias, isString := value.(string)
+		if !isString {
+			return nil, errors.New("alsoKnownAs must only contain IRIs")
+		}
+		aliasURL, err := url.Parse(alias)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse alias")
+		}
+		aliases = append(aliases, aliasURL)
+	}
+	return aliases, nil
+}
+
+// GetPreferredUsername returns the username of an actor, which is the user part of its webfinger handle.
+func GetPreferredUsername(actor vocab.Type) (string, error) {
+	named, isActor := actor.(withPreferredUsername)
+	if !isActor {
+		return "", errors.WithStack(ErrNotAnActor)
+	}
+	username := named.GetActivityStreamsPreferredUsername()
+	if username == nil || !username.IsXMLSchemaString() {
+		return "", errors.New("actor has no preferred username")
+	}
+	return username.GetXMLSchemaString(), nil
+}
+
+func propertyURL(property pub.IdProperty) (*url.URL, error) {
+	id, err := pub.ToId(property)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get id")
+	}
+	if !id.IsAbs() || (id.Scheme != "https" && id.Scheme != "http") {
+		return nil, errors.Errorf("invalid id '%s'", id.String())
+	}
+	return id, nil
+}
+
+// GetProfileLinks returns the urls an actor links to from its profile: its url, the links of its profile fields
+// and its aliases. Values which are not valid urls are ignored.
+func GetProfileLinks(actor vocab.Type) ([]*url.URL, error) {
+	document, err := actor.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to serialize actor")
+	}
+
+	var links []string
+	for _, property := range []string{"url", "attachment", "alsoKnownAs"} {
+		var values []any
+		switch value := document[property].(type) {
+		case nil:
+			continue
+		case []any:
+			values = value
+		default:
+			values = []any{value}
+		}
+		for _, value := range values {
+			switch value := value.(type) {
+			case string:
+				links = append(links, value)
+			case map[string]any:
+				// Links are objects with an href, profile fields are PropertyValue with an html value
+				if href, isString := value["href"].(string); isString {
+					links = append(links, href)
+				}
+				if fieldValue, isString := value["value"].(string); isString {
+					for _, match := range hrefRegexp.FindAllStringSubmatch(fieldValue, -1) {
+						links = append(links, match[1])
+					}
+				}
+			}
+		}
+	}
+
+	urls := make([]*url.URL, 0, len(links))
+	for _, link := range links {
+		linkURL, err := url.Parse(link)
+		if err != nil || !linkURL.IsAbs() {
+			continue
+		}
+		urls = append(urls, linkURL)
+	}
+	return urls, nil
+}
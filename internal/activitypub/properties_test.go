@@ -0,0 +1,215 @@
+package activitypub_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/stretchr/testify/require"
+
+	"github.com/estrys/estrys/internal/activitypub"
+)
+
+func activityFromFile(t *testing.T, name string) vocab.Type {
+	t.Helper()
+	data, err := os.ReadFile(path.Join("testdata", "activities", name+".json"))
+	require.NoError(t, err)
+	var document map[string]any
+	require.NoError(t, json.Unmarshal(data, &document))
+	activity, err := streams.ToType(context.Background(), document)
+	require.NoError(t, err)
+	return activity
+}
+
+func TestGetActorAndObjectURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		actorURL   string
+		objectURL  string
+		objectType string
+	}{
+		{
+			name:      "mastodon_follow",
+			actorURL:  "https://mastodon.example/users/alice",
+			objectURL: "https://example.com/users/foobar",
+		},
+		{
+			name:       "mastodon_undo_follow",
+			actorURL:   "https://mastodon.example/users/alice",
+			objectURL:  "https://mastodon.example/1b6f4e2a-63f8-4e8c-9d4b-7c1f5a2d9e10",
+			objectType: "Follow",
+		},
+		{
+			name:      "mastodon_delete_actor",
+			actorURL:  "https://mastodon.example/users/alice",
+			objectURL: "https://mastodon.example/users/alice",
+		},
+		{
+			name:       "mastodon_update_actor",
+			actorURL:   "https://mastodon.example/users/alice",
+			objectURL:  "https://mastodon.example/users/alice",
+			objectType: "Person",
+		},
+		{
+			name:      "mastodon_block",
+			actorURL:  "https://mastodon.example/users/alice",
+			objectURL: "https://example.com/users/foobar",
+		},
+		{
+			name:      "pleroma_follow",
+			actorURL:  "https://pleroma.example/users/bob",
+			objectURL: "https://example.com/users/foobar",
+		},
+		{
+			name:       "pleroma_undo_follow",
+			actorURL:   "https://pleroma.example/users/bob",
+			objectURL:  "https://pleroma.example/activities/7d1b8c6e-0a5f-4f3e-b2c1-9e8d7c6b5a4f",
+			objectType: "Follow",
+		},
+		{
+			name:      "pleroma_delete_note",
+			actorURL:  "https://pleroma.example/users/bob",
+			objectURL: "https://pleroma.example/objects/4a3b2c1d-0e9f-4876-a5b4-c3d2e1f0a9b8",
+		},
+		{
+			name:      "misskey_follow",
+			actorURL:  "https://misskey.example/users/9a8b7c6d5e",
+			objectURL: "https://example.com/users/foobar",
+		},
+		{
+			name:       "misskey_undo_follow",
+			actorURL:   "https://misskey.example/users/9a8b7c6d5e",
+			objectURL:  "https://misskey.example/follows/9a8b7c6d5e/9f0e1d2c3b",
+			objectType: "Follow",
+		},
+		{
+			name:       "misskey_delete_note",
+			actorURL:   "https://misskey.example/users/9a8b7c6d5e",
+			objectURL:  "https://misskey.example/notes/9c8d7e6f5a",
+			objectType: "Tombstone",
+		},
+		{
+			name:      "gotosocial_follow",
+			actorURL:  "https://gts.example/users/carol",
+			objectURL: "https://example.com/users/foobar",
+		},
+		{
+			name:       "gotosocial_undo_follow",
+			actorURL:   "https://gts.example/users/carol",
+			objectURL:  "https://gts.example/users/carol/follow/01GKX3Z8Q7R6S5T4V3W2X1Y0Z9",
+			objectType: "Follow",
+		},
+		{
+			name:      "gotosocial_block",
+			actorURL:  "https://gts.example/users/carol",
+			objectURL: "https://example.com/users/foobar",
+		},
+		{
+			name:      "embedded_actor_follow",
+			actorURL:  "https://smithereen.example/users/dave",
+			objectURL: "https://example.com/users/foobar",
+		},
+		{
+			name:      "undo_follow_iri",
+			actorURL:  "https://mastodon.example/users/alice",
+			objectURL: "https://mastodon.example/1b6f4e2a-63f8-4e8c-9d4b-7c1f5a2d9e10",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity, isActivity := activityFromFile(t, tt.name).(interface {
+				GetActivityStreamsActor() vocab.ActivityStreamsActorProperty
+				GetActivityStreamsObject() vocab.ActivityStreamsObjectProperty
+			})
+			require.True(t, isActivity)
+
+			actorURL, err := activitypub.GetActorURL(activity)
+			require.NoError(t, err)
+			require.Equal(t, tt.actorURL, actorURL.String())
+
+			objectURL, err := activitypub.GetObjectURL(activity)
+			require.NoError(t, err)
+			require.Equal(t, tt.objectURL, objectURL.String())
+
+			object := activitypub.GetObject(activity)
+			require.NotNil(t, object)
+			if tt.objectType == "" {
+				require.True(t, object.IsIRI())
+				return
+			}
+			require.Equal(t, tt.objectType, object.GetType().GetTypeName())
+		})
+	}
+}
+
+func TestGetActorURL_Errors(t *testing.T) {
+	follow := streams.NewActivityStreamsFollow()
+	_, err := activitypub.GetActorURL(follow)
+	require.ErrorIs(t, err, activitypub.ErrMissingActor)
+
+	_, err = activitypub.GetObjectURL(follow)
+	require.ErrorIs(t, err, activitypub.ErrMissingObject)
+
+	actor := streams.NewActivityStreamsActorProperty()
+	actor.AppendActivityStreamsPerson(streams.NewActivityStreamsPerson())
+	follow.SetActivityStreamsActor(actor)
+	_, err = activitypub.GetActorURL(follow)
+	require.EqualError(t, err, "unable to read activity actor: unable to get id: cannot determine id of activitystreams value")
+}
+
+func TestGetTargetURL(t *testing.T) {
+	move, isMove := activityFromFile(t, "mastodon_move").(vocab.ActivityStreamsMove)
+	require.True(t, isMove)
+	targetURL, err := activitypub.GetTargetURL(move)
+	require.NoError(t, err)
+	require.Equal(t, "https://another-mastodon.example/users/alice", targetURL.String())
+
+	_, err = activitypub.GetTargetURL(streams.NewActivityStreamsMove())
+	require.ErrorIs(t, err, activitypub.ErrMissingTarget)
+}
+
+func TestGetAlsoKnownAs(t *testing.T) {
+	aliases, err := activitypub.GetAlsoKnownAs(activityFromFile(t, "mastodon_moved_actor"))
+	require.NoError(t, err)
+	require.Len(t, aliases, 2)
+	require.Equal(t, "https://mastodon.example/users/alice", aliases[0].String())
+	require.Equal(t, "https://old-mastodon.example/users/alice", aliases[1].String())
+
+	aliases, err = activitypub.GetAlsoKnownAs(streams.NewActivityStreamsPerson())
+	require.NoError(t, err)
+	require.Empty(t, aliases)
+}
+
+func TestGetPreferredUsername(t *testing.T) {
+	username, err := activitypub.GetPreferredUsername(activityFromFile(t, "mastodon_moved_actor"))
+	require.NoError(t, err)
+	require.Equal(t, "alice", username)
+
+	_, err = activitypub.GetPreferredUsername(streams.NewActivityStreamsPerson())
+	require.EqualError(t, err, "actor has no preferred username")
+
+	_, err = activitypub.GetPreferredUsername(streams.NewActivityStreamsNote())
+	require.ErrorIs(t, err, activitypub.ErrNotAnActor)
+}
+
+func TestGetProfileLinks(t *testing.T) {
+	links, err := activitypub.GetProfileLinks(activityFromFile(t, "mastodon_actor_links"))
+	require.NoError(t, err)
+	linkStrings := make([]string, 0, len(links))
+	for _, link := range links {
+		linkStrings = append(linkStrings, link.String())
+	}
+	require.Equal(t, []string{
+		"https://mastodon.example/@alice",
+		"https://twitter.com/alice",
+		"https://old-mastodon.example/users/alice",
+	}, linkStrings)
+
+	links, err = activitypub.GetProfileLinks(streams.NewActivityStreamsPerson())
+	require.NoError(t, err)
+	require.Empty(t, links)
+}
@@ -0,0 +1,195 @@
+package resolver
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	_http "github.com/estrys/estrys/internal/http"
+	"github.com/estrys/estrys/internal/logger"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+)
+
+type actorDocument struct {
+	ID                string `json:"id"`
+	Inbox             string `json:"inbox"`
+	PreferredUsername string `json:"preferredUsername"` //nolint:tagliatelle
+	Endpoints         struct {
+		SharedInbox string `json:"sharedInbox"` //nolint:tagliatelle
+	} `json:"endpoints"`
+	PublicKey struct {
+		ID           string `json:"id"`
+		Owner        string `json:"owner"`
+		PublicKeyPEM string `json:"publicKeyPem"` //nolint:tagliatelle
+	} `json:"publicKey"` //nolint:tagliatelle
+}
+
+//go:generate mockery --with-expecter --name=ActorResolver
+type ActorResolver interface {
+	// Resolve returns the locally known actor, dereferencing it if we never saw it
+	// or if the stored informations are stale.
+	Resolve(context.Context, *url.URL) (*models.Actor, error)
+	// Refresh dereferences the actor again, even if the stored informations are not stale.
+	Refresh(context.Context, *url.URL) (*models.Actor, error)
+}
+
+type actorResolver struct {
+	log             logger.Logger
+	client          _http.Client
+	actorRepo       repository.ActorRepository
+	refreshInterval time.Duration
+}
+
+func NewActorResolver(
+	log logger.Logger,
+	client _http.Client,
+	actorRepo repository.ActorRepository,
+	refreshInterval time.Duration,
+) *actorResolver {
+	return &actorResolver{
+		log:             log,
+		client:          client,
+		actorRepo:       actorRepo,
+		refreshInterval: refreshInterval,
+	}
+}
+
+func (r *actorResolver) isStale(actor *models.Actor) bool {
+	if !actor.FetchedAt.Valid || !actor.Inbox.Valid {
+		return true
+	}
+	return time.Since(actor.FetchedAt.Time) > r.refreshInterval
+}
+
+func (r *actorResolver) Resolve(ctx context.Context, actorURL *url.URL) (*models.Actor, error) {
+	return r.resolve(ctx, actorURL, false)
+}
+
+func (r *actorResolver) Refresh(ctx context.Context, actorURL *url.URL) (*models.Actor, error) {
+	return r.resolve(ctx, actorURL, true)
+}
+
+func (r *actorResolver) resolve(ctx context.Context, actorURL *url.URL, force bool) (*models.Actor, error) {
+	actor, err := r.actorRepo.Get(ctx, actorURL)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(err, "unable to fetch actor from db")
+	}
+
+	if actor != nil && !force && !r.isStale(actor) {
+		return actor, nil
+	}
+
+	details, err := r.fetch(ctx, actorURL)
+	if err != nil {
+		// Better to use outdated informations than nothing, remote server may be temporarily down
+		if actor != nil && actor.Inbox.Valid {
+			r.log.WithError(err).WithField("actor", actor.URL).Warn("unable to refresh actor, using stale one")
+			return actor, nil
+		}
+		return nil, err
+	}
+
+	if actor == nil {
+		actor, err = r.actorRepo.Create(ctx, repository.CreateActorRequest{
+			URL:          actorURL,
+			ActorDetails: *details,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to create actor")
+		}
+		r.log.WithField("actor", actor.URL).Debug("new actor created")
+		return actor, nil
+	}
+
+	err = r.actorRepo.Update(ctx, actor, *details)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to refresh actor")
+	}
+	r.log.WithField("actor", actor.URL).Debug("actor refreshed")
+
+	return actor, nil
+}
+
+func (r *actorResolver) fetch(ctx context.Context, actorURL *url.URL) (*repository.ActorDetails, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, actorURL.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create actor request")
+	}
+	req.Header.Set("accept", "application/activity+json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting actor")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d while fetching actor", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while reading response body")
+	}
+	var document actorDocument
+	err = json.Unmarshal(body, &document)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode actor json")
+	}
+
+	if document.ID != actorURL.String() {
+		return nil, errors.Errorf("actor id '%s' does not match requested url", document.ID)
+	}
+
+	inbox, err := url.Parse(document.Inbox)
+	if err != nil || document.Inbox == "" {
+		return nil, errors.New("actor does not have a valid inbox")
+	}
+	if inbox.Host != actorURL.Host {
+		return nil, errors.Errorf("actor inbox host '%s' does not match actor host", inbox.Host)
+	}
+
+	// The key is looked up by its id when the actor can not be fetched, it must belong to the actor
+	keyID, err := url.Parse(document.PublicKey.ID)
+	if err != nil || document.PublicKey.ID == "" {
+		return nil, errors.New("actor does not have a valid key id")
+	}
+	if keyID.Host != actorURL.Host {
+		return nil, errors.Errorf("actor key id host '%s' does not match actor host", keyID.Host)
+	}
+	if document.PublicKey.Owner != document.ID {
+		return nil, errors.Errorf("actor key owner '%s' does not match actor", document.PublicKey.Owner)
+	}
+
+	details := &repository.ActorDetails{
+		Inbox:             inbox,
+		PublicKeyID:       document.PublicKey.ID,
+		PreferredUsername: document.PreferredUsername,
+	}
+
+	if document.Endpoints.SharedInbox != "" {
+		sharedInbox, err := url.Parse(document.Endpoints.SharedInbox)
+		if err == nil && sharedInbox.Host == actorURL.Host {
+			details.SharedInbox = sharedInbox
+		}
+	}
+
+	pemBlock, _ := pem.Decode([]byte(document.PublicKey.PublicKeyPEM))
+	if pemBlock == nil {
+		return nil, errors.New("unable to decode key PEM")
+	}
+	details.PublicKey, err = x509.ParsePKIXPublicKey(pemBlock.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse public key")
+	}
+
+	return details, nil
+}
@@ -0,0 +1,255 @@
+package resolver
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	httpmock "github.com/estrys/estrys/internal/http/mocks"
+	loggermock "github.com/estrys/estrys/internal/logger/mocks"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	repositorymocks "github.com/estrys/estrys/internal/repository/mocks"
+)
+
+func mustParseURL(rawURL string) *url.URL {
+	parsedURL, _ := url.Parse(rawURL)
+	return parsedURL
+}
+
+func responseFromFile(t *testing.T, name string) *http.Response {
+	t.Helper()
+	data, err := os.ReadFile(path.Join("testdata", name+".json"))
+	require.NoError(t, err)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(data)),
+	}
+}
+
+func Test_actorResolver_Resolve(t *testing.T) {
+	pemBlock, _ := pem.Decode([]byte("-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAtQKn/lAs285puIPRoWQv\n0lQpA4wzoqWt2YRcXy7O3qbllb4dkX7XmG6nJluuWOpkS5E4cajLzvrtRq1MFzOW\ndWgPmIbO4uf4S8ByhvLMR3ytvp+iEynckI9XLva9ObnUXxV7ovLD94hlES400lhS\n46DY/Dt26mrEkHiZqoV5JfTKsS1Pa8MhHZ8NuBXholL75cf8UdWgjHqBZj+jcjht\nCDTLnU2N0i1MjowTcOXdcNocC4iZLUGPCjHZHRQoP/CD+JnI8sHVQ1Iw8OH0Kgcy\ne2wv8hAiAcPIqSFl76KOH6VJpBbIsG6azyaFz5/qu15MyJGqcaZv1Ct52cQdBIk8\nwQIDAQAB\n-----END PUBLIC KEY-----\n"))
+	expectedPublicKey, _ := x509.ParsePKIXPublicKey(pemBlock.Bytes)
+
+	mastodonActorURL := mustParseURL("https://mastodon.example.com/users/alice")
+	misskeyActorURL := mustParseURL("https://misskey.example.com/users/9a8b7c6d5e")
+
+	freshActor := &models.Actor{
+		URL:       mastodonActorURL.String(),
+		Inbox:     null.StringFrom("https://mastodon.example.com/users/alice/inbox"),
+		FetchedAt: null.TimeFrom(time.Now().Add(-time.Minute)),
+	}
+	staleActor := &models.Actor{
+		URL:       mastodonActorURL.String(),
+		Inbox:     null.StringFrom("https://mastodon.example.com/users/alice/inbox"),
+		FetchedAt: null.TimeFrom(time.Now().Add(-48 * time.Hour)),
+	}
+	legacyActor := &models.Actor{
+		URL: mastodonActorURL.String(),
+	}
+
+	tests := []struct {
+		name     string
+		actorURL *url.URL
+		mock     func(*httpmock.Client, *repositorymocks.ActorRepository)
+		want     *models.Actor
+		err      string
+	}{
+		{
+			name:     "fresh actor is not fetched",
+			actorURL: mastodonActorURL,
+			mock: func(_ *httpmock.Client, repo *repositorymocks.ActorRepository) {
+				repo.EXPECT().Get(mock.Anything, mastodonActorURL).Return(freshActor, nil)
+			},
+			want: freshActor,
+		},
+		{
+			name:     "database error",
+			actorURL: mastodonActorURL,
+			mock: func(_ *httpmock.Client, repo *repositorymocks.ActorRepository) {
+				repo.EXPECT().Get(mock.Anything, mastodonActorURL).Return(nil, errors.New("db is down"))
+			},
+			err: "unable to fetch actor from db: db is down",
+		},
+		{
+			name:     "unknown actor is created",
+			actorURL: mastodonActorURL,
+			mock: func(client *httpmock.Client, repo *repositorymocks.ActorRepository) {
+				repo.EXPECT().Get(mock.Anything, mastodonActorURL).Return(nil, errors.Wrap(sql.ErrNoRows, "not found"))
+				client.EXPECT().Do(mock.MatchedBy(func(r *http.Request) bool {
+					return r.URL.String() == mastodonActorURL.String() &&
+						r.Header.Get("accept") == "application/activity+json"
+				})).Return(responseFromFile(t, "mastodon"), nil)
+				repo.EXPECT().Create(mock.Anything, repository.CreateActorRequest{
+					URL: mastodonActorURL,
+					ActorDetails: repository.ActorDetails{
+						PublicKey:         expectedPublicKey,
+						PublicKeyID:       "https://mastodon.example.com/users/alice#main-key",
+						Inbox:             mustParseURL("https://mastodon.example.com/users/alice/inbox"),
+						SharedInbox:       mustParseURL("https://mastodon.example.com/inbox"),
+						PreferredUsername: "alice",
+					},
+				}).Return(freshActor, nil)
+			},
+			want: freshActor,
+		},
+		{
+			name:     "misskey actor does not follow the /inbox layout",
+			actorURL: misskeyActorURL,
+			mock: func(client *httpmock.Client, repo *repositorymocks.ActorRepository) {
+				repo.EXPECT().Get(mock.Anything, misskeyActorURL).Return(nil, sql.ErrNoRows)
+				client.EXPECT().Do(mock.Anything).Return(responseFromFile(t, "misskey"), nil)
+				repo.EXPECT().Create(mock.Anything, repository.CreateActorRequest{
+					URL: misskeyActorURL,
+					ActorDetails: repository.ActorDetails{
+						PublicKey:         expectedPublicKey,
+						PublicKeyID:       "https://misskey.example.com/users/9a8b7c6d5e#main-key",
+						Inbox:             mustParseURL("https://misskey.example.com/users/9a8b7c6d5e/inbox"),
+						SharedInbox:       mustParseURL("https://misskey.example.com/inbox"),
+						PreferredUsername: "bob",
+					},
+				}).Return(freshActor, nil)
+			},
+			want: freshActor,
+		},
+		{
+			name:     "stale actor is refreshed",
+			actorURL: mastodonActorURL,
+			mock: func(client *httpmock.Client, repo *repositorymocks.ActorRepository) {
+				repo.EXPECT().Get(mock.Anything, mastodonActorURL).Return(staleActor, nil)
+				client.EXPECT().Do(mock.Anything).Return(responseFromFile(t, "mastodon"), nil)
+				repo.EXPECT().Update(mock.Anything, staleActor, mock.MatchedBy(func(details repository.ActorDetails) bool {
+					return details.Inbox.String() == "https://mastodon.example.com/users/alice/inbox"
+				})).Return(nil)
+			},
+			want: staleActor,
+		},
+		{
+			name:     "actor without inbox is refreshed",
+			actorURL: mastodonActorURL,
+			mock: func(client *httpmock.Client, repo *repositorymocks.ActorRepository) {
+				repo.EXPECT().Get(mock.Anything, mastodonActorURL).Return(legacyActor, nil)
+				client.EXPECT().Do(mock.Anything).Return(responseFromFile(t, "mastodon"), nil)
+				repo.EXPECT().Update(mock.Anything, legacyActor, mock.Anything).Return(nil)
+			},
+			want: legacyActor,
+		},
+		{
+			name:     "stale actor is used when refresh fails",
+			actorURL: mastodonActorURL,
+			mock: func(client *httpmock.Client, repo *repositorymocks.ActorRepository) {
+				repo.EXPECT().Get(mock.Anything, mastodonActorURL).Return(staleActor, nil)
+				client.EXPECT().Do(mock.Anything).Return(nil, errors.New("connection refused"))
+			},
+			want: staleActor,
+		},
+		{
+			name:     "unknown actor fetch failure",
+			actorURL: mastodonActorURL,
+			mock: func(client *httpmock.Client, repo *repositorymocks.ActorRepository) {
+				repo.EXPECT().Get(mock.Anything, mastodonActorURL).Return(nil, sql.ErrNoRows)
+				client.EXPECT().Do(mock.Anything).Return(&http.Response{
+					StatusCode: http.StatusGone,
+					Body:       io.NopCloser(bytes.NewReader(nil)),
+				}, nil)
+			},
+			err: "unexpected status code 410 while fetching actor",
+		},
+		{
+			name:     "actor without inbox",
+			actorURL: mastodonActorURL,
+			mock: func(client *httpmock.Client, repo *repositorymocks.ActorRepository) {
+				repo.EXPECT().Get(mock.Anything, mastodonActorURL).Return(nil, sql.ErrNoRows)
+				client.EXPECT().Do(mock.Anything).Return(responseFromFile(t, "no_inbox"), nil)
+			},
+			err: "actor does not have a valid inbox",
+		},
+		{
+			name:     "actor id mismatch",
+			actorURL: mastodonActorURL,
+			mock: func(client *httpmock.Client, repo *repositorymocks.ActorRepository) {
+				repo.EXPECT().Get(mock.Anything, mastodonActorURL).Return(nil, sql.ErrNoRows)
+				client.EXPECT().Do(mock.Anything).Return(responseFromFile(t, "id_mismatch"), nil)
+			},
+			err: "actor id 'https://evil.example.com/users/alice' does not match requested url",
+		},
+		{
+			name:     "actor key of another instance",
+			actorURL: mastodonActorURL,
+			mock: func(client *httpmock.Client, repo *repositorymocks.ActorRepository) {
+				repo.EXPECT().Get(mock.Anything, mastodonActorURL).Return(nil, sql.ErrNoRows)
+				client.EXPECT().Do(mock.Anything).Return(responseFromFile(t, "key_host_mismatch"), nil)
+			},
+			err: "actor key id host 'evil.example.com' does not match actor host",
+		},
+		{
+			name:     "actor key owned by another actor",
+			actorURL: mastodonActorURL,
+			mock: func(client *httpmock.Client, repo *repositorymocks.ActorRepository) {
+				repo.EXPECT().Get(mock.Anything, mastodonActorURL).Return(nil, sql.ErrNoRows)
+				client.EXPECT().Do(mock.Anything).Return(responseFromFile(t, "key_owner_mismatch"), nil)
+			},
+			err: "actor key owner 'https://mastodon.example.com/users/bob' does not match actor",
+		},
+		{
+			name:     "unable to create actor",
+			actorURL: mastodonActorURL,
+			mock: func(client *httpmock.Client, repo *repositorymocks.ActorRepository) {
+				repo.EXPECT().Get(mock.Anything, mastodonActorURL).Return(nil, sql.ErrNoRows)
+				client.EXPECT().Do(mock.Anything).Return(responseFromFile(t, "mastodon"), nil)
+				repo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, errors.New("db is down"))
+			},
+			err: "unable to create actor: db is down",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := httpmock.NewClient(t)
+			actorRepo := repositorymocks.NewActorRepository(t)
+			tt.mock(httpClient, actorRepo)
+
+			r := NewActorResolver(loggermock.NewNullLogger(), httpClient, actorRepo, 24*time.Hour)
+			got, err := r.Resolve(context.Background(), tt.actorURL)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_actorResolver_Refresh(t *testing.T) {
+	actorURL := mustParseURL("https://mastodon.example.com/users/alice")
+	freshActor := &models.Actor{
+		URL:       actorURL.String(),
+		Inbox:     null.StringFrom("https://mastodon.example.com/users/alice/inbox"),
+		FetchedAt: null.TimeFrom(time.Now().Add(-time.Minute)),
+	}
+
+	httpClient := httpmock.NewClient(t)
+	actorRepo := repositorymocks.NewActorRepository(t)
+	actorRepo.EXPECT().Get(mock.Anything, actorURL).Return(freshActor, nil)
+	httpClient.EXPECT().Do(mock.Anything).Return(responseFromFile(t, "mastodon"), nil)
+	actorRepo.EXPECT().Update(mock.Anything, freshActor, mock.Anything).Return(nil)
+
+	r := NewActorResolver(loggermock.NewNullLogger(), httpClient, actorRepo, 24*time.Hour)
+	got, err := r.Refresh(context.Background(), actorURL)
+	require.NoError(t, err)
+	require.Equal(t, freshActor, got)
+}
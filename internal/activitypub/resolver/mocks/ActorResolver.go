@@ -0,0 +1,134 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/estrys/estrys/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	url "net/url"
+)
+
+// ActorResolver is an autogenerated mock type for the ActorResolver type
+type ActorResolver struct {
+	mock.Mock
+}
+
+type ActorResolver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ActorResolver) EXPECT() *ActorResolver_Expecter {
+	return &ActorResolver_Expecter{mock: &_m.Mock}
+}
+
+// Refresh provides a mock function with given fields: _a0, _a1
+func (_m *ActorResolver) Refresh(_a0 context.Context, _a1 *url.URL) (*models.Actor, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *models.Actor
+	if rf, ok := ret.Get(0).(func(context.Context, *url.URL) *models.Actor); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Actor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *url.URL) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ActorResolver_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type ActorResolver_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *url.URL
+func (_e *ActorResolver_Expecter) Refresh(_a0 interface{}, _a1 interface{}) *ActorResolver_Refresh_Call {
+	return &ActorResolver_Refresh_Call{Call: _e.mock.On("Refresh", _a0, _a1)}
+}
+
+func (_c *ActorResolver_Refresh_Call) Run(run func(_a0 context.Context, _a1 *url.URL)) *ActorResolver_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*url.URL))
+	})
+	return _c
+}
+
+func (_c *ActorResolver_Refresh_Call) Return(_a0 *models.Actor, _a1 error) *ActorResolver_Refresh_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Resolve provides a mock function with given fields: _a0, _a1
+func (_m *ActorResolver) Resolve(_a0 context.Context, _a1 *url.URL) (*models.Actor, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *models.Actor
+	if rf, ok := ret.Get(0).(func(context.Context, *url.URL) *models.Actor); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Actor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *url.URL) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ActorResolver_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type ActorResolver_Resolve_Call struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *url.URL
+func (_e *ActorResolver_Expecter) Resolve(_a0 interface{}, _a1 interface{}) *ActorResolver_Resolve_Call {
+	return &ActorResolver_Resolve_Call{Call: _e.mock.On("Resolve", _a0, _a1)}
+}
+
+func (_c *ActorResolver_Resolve_Call) Run(run func(_a0 context.Context, _a1 *url.URL)) *ActorResolver_Resolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*url.URL))
+	})
+	return _c
+}
+
+func (_c *ActorResolver_Resolve_Call) Return(_a0 *models.Actor, _a1 error) *ActorResolver_Resolve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewActorResolver interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewActorResolver creates a new instance of ActorResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewActorResolver(t mockConstructorTestingTNewActorResolver) *ActorResolver {
+	mock := &ActorResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
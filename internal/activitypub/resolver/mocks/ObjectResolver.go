@@ -0,0 +1,87 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pub "github.com/go-fed/activity/pub"
+	mock "github.com/stretchr/testify/mock"
+
+	vocab "github.com/go-fed/activity/streams/vocab"
+)
+
+// ObjectResolver is an autogenerated mock type for the ObjectResolver type
+type ObjectResolver struct {
+	mock.Mock
+}
+
+type ObjectResolver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ObjectResolver) EXPECT() *ObjectResolver_Expecter {
+	return &ObjectResolver_Expecter{mock: &_m.Mock}
+}
+
+// Resolve provides a mock function with given fields: _a0, _a1
+func (_m *ObjectResolver) Resolve(_a0 context.Context, _a1 pub.IdProperty) (vocab.Type, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 vocab.Type
+	if rf, ok := ret.Get(0).(func(context.Context, pub.IdProperty) vocab.Type); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(vocab.Type)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, pub.IdProperty) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ObjectResolver_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type ObjectResolver_Resolve_Call struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 pub.IdProperty
+func (_e *ObjectResolver_Expecter) Resolve(_a0 interface{}, _a1 interface{}) *ObjectResolver_Resolve_Call {
+	return &ObjectResolver_Resolve_Call{Call: _e.mock.On("Resolve", _a0, _a1)}
+}
+
+func (_c *ObjectResolver_Resolve_Call) Run(run func(_a0 context.Context, _a1 pub.IdProperty)) *ObjectResolver_Resolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pub.IdProperty))
+	})
+	return _c
+}
+
+func (_c *ObjectResolver_Resolve_Call) Return(_a0 vocab.Type, _a1 error) *ObjectResolver_Resolve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewObjectResolver interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewObjectResolver creates a new instance of ObjectResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewObjectResolver(t mockConstructorTestingTNewObjectResolver) *ObjectResolver {
+	mock := &ObjectResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
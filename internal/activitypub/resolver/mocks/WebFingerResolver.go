@@ -0,0 +1,86 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	url "net/url"
+)
+
+// WebFingerResolver is an autogenerated mock type for the WebFingerResolver type
+type WebFingerResolver struct {
+	mock.Mock
+}
+
+type WebFingerResolver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebFingerResolver) EXPECT() *WebFingerResolver_Expecter {
+	return &WebFingerResolver_Expecter{mock: &_m.Mock}
+}
+
+// Resolve provides a mock function with given fields: ctx, handle
+func (_m *WebFingerResolver) Resolve(ctx context.Context, handle string) (*url.URL, error) {
+	ret := _m.Called(ctx, handle)
+
+	var r0 *url.URL
+	if rf, ok := ret.Get(0).(func(context.Context, string) *url.URL); ok {
+		r0 = rf(ctx, handle)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*url.URL)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, handle)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebFingerResolver_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type WebFingerResolver_Resolve_Call struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - handle string
+func (_e *WebFingerResolver_Expecter) Resolve(ctx interface{}, handle interface{}) *WebFingerResolver_Resolve_Call {
+	return &WebFingerResolver_Resolve_Call{Call: _e.mock.On("Resolve", ctx, handle)}
+}
+
+func (_c *WebFingerResolver_Resolve_Call) Run(run func(ctx context.Context, handle string)) *WebFingerResolver_Resolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WebFingerResolver_Resolve_Call) Return(_a0 *url.URL, _a1 error) *WebFingerResolver_Resolve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewWebFingerResolver interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewWebFingerResolver creates a new instance of WebFingerResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewWebFingerResolver(t mockConstructorTestingTNewWebFingerResolver) *WebFingerResolver {
+	mock := &WebFingerResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
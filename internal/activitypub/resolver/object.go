@@ -0,0 +1,85 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/go-fed/activity/pub"
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/pkg/errors"
+
+	_http "github.com/estrys/estrys/internal/http"
+	"github.com/estrys/estrys/internal/logger"
+)
+
+//go:generate mockery --with-expecter --name=ObjectResolver
+type ObjectResolver interface {
+	// Resolve returns the embedded object, or dereferences it when the property is a bare IRI.
+	Resolve(context.Context, pub.IdProperty) (vocab.Type, error)
+}
+
+type objectResolver struct {
+	log    logger.Logger
+	client _http.Client
+}
+
+func NewObjectResolver(log logger.Logger, client _http.Client) *objectResolver {
+	return &objectResolver{
+		log:    log,
+		client: client,
+	}
+}
+
+func (r *objectResolver) Resolve(ctx context.Context, property pub.IdProperty) (vocab.Type, error) {
+	if property.GetType() != nil {
+		return property.GetType(), nil
+	}
+	if !property.IsIRI() {
+		return nil, errors.New("property is neither an object nor an iri")
+	}
+	return r.fetch(ctx, property.GetIRI())
+}
+
+func (r *objectResolver) fetch(ctx context.Context, objectURL *url.URL) (vocab.Type, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, objectURL.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create object request")
+	}
+	req.Header.Set("accept", "application/activity+json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting object")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d while fetching object", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while reading response body")
+	}
+	var document map[string]any
+	err = json.Unmarshal(body, &document)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode object json")
+	}
+	object, err := streams.ToType(ctx, document)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read object")
+	}
+
+	// The object must be served by its origin, otherwise anyone could forge it
+	id, err := pub.GetId(object)
+	if err != nil || id.String() != objectURL.String() {
+		return nil, errors.Errorf("object id does not match requested url '%s'", objectURL.String())
+	}
+	r.log.WithField("object", objectURL.String()).Debug("object dereferenced")
+
+	return object, nil
+}
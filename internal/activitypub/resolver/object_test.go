@@ -0,0 +1,99 @@
+package resolver
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	httpmock "github.com/estrys/estrys/internal/http/mocks"
+	loggermock "github.com/estrys/estrys/internal/logger/mocks"
+)
+
+func Test_objectResolver_Resolve(t *testing.T) {
+	followURL := mustParseURL("https://mastodon.example.com/1b6f4e2a-63f8-4e8c-9d4b-7c1f5a2d9e10")
+
+	tests := []struct {
+		name     string
+		property func() vocab.ActivityStreamsObjectPropertyIterator
+		mock     func(client *httpmock.Client)
+		wantType string
+		err      string
+	}{
+		{
+			name: "embedded object",
+			property: func() vocab.ActivityStreamsObjectPropertyIterator {
+				follow := streams.NewActivityStreamsFollow()
+				object := streams.NewActivityStreamsObjectProperty()
+				object.AppendActivityStreamsFollow(follow)
+				return object.Begin()
+			},
+			wantType: "Follow",
+		},
+		{
+			name: "dereferenced iri",
+			property: func() vocab.ActivityStreamsObjectPropertyIterator {
+				object := streams.NewActivityStreamsObjectProperty()
+				object.AppendIRI(followURL)
+				return object.Begin()
+			},
+			mock: func(client *httpmock.Client) {
+				client.EXPECT().Do(mock.MatchedBy(func(r *http.Request) bool {
+					return r.URL.String() == followURL.String() &&
+						r.Header.Get("accept") == "application/activity+json"
+				})).Return(responseFromFile(t, "follow"), nil)
+			},
+			wantType: "Follow",
+		},
+		{
+			name: "object served by another url",
+			property: func() vocab.ActivityStreamsObjectPropertyIterator {
+				object := streams.NewActivityStreamsObjectProperty()
+				object.AppendIRI(mustParseURL("https://evil.example.com/follow"))
+				return object.Begin()
+			},
+			mock: func(client *httpmock.Client) {
+				client.EXPECT().Do(mock.Anything).Return(responseFromFile(t, "follow"), nil)
+			},
+			err: "object id does not match requested url 'https://evil.example.com/follow'",
+		},
+		{
+			name: "object not found",
+			property: func() vocab.ActivityStreamsObjectPropertyIterator {
+				object := streams.NewActivityStreamsObjectProperty()
+				object.AppendIRI(followURL)
+				return object.Begin()
+			},
+			mock: func(client *httpmock.Client) {
+				client.EXPECT().Do(mock.Anything).Return(&http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(bytes.NewReader(nil)),
+				}, nil)
+			},
+			err: "unexpected status code 404 while fetching object",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := httpmock.NewClient(t)
+			if tt.mock != nil {
+				tt.mock(httpClient)
+			}
+
+			r := NewObjectResolver(loggermock.NewNullLogger(), httpClient)
+			got, err := r.Resolve(context.Background(), tt.property())
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantType, got.GetTypeName())
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	_http "github.com/estrys/estrys/internal/http"
+	"github.com/estrys/estrys/internal/logger"
+)
+
+var ErrInvalidHandle = errors.New("handle should be formatted as user@domain")
+
+//go:generate mockery --with-expecter --name=WebFingerResolver
+type WebFingerResolver interface {
+	// Resolve returns the actor url of a fediverse handle such as user@instance.example.
+	Resolve(ctx context.Context, handle string) (*url.URL, error)
+}
+
+type webFingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type"`
+	Href string `json:"href"`
+}
+
+type webFingerResponse struct {
+	Subject string          `json:"subject"`
+	Links   []webFingerLink `json:"links"`
+}
+
+type webFingerResolver struct {
+	log    logger.Logger
+	client _http.Client
+}
+
+func NewWebFingerResolver(log logger.Logger, client _http.Client) *webFingerResolver {
+	return &webFingerResolver{
+		log:    log,
+		client: client,
+	}
+}
+
+func (r *webFingerResolver) Resolve(ctx context.Context, handle string) (*url.URL, error) {
+	handle = strings.TrimPrefix(handle, "@")
+	username, domain, found := strings.Cut(handle, "@")
+	if !found || username == "" || domain == "" || strings.Contains(domain, "/") {
+		return nil, errors.WithStack(ErrInvalidHandle)
+	}
+
+	webFingerURL := &url.URL{
+		Scheme:   "https",
+		Host:     domain,
+		Path:     "/.well-known/webfinger",
+		RawQuery: url.Values{"resource": []string{"acct:" + handle}}.Encode(),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, webFingerURL.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create webfinger request")
+	}
+	req.Header.Set("accept", "application/jrd+json, application/json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting webfinger")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d while requesting webfinger", resp.StatusCode)
+	}
+
+	var webFinger webFingerResponse
+	err = json.NewDecoder(resp.Body).Decode(&webFinger)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode webfinger json")
+	}
+
+	for _, link := range webFinger.Links {
+		if link.Rel != "self" || !isActivityPubMediaType(link.Type) {
+			continue
+		}
+		actorURL, err := url.Parse(link.Href)
+		if err != nil || actorURL.Scheme != "https" {
+			return nil, errors.Errorf("invalid actor url '%s' in webfinger", link.Href)
+		}
+		r.log.WithField("handle", handle).Debug("webfinger resolved")
+		return actorURL, nil
+	}
+
+	return nil, errors.Errorf("no actor found in webfinger of '%s'", handle)
+}
+
+func isActivityPubMediaType(mediaType string) bool {
+	return mediaType == "application/activity+json" ||
+		(strings.HasPrefix(mediaType, "application/ld+json") && strings.Contains(mediaType, "activitystreams"))
+}
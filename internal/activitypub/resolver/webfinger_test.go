@@ -0,0 +1,103 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	loggermock "github.com/estrys/estrys/internal/logger/mocks"
+)
+
+// newFakeWebFingerServer serves the webfinger of alice, with the links written by the given function.
+func newFakeWebFingerServer(t *testing.T, links func(server *httptest.Server) string) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource := "acct:alice@" + server.Listener.Addr().String()
+		if r.URL.Path != "/.well-known/webfinger" || r.URL.Query().Get("resource") != resource {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("content-type", "application/jrd+json")
+		_, _ = fmt.Fprintf(w, `{"subject": %q, "links": [%s]}`, resource, links(server))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func Test_webFingerResolver_Resolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		handle   func(server *httptest.Server) string
+		links    func(server *httptest.Server) string
+		expected func(server *httptest.Server) string
+		err      string
+	}{
+		{
+			name:   "invalid handle",
+			handle: func(_ *httptest.Server) string { return "alice" },
+			err:    "handle should be formatted as user@domain",
+		},
+		{
+			name:   "unknown account",
+			handle: func(server *httptest.Server) string { return "bob@" + server.Listener.Addr().String() },
+			err:    "unexpected status code 404 while requesting webfinger",
+		},
+		{
+			name: "no actor link",
+			links: func(server *httptest.Server) string {
+				return `{"rel": "http://webfinger.net/rel/profile-page", "type": "text/html", "href": "` +
+					server.URL + `/@alice"}`
+			},
+			err: "no actor found in webfinger of 'alice@",
+		},
+		{
+			name: "actor link",
+			handle: func(server *httptest.Server) string {
+				return "@alice@" + server.Listener.Addr().String()
+			},
+			links: func(server *httptest.Server) string {
+				return `{"rel": "http://webfinger.net/rel/profile-page", "type": "text/html", "href": "` +
+					server.URL + `/@alice"}, {"rel": "self", "type": "application/activity+json", "href": "` +
+					server.URL + `/users/alice"}`
+			},
+			expected: func(server *httptest.Server) string { return server.URL + "/users/alice" },
+		},
+		{
+			name: "json-ld actor link",
+			links: func(server *httptest.Server) string {
+				return `{"rel": "self", "type": "application/ld+json; ` +
+					`profile=\"https://www.w3.org/ns/activitystreams\"", "href": "` + server.URL + `/users/alice"}`
+			},
+			expected: func(server *httptest.Server) string { return server.URL + "/users/alice" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := tt.links
+			if links == nil {
+				links = func(_ *httptest.Server) string { return "" }
+			}
+			server := newFakeWebFingerServer(t, links)
+			handle := "alice@" + server.Listener.Addr().String()
+			if tt.handle != nil {
+				handle = tt.handle(server)
+			}
+
+			webFingerResolver := NewWebFingerResolver(loggermock.NewNullLogger(), server.Client())
+			actorURL, err := webFingerResolver.Resolve(context.Background(), handle)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			expected, _ := url.Parse(tt.expected(server))
+			require.Equal(t, expected, actorURL)
+		})
+	}
+}
@@ -22,21 +22,33 @@ func Router(rootRouter *mux.Router) {
 		Methods(http.MethodGet).
 		HandlerFunc(errors.HTTPErrorHandler(handlers.HandleWebFinger))
 
+	instanceRouter := rootRouter.PathPrefix("/actor").Subrouter()
+	instanceRouter.NewRoute().Name(routes.InstanceActorRoute).
+		Path("").
+		Methods(http.MethodGet).
+		HandlerFunc(errors.HTTPErrorHandler(handlers.HandleInstanceActor))
+	instanceRouter.NewRoute().Name(routes.InstanceInboxRoute).
+		Path("/inbox").
+		Methods(http.MethodPost).
+		HandlerFunc(handlers.HandleInstanceInbox)
+
 	// TODO Add regex on Accept header
 	userRouter := rootRouter.PathPrefix("/users").Subrouter()
-	userRouter.NewRoute().Name(routes.UserRoute).
+	fetchRouter := userRouter.NewRoute().Subrouter()
+	fetchRouter.Use(auth.AuthorizedFetchMiddleware)
+	fetchRouter.NewRoute().Name(routes.UserRoute).
 		Path("/{username}").
 		Methods(http.MethodGet).
 		HandlerFunc(errors.HTTPErrorHandler(handlers.HandleUser))
-	userRouter.NewRoute().Name(routes.UserFollowingRoute).
+	fetchRouter.NewRoute().Name(routes.UserFollowingRoute).
 		Path("/{username}/following").
 		Methods(http.MethodGet).
 		HandlerFunc(errors.HTTPErrorHandler(handlers.HandleFollowing))
-	userRouter.NewRoute().Name(routes.UserFollowersRoute).
+	fetchRouter.NewRoute().Name(routes.UserFollowersRoute).
 		Path("/{username}/followers").
 		Methods(http.MethodGet).
 		HandlerFunc(errors.HTTPErrorHandler(handlers.HandleFollowers))
-	userRouter.NewRoute().Name(routes.UserOutboxRoute).
+	fetchRouter.NewRoute().Name(routes.UserOutboxRoute).
 		Path("/{username}/outbox").
 		Methods(http.MethodGet).
 		HandlerFunc(errors.HTTPErrorHandler(handlers.HandleOutbox))
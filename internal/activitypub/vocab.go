@@ -3,25 +3,65 @@ package activitypub
 
 import (
 	"context"
+	"fmt"
+	"html"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/go-fed/activity/pub"
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/pkg/errors"
 
+	"github.com/estrys/estrys/internal/activitypub/instance"
+	"github.com/estrys/estrys/internal/config"
 	"github.com/estrys/estrys/internal/domain/domainmodels"
 	"github.com/estrys/estrys/internal/models"
 	"github.com/estrys/estrys/internal/router/routes"
 	"github.com/estrys/estrys/internal/router/urlgenerator"
 	twittermodels "github.com/estrys/estrys/internal/twitter/models"
+	twitterrepository "github.com/estrys/estrys/internal/twitter/repository"
 )
 
+// CollectionPage locates a page of a collection paginated by page number, starting at 1.
+type CollectionPage struct {
+	Number     int
+	Size       int
+	TotalItems int
+}
+
+// LastPage returns the number of the last page, an empty collection still has a first page.
+func (p CollectionPage) LastPage() int {
+	if p.Size <= 0 || p.TotalItems <= p.Size {
+		return 1
+	}
+	return (p.TotalItems + p.Size - 1) / p.Size
+}
+
 type VocabService interface {
 	GetActor(*domainmodels.User) (map[string]any, error)
-	GetFollowers(*domainmodels.User) (map[string]any, error)
+	// GetMinimalActor returns only what is needed to address the actor and verify its signatures.
+	GetMinimalActor(*domainmodels.User) (map[string]any, error)
+	// GetInstanceActor returns the application actor of the instance with the given public key.
+	GetInstanceActor(publicKeyPem string) (map[string]any, error)
+	// GetFollowers returns the followers collection, with a link to its first page unless paginated is false.
+	GetFollowers(user *domainmodels.User, totalItems int, paginated bool) (map[string]any, error)
+	GetFollowersPage(*domainmodels.User, CollectionPage, []*url.URL) (map[string]any, error)
 	GetFollowing(*domainmodels.User) (map[string]any, error)
 	GetOutbox(*domainmodels.User) (map[string]any, error)
+	// GetStatusCollection returns the replies, likes or shares collection of a status, given by its route.
+	// Items are only listed when they are not nil, likes and shares are only counted.
+	GetStatusCollection(
+		route string,
+		username string,
+		tweetID string,
+		totalItems int,
+		items []*url.URL,
+	) (map[string]any, error)
+	GetOutboxPage(*domainmodels.User, twitterrepository.TimelineQuery, []twittermodels.Tweet) (map[string]any, error)
+	// GetFollow rebuilds the follow of the user by the given actor from its id.
+	GetFollow(user *models.User, actorURL *url.URL, followID *url.URL) (vocab.ActivityStreamsFollow, error)
 	GetAccept(
 		user *models.User,
 		act streams.ActivityStreamsInterface,
@@ -30,18 +70,30 @@ type VocabService interface {
 		user *models.User,
 		act streams.ActivityStreamsInterface,
 	) (vocab.ActivityStreamsReject, error)
+	// GetMove returns the move of a migrated user to its new account.
+	GetMove(user *models.User) (vocab.ActivityStreamsMove, error)
+	// GetDelete returns the deletion of the actor of a user who refused to be bridged.
+	GetDelete(user *models.User, deletedAt time.Time) (vocab.ActivityStreamsDelete, error)
+	GetNoteFromTweet(string, twittermodels.Tweet) (vocab.ActivityStreamsNote, error)
 	GetCreateNoteFromTweet(string, twittermodels.Tweet) (vocab.ActivityStreamsCreate, error)
+	GetAnnounceFromTweet(string, twittermodels.Tweet) (vocab.ActivityStreamsAnnounce, error)
 }
 
+// twitterProfileURL is the base url of twitter profiles, linked from the bridged actors.
+const twitterProfileURL = "https://twitter.com/"
+
 type activityPubService struct {
 	URLGenerator urlgenerator.URLGenerator
+	conf         config.Config
 }
 
 func NewActivityPubVocabService(
 	urlGenerator urlgenerator.URLGenerator,
+	conf config.Config,
 ) *activityPubService {
 	return &activityPubService{
 		URLGenerator: urlGenerator,
+		conf:         conf,
 	}
 }
 
@@ -53,7 +105,11 @@ func (a *activityPubService) serialize(vocab vocab.Type) (map[string]any, error)
 	return serializedStreams, nil
 }
 
-func (a *activityPubService) GetFollowers(user *domainmodels.User) (map[string]any, error) {
+func (a *activityPubService) GetFollowers(
+	user *domainmodels.User,
+	totalItems int,
+	paginated bool,
+) (map[string]any, error) {
 	followersURL, err := a.URLGenerator.URL(
 		routes.UserFollowersRoute,
 		[]string{"username", user.Username},
@@ -64,16 +120,106 @@ func (a *activityPubService) GetFollowers(user *domainmodels.User) (map[string]a
 	}
 
 	collection := streams.NewActivityStreamsOrderedCollection()
-	totalItems := streams.NewActivityStreamsTotalItemsProperty()
-	totalItems.Set(int(user.Metrics.Followers))
+	totalItemsProperty := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProperty.Set(totalItems)
 	id := streams.NewJSONLDIdProperty()
 	id.Set(followersURL)
 	collection.SetJSONLDId(id)
-	collection.SetActivityStreamsTotalItems(totalItems)
+	collection.SetActivityStreamsTotalItems(totalItemsProperty)
+	if paginated {
+		first := streams.NewActivityStreamsFirstProperty()
+		first.SetIRI(collectionPageURL(followersURL, 1))
+		collection.SetActivityStreamsFirst(first)
+	}
+
+	return a.serialize(collection)
+}
+
+func (a *activityPubService) GetStatusCollection(
+	route string,
+	username string,
+	tweetID string,
+	totalItems int,
+	items []*url.URL,
+) (map[string]any, error) {
+	collectionURL, err := a.URLGenerator.URL(
+		route,
+		[]string{"username", username, "id", tweetID},
+		urlgenerator.OptionAbsoluteURL,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate status collection URL")
+	}
+
+	collection := streams.NewActivityStreamsOrderedCollection()
+	id := streams.NewJSONLDIdProperty()
+	id.Set(collectionURL)
+	collection.SetJSONLDId(id)
+	totalItemsProperty := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProperty.Set(totalItems)
+	collection.SetActivityStreamsTotalItems(totalItemsProperty)
+	if items != nil {
+		orderedItems := streams.NewActivityStreamsOrderedItemsProperty()
+		for _, item := range items {
+			orderedItems.AppendIRI(item)
+		}
+		collection.SetActivityStreamsOrderedItems(orderedItems)
+	}
 
 	return a.serialize(collection)
 }
 
+func collectionPageURL(collectionURL *url.URL, number int) *url.URL {
+	pageURL := *collectionURL
+	pageURL.RawQuery = url.Values{"page": []string{strconv.Itoa(number)}}.Encode()
+	return &pageURL
+}
+
+func (a *activityPubService) GetFollowersPage(
+	user *domainmodels.User,
+	page CollectionPage,
+	followers []*url.URL,
+) (map[string]any, error) {
+	followersURL, err := a.URLGenerator.URL(
+		routes.UserFollowersRoute,
+		[]string{"username", user.Username},
+		urlgenerator.OptionAbsoluteURL,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate followers URL")
+	}
+
+	collectionPage := streams.NewActivityStreamsOrderedCollectionPage()
+	id := streams.NewJSONLDIdProperty()
+	id.Set(collectionPageURL(followersURL, page.Number))
+	collectionPage.SetJSONLDId(id)
+	partOf := streams.NewActivityStreamsPartOfProperty()
+	partOf.SetIRI(followersURL)
+	collectionPage.SetActivityStreamsPartOf(partOf)
+	totalItems := streams.NewActivityStreamsTotalItemsProperty()
+	totalItems.Set(page.TotalItems)
+	collectionPage.SetActivityStreamsTotalItems(totalItems)
+
+	items := streams.NewActivityStreamsOrderedItemsProperty()
+	for _, follower := range followers {
+		items.AppendIRI(follower)
+	}
+	collectionPage.SetActivityStreamsOrderedItems(items)
+
+	if page.Number > 1 {
+		prev := streams.NewActivityStreamsPrevProperty()
+		prev.SetIRI(collectionPageURL(followersURL, page.Number-1))
+		collectionPage.SetActivityStreamsPrev(prev)
+	}
+	if page.Number*page.Size < page.TotalItems {
+		next := streams.NewActivityStreamsNextProperty()
+		next.SetIRI(collectionPageURL(followersURL, page.Number+1))
+		collectionPage.SetActivityStreamsNext(next)
+	}
+
+	return a.serialize(collectionPage)
+}
+
 func (a *activityPubService) GetFollowing(user *domainmodels.User) (map[string]any, error) {
 	followingURL, err := a.URLGenerator.URL(
 		routes.UserFollowingRoute,
@@ -112,10 +258,89 @@ func (a *activityPubService) GetOutbox(user *domainmodels.User) (map[string]any,
 	id.Set(outboxURL)
 	collection.SetJSONLDId(id)
 	collection.SetActivityStreamsTotalItems(totalItems)
+	first := streams.NewActivityStreamsFirstProperty()
+	first.SetIRI(outboxPageURL(outboxURL, nil))
+	collection.SetActivityStreamsFirst(first)
+	last := streams.NewActivityStreamsLastProperty()
+	last.SetIRI(outboxPageURL(outboxURL, url.Values{"min_id": []string{"0"}}))
+	collection.SetActivityStreamsLast(last)
 
 	return a.serialize(collection)
 }
 
+// outboxPageURL returns the URL of an outbox page, using the same cursors as mastodon.
+func outboxPageURL(outboxURL *url.URL, cursor url.Values) *url.URL {
+	query := url.Values{"page": []string{strconv.FormatBool(true)}}
+	for key, values := range cursor {
+		query[key] = values
+	}
+	pageURL := *outboxURL
+	pageURL.RawQuery = query.Encode()
+	return &pageURL
+}
+
+func (a *activityPubService) GetOutboxPage(
+	user *domainmodels.User,
+	query twitterrepository.TimelineQuery,
+	tweets []twittermodels.Tweet,
+) (map[string]any, error) {
+	outboxURL, err := a.URLGenerator.URL(
+		routes.UserOutboxRoute,
+		[]string{"username", user.Username},
+		urlgenerator.OptionAbsoluteURL,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate outbox URL")
+	}
+
+	page := streams.NewActivityStreamsOrderedCollectionPage()
+	cursor := url.Values{}
+	if query.MaxID != "" {
+		cursor.Set("max_id", query.MaxID)
+	}
+	if query.MinID != "" {
+		cursor.Set("min_id", query.MinID)
+	}
+	id := streams.NewJSONLDIdProperty()
+	id.Set(outboxPageURL(outboxURL, cursor))
+	page.SetJSONLDId(id)
+	partOf := streams.NewActivityStreamsPartOfProperty()
+	partOf.SetIRI(outboxURL)
+	page.SetActivityStreamsPartOf(partOf)
+
+	items := streams.NewActivityStreamsOrderedItemsProperty()
+	for _, tweet := range tweets {
+		if tweet.Retweet() != nil {
+			announce, err := a.GetAnnounceFromTweet(user.Username, tweet)
+			if err != nil {
+				return nil, err
+			}
+			items.AppendActivityStreamsAnnounce(announce)
+			continue
+		}
+		create, err := a.GetCreateNoteFromTweet(user.Username, tweet)
+		if err != nil {
+			return nil, err
+		}
+		items.AppendActivityStreamsCreate(create)
+	}
+	page.SetActivityStreamsOrderedItems(items)
+
+	if len(tweets) > 0 {
+		prev := streams.NewActivityStreamsPrevProperty()
+		prev.SetIRI(outboxPageURL(outboxURL, url.Values{"min_id": []string{tweets[0].ID}}))
+		page.SetActivityStreamsPrev(prev)
+	}
+	// A page shorter than the limit is the last one
+	if len(tweets) > 0 && len(tweets) >= query.Limit {
+		next := streams.NewActivityStreamsNextProperty()
+		next.SetIRI(outboxPageURL(outboxURL, url.Values{"max_id": []string{tweets[len(tweets)-1].ID}}))
+		page.SetActivityStreamsNext(next)
+	}
+
+	return a.serialize(page)
+}
+
 func (a *activityPubService) GetActor(user *domainmodels.User) (map[string]any, error) {
 	actor := streams.NewActivityStreamsService()
 
@@ -172,12 +397,13 @@ func (a *activityPubService) GetActor(user *domainmodels.User) (map[string]any,
 	username.SetXMLSchemaString(user.Username)
 	actor.SetActivityStreamsPreferredUsername(username)
 
+	// Suffixes tell the bridged actor apart from its owner
 	name := streams.NewActivityStreamsNameProperty()
-	name.AppendXMLSchemaString(user.Name)
+	name.AppendXMLSchemaString(strings.TrimSpace(user.Name + " " + a.conf.ActorNameSuffix))
 	actor.SetActivityStreamsName(name)
 
 	summary := streams.NewActivityStreamsSummaryProperty()
-	summary.AppendXMLSchemaString(user.Description)
+	summary.AppendXMLSchemaString(strings.TrimSpace(user.Description + "\n\n" + a.conf.ActorBioSuffix))
 	actor.SetActivityStreamsSummary(summary)
 
 	published := streams.NewActivityStreamsPublishedProperty()
@@ -215,15 +441,216 @@ func (a *activityPubService) GetActor(user *domainmodels.User) (map[string]any,
 	icon.AppendActivityStreamsImage(image)
 	actor.SetActivityStreamsIcon(icon)
 
+	if user.BannerURL != nil {
+		headerImage := streams.NewActivityStreamsImageProperty()
+		banner := streams.NewActivityStreamsImage()
+		bannerURL := streams.NewActivityStreamsUrlProperty()
+		bannerURL.AppendIRI(user.BannerURL)
+		banner.SetActivityStreamsUrl(bannerURL)
+		headerImage.AppendActivityStreamsImage(banner)
+		actor.SetActivityStreamsImage(headerImage)
+	}
+
+	profileURL, err := a.URLGenerator.URL(
+		routes.ProfileRoute,
+		[]string{"username", user.Username},
+		urlgenerator.OptionAbsoluteURL,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate profile URL")
+	}
+	actorURL := streams.NewActivityStreamsUrlProperty()
+	actorURL.AppendIRI(profileURL)
+	actor.SetActivityStreamsUrl(actorURL)
+
 	discoverable := streams.NewTootDiscoverableProperty()
 	discoverable.Set(false)
 	actor.SetTootDiscoverable(discoverable)
 
+	manuallyApprovesFollowers := streams.NewActivityStreamsManuallyApprovesFollowersProperty()
+	manuallyApprovesFollowers.Set(user.ManuallyApprovesFollowers)
+	actor.SetActivityStreamsManuallyApprovesFollowers(manuallyApprovesFollowers)
+
 	actor.SetJSONLDId(userID)
 
+	serializedActor, err := a.serialize(actor)
+	if err != nil {
+		return nil, err
+	}
+	// Mastodon only shows the first four fields, the most useful to tell whether the account is genuine come first
+	propertyValues := []propertyValue{
+		{Name: "Twitter", Value: profileLink(twitterProfileURL+user.Username, "@"+user.Username)},
+	}
+	if len(user.FediverseLinks) > 0 {
+		links := make([]string, 0, len(user.FediverseLinks))
+		for _, link := range user.FediverseLinks {
+			links = append(links, profileLink(link.URL.String(), "@"+link.Handle))
+		}
+		propertyValues = append(propertyValues, propertyValue{Name: "Fediverse", Value: strings.Join(links, " ")})
+	}
+	if user.Website != nil {
+		propertyValues = append(propertyValues, propertyValue{
+			Name:  "Website",
+			Value: profileLink(user.Website.String(), user.Website.Host+user.Website.Path),
+		})
+	}
+	if user.Location != "" {
+		propertyValues = append(propertyValues, propertyValue{Name: "Location", Value: html.EscapeString(user.Location)})
+	}
+	propertyValues = append(propertyValues,
+		propertyValue{Name: "Tweets", Value: strconv.FormatUint(user.Metrics.Tweets, 10)},
+		propertyValue{Name: "Twitter followers", Value: strconv.FormatUint(user.Metrics.Followers, 10)},
+		propertyValue{Name: "Twitter following", Value: strconv.FormatUint(user.Metrics.Following, 10)},
+	)
+	addPropertyValues(serializedActor, propertyValues)
+	if !a.conf.BotActors {
+		serializedActor["type"] = "Person"
+	}
+	// movedTo is not part of the vocabulary either, servers follow it to redirect to the new account
+	if user.MovedTo != nil {
+		serializedActor["movedTo"] = user.MovedTo.String()
+		appendJSONLDContext(serializedActor, map[string]any{
+			"movedTo": map[string]any{"@id": "as:movedTo", "@type": "@id"},
+		})
+	}
+	return serializedActor, nil
+}
+
+func (a *activityPubService) GetMinimalActor(user *domainmodels.User) (map[string]any, error) {
+	actor, err := a.GetActor(user)
+	if err != nil {
+		return nil, err
+	}
+	minimalActor := make(map[string]any)
+	for _, property := range []string{"@context", "id", "type", "preferredUsername", "inbox", "publicKey"} {
+		if value, exists := actor[property]; exists {
+			minimalActor[property] = value
+		}
+	}
+	return minimalActor, nil
+}
+
+func (a *activityPubService) GetInstanceActor(publicKeyPem string) (map[string]any, error) {
+	actor := streams.NewActivityStreamsApplication()
+
+	actorURL, err := a.URLGenerator.URL(routes.InstanceActorRoute, nil, urlgenerator.OptionAbsoluteURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate instance actor URL")
+	}
+	actorID := streams.NewJSONLDIdProperty()
+	actorID.Set(actorURL)
+	actor.SetJSONLDId(actorID)
+
+	inboxURL, err := a.URLGenerator.URL(routes.InstanceInboxRoute, nil, urlgenerator.OptionAbsoluteURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate instance inbox URL")
+	}
+	inbox := streams.NewActivityStreamsInboxProperty()
+	inbox.SetIRI(inboxURL)
+	actor.SetActivityStreamsInbox(inbox)
+
+	username := streams.NewActivityStreamsPreferredUsernameProperty()
+	username.SetXMLSchemaString(actorURL.Host)
+	actor.SetActivityStreamsPreferredUsername(username)
+
+	manuallyApprovesFollowers := streams.NewActivityStreamsManuallyApprovesFollowersProperty()
+	manuallyApprovesFollowers.Set(true)
+	actor.SetActivityStreamsManuallyApprovesFollowers(manuallyApprovesFollowers)
+
+	keyURL := *actorURL
+	keyURL.Fragment = instance.KeyFragment
+	keyID := streams.NewJSONLDIdProperty()
+	keyID.Set(&keyURL)
+	publicKeyProp := streams.NewW3IDSecurityV1PublicKeyProperty()
+	pubKey := streams.NewW3IDSecurityV1PublicKey()
+	publicKeyPemProp := streams.NewW3IDSecurityV1PublicKeyPemProperty()
+	publicKeyPemProp.Set(publicKeyPem)
+	pubKeyOwner := streams.NewW3IDSecurityV1OwnerProperty()
+	pubKeyOwner.Set(actorURL)
+	pubKey.SetJSONLDId(keyID)
+	pubKey.SetW3IDSecurityV1Owner(pubKeyOwner)
+	pubKey.SetW3IDSecurityV1PublicKeyPem(publicKeyPemProp)
+	publicKeyProp.AppendW3IDSecurityV1PublicKey(pubKey)
+	actor.SetW3IDSecurityV1PublicKey(publicKeyProp)
+
 	return a.serialize(actor)
 }
 
+// profileLink returns the html link of a profile field.
+func profileLink(href string, text string) string {
+	return fmt.Sprintf(
+		`<a href="%s" rel="me nofollow noopener noreferrer" target="_blank">%s</a>`,
+		html.EscapeString(href),
+		html.EscapeString(text),
+	)
+}
+
+type propertyValue struct {
+	Name  string
+	Value string
+}
+
+// addPropertyValues adds profile fields as mastodon does, the vocab library
+// does not support schema.org PropertyValue so this is done on the serialized actor.
+func addPropertyValues(serializedActor map[string]any, values []propertyValue) {
+	attachments := make([]any, 0, len(values))
+	for _, value := range values {
+		attachments = append(attachments, map[string]any{
+			"type":  "PropertyValue",
+			"name":  value.Name,
+			"value": value.Value,
+		})
+	}
+	serializedActor["attachment"] = attachments
+
+	appendJSONLDContext(serializedActor, map[string]any{
+		"schema":        "http://schema.org#",
+		"PropertyValue": "schema:PropertyValue",
+		"value":         "schema:value",
+	})
+}
+
+// appendJSONLDContext declares the terms added on a serialized object in its context.
+func appendJSONLDContext(serialized map[string]any, terms map[string]any) {
+	var jsonLDContext []any
+	switch currentContext := serialized["@context"].(type) {
+	case []any:
+		jsonLDContext = currentContext
+	case nil:
+	default:
+		jsonLDContext = []any{currentContext}
+	}
+	serialized["@context"] = append(jsonLDContext, terms)
+}
+
+func (a *activityPubService) GetFollow(
+	user *models.User,
+	actorURL *url.URL,
+	followID *url.URL,
+) (vocab.ActivityStreamsFollow, error) {
+	userURL, err := a.URLGenerator.URL(
+		routes.UserRoute,
+		[]string{"username", user.Username},
+		urlgenerator.OptionAbsoluteURL,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate user URL")
+	}
+
+	follow := streams.NewActivityStreamsFollow()
+	id := streams.NewJSONLDIdProperty()
+	id.Set(followID)
+	follow.SetJSONLDId(id)
+	actor := streams.NewActivityStreamsActorProperty()
+	actor.AppendIRI(actorURL)
+	follow.SetActivityStreamsActor(actor)
+	object := streams.NewActivityStreamsObjectProperty()
+	object.AppendIRI(userURL)
+	follow.SetActivityStreamsObject(object)
+
+	return follow, nil
+}
+
 func (a *activityPubService) GetAccept(
 	user *models.User,
 	act streams.ActivityStreamsInterface,
@@ -268,6 +695,73 @@ func (a *activityPubService) GetAccept(
 	return acceptActivity, nil
 }
 
+func (a *activityPubService) GetMove(user *models.User) (vocab.ActivityStreamsMove, error) {
+	if !user.MovedTo.Valid {
+		return nil, errors.New("user has not moved")
+	}
+	targetURL, err := url.Parse(user.MovedTo.String)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid moved to url")
+	}
+	userURL, err := a.URLGenerator.URL(
+		routes.UserRoute,
+		[]string{"username", user.Username},
+		urlgenerator.OptionAbsoluteURL,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate user URL")
+	}
+
+	// The id only changes if the user moves again
+	moveURL := *userURL
+	moveURL.Fragment = "moves/" + strconv.FormatInt(user.MovedAt.Time.Unix(), 10)
+	move := streams.NewActivityStreamsMove()
+	id := streams.NewJSONLDIdProperty()
+	id.Set(&moveURL)
+	move.SetJSONLDId(id)
+	actor := streams.NewActivityStreamsActorProperty()
+	actor.AppendIRI(userURL)
+	move.SetActivityStreamsActor(actor)
+	object := streams.NewActivityStreamsObjectProperty()
+	object.AppendIRI(userURL)
+	move.SetActivityStreamsObject(object)
+	target := streams.NewActivityStreamsTargetProperty()
+	target.AppendIRI(targetURL)
+	move.SetActivityStreamsTarget(target)
+
+	return move, nil
+}
+
+func (a *activityPubService) GetDelete(user *models.User, deletedAt time.Time) (vocab.ActivityStreamsDelete, error) {
+	userURL, err := a.URLGenerator.URL(
+		routes.UserRoute,
+		[]string{"username", user.Username},
+		urlgenerator.OptionAbsoluteURL,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate user URL")
+	}
+
+	deleteURL := *userURL
+	deleteURL.Fragment = "delete/" + strconv.FormatInt(deletedAt.Unix(), 10)
+	deleteActivity := streams.NewActivityStreamsDelete()
+	id := streams.NewJSONLDIdProperty()
+	id.Set(&deleteURL)
+	deleteActivity.SetJSONLDId(id)
+	actor := streams.NewActivityStreamsActorProperty()
+	actor.AppendIRI(userURL)
+	deleteActivity.SetActivityStreamsActor(actor)
+	object := streams.NewActivityStreamsObjectProperty()
+	object.AppendIRI(userURL)
+	deleteActivity.SetActivityStreamsObject(object)
+	to := streams.NewActivityStreamsToProperty()
+	publicURL, _ := url.Parse("https://www.w3.org/ns/activitystreams#Public")
+	to.AppendIRI(publicURL)
+	deleteActivity.SetActivityStreamsTo(to)
+
+	return deleteActivity, nil
+}
+
 func (a *activityPubService) GetReject(
 	user *models.User,
 	act streams.ActivityStreamsInterface,
@@ -312,10 +806,10 @@ func (a *activityPubService) GetReject(
 	return acceptActivity, nil
 }
 
-func (a *activityPubService) GetCreateNoteFromTweet(
+func (a *activityPubService) GetNoteFromTweet(
 	username string,
 	tweet twittermodels.Tweet,
-) (vocab.ActivityStreamsCreate, error) {
+) (vocab.ActivityStreamsNote, error) {
 	userURL, err := a.URLGenerator.URL(
 		routes.UserRoute,
 		[]string{"username", username},
@@ -333,15 +827,6 @@ func (a *activityPubService) GetCreateNoteFromTweet(
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot generate followers URL")
 	}
-	create := streams.NewActivityStreamsCreate()
-	note := streams.NewActivityStreamsNote()
-
-	cc := streams.NewActivityStreamsCcProperty()
-	cc.AppendIRI(followersURL)
-	create.SetActivityStreamsCc(cc)
-	note.SetActivityStreamsCc(cc)
-
-	id := streams.NewJSONLDIdProperty()
 
 	statusURL, err := a.URLGenerator.URL(
 		routes.StatusRoute,
@@ -351,12 +836,47 @@ func (a *activityPubService) GetCreateNoteFromTweet(
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot generate status URL")
 	}
+
+	profileStatusURL, err := a.URLGenerator.URL(
+		routes.ProfileStatusRoute,
+		[]string{"username", username, "id", tweet.ID},
+		urlgenerator.OptionAbsoluteURL,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate profile status URL")
+	}
+
+	collectionURLs := make(map[string]*url.URL, 3)
+	for _, route := range []string{routes.StatusRepliesRoute, routes.StatusLikesRoute, routes.StatusSharesRoute} {
+		collectionURLs[route], err = a.URLGenerator.URL(
+			route,
+			[]string{"username", username, "id", tweet.ID},
+			urlgenerator.OptionAbsoluteURL,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot generate status collection URL")
+		}
+	}
+
+	note := streams.NewActivityStreamsNote()
+	id := streams.NewJSONLDIdProperty()
 	id.Set(statusURL)
-	create.SetJSONLDId(id)
 	note.SetJSONLDId(id)
-	act := streams.NewActivityStreamsActorProperty()
-	act.AppendIRI(userURL)
-	create.SetActivityStreamsActor(act)
+	replies := streams.NewActivityStreamsRepliesProperty()
+	replies.SetIRI(collectionURLs[routes.StatusRepliesRoute])
+	note.SetActivityStreamsReplies(replies)
+	likes := streams.NewActivityStreamsLikesProperty()
+	likes.SetIRI(collectionURLs[routes.StatusLikesRoute])
+	note.SetActivityStreamsLikes(likes)
+	shares := streams.NewActivityStreamsSharesProperty()
+	shares.SetIRI(collectionURLs[routes.StatusSharesRoute])
+	note.SetActivityStreamsShares(shares)
+	noteURL := streams.NewActivityStreamsUrlProperty()
+	noteURL.AppendIRI(profileStatusURL)
+	note.SetActivityStreamsUrl(noteURL)
+	cc := streams.NewActivityStreamsCcProperty()
+	cc.AppendIRI(followersURL)
+	note.SetActivityStreamsCc(cc)
 	noteAttributedTo := streams.NewActivityStreamsAttributedToProperty()
 	noteAttributedTo.AppendIRI(userURL)
 	note.SetActivityStreamsAttributedTo(noteAttributedTo)
@@ -373,54 +893,149 @@ func (a *activityPubService) GetCreateNoteFromTweet(
 	sensitive := streams.NewActivityStreamsSensitiveProperty()
 	sensitive.AppendXMLSchemaBoolean(tweet.Sensitive)
 	note.SetActivityStreamsSensitive(sensitive)
-	obj := streams.NewActivityStreamsObjectProperty()
-	obj.AppendActivityStreamsNote(note)
 
 	attachments := streams.NewActivityStreamsAttachmentProperty()
 	for _, media := range tweet.Medias {
-		doc := streams.NewActivityStreamsDocument()
-		mediaType := streams.NewActivityStreamsMediaTypeProperty()
-		// TODO Handle this dynamically
-		mediaType.Set("image/jpeg")
-		doc.SetActivityStreamsMediaType(mediaType)
-		mediaURL := streams.NewActivityStreamsUrlProperty()
-		mediaURL.AppendIRI(media.URL)
-		doc.SetActivityStreamsUrl(mediaURL)
-		attachments.AppendActivityStreamsDocument(doc)
+		attachments.AppendActivityStreamsDocument(a.getMediaDocument(media))
 	}
 	note.SetActivityStreamsAttachment(attachments)
 
-	create.SetActivityStreamsTo(noteTo)
-	create.SetActivityStreamsPublished(notePublished)
+	return note, nil
+}
+
+func (a *activityPubService) GetCreateNoteFromTweet(
+	username string,
+	tweet twittermodels.Tweet,
+) (vocab.ActivityStreamsCreate, error) {
+	note, err := a.GetNoteFromTweet(username, tweet)
+	if err != nil {
+		return nil, err
+	}
+	userURL, err := a.URLGenerator.URL(
+		routes.UserRoute,
+		[]string{"username", username},
+		urlgenerator.OptionAbsoluteURL,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate user URL")
+	}
+
+	create := streams.NewActivityStreamsCreate()
+	create.SetJSONLDId(note.GetJSONLDId())
+	act := streams.NewActivityStreamsActorProperty()
+	act.AppendIRI(userURL)
+	create.SetActivityStreamsActor(act)
+	create.SetActivityStreamsTo(note.GetActivityStreamsTo())
+	create.SetActivityStreamsCc(note.GetActivityStreamsCc())
+	create.SetActivityStreamsPublished(note.GetActivityStreamsPublished())
+	obj := streams.NewActivityStreamsObjectProperty()
+	obj.AppendActivityStreamsNote(note)
 	create.SetActivityStreamsObject(obj)
 
 	return create, nil
 }
 
-type withObject interface {
-	GetActivityStreamsObject() vocab.ActivityStreamsObjectProperty
-}
+func (a *activityPubService) GetAnnounceFromTweet(
+	username string,
+	tweet twittermodels.Tweet,
+) (vocab.ActivityStreamsAnnounce, error) {
+	retweet := tweet.Retweet()
+	if retweet == nil {
+		return nil, errors.Errorf("tweet %s is not a retweet", tweet.ID)
+	}
 
-func GetActorURL(act pub.Activity) (*url.URL, error) {
-	actorStr, err := act.GetActivityStreamsActor().Serialize()
+	userURL, err := a.URLGenerator.URL(
+		routes.UserRoute,
+		[]string{"username", username},
+		urlgenerator.OptionAbsoluteURL,
+	)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable read activity streams actor field")
+		return nil, errors.Wrap(err, "cannot generate user URL")
 	}
-	actorURL, err := url.Parse(actorStr.(string))
+	followersURL, err := a.URLGenerator.URL(
+		routes.UserFollowersRoute,
+		[]string{"username", username},
+		urlgenerator.OptionAbsoluteURL,
+	)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to parse actor as URL")
+		return nil, errors.Wrap(err, "cannot generate followers URL")
 	}
-	return actorURL, nil
-}
-
-func GetObjectURL(act withObject) (*url.URL, error) {
-	objectStr, err := act.GetActivityStreamsObject().Serialize()
+	statusURL, err := a.URLGenerator.URL(
+		routes.StatusRoute,
+		[]string{"username", username, "id", tweet.ID},
+		urlgenerator.OptionAbsoluteURL,
+	)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable read activity streams object field")
+		return nil, errors.Wrap(err, "cannot generate status URL")
 	}
-	objectURL, err := url.Parse(objectStr.(string))
+	retweetAuthorURL, err := a.URLGenerator.URL(
+		routes.UserRoute,
+		[]string{"username", retweet.AuthorUsername},
+		urlgenerator.OptionAbsoluteURL,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate retweet author URL")
+	}
+	retweetURL, err := a.URLGenerator.URL(
+		routes.StatusRoute,
+		[]string{"username", retweet.AuthorUsername, "id", retweet.ID},
+		urlgenerator.OptionAbsoluteURL,
+	)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to parse follow object as URL")
+		return nil, errors.Wrap(err, "cannot generate retweet status URL")
 	}
-	return objectURL, nil
+
+	announce := streams.NewActivityStreamsAnnounce()
+	id := streams.NewJSONLDIdProperty()
+	id.Set(statusURL)
+	announce.SetJSONLDId(id)
+	act := streams.NewActivityStreamsActorProperty()
+	act.AppendIRI(userURL)
+	announce.SetActivityStreamsActor(act)
+	published := streams.NewActivityStreamsPublishedProperty()
+	published.Set(tweet.Published)
+	announce.SetActivityStreamsPublished(published)
+	to := streams.NewActivityStreamsToProperty()
+	publicURL, _ := url.Parse("https://www.w3.org/ns/activitystreams#Public")
+	to.AppendIRI(publicURL)
+	announce.SetActivityStreamsTo(to)
+	cc := streams.NewActivityStreamsCcProperty()
+	cc.AppendIRI(retweetAuthorURL)
+	cc.AppendIRI(followersURL)
+	announce.SetActivityStreamsCc(cc)
+	obj := streams.NewActivityStreamsObjectProperty()
+	obj.AppendIRI(retweetURL)
+	announce.SetActivityStreamsObject(obj)
+
+	return announce, nil
+}
+
+func (a *activityPubService) getMediaDocument(media twittermodels.TweetMedia) vocab.ActivityStreamsDocument {
+	doc := streams.NewActivityStreamsDocument()
+	mediaType := streams.NewActivityStreamsMediaTypeProperty()
+	contentType := media.ContentType
+	if contentType == "" {
+		contentType = "image/jpeg"
+	}
+	mediaType.Set(contentType)
+	doc.SetActivityStreamsMediaType(mediaType)
+	mediaURL := streams.NewActivityStreamsUrlProperty()
+	mediaURL.AppendIRI(media.URL)
+	doc.SetActivityStreamsUrl(mediaURL)
+	// width, height and focalPoint are not supported by the vocab library on documents,
+	// but mastodon and others use them to display the image placeholder.
+	unknownProperties := doc.GetUnknownProperties()
+	if media.Width > 0 && media.Height > 0 {
+		unknownProperties["width"] = media.Width
+		unknownProperties["height"] = media.Height
+	}
+	// The blurhash and the focal point are only known once the image has been processed
+	if media.Blurhash != "" {
+		blurhash := streams.NewTootBlurhashProperty()
+		blurhash.Set(media.Blurhash)
+		doc.SetTootBlurhash(blurhash)
+		unknownProperties["focalPoint"] = []float64{media.FocalPoint[0], media.FocalPoint[1]}
+	}
+
+	return doc
 }
@@ -4,4 +4,6 @@ type Attribute int
 
 const (
 	CanFollow Attribute = iota
+	CanFetch
+	CanInteract
 )
@@ -0,0 +1,47 @@
+package voter
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/estrys/estrys/internal/authorization/attributes"
+)
+
+type domainVoter struct {
+	blockedDomains []string
+}
+
+func NewDomainVoter(blockedDomains []string) *domainVoter {
+	return &domainVoter{blockedDomains: blockedDomains}
+}
+
+func (d *domainVoter) Supports(a any) bool {
+	_, ok := a.(*url.URL)
+	return ok
+}
+
+func (d *domainVoter) Vote(a any, attr attributes.Attribute) decision {
+	remoteURL, _ := a.(*url.URL)
+	if attr == attributes.CanFetch || attr == attributes.CanInteract {
+		return d.canFetch(remoteURL)
+	}
+	return AccessDenied
+}
+
+// canFetch denies blocked domains and their subdomains.
+func (d *domainVoter) canFetch(remoteURL *url.URL) decision {
+	host := strings.ToLower(remoteURL.Hostname())
+	if host == "" {
+		return AccessDenied
+	}
+	for _, blockedDomain := range d.blockedDomains {
+		blockedDomain = strings.ToLower(strings.TrimSpace(blockedDomain))
+		if blockedDomain == "" {
+			continue
+		}
+		if host == blockedDomain || strings.HasSuffix(host, "."+blockedDomain) {
+			return AccessDenied
+		}
+	}
+	return AccessGranted
+}
@@ -0,0 +1,88 @@
+package voter
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/estrys/estrys/internal/authorization/attributes"
+)
+
+func Test_domainVoter_Vote_CanFetch(t *testing.T) {
+	tests := []struct {
+		name           string
+		subject        string
+		attr           attributes.Attribute
+		blockedDomains []string
+		want           decision
+	}{
+		{
+			name:           "access granted no blocked domains",
+			subject:        "https://example.com/users/foobar#main-key",
+			attr:           attributes.CanFetch,
+			blockedDomains: []string{},
+			want:           AccessGranted,
+		},
+		{
+			name:           "access granted other domain",
+			subject:        "https://example.com/users/foobar#main-key",
+			attr:           attributes.CanFetch,
+			blockedDomains: []string{"example.org", "ample.com"},
+			want:           AccessGranted,
+		},
+		{
+			name:           "access denied domain match",
+			subject:        "https://Example.com/users/foobar#main-key",
+			attr:           attributes.CanFetch,
+			blockedDomains: []string{"example.com"},
+			want:           AccessDenied,
+		},
+		{
+			name:           "access denied subdomain match",
+			subject:        "https://social.example.com/users/foobar#main-key",
+			attr:           attributes.CanFetch,
+			blockedDomains: []string{"example.com"},
+			want:           AccessDenied,
+		},
+		{
+			name:           "access denied without host",
+			subject:        "mailto:example.com",
+			attr:           attributes.CanFetch,
+			blockedDomains: []string{},
+			want:           AccessDenied,
+		},
+		{
+			name:           "interaction denied domain match",
+			subject:        "https://example.com/users/foobar",
+			attr:           attributes.CanInteract,
+			blockedDomains: []string{"example.com"},
+			want:           AccessDenied,
+		},
+		{
+			name:           "interaction granted other domain",
+			subject:        "https://example.com/users/foobar",
+			attr:           attributes.CanInteract,
+			blockedDomains: []string{"example.org"},
+			want:           AccessGranted,
+		},
+		{
+			name:           "access denied unsupported attribute",
+			subject:        "https://example.com/users/foobar#main-key",
+			attr:           attributes.CanFollow,
+			blockedDomains: []string{},
+			want:           AccessDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, err := url.Parse(tt.subject)
+			require.NoError(t, err)
+			d := NewDomainVoter(tt.blockedDomains)
+			require.True(t, d.Supports(subject))
+			if got := d.Vote(subject, tt.attr); got != tt.want {
+				t.Errorf("Vote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
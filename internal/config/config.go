@@ -10,6 +10,20 @@ import (
 	"github.com/spf13/viper"
 )
 
+const (
+	defaultActorRefreshInterval = 24 * time.Hour
+	defaultPublicKeyCacheTTL    = 24 * time.Hour
+	defaultOutboxPageSize       = 20
+	defaultFollowersPageSize    = 40
+	defaultSignatureClockSkew   = time.Hour
+	defaultFollowRequestTTL     = 7 * 24 * time.Hour
+	defaultFediverseScanPeriod  = 24 * time.Hour
+)
+
+var defaultOptOutMarkers = []string{"#nobridge", "#nobot"}
+
+const defaultActorBioSuffix = "Unofficial mirror of a Twitter account, posts are copied automatically."
+
 type Config struct {
 	Address                    string        `mapstructure:"address"`
 	Domain                     *url.URL      `mapstructure:"-"`
@@ -19,10 +33,26 @@ type Config struct {
 	RedisAddress               string        `mapstructure:"redis_address"`
 	TwitterUserCacheTimeout    time.Duration `mapstructure:"-"`
 	TwitterTweetCacheTimeout   time.Duration `mapstructure:"-"`
+	ActorRefreshInterval       time.Duration `mapstructure:"-"`
+	PublicKeyCacheTimeout      time.Duration `mapstructure:"-"`
+	OutboxPageSize             int           `mapstructure:"outbox_page_size"`
+	FollowersPageSize          int           `mapstructure:"followers_page_size"`
+	HideFollowers              bool          `mapstructure:"hide_followers"`
 	DisableHTTPSignatureVerify bool          `mapstructure:"disable_http_signature_verify"`
+	AllowPrivateNetworks       bool          `mapstructure:"allow_private_networks"`
+	SignatureClockSkew         time.Duration `mapstructure:"-"`
+	AuthorizedFetch            bool          `mapstructure:"authorized_fetch"`
+	BlockedDomains             []string      `mapstructure:"blocked_domains"`
 	DisableEmbedWorker         bool          `mapstructure:"disable_embed_worker"`
 	AllowedUsers               []string      `mapstructure:"allowed_users"`
 	TwitterAllowedUsers        []string      `mapstructure:"twitter_allowed_users"`
+	ManuallyApprovedUsers      []string      `mapstructure:"manually_approved_users"`
+	OptOutMarkers              []string      `mapstructure:"opt_out_markers"`
+	BotActors                  bool          `mapstructure:"bot_actors"`
+	ActorNameSuffix            string        `mapstructure:"actor_name_suffix"`
+	ActorBioSuffix             string        `mapstructure:"actor_bio_suffix"`
+	FollowRequestExpiration    time.Duration `mapstructure:"-"`
+	FediverseScanInterval      time.Duration `mapstructure:"-"`
 	RunMigrations              bool          `mapstructure:"run_migrations"`
 	SentryDSN                  string        `mapstructure:"sentry_dsn"`
 	SentryTraceSampleRate      float64       `mapstructure:"sentry_trace_sample_rate"`
@@ -110,6 +140,68 @@ func (l *configLoader) Load() error {
 		}
 	}
 
+	conf.ActorRefreshInterval = defaultActorRefreshInterval
+	actorRefreshInterval := viper.GetString("actor_refresh_interval")
+	if actorRefreshInterval != "" {
+		conf.ActorRefreshInterval, err = time.ParseDuration(actorRefreshInterval)
+		if err != nil {
+			return errors.Wrap(err, "unable to parse actor refresh interval duration")
+		}
+	}
+
+	conf.PublicKeyCacheTimeout = defaultPublicKeyCacheTTL
+	publicKeyCacheTTL := viper.GetString("cache_public_key_ttl")
+	if publicKeyCacheTTL != "" {
+		conf.PublicKeyCacheTimeout, err = time.ParseDuration(publicKeyCacheTTL)
+		if err != nil {
+			return errors.Wrap(err, "unable to parse public key cache ttl duration")
+		}
+	}
+
+	conf.SignatureClockSkew = defaultSignatureClockSkew
+	signatureClockSkew := viper.GetString("signature_clock_skew")
+	if signatureClockSkew != "" {
+		conf.SignatureClockSkew, err = time.ParseDuration(signatureClockSkew)
+		if err != nil {
+			return errors.Wrap(err, "unable to parse signature clock skew duration")
+		}
+	}
+
+	conf.FollowRequestExpiration = defaultFollowRequestTTL
+	followRequestExpiration := viper.GetString("follow_request_expiration")
+	if followRequestExpiration != "" {
+		conf.FollowRequestExpiration, err = time.ParseDuration(followRequestExpiration)
+		if err != nil {
+			return errors.Wrap(err, "unable to parse follow request expiration duration")
+		}
+	}
+
+	conf.FediverseScanInterval = defaultFediverseScanPeriod
+	fediverseScanInterval := viper.GetString("fediverse_scan_interval")
+	if fediverseScanInterval != "" {
+		conf.FediverseScanInterval, err = time.ParseDuration(fediverseScanInterval)
+		if err != nil {
+			return errors.Wrap(err, "unable to parse fediverse scan interval duration")
+		}
+	}
+
+	if !viper.IsSet("opt_out_markers") {
+		conf.OptOutMarkers = defaultOptOutMarkers
+	}
+	if !viper.IsSet("bot_actors") {
+		conf.BotActors = true
+	}
+	if !viper.IsSet("actor_bio_suffix") {
+		conf.ActorBioSuffix = defaultActorBioSuffix
+	}
+
+	if conf.OutboxPageSize <= 0 {
+		conf.OutboxPageSize = defaultOutboxPageSize
+	}
+	if conf.FollowersPageSize <= 0 {
+		conf.FollowersPageSize = defaultFollowersPageSize
+	}
+
 	if conf.Token == "" {
 		return errors.New("you need to configure a token")
 	}
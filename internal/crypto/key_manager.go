@@ -10,35 +10,54 @@ import (
 	"encoding/pem"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 
-	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/pkg/errors"
 
+	"github.com/estrys/estrys/internal/cache"
 	_http "github.com/estrys/estrys/internal/http"
 	"github.com/estrys/estrys/internal/logger"
 )
 
+//go:generate mockery --with-expecter --name=KeyManager
 type KeyManager interface {
 	GenerateKey() (*rsa.PrivateKey, error)
 	FormatPubKey(crypto.PublicKey) string
-	FetchKey(ctx context.Context, id string) (crypto.PublicKey, error)
+	// FetchKey returns a remote key, from the cache when possible.
+	FetchKey(ctx context.Context, id string) (*PublicKey, error)
+	// RefreshKey fetches a remote key again, it should be used when the cached one may have been rotated.
+	RefreshKey(ctx context.Context, id string) (*PublicKey, error)
+}
+
+// PublicKey is a remote key along with the actor owning it.
+type PublicKey struct {
+	Key   crypto.PublicKey
+	Owner string
+}
+
+// CachedPublicKey is the form under which remote keys are kept in cache.
+type CachedPublicKey struct {
+	PublicKeyPEM string
+	Owner        string
 }
 
 type rsaKeyManager struct {
 	log    logger.Logger
 	client _http.Client
-	cache  *lru.Cache[string, crypto.PublicKey]
+	cache  cache.Cache[CachedPublicKey]
 }
 
 type keyResponse struct {
+	ID        string `json:"id"`
 	PublicKey struct {
+		ID           string `json:"id"`
 		PublicKeyPEM string `json:"publicKeyPem"` //nolint:tagliatelle
 		Owner        string `json:"owner"`
 	} `json:"publicKey"` //nolint:tagliatelle
 }
 
-func NewKeyManager(log logger.Logger, client _http.Client) *rsaKeyManager {
-	cache, _ := lru.New[string, crypto.PublicKey](1000)
+func NewKeyManager(log logger.Logger, client _http.Client, cache cache.Cache[CachedPublicKey]) *rsaKeyManager {
 	return &rsaKeyManager{
 		log:    log,
 		client: client,
@@ -46,11 +65,59 @@ func NewKeyManager(log logger.Logger, client _http.Client) *rsaKeyManager {
 	}
 }
 
-func (k *rsaKeyManager) FetchKey(ctx context.Context, id string) (crypto.PublicKey, error) {
-	if key, cacheHit := k.cache.Get(id); cacheHit {
-		return key, nil
+func keyCacheKey(id string) string {
+	return "activitypub/key/" + id
+}
+
+func (k *rsaKeyManager) FetchKey(ctx context.Context, id string) (*PublicKey, error) {
+	cachedKey, err := k.cache.Get(ctx, keyCacheKey(id))
+	if err == nil {
+		publicKey, err := parsePublicKey(*cachedKey)
+		if err == nil {
+			return publicKey, nil
+		}
+		k.log.WithError(err).WithField("key", id).Warn("invalid key in cache")
+	} else if !errors.Is(err, cache.ErrMiss) {
+		k.log.WithError(err).WithField("key", id).Warn("unable to get key from cache")
+	}
+
+	return k.RefreshKey(ctx, id)
+}
+
+func (k *rsaKeyManager) RefreshKey(ctx context.Context, id string) (*PublicKey, error) {
+	publicKeyResponse, err := k.fetchKeyDocument(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	k.log.WithField("owner", publicKeyResponse.PublicKey.Owner).
+		WithField("key", publicKeyResponse.PublicKey.PublicKeyPEM).
+		Trace("success fetching key")
+
+	cachedKey := CachedPublicKey{
+		PublicKeyPEM: publicKeyResponse.PublicKey.PublicKeyPEM,
+		Owner:        publicKeyResponse.PublicKey.Owner,
+	}
+	publicKey, err := parsePublicKey(cachedKey)
+	if err != nil {
+		return nil, err
+	}
+	if cachedKey.Owner != "" {
+		err = k.checkOwner(ctx, id, publicKeyResponse)
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	k.log.WithField("key", publicKeyResponse.PublicKey.PublicKeyPEM).Debug("fetched key")
+
+	err = k.cache.Set(ctx, keyCacheKey(id), cachedKey)
+	if err != nil {
+		k.log.WithError(err).WithField("key", id).Warn("unable to save key in cache")
+	}
+	return publicKey, nil
+}
+
+func (k *rsaKeyManager) fetchKeyDocument(ctx context.Context, id string) (*keyResponse, error) {
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, id, nil)
 	req.Header.Set("accept", "application/activity+json")
 	resp, err := k.client.Do(req)
@@ -68,27 +135,61 @@ func (k *rsaKeyManager) FetchKey(ctx context.Context, id string) (crypto.PublicK
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to decode user json")
 	}
-	k.log.WithField("owner", publicKeyResponse.PublicKey.Owner).
-		WithField("key", publicKeyResponse.PublicKey.PublicKeyPEM).
-		Trace("success fetching key")
+	return &publicKeyResponse, nil
+}
 
-	pemBlock, _ := pem.Decode([]byte(publicKeyResponse.PublicKey.PublicKeyPEM))
+// checkOwner makes sure the actor declared as the owner of a key really owns it, the key document
+// is controlled by whoever hosts it and could claim any owner.
+func (k *rsaKeyManager) checkOwner(ctx context.Context, id string, keyDocument *keyResponse) error {
+	keyURL, err := url.Parse(id)
+	if err != nil {
+		return errors.Wrap(err, "invalid key id")
+	}
+	owner := keyDocument.PublicKey.Owner
+	ownerURL, err := url.Parse(owner)
+	if err != nil {
+		return errors.Wrap(err, "invalid key owner")
+	}
+	if !strings.EqualFold(keyURL.Host, ownerURL.Host) {
+		return errors.Errorf("key %s is not hosted by its owner %s", id, owner)
+	}
+
+	// Keys are usually embedded in the owner document, otherwise the owner must list the key as its own
+	keyURL.Fragment = ""
+	ownerDocument := keyDocument
+	if keyURL.String() != owner || keyDocument.ID != owner {
+		ownerDocument, err = k.fetchKeyDocument(ctx, owner)
+		if err != nil {
+			return errors.Wrap(err, "unable to fetch key owner")
+		}
+	}
+	if ownerDocument.PublicKey.ID != id {
+		return errors.Errorf("key %s is not the key of its owner %s", id, owner)
+	}
+	return nil
+}
 
+func parsePublicKey(cachedKey CachedPublicKey) (*PublicKey, error) {
+	pemBlock, _ := pem.Decode([]byte(cachedKey.PublicKeyPEM))
 	if pemBlock == nil {
 		return nil, errors.New("unable to decode key PEM")
 	}
-
-	k.log.WithField("key", publicKeyResponse.PublicKey.PublicKeyPEM).Debug("fetched key")
-
 	key, err := x509.ParsePKIXPublicKey(pemBlock.Bytes)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to parse public key")
 	}
-	k.cache.Add(id, key)
-	return key, nil
+	return &PublicKey{
+		Key:   key,
+		Owner: cachedKey.Owner,
+	}, nil
 }
 
 func (k *rsaKeyManager) GenerateKey() (*rsa.PrivateKey, error) {
+	return GenerateKey()
+}
+
+// GenerateKey creates a keypair suitable for signing activitypub requests.
+func GenerateKey() (*rsa.PrivateKey, error) {
 	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
 	return privateKey, errors.Wrap(err, "rsa key generation failed")
 }
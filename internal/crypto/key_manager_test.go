@@ -3,7 +3,6 @@ package crypto
 import (
 	"bytes"
 	"context"
-	"crypto"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
@@ -18,37 +17,39 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/estrys/estrys/internal/cache"
+	cachemocks "github.com/estrys/estrys/internal/cache/mocks"
 	httpmock "github.com/estrys/estrys/internal/http/mocks"
 	loggermock "github.com/estrys/estrys/internal/logger/mocks"
 )
 
 func Test_rsaKeyManager_FetchKey(t *testing.T) {
 	fakeLogger := loggermock.NewNullLogger()
-	fakeUrl := "https://example.com/key"
+	fakeUrl := "https://example.com/users/foobar#main-key"
 
 	tests := []struct {
 		name string
 		id   string
-		want crypto.PublicKey
+		want *PublicKey
 		mock func(client *httpmock.Client)
 		err  error
 	}{
 		{
 			name: "rsa",
 			id:   fakeUrl,
-			want: func() crypto.PublicKey {
+			want: func() *PublicKey {
 				pemBlock, _ := pem.Decode([]byte("-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAtQKn/lAs285puIPRoWQv\n0lQpA4wzoqWt2YRcXy7O3qbllb4dkX7XmG6nJluuWOpkS5E4cajLzvrtRq1MFzOW\ndWgPmIbO4uf4S8ByhvLMR3ytvp+iEynckI9XLva9ObnUXxV7ovLD94hlES400lhS\n46DY/Dt26mrEkHiZqoV5JfTKsS1Pa8MhHZ8NuBXholL75cf8UdWgjHqBZj+jcjht\nCDTLnU2N0i1MjowTcOXdcNocC4iZLUGPCjHZHRQoP/CD+JnI8sHVQ1Iw8OH0Kgcy\ne2wv8hAiAcPIqSFl76KOH6VJpBbIsG6azyaFz5/qu15MyJGqcaZv1Ct52cQdBIk8\nwQIDAQAB\n-----END PUBLIC KEY-----\n"))
 				expectedPublicKey, _ := x509.ParsePKIXPublicKey(pemBlock.Bytes)
-				return expectedPublicKey
+				return &PublicKey{Key: expectedPublicKey, Owner: "https://example.com/users/foobar"}
 			}(),
 		},
 		{
 			name: "ed25519",
 			id:   fakeUrl,
-			want: func() crypto.PublicKey {
+			want: func() *PublicKey {
 				pemBlock, _ := pem.Decode([]byte("-----BEGIN PUBLIC KEY-----\nMCowBQYDK2VwAyEAIVYwccn41LcXRnrrX9+mfIHgg7XviPGon6WUhbmN32M=\n-----END PUBLIC KEY-----\n"))
 				expectedPublicKey, _ := x509.ParsePKIXPublicKey(pemBlock.Bytes)
-				return expectedPublicKey
+				return &PublicKey{Key: expectedPublicKey}
 			}(),
 		},
 		{
@@ -109,9 +110,16 @@ func Test_rsaKeyManager_FetchKey(t *testing.T) {
 				tt.mock(httpClient)
 			}
 
+			fakeCache := cachemocks.NewCache[CachedPublicKey](t)
+			fakeCache.EXPECT().Get(mock.Anything, "activitypub/key/"+fakeUrl).Return(nil, cache.ErrMiss)
+			if tt.want != nil {
+				fakeCache.EXPECT().Set(mock.Anything, "activitypub/key/"+fakeUrl, mock.Anything).Return(nil)
+			}
+
 			k := NewKeyManager(
 				fakeLogger,
 				httpClient,
+				fakeCache,
 			)
 
 			got, err := k.FetchKey(context.Background(), tt.id)
@@ -126,10 +134,124 @@ func Test_rsaKeyManager_FetchKey(t *testing.T) {
 	}
 }
 
+func Test_rsaKeyManager_FetchKey_CacheHit(t *testing.T) {
+	fakeCache := cachemocks.NewCache[CachedPublicKey](t)
+	fakeCache.EXPECT().Get(mock.Anything, "activitypub/key/https://example.com/key").Return(&CachedPublicKey{
+		PublicKeyPEM: "-----BEGIN PUBLIC KEY-----\nMCowBQYDK2VwAyEAIVYwccn41LcXRnrrX9+mfIHgg7XviPGon6WUhbmN32M=\n-----END PUBLIC KEY-----\n",
+		Owner:        "https://example.com/users/foobar",
+	}, nil)
+
+	k := NewKeyManager(loggermock.NewNullLogger(), httpmock.NewClient(t), fakeCache)
+	got, err := k.FetchKey(context.Background(), "https://example.com/key")
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/users/foobar", got.Owner)
+	require.NotNil(t, got.Key)
+}
+
+func Test_rsaKeyManager_RefreshKey(t *testing.T) {
+	data, err := os.ReadFile("testdata/rsa.json")
+	require.NoError(t, err)
+	httpClient := httpmock.NewClient(t)
+	httpClient.On("Do", mock.Anything).Once().Return(&http.Response{
+		Body: io.NopCloser(bytes.NewReader(data)),
+	}, nil)
+
+	// The cache is not read, the key may have been rotated
+	fakeCache := cachemocks.NewCache[CachedPublicKey](t)
+	fakeCache.EXPECT().Set(mock.Anything, "activitypub/key/https://example.com/users/foobar#main-key", mock.MatchedBy(
+		func(cachedKey CachedPublicKey) bool {
+			return cachedKey.Owner == "https://example.com/users/foobar" && cachedKey.PublicKeyPEM != ""
+		},
+	)).Return(nil)
+
+	k := NewKeyManager(loggermock.NewNullLogger(), httpClient, fakeCache)
+	got, err := k.RefreshKey(context.Background(), "https://example.com/users/foobar#main-key")
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/users/foobar", got.Owner)
+}
+
+func Test_rsaKeyManager_RefreshKey_Owner(t *testing.T) {
+	const keyPem = "-----BEGIN PUBLIC KEY-----\\nMCowBQYDK2VwAyEAIVYwccn41LcXRnrrX9+mfIHgg7XviPGon6WUhbmN32M=\\n-----END PUBLIC KEY-----\\n"
+	keyDocument := func(id string, keyID string, owner string) string {
+		return fmt.Sprintf(
+			`{"id": "%s", "publicKey": {"id": "%s", "owner": "%s", "publicKeyPem": "%s"}}`,
+			id, keyID, owner, keyPem,
+		)
+	}
+
+	tests := []struct {
+		name      string
+		keyID     string
+		documents map[string]string
+		err       string
+	}{
+		{
+			name:  "key hosted apart from its owner",
+			keyID: "https://example.com/keys/foobar",
+			documents: map[string]string{
+				"https://example.com/keys/foobar": keyDocument(
+					"https://example.com/keys/foobar", "https://example.com/keys/foobar", "https://example.com/users/foobar",
+				),
+				"https://example.com/users/foobar": keyDocument(
+					"https://example.com/users/foobar", "https://example.com/keys/foobar", "https://example.com/users/foobar",
+				),
+			},
+		},
+		{
+			name:  "forged owner on another host",
+			keyID: "https://evil.example/k",
+			documents: map[string]string{
+				"https://evil.example/k": keyDocument(
+					"https://evil.example/k", "https://evil.example/k", "https://victim.example/users/alice",
+				),
+			},
+			err: "key https://evil.example/k is not hosted by its owner https://victim.example/users/alice",
+		},
+		{
+			name:  "forged owner on the same host",
+			keyID: "https://example.com/users/mallory#main-key",
+			documents: map[string]string{
+				"https://example.com/users/mallory#main-key": keyDocument(
+					"https://example.com/users/mallory", "https://example.com/users/mallory#main-key", "https://example.com/users/alice",
+				),
+				"https://example.com/users/alice": keyDocument(
+					"https://example.com/users/alice", "https://example.com/users/alice#main-key", "https://example.com/users/alice",
+				),
+			},
+			err: "key https://example.com/users/mallory#main-key is not the key of its owner https://example.com/users/alice",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := httpmock.NewClient(t)
+			for documentURL, document := range tt.documents {
+				documentURL, document := documentURL, document
+				httpClient.EXPECT().Do(mock.MatchedBy(func(r *http.Request) bool {
+					return r.URL.String() == documentURL
+				})).Return(&http.Response{Body: io.NopCloser(bytes.NewReader([]byte(document)))}, nil).Once()
+			}
+			fakeCache := cachemocks.NewCache[CachedPublicKey](t)
+			if tt.err == "" {
+				fakeCache.EXPECT().Set(mock.Anything, "activitypub/key/"+tt.keyID, mock.Anything).Return(nil)
+			}
+
+			k := NewKeyManager(loggermock.NewNullLogger(), httpClient, fakeCache)
+			got, err := k.RefreshKey(context.Background(), tt.keyID)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "https://example.com/users/foobar", got.Owner)
+		})
+	}
+}
+
 func Test_rsaKeyManager_FormatPubKey(t *testing.T) {
 	keyManager := NewKeyManager(
 		nil,
 		nil,
+		nil,
 	)
 
 	keyBytes, _ := pem.Decode([]byte("-----BEGIN PUBLIC KEY-----\nMIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC+ytTPYSJGWBWLiSL8oUoHbFks\nZhMneshS06URryUlmTg3TuS/qO27yCMe8NOWTqweU4KV0hTxbFkd8X/64brZUzWN\nTIeMDOoKnCd0OqK+MkrKK2fSdl+5t5goWZx/IYnopQOcfkEpxADmfqElS+67vdvc\nqDFSCzpz1cTaE5CwJwIDAQAB\n-----END PUBLIC KEY-----\n"))
@@ -0,0 +1,218 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	crypto2 "crypto"
+
+	crypto "github.com/estrys/estrys/internal/crypto"
+
+	mock "github.com/stretchr/testify/mock"
+
+	rsa "crypto/rsa"
+)
+
+// KeyManager is an autogenerated mock type for the KeyManager type
+type KeyManager struct {
+	mock.Mock
+}
+
+type KeyManager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *KeyManager) EXPECT() *KeyManager_Expecter {
+	return &KeyManager_Expecter{mock: &_m.Mock}
+}
+
+// FetchKey provides a mock function with given fields: ctx, id
+func (_m *KeyManager) FetchKey(ctx context.Context, id string) (*crypto.PublicKey, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *crypto.PublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, string) *crypto.PublicKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*crypto.PublicKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KeyManager_FetchKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchKey'
+type KeyManager_FetchKey_Call struct {
+	*mock.Call
+}
+
+// FetchKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *KeyManager_Expecter) FetchKey(ctx interface{}, id interface{}) *KeyManager_FetchKey_Call {
+	return &KeyManager_FetchKey_Call{Call: _e.mock.On("FetchKey", ctx, id)}
+}
+
+func (_c *KeyManager_FetchKey_Call) Run(run func(ctx context.Context, id string)) *KeyManager_FetchKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *KeyManager_FetchKey_Call) Return(_a0 *crypto.PublicKey, _a1 error) *KeyManager_FetchKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// FormatPubKey provides a mock function with given fields: _a0
+func (_m *KeyManager) FormatPubKey(_a0 crypto2.PublicKey) string {
+	ret := _m.Called(_a0)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(crypto2.PublicKey) string); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// KeyManager_FormatPubKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FormatPubKey'
+type KeyManager_FormatPubKey_Call struct {
+	*mock.Call
+}
+
+// FormatPubKey is a helper method to define mock.On call
+//   - _a0 crypto2.PublicKey
+func (_e *KeyManager_Expecter) FormatPubKey(_a0 interface{}) *KeyManager_FormatPubKey_Call {
+	return &KeyManager_FormatPubKey_Call{Call: _e.mock.On("FormatPubKey", _a0)}
+}
+
+func (_c *KeyManager_FormatPubKey_Call) Run(run func(_a0 crypto2.PublicKey)) *KeyManager_FormatPubKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(crypto2.PublicKey))
+	})
+	return _c
+}
+
+func (_c *KeyManager_FormatPubKey_Call) Return(_a0 string) *KeyManager_FormatPubKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// GenerateKey provides a mock function with given fields:
+func (_m *KeyManager) GenerateKey() (*rsa.PrivateKey, error) {
+	ret := _m.Called()
+
+	var r0 *rsa.PrivateKey
+	if rf, ok := ret.Get(0).(func() *rsa.PrivateKey); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rsa.PrivateKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KeyManager_GenerateKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateKey'
+type KeyManager_GenerateKey_Call struct {
+	*mock.Call
+}
+
+// GenerateKey is a helper method to define mock.On call
+func (_e *KeyManager_Expecter) GenerateKey() *KeyManager_GenerateKey_Call {
+	return &KeyManager_GenerateKey_Call{Call: _e.mock.On("GenerateKey")}
+}
+
+func (_c *KeyManager_GenerateKey_Call) Run(run func()) *KeyManager_GenerateKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *KeyManager_GenerateKey_Call) Return(_a0 *rsa.PrivateKey, _a1 error) *KeyManager_GenerateKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// RefreshKey provides a mock function with given fields: ctx, id
+func (_m *KeyManager) RefreshKey(ctx context.Context, id string) (*crypto.PublicKey, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *crypto.PublicKey
+	if rf, ok := ret.Get(0).(func(context.Context, string) *crypto.PublicKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*crypto.PublicKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KeyManager_RefreshKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshKey'
+type KeyManager_RefreshKey_Call struct {
+	*mock.Call
+}
+
+// RefreshKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *KeyManager_Expecter) RefreshKey(ctx interface{}, id interface{}) *KeyManager_RefreshKey_Call {
+	return &KeyManager_RefreshKey_Call{Call: _e.mock.On("RefreshKey", ctx, id)}
+}
+
+func (_c *KeyManager_RefreshKey_Call) Run(run func(ctx context.Context, id string)) *KeyManager_RefreshKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *KeyManager_RefreshKey_Call) Return(_a0 *crypto.PublicKey, _a1 error) *KeyManager_RefreshKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewKeyManager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewKeyManager creates a new instance of KeyManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewKeyManager(t mockConstructorTestingTNewKeyManager) *KeyManager {
+	mock := &KeyManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
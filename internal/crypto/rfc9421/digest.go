@@ -0,0 +1,52 @@
+package rfc9421
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SetContentDigest adds the Content-Digest header (RFC 9530) of the body.
+func SetContentDigest(request *http.Request, body []byte) {
+	digest := sha256.Sum256(body)
+	request.Header.Set("content-digest", "sha-256="+serializeBareItem(digest[:]))
+}
+
+// VerifyContentDigest checks that the body matches one of the supported digests of the Content-Digest header.
+func VerifyContentDigest(request *http.Request, body []byte) error {
+	header := strings.Join(request.Header.Values("content-digest"), ", ")
+	if header == "" {
+		return errors.New("missing content-digest header")
+	}
+	digests, err := parseDictionary(header)
+	if err != nil {
+		return errors.Wrap(err, "invalid content-digest header")
+	}
+	for _, digest := range digests {
+		digestItem, isItem := digest.value.(item)
+		value, isBytes := digestItem.value.([]byte)
+		if !isItem || !isBytes {
+			continue
+		}
+		var expected []byte
+		switch digest.key {
+		case "sha-256":
+			sum := sha256.Sum256(body)
+			expected = sum[:]
+		case "sha-512":
+			sum := sha512.Sum512(body)
+			expected = sum[:]
+		default:
+			continue
+		}
+		if !bytes.Equal(expected, value) {
+			return errors.New("content-digest does not match request body")
+		}
+		return nil
+	}
+	return errors.Errorf("unsupported content-digest '%s'", header)
+}
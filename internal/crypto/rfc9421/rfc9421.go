@@ -0,0 +1,264 @@
+// Package rfc9421 implements the subset of HTTP Message Signatures (RFC 9421)
+// used by fediverse servers to sign requests.
+package rfc9421
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	AlgorithmRSAV15SHA256    = "rsa-v1_5-sha256"
+	AlgorithmRSAPSSSHA512    = "rsa-pss-sha512"
+	AlgorithmECDSAP256SHA256 = "ecdsa-p256-sha256"
+	AlgorithmEd25519         = "ed25519"
+
+	label = "sig1"
+)
+
+var ErrNotSigned = errors.New("request has no rfc9421 signature")
+
+// Signature is a message signature read from the Signature-Input and Signature headers.
+type Signature struct {
+	Components []string
+	KeyID      string
+	Algorithm  string
+	Created    time.Time
+	Expires    time.Time
+	Value      []byte
+	input      innerList
+}
+
+// IsSigned tells if the request carries an rfc9421 signature rather than a draft-cavage one.
+func IsSigned(request *http.Request) bool {
+	return request.Header.Get("signature-input") != ""
+}
+
+// Parse reads the first signature of the request.
+func Parse(request *http.Request) (*Signature, error) {
+	if !IsSigned(request) {
+		return nil, ErrNotSigned
+	}
+	inputs, err := parseDictionary(strings.Join(request.Header.Values("signature-input"), ", "))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature-input header")
+	}
+	values, err := parseDictionary(strings.Join(request.Header.Values("signature"), ", "))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature header")
+	}
+
+	for _, input := range inputs {
+		list, isList := input.value.(innerList)
+		if !isList {
+			continue
+		}
+		for _, value := range values {
+			if value.key != input.key {
+				continue
+			}
+			signatureItem, isItem := value.value.(item)
+			signatureValue, isBytes := signatureItem.value.([]byte)
+			if !isItem || !isBytes {
+				return nil, errors.Errorf("invalid signature value for %s", input.key)
+			}
+			return newSignature(list, signatureValue)
+		}
+	}
+	return nil, errors.New("no signature matches the signature inputs")
+}
+
+func newSignature(list innerList, value []byte) (*Signature, error) {
+	signature := &Signature{
+		input: list,
+		Value: value,
+	}
+	for _, component := range list.items {
+		name, isString := component.value.(string)
+		if !isString {
+			return nil, errors.New("invalid component identifier")
+		}
+		if len(component.params) > 0 {
+			return nil, errors.Errorf("component parameters are not supported for %s", name)
+		}
+		signature.Components = append(signature.Components, name)
+	}
+	if keyID, exists := list.params.get("keyid"); exists {
+		signature.KeyID, _ = keyID.(string)
+	}
+	if signature.KeyID == "" {
+		return nil, errors.New("signature has no keyid")
+	}
+	if algorithm, exists := list.params.get("alg"); exists {
+		signature.Algorithm, _ = algorithm.(string)
+	}
+	if created, exists := list.params.get("created"); exists {
+		if timestamp, isInt := created.(int64); isInt {
+			signature.Created = time.Unix(timestamp, 0)
+		}
+	}
+	if expires, exists := list.params.get("expires"); exists {
+		if timestamp, isInt := expires.(int64); isInt {
+			signature.Expires = time.Unix(timestamp, 0)
+		}
+	}
+	return signature, nil
+}
+
+// HasComponent tells if the component is covered by the signature.
+func (s *Signature) HasComponent(name string) bool {
+	for _, component := range s.Components {
+		if component == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify checks the signature against the public key, targetURI is the URL
+// the request was sent to, it defaults to the request URL.
+func (s *Signature) Verify(request *http.Request, targetURI *url.URL, publicKey crypto.PublicKey) error {
+	base, err := signatureBase(request, targetURI, s.input)
+	if err != nil {
+		return err
+	}
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if s.Algorithm == AlgorithmRSAPSSSHA512 {
+			digest := sha512.Sum512([]byte(base))
+			err = rsa.VerifyPSS(key, crypto.SHA512, digest[:], s.Value, &rsa.PSSOptions{SaltLength: 64})
+			break
+		}
+		if s.Algorithm != "" && s.Algorithm != AlgorithmRSAV15SHA256 {
+			return errors.Errorf("algorithm %s does not match rsa key", s.Algorithm)
+		}
+		digest := sha256.Sum256([]byte(base))
+		err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], s.Value)
+	case *ecdsa.PublicKey:
+		if s.Algorithm != "" && s.Algorithm != AlgorithmECDSAP256SHA256 {
+			return errors.Errorf("algorithm %s does not match ecdsa key", s.Algorithm)
+		}
+		if len(s.Value) != 64 {
+			return errors.New("invalid ecdsa signature length")
+		}
+		digest := sha256.Sum256([]byte(base))
+		r := new(big.Int).SetBytes(s.Value[:32])
+		sigS := new(big.Int).SetBytes(s.Value[32:])
+		if !ecdsa.Verify(key, digest[:], r, sigS) {
+			err = errors.New("ecdsa verification error")
+		}
+	case ed25519.PublicKey:
+		if s.Algorithm != "" && s.Algorithm != AlgorithmEd25519 {
+			return errors.Errorf("algorithm %s does not match ed25519 key", s.Algorithm)
+		}
+		if !ed25519.Verify(key, []byte(base), s.Value) {
+			err = errors.New("ed25519 verification error")
+		}
+	default:
+		return errors.Errorf("unsupported key type %T", publicKey)
+	}
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	return nil
+}
+
+// Sign adds Signature-Input and Signature headers covering the given components.
+// Only rsa keys are supported since this is what actors keys are.
+func Sign(
+	request *http.Request,
+	privateKey crypto.PrivateKey,
+	keyID string,
+	components []string,
+	created time.Time,
+) error {
+	rsaKey, isRsa := privateKey.(*rsa.PrivateKey)
+	if !isRsa {
+		return errors.Errorf("unsupported key type %T", privateKey)
+	}
+	input := innerList{
+		params: parameters{
+			{key: "created", value: created.Unix()},
+			{key: "keyid", value: keyID},
+		},
+	}
+	for _, component := range components {
+		input.items = append(input.items, item{value: component})
+	}
+	base, err := signatureBase(request, nil, input)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256([]byte(base))
+	value, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return errors.Wrap(err, "unable to sign request")
+	}
+	request.Header.Set("signature-input", label+"="+serializeInnerList(input))
+	request.Header.Set("signature", label+"="+serializeBareItem(value))
+	return nil
+}
+
+func signatureBase(request *http.Request, targetURI *url.URL, input innerList) (string, error) {
+	if targetURI == nil {
+		targetURI = request.URL
+	}
+	var base strings.Builder
+	for _, component := range input.items {
+		name, _ := component.value.(string)
+		value, err := componentValue(request, targetURI, name)
+		if err != nil {
+			return "", err
+		}
+		base.WriteString(serializeBareItem(name) + ": " + value + "\n")
+	}
+	base.WriteString(`"@signature-params": ` + serializeInnerList(input))
+	return base.String(), nil
+}
+
+func componentValue(request *http.Request, targetURI *url.URL, name string) (string, error) {
+	switch name {
+	case "@method":
+		return request.Method, nil
+	case "@target-uri":
+		return targetURI.String(), nil
+	case "@authority":
+		return strings.ToLower(targetURI.Host), nil
+	case "@scheme":
+		return strings.ToLower(targetURI.Scheme), nil
+	case "@request-target":
+		return targetURI.RequestURI(), nil
+	case "@path":
+		return targetURI.EscapedPath(), nil
+	case "@query":
+		return "?" + targetURI.RawQuery, nil
+	case "host":
+		if request.Header.Get("host") == "" {
+			return strings.ToLower(targetURI.Host), nil
+		}
+	}
+	if strings.HasPrefix(name, "@") {
+		return "", errors.Errorf("unsupported derived component %s", name)
+	}
+	headerValues := request.Header.Values(name)
+	if len(headerValues) == 0 {
+		return "", errors.Errorf("header %s is not present", name)
+	}
+	values := make([]string, 0, len(headerValues))
+	for _, value := range headerValues {
+		values = append(values, strings.TrimSpace(value))
+	}
+	return strings.Join(values, ", "), nil
+}
@@ -0,0 +1,126 @@
+package rfc9421
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Example from https://www.rfc-editor.org/rfc/rfc9421#appendix-B.2.6
+func TestSignature_Verify_RFCExample(t *testing.T) {
+	pemBlock, _ := pem.Decode([]byte("-----BEGIN PUBLIC KEY-----\nMCowBQYDK2VwAyEAJrQLj5P/89iXES9+vFgrIy29clF9CC/oPPsw3c5D0bs=\n-----END PUBLIC KEY-----\n"))
+	publicKey, err := x509.ParsePKIXPublicKey(pemBlock.Bytes)
+	require.NoError(t, err)
+	require.IsType(t, ed25519.PublicKey{}, publicKey)
+
+	request, err := http.NewRequest(http.MethodPost, "https://example.com/foo?param=Value&Pet=dog", strings.NewReader(`{"hello": "world"}`))
+	require.NoError(t, err)
+	request.Header.Set("date", "Tue, 20 Apr 2021 02:07:55 GMT")
+	request.Header.Set("content-type", "application/json")
+	request.Header.Set("content-digest", "sha-512=:WZDPaVn/7XgHaAy8pmojAkGWoRx2UFChF41A2svX+TaPm+AbwAgBWnrIiYllu7BNNyealdVLvRwEmTHWXvJwew==:")
+	request.Header.Set("content-length", "18")
+	request.Header.Set("signature-input", `sig-b26=("date" "@method" "@path" "@authority" "content-type" "content-length");created=1618884473;keyid="test-key-ed25519"`)
+	request.Header.Set("signature", "sig-b26=:wqcAqbmYJ2ji2glfAMaRy4gruYYnx2nEFN2HN6jrnDnQCK1u02Gb04v9EDgwUPiu4A0w6vuQv5lIp5WPpBKRCw==:")
+
+	signature, err := Parse(request)
+	require.NoError(t, err)
+	require.Equal(t, "test-key-ed25519", signature.KeyID)
+	require.Equal(t, time.Unix(1618884473, 0), signature.Created)
+	require.True(t, signature.HasComponent("@authority"))
+	require.NoError(t, signature.Verify(request, nil, publicKey))
+	require.NoError(t, VerifyContentDigest(request, []byte(`{"hello": "world"}`)))
+
+	request.Header.Set("content-type", "text/plain")
+	require.EqualError(t, signature.Verify(request, nil, publicKey), "invalid signature: ed25519 verification error")
+}
+
+func TestSign(t *testing.T) {
+	keyPem, err := os.ReadFile(path.Join("testdata", "key.pem"))
+	require.NoError(t, err)
+	pemBlock, _ := pem.Decode(keyPem)
+	privateKey, err := x509.ParsePKCS1PrivateKey(pemBlock.Bytes)
+	require.NoError(t, err)
+
+	body := []byte(`{"type":"Follow"}`)
+	request, err := http.NewRequest(http.MethodPost, "https://example.com/users/foobar/inbox", strings.NewReader(string(body)))
+	require.NoError(t, err)
+	SetContentDigest(request, body)
+	require.Equal(t, "sha-256=:GYwYnH3BiO6aICFt0ThC5bUIJ4byvqdpWtR8m5fNkww=:", request.Header.Get("content-digest"))
+
+	created := time.Unix(1669896000, 0)
+	err = Sign(request, privateKey, "https://example.com/actor#main-key", []string{"@method", "@target-uri", "content-digest"}, created)
+	require.NoError(t, err)
+	require.Equal(t,
+		`sig1=("@method" "@target-uri" "content-digest");created=1669896000;keyid="https://example.com/actor#main-key"`,
+		request.Header.Get("signature-input"),
+	)
+
+	signature, err := Parse(request)
+	require.NoError(t, err)
+	require.Equal(t, []string{"@method", "@target-uri", "content-digest"}, signature.Components)
+	require.Equal(t, created, signature.Created)
+	require.NoError(t, signature.Verify(request, nil, privateKey.Public()))
+	require.NoError(t, VerifyContentDigest(request, body))
+	require.EqualError(t, VerifyContentDigest(request, []byte("{}")), "content-digest does not match request body")
+
+	request.Method = http.MethodPut
+	require.Error(t, signature.Verify(request, nil, privateKey.Public()))
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		signatureInput string
+		signature      string
+		err            string
+	}{
+		{
+			name: "not signed",
+			err:  "request has no rfc9421 signature",
+		},
+		{
+			name:           "missing keyid",
+			signatureInput: `sig1=("@method");created=1669896000`,
+			signature:      "sig1=:AAAA:",
+			err:            "signature has no keyid",
+		},
+		{
+			name:           "label mismatch",
+			signatureInput: `sig1=("@method");keyid="foo"`,
+			signature:      "sig2=:AAAA:",
+			err:            "no signature matches the signature inputs",
+		},
+		{
+			name:           "invalid input",
+			signatureInput: `sig1=("@method";keyid="foo"`,
+			signature:      "sig1=:AAAA:",
+			err:            "invalid signature-input header: unexpected character in inner list at position 27",
+		},
+		{
+			name:           "component parameters",
+			signatureInput: `sig1=("@query-param";name="foo");keyid="foo"`,
+			signature:      "sig1=:AAAA:",
+			err:            "component parameters are not supported for @query-param",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodGet, "https://example.com/users/foobar", nil)
+			require.NoError(t, err)
+			if tt.signatureInput != "" {
+				request.Header.Set("signature-input", tt.signatureInput)
+				request.Header.Set("signature", tt.signature)
+			}
+			_, err = Parse(request)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package rfc9421
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// This is a subset of the structured field values (RFC 8941) required to read
+// the Signature-Input, Signature and Content-Digest dictionaries.
+
+type token string
+
+type parameter struct {
+	key   string
+	value any
+}
+
+type parameters []parameter
+
+func (p parameters) get(key string) (any, bool) {
+	for _, param := range p {
+		if param.key == key {
+			return param.value, true
+		}
+	}
+	return nil, false
+}
+
+type item struct {
+	value  any
+	params parameters
+}
+
+type innerList struct {
+	items  []item
+	params parameters
+}
+
+type dictionaryMember struct {
+	key   string
+	value any // item or innerList
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func parseDictionary(input string) ([]dictionaryMember, error) {
+	p := &parser{input: input}
+	p.skipSpaces()
+	var members []dictionaryMember
+	for !p.eof() {
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		member := dictionaryMember{key: key}
+		if p.peek() == '=' {
+			p.pos++
+			if p.peek() == '(' {
+				member.value, err = p.parseInnerList()
+			} else {
+				member.value, err = p.parseItem()
+			}
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			params, err := p.parseParameters()
+			if err != nil {
+				return nil, err
+			}
+			member.value = item{value: true, params: params}
+		}
+		members = append(members, member)
+
+		p.skipOWS()
+		if p.eof() {
+			break
+		}
+		if p.peek() != ',' {
+			return nil, errors.Errorf("unexpected character at position %d", p.pos)
+		}
+		p.pos++
+		p.skipOWS()
+		if p.eof() {
+			return nil, errors.New("trailing comma in dictionary")
+		}
+	}
+	return members, nil
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() && p.peek() == ' ' {
+		p.pos++
+	}
+}
+
+func (p *parser) skipOWS() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+func (p *parser) parseKey() (string, error) {
+	start := p.pos
+	if c := p.peek(); !(c >= 'a' && c <= 'z') && c != '*' {
+		return "", errors.Errorf("invalid key at position %d", p.pos)
+	}
+	for !p.eof() {
+		c := p.peek()
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || strings.IndexByte("_-.*", c) >= 0 {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.input[start:p.pos], nil
+}
+
+func (p *parser) parseInnerList() (innerList, error) {
+	var list innerList
+	p.pos++ // (
+	for {
+		p.skipSpaces()
+		if p.eof() {
+			return list, errors.New("unterminated inner list")
+		}
+		if p.peek() == ')' {
+			p.pos++
+			params, err := p.parseParameters()
+			list.params = params
+			return list, err
+		}
+		listItem, err := p.parseItem()
+		if err != nil {
+			return list, err
+		}
+		list.items = append(list.items, listItem)
+		if c := p.peek(); c != ' ' && c != ')' {
+			return list, errors.Errorf("unexpected character in inner list at position %d", p.pos)
+		}
+	}
+}
+
+func (p *parser) parseItem() (item, error) {
+	value, err := p.parseBareItem()
+	if err != nil {
+		return item{}, err
+	}
+	params, err := p.parseParameters()
+	return item{value: value, params: params}, err
+}
+
+func (p *parser) parseParameters() (parameters, error) {
+	var params parameters
+	for p.peek() == ';' {
+		p.pos++
+		p.skipSpaces()
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		var value any = true
+		if p.peek() == '=' {
+			p.pos++
+			value, err = p.parseBareItem()
+			if err != nil {
+				return nil, err
+			}
+		}
+		params = append(params, parameter{key: key, value: value})
+	}
+	return params, nil
+}
+
+func (p *parser) parseBareItem() (any, error) {
+	c := p.peek()
+	switch {
+	case c == '"':
+		return p.parseString()
+	case c == ':':
+		return p.parseByteSequence()
+	case c == '?':
+		p.pos++
+		switch p.peek() {
+		case '1':
+			p.pos++
+			return true, nil
+		case '0':
+			p.pos++
+			return false, nil
+		}
+		return nil, errors.Errorf("invalid boolean at position %d", p.pos)
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseInteger()
+	case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '*':
+		return p.parseToken(), nil
+	}
+	return nil, errors.Errorf("invalid item at position %d", p.pos)
+}
+
+func (p *parser) parseString() (string, error) {
+	var builder strings.Builder
+	p.pos++ // "
+	for !p.eof() {
+		c := p.peek()
+		p.pos++
+		switch c {
+		case '\\':
+			if p.eof() {
+				return "", errors.New("unterminated string")
+			}
+			builder.WriteByte(p.peek())
+			p.pos++
+		case '"':
+			return builder.String(), nil
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return "", errors.New("unterminated string")
+}
+
+func (p *parser) parseByteSequence() ([]byte, error) {
+	p.pos++ // :
+	end := strings.IndexByte(p.input[p.pos:], ':')
+	if end < 0 {
+		return nil, errors.New("unterminated byte sequence")
+	}
+	value, err := base64.StdEncoding.DecodeString(p.input[p.pos : p.pos+end])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid byte sequence")
+	}
+	p.pos += end + 1
+	return value, nil
+}
+
+func (p *parser) parseInteger() (int64, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	if p.peek() == '.' {
+		return 0, errors.Errorf("decimals are not supported at position %d", p.pos)
+	}
+	value, err := strconv.ParseInt(p.input[start:p.pos], 10, 64)
+	return value, errors.Wrap(err, "invalid integer")
+}
+
+func (p *parser) parseToken() token {
+	start := p.pos
+	for !p.eof() {
+		c := p.peek()
+		if c <= ' ' || c >= 0x7f || strings.IndexByte(`"(),;<=>?@[\]{}`, c) >= 0 {
+			break
+		}
+		p.pos++
+	}
+	return token(p.input[start:p.pos])
+}
+
+func serializeBareItem(value any) string {
+	switch typedValue := value.(type) {
+	case string:
+		escaped := strings.ReplaceAll(typedValue, `\`, `\\`)
+		return `"` + strings.ReplaceAll(escaped, `"`, `\"`) + `"`
+	case int64:
+		return strconv.FormatInt(typedValue, 10)
+	case token:
+		return string(typedValue)
+	case []byte:
+		return ":" + base64.StdEncoding.EncodeToString(typedValue) + ":"
+	case bool:
+		if typedValue {
+			return "?1"
+		}
+		return "?0"
+	}
+	return ""
+}
+
+func serializeParameters(params parameters) string {
+	var builder strings.Builder
+	for _, param := range params {
+		builder.WriteString(";" + param.key)
+		if boolValue, isBool := param.value.(bool); !isBool || !boolValue {
+			builder.WriteString("=" + serializeBareItem(param.value))
+		}
+	}
+	return builder.String()
+}
+
+func serializeInnerList(list innerList) string {
+	serializedItems := make([]string, 0, len(list.items))
+	for _, listItem := range list.items {
+		serializedItems = append(serializedItems, serializeBareItem(listItem.value)+serializeParameters(listItem.params))
+	}
+	return "(" + strings.Join(serializedItems, " ") + ")" + serializeParameters(list.params)
+}
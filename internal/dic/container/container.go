@@ -1,6 +1,7 @@
 package container
 
 import (
+	"fmt"
 	"net/http"
 
 	gotwitter "github.com/g8rswimmer/go-twitter/v2"
@@ -10,7 +11,10 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/estrys/estrys/internal/activitypub"
+	"github.com/estrys/estrys/internal/activitypub/auth"
 	activitypubclient "github.com/estrys/estrys/internal/activitypub/client"
+	"github.com/estrys/estrys/internal/activitypub/instance"
+	"github.com/estrys/estrys/internal/activitypub/resolver"
 	"github.com/estrys/estrys/internal/authorization"
 	"github.com/estrys/estrys/internal/authorization/voter"
 	"github.com/estrys/estrys/internal/cache"
@@ -19,7 +23,9 @@ import (
 	"github.com/estrys/estrys/internal/database"
 	"github.com/estrys/estrys/internal/dic"
 	"github.com/estrys/estrys/internal/domain"
+	_http "github.com/estrys/estrys/internal/http"
 	"github.com/estrys/estrys/internal/logger"
+	"github.com/estrys/estrys/internal/media"
 	"github.com/estrys/estrys/internal/repository"
 	"github.com/estrys/estrys/internal/router"
 	"github.com/estrys/estrys/internal/router/urlgenerator"
@@ -28,6 +34,7 @@ import (
 	"github.com/estrys/estrys/internal/twitter/poller"
 	twitterrepository "github.com/estrys/estrys/internal/twitter/repository"
 	"github.com/estrys/estrys/internal/worker/client"
+	"github.com/estrys/estrys/internal/worker/tasks"
 )
 
 func BuildContainer() error {
@@ -46,6 +53,7 @@ func BuildContainer() error {
 	))
 	_ = dic.Register[activitypub.VocabService](activitypub.NewActivityPubVocabService(
 		dic.GetService[urlgenerator.URLGenerator](),
+		conf,
 	))
 
 	_ = dic.Register[database.Database](database.NewPostgres(
@@ -58,6 +66,10 @@ func BuildContainer() error {
 		redisClient,
 		cache.OptionDefaultTTL(conf.TwitterUserCacheTimeout),
 	))
+	_ = dic.Register[cache.Cache[twitter.UserProfile]](cache.CreateRedisCache[twitter.UserProfile](
+		redisClient,
+		cache.OptionDefaultTTL(conf.TwitterUserCacheTimeout),
+	))
 	_ = dic.Register[cache.Cache[models.Tweet]](cache.CreateRedisCache[models.Tweet](
 		redisClient,
 		cache.OptionDefaultTTL(conf.TwitterTweetCacheTimeout),
@@ -66,7 +78,7 @@ func BuildContainer() error {
 		asynq.NewClient(asynq.RedisClientOpt{Addr: conf.RedisAddress}),
 	))
 
-	_ = dic.Register[twitter.Backend](&gotwitter.Client{
+	_ = dic.Register[twitter.Backend](twitter.NewBackend(&gotwitter.Client{
 		Authorizer: twitter.Authorizer{
 			Token: conf.Token,
 		},
@@ -77,21 +89,32 @@ func BuildContainer() error {
 			},
 		},
 		Host: "https://api.twitter.com",
-	})
+	}))
 	_ = dic.Register[twitter.TwitterClient](twitter.NewClient(
 		dic.GetService[logger.Logger](),
 		dic.GetService[cache.Cache[gotwitter.UserObj]](),
+		dic.GetService[cache.Cache[twitter.UserProfile]](),
 		dic.GetService[twitter.Backend](),
 	))
 	_ = dic.Register[twitterrepository.TweetRepository](
 		twitterrepository.NewRedisTweetRepository(
 			dic.GetService[cache.Cache[models.Tweet]](),
+			redisClient.Client(),
+			conf.TwitterTweetCacheTimeout,
 		),
 	)
+	_ = dic.Register[activitypubclient.SignatureFormatStore](activitypubclient.NewRedisSignatureFormatStore(
+		redisClient.Client(),
+	))
+	safeClientOptions := _http.SafeClientOptions{
+		AllowPrivateNetworks: conf.AllowPrivateNetworks,
+		UserAgent:            fmt.Sprintf("Estrys (+%s)", conf.Domain.String()),
+	}
 	activityPubClient, err := activitypubclient.NewActivityPubClient(
-		&http.Client{},
+		_http.NewSafeClient(safeClientOptions),
 		dic.GetService[logger.Logger](),
 		dic.GetService[urlgenerator.URLGenerator](),
+		dic.GetService[activitypubclient.SignatureFormatStore](),
 	)
 	if err != nil {
 		return errors.Wrap(err, "unable to create the activitypub client")
@@ -104,43 +127,153 @@ func BuildContainer() error {
 	_ = dic.Register[repository.ActorRepository](repository.NewActorRepository(
 		dic.GetService[database.Database](),
 	))
+	_ = dic.Register[repository.InstanceKeyRepository](repository.NewInstanceKeyRepository(
+		dic.GetService[database.Database](),
+	))
+	_ = dic.Register[repository.FollowerRepository](repository.NewFollowerRepository(
+		dic.GetService[database.Database](),
+	))
+	_ = dic.Register[repository.InboundActivityRepository](repository.NewInboundActivityRepository(
+		dic.GetService[database.Database](),
+	))
+	_ = dic.Register[repository.InteractionRepository](repository.NewInteractionRepository(
+		dic.GetService[database.Database](),
+	))
+	_ = dic.Register[repository.FediverseLinkRepository](repository.NewFediverseLinkRepository(
+		dic.GetService[database.Database](),
+	))
+	_ = dic.Register[repository.OptOutRepository](repository.NewOptOutRepository(
+		dic.GetService[database.Database](),
+	))
+	_ = dic.Register[instance.InstanceActor](instance.NewInstanceActor(
+		dic.GetService[logger.Logger](),
+		dic.GetService[repository.InstanceKeyRepository](),
+	))
+	signedClient, err := activitypubclient.NewSignedClient(
+		_http.NewSafeClient(safeClientOptions),
+		dic.GetService[logger.Logger](),
+		dic.GetService[urlgenerator.URLGenerator](),
+		dic.GetService[instance.InstanceActor](),
+		dic.GetService[activitypubclient.SignatureFormatStore](),
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to create the signed http client")
+	}
+	_ = dic.Register[auth.SignatureReplayCache](auth.NewRedisSignatureReplayCache(
+		redisClient.Client(),
+	))
+	_ = dic.Register[auth.KeyRefreshLimiter](auth.NewRedisKeyRefreshLimiter(
+		redisClient.Client(),
+	))
 	_ = dic.Register[authorization.AuthorizationChecker](authorization.NewVoterAuthorizationChecker([]voter.Voter{
 		voter.NewActivityVoter(conf.AllowedUsers),
+		voter.NewDomainVoter(conf.BlockedDomains),
 	}))
+	_ = dic.Register[cache.Cache[crypto.CachedPublicKey]](cache.CreateRedisCache[crypto.CachedPublicKey](
+		redisClient,
+		cache.OptionDefaultTTL(conf.PublicKeyCacheTimeout),
+	))
 	_ = dic.Register[crypto.KeyManager](crypto.NewKeyManager(
 		dic.GetService[logger.Logger](),
-		&http.Client{},
+		signedClient,
+		dic.GetService[cache.Cache[crypto.CachedPublicKey]](),
+	))
+	_ = dic.Register[resolver.ActorResolver](resolver.NewActorResolver(
+		dic.GetService[logger.Logger](),
+		signedClient,
+		dic.GetService[repository.ActorRepository](),
+		conf.ActorRefreshInterval,
+	))
+	_ = dic.Register[resolver.ObjectResolver](resolver.NewObjectResolver(
+		dic.GetService[logger.Logger](),
+		signedClient,
+	))
+	_ = dic.Register[domain.ConsentService](domain.NewConsentService(
+		dic.GetService[logger.Logger](),
+		dic.GetService[repository.UserRepository](),
+		dic.GetService[repository.OptOutRepository](),
+		dic.GetService[twitter.TwitterClient](),
+		dic.GetService[activitypub.VocabService](),
+		dic.GetService[client.BackgroundWorkerClient](),
+		conf.OptOutMarkers,
 	))
 	_ = dic.Register[domain.UserService](domain.NewUserService(
 		dic.GetService[logger.Logger](),
 		dic.GetService[crypto.KeyManager](),
 		dic.GetService[repository.UserRepository](),
+		dic.GetService[repository.FediverseLinkRepository](),
 		dic.GetService[twitter.TwitterClient](),
+		dic.GetService[domain.ConsentService](),
+		conf.ManuallyApprovedUsers,
 	))
 	_ = dic.Register[domain.InboxService](domain.NewInboxService(
 		dic.GetService[logger.Logger](),
 		dic.GetService[database.Database](),
 		dic.GetService[repository.ActorRepository](),
 		dic.GetService[repository.UserRepository](),
-		dic.GetService[crypto.KeyManager](),
+		dic.GetService[repository.FollowerRepository](),
+		dic.GetService[repository.InboundActivityRepository](),
+		dic.GetService[repository.InteractionRepository](),
+		dic.GetService[twitterrepository.TweetRepository](),
+		dic.GetService[resolver.ActorResolver](),
+		dic.GetService[resolver.ObjectResolver](),
 		dic.GetService[activitypubclient.ActivityPubClient](),
 		dic.GetService[activitypub.VocabService](),
 		dic.GetService[client.BackgroundWorkerClient](),
 		dic.GetService[authorization.AuthorizationChecker](),
+		dic.GetService[crypto.KeyManager](),
+		dic.GetService[domain.ConsentService](),
 		conf,
 	))
+	_ = dic.Register[domain.FollowRequestService](domain.NewFollowRequestService(
+		dic.GetService[logger.Logger](),
+		dic.GetService[repository.FollowerRepository](),
+		dic.GetService[activitypub.VocabService](),
+		dic.GetService[client.BackgroundWorkerClient](),
+		conf.FollowRequestExpiration,
+	))
+	_ = dic.Register[domain.MigrationService](domain.NewMigrationService(
+		dic.GetService[logger.Logger](),
+		dic.GetService[repository.UserRepository](),
+		dic.GetService[resolver.ObjectResolver](),
+		dic.GetService[activitypub.VocabService](),
+		dic.GetService[urlgenerator.URLGenerator](),
+		dic.GetService[client.BackgroundWorkerClient](),
+	))
+	_ = dic.Register[resolver.WebFingerResolver](resolver.NewWebFingerResolver(
+		dic.GetService[logger.Logger](),
+		_http.NewSafeClient(safeClientOptions),
+	))
+	_ = dic.Register[domain.FediverseLinkService](domain.NewFediverseLinkService(
+		dic.GetService[logger.Logger](),
+		dic.GetService[repository.UserRepository](),
+		dic.GetService[repository.FediverseLinkRepository](),
+		dic.GetService[twitter.TwitterClient](),
+		dic.GetService[resolver.WebFingerResolver](),
+		dic.GetService[resolver.ObjectResolver](),
+		dic.GetService[urlgenerator.URLGenerator](),
+	))
+	// Media urls come from twitter, they are fetched as any other remote url
+	mediaClientOptions := safeClientOptions
+	mediaClientOptions.MaxBodySize = media.MaxImageSize
+	_ = dic.Register[media.ImageProcessor](media.NewImageProcessor(
+		_http.NewSafeClient(mediaClientOptions),
+	))
 	_ = dic.Register[domain.TweetService](domain.NewTweetService(
 		dic.GetService[logger.Logger](),
 		dic.GetService[domain.UserService](),
 		dic.GetService[twitter.TwitterClient](),
 		dic.GetService[twitterrepository.TweetRepository](),
+		dic.GetService[media.ImageProcessor](),
 	))
+	_ = dic.Register[tasks.TweetSaver](dic.GetService[domain.TweetService]())
 
 	_ = dic.Register[poller.TwitterPoller](poller.NewPoller(
 		dic.GetService[logger.Logger](),
 		dic.GetService[twitter.TwitterClient](),
 		dic.GetService[repository.UserRepository](),
 		dic.GetService[client.BackgroundWorkerClient](),
+		dic.GetService[domain.ConsentService](),
 	))
 
 	return nil
@@ -0,0 +1,236 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"time"
+
+	gotwitter "github.com/g8rswimmer/go-twitter/v2"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/estrys/estrys/internal/activitypub"
+	"github.com/estrys/estrys/internal/logger"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	"github.com/estrys/estrys/internal/twitter"
+	"github.com/estrys/estrys/internal/worker/client"
+	"github.com/estrys/estrys/internal/worker/tasks"
+)
+
+// twitterLookupSize is the maximum number of users twitter returns in a single lookup.
+const twitterLookupSize = 100
+
+//go:generate mockery --with-expecter --name=ConsentService
+type ConsentService interface {
+	// Check returns ErrUserOptedOut if the twitter user refused to be bridged, either because it is in the
+	// opt-out registry or because its bio contains an opt-out marker. Users found with a marker are added to the registry.
+	Check(ctx context.Context, twitterUser *gotwitter.UserObj) error
+	// CheckUser fetches the twitter user and checks it, see Check.
+	CheckUser(ctx context.Context, username string) error
+	// FilterUsers returns the users which did not refuse to be bridged.
+	FilterUsers(ctx context.Context, users models.UserSlice) (models.UserSlice, error)
+	// OptOut adds the user to the registry, the followers of its actor are sent a Delete.
+	// The number of scheduled deliveries is returned. Opting out again sends the Delete again.
+	OptOut(ctx context.Context, username string) (int, error)
+	// OptIn removes the user from the registry, accounts with an opt-out marker are still refused.
+	OptIn(ctx context.Context, username string) error
+	List(ctx context.Context) (models.OptOutSlice, error)
+}
+
+type consentService struct {
+	log           logger.Logger
+	userRepo      repository.UserRepository
+	optOutRepo    repository.OptOutRepository
+	twitterClient twitter.TwitterClient
+	vocabService  activitypub.VocabService
+	worker        client.BackgroundWorkerClient
+	markers       []*regexp.Regexp
+}
+
+func NewConsentService(
+	log logger.Logger,
+	userRepo repository.UserRepository,
+	optOutRepo repository.OptOutRepository,
+	twitterClient twitter.TwitterClient,
+	vocabService activitypub.VocabService,
+	worker client.BackgroundWorkerClient,
+	markers []string,
+) *consentService {
+	markerRegexps := make([]*regexp.Regexp, 0, len(markers))
+	for _, marker := range markers {
+		marker = strings.TrimSpace(marker)
+		if marker == "" {
+			continue
+		}
+		// Markers are whole words, #nobot should not match #nobotany
+		markerRegexps = append(markerRegexps, regexp.MustCompile(`(?i)(?:^|\W)`+regexp.QuoteMeta(marker)+`(?:$|\W)`))
+	}
+	return &consentService{
+		log:           log,
+		userRepo:      userRepo,
+		optOutRepo:    optOutRepo,
+		twitterClient: twitterClient,
+		vocabService:  vocabService,
+		worker:        worker,
+		markers:       markerRegexps,
+	}
+}
+
+func (c *consentService) Check(ctx context.Context, twitterUser *gotwitter.UserObj) error {
+	optedOut, err := c.isInRegistry(ctx, twitterUser.UserName)
+	if err != nil {
+		return err
+	}
+	if optedOut {
+		return errors.WithStack(ErrUserOptedOut)
+	}
+	if !c.hasMarker(twitterUser.Description) {
+		return nil
+	}
+
+	c.log.WithField("user", twitterUser.UserName).Info("opt-out marker found in bio")
+	_, err = c.optOut(ctx, twitterUser.UserName, repository.OptOutReasonMarker)
+	if err != nil {
+		return err
+	}
+	return errors.WithStack(ErrUserOptedOut)
+}
+
+func (c *consentService) CheckUser(ctx context.Context, username string) error {
+	optedOut, err := c.isInRegistry(ctx, username)
+	if err != nil {
+		return err
+	}
+	if optedOut {
+		return errors.WithStack(ErrUserOptedOut)
+	}
+	twitterUser, err := c.twitterClient.GetUser(ctx, username)
+	if err != nil {
+		return errors.Wrap(err, "unable to fetch twitter user")
+	}
+	return c.Check(ctx, twitterUser)
+}
+
+func (c *consentService) FilterUsers(ctx context.Context, users models.UserSlice) (models.UserSlice, error) {
+	candidates := make(map[string]*models.User, len(users))
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		optedOut, err := c.isInRegistry(ctx, user.Username)
+		if err != nil {
+			return nil, err
+		}
+		if !optedOut {
+			candidates[user.ID] = user
+			ids = append(ids, user.ID)
+		}
+	}
+
+	allowed := make(models.UserSlice, 0, len(ids))
+	for start := 0; start < len(ids); start += twitterLookupSize {
+		end := start + twitterLookupSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		twitterUsers, err := c.twitterClient.GetUserByIDs(ctx, ids[start:end])
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to fetch twitter users")
+		}
+		for _, twitterUser := range twitterUsers {
+			user, exists := candidates[twitterUser.ID]
+			if !exists {
+				continue
+			}
+			err := c.Check(ctx, twitterUser)
+			if errors.Is(err, ErrUserOptedOut) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			allowed = append(allowed, user)
+		}
+	}
+	return allowed, nil
+}
+
+func (c *consentService) OptOut(ctx context.Context, username string) (int, error) {
+	return c.optOut(ctx, username, repository.OptOutReasonAdmin)
+}
+
+func (c *consentService) OptIn(ctx context.Context, username string) error {
+	deleted, err := c.optOutRepo.Delete(ctx, username)
+	if err != nil {
+		return errors.Wrap(err, "unable to remove user from the opt-out registry")
+	}
+	if !deleted {
+		return errors.WithStack(ErrUserNotOptedOut)
+	}
+	c.log.WithField("user", username).Info("user removed from the opt-out registry")
+	return nil
+}
+
+func (c *consentService) List(ctx context.Context) (models.OptOutSlice, error) {
+	return c.optOutRepo.List(ctx) //nolint:wrapcheck
+}
+
+func (c *consentService) isInRegistry(ctx context.Context, username string) (bool, error) {
+	_, err := c.optOutRepo.Get(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "unable to check the opt-out registry")
+	}
+	return true, nil
+}
+
+func (c *consentService) hasMarker(bio string) bool {
+	for _, marker := range c.markers {
+		if marker.MatchString(bio) {
+			return true
+		}
+	}
+	return false
+}
+
+// optOut records the user in the registry if needed, and deletes its actor from the servers of its followers.
+func (c *consentService) optOut(ctx context.Context, username string, reason repository.OptOutReason) (int, error) {
+	optOut, err := c.optOutRepo.Get(ctx, username)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, errors.Wrap(err, "unable to check the opt-out registry")
+	}
+	if optOut == nil {
+		optOut, err = c.optOutRepo.Create(ctx, username, reason)
+		if err != nil {
+			return 0, errors.Wrap(err, "unable to add user to the opt-out registry")
+		}
+	}
+
+	user, err := c.userRepo.Get(ctx, username)
+	if err != nil {
+		// Accounts which were never bridged have no follower to notify
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "unable to fetch user from database")
+	}
+	// The id of the delete changes on each run, so a delete sent again is not taken for the previous one
+	deleteActivity, err := c.vocabService.GetDelete(user, time.Now())
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to create delete activity")
+	}
+	deliveries, err := sendToFollowers(ctx, c.userRepo, c.worker, user, tasks.TypeSendDelete, deleteActivity)
+	if err != nil {
+		return 0, err
+	}
+
+	c.log.WithFields(logrus.Fields{
+		"user":       user.Username,
+		"reason":     optOut.Reason,
+		"deliveries": deliveries,
+	}).Info("user opted out")
+	return deliveries, nil
+}
@@ -0,0 +1,234 @@
+package domain_test
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	gotwitter "github.com/g8rswimmer/go-twitter/v2"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/domain"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	repositorymocks "github.com/estrys/estrys/internal/repository/mocks"
+	"github.com/estrys/estrys/internal/twitter"
+	twittermocks "github.com/estrys/estrys/internal/twitter/mocks"
+	"github.com/estrys/estrys/internal/worker/client"
+	clientmocks "github.com/estrys/estrys/internal/worker/client/mocks"
+	"github.com/estrys/estrys/internal/worker/tasks"
+	dic_test "github.com/estrys/estrys/tests/dic"
+)
+
+var optedOutAt = time.Date(2022, 11, 20, 10, 0, 0, 0, time.UTC)
+
+// expectDeleteDeliveries expects a delete task for each recipient, with an id newer than the opt-out.
+func expectDeleteDeliveries(t *testing.T, recipients ...string) {
+	t.Helper()
+	staleDeleteID := "#delete/" + strconv.FormatInt(optedOutAt.Unix(), 10)
+	fakeWorker := clientmocks.NewBackgroundWorkerClient(t)
+	for _, recipient := range recipients {
+		recipient := recipient
+		fakeWorker.EXPECT().Enqueue(mock.MatchedBy(func(task *asynq.Task) bool {
+			return task.Type() == tasks.TypeSendDelete &&
+				strings.Contains(string(task.Payload()), recipient) &&
+				!strings.Contains(string(task.Payload()), staleDeleteID)
+		})).Return(nil, nil).Once()
+	}
+	_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
+}
+
+type consentMocks struct {
+	userRepo   *repositorymocks.UserRepository
+	optOutRepo *repositorymocks.OptOutRepository
+}
+
+func newConsentService(t *testing.T, mockFunc func(t *testing.T, mocks consentMocks)) domain.ConsentService {
+	t.Helper()
+	mocks := consentMocks{
+		userRepo:   repositorymocks.NewUserRepository(t),
+		optOutRepo: repositorymocks.NewOptOutRepository(t),
+	}
+	_ = dic.Register[repository.UserRepository](mocks.userRepo)
+	_ = dic.Register[repository.OptOutRepository](mocks.optOutRepo)
+	if mockFunc != nil {
+		mockFunc(t, mocks)
+	}
+
+	dic_test.BuildTestContainer(t)
+	t.Cleanup(dic.ResetContainer)
+	return dic.GetService[domain.ConsentService]()
+}
+
+func TestConsentService_Check(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		mock        func(t *testing.T, mocks consentMocks)
+		err         string
+	}{
+		{
+			name:        "user did not opt out",
+			description: "Tweets about #nobotany and #bridges",
+			mock: func(t *testing.T, mocks consentMocks) {
+				mocks.optOutRepo.EXPECT().Get(mock.Anything, "validuser").Return(nil, sql.ErrNoRows)
+			},
+		},
+		{
+			name: "user in the registry",
+			mock: func(t *testing.T, mocks consentMocks) {
+				mocks.optOutRepo.EXPECT().Get(mock.Anything, "validuser").Return(&models.OptOut{}, nil)
+			},
+			err: "user refused to be bridged",
+		},
+		{
+			name: "unable to check the registry",
+			mock: func(t *testing.T, mocks consentMocks) {
+				mocks.optOutRepo.EXPECT().Get(mock.Anything, "validuser").Return(nil, errors.New("database error"))
+			},
+			err: "unable to check the opt-out registry: database error",
+		},
+		{
+			name:        "marker in the bio of a bridged user",
+			description: "Not a bot, please no bridge (#NoBot)",
+			mock: func(t *testing.T, mocks consentMocks) {
+				mocks.optOutRepo.EXPECT().Get(mock.Anything, "validuser").Return(nil, sql.ErrNoRows)
+				mocks.optOutRepo.EXPECT().Create(mock.Anything, "validuser", repository.OptOutReasonMarker).
+					Return(&models.OptOut{Username: "validuser", CreatedAt: optedOutAt}, nil)
+				mocks.userRepo.EXPECT().Get(mock.Anything, "validuser").Return(&models.User{Username: "validuser"}, nil)
+				mocks.userRepo.EXPECT().GetFollowers(mock.Anything, mock.Anything).Return(models.ActorSlice{
+					{URL: "https://another-instance.example.com/users/first", SharedInbox: null.StringFrom("https://another-instance.example.com/inbox")},
+					{URL: "https://another-instance.example.com/users/second", SharedInbox: null.StringFrom("https://another-instance.example.com/inbox")},
+				}, nil)
+				expectDeleteDeliveries(t, "https://another-instance.example.com/inbox")
+			},
+			err: "user refused to be bridged",
+		},
+		{
+			name:        "marker in the bio of a user never bridged",
+			description: "#nobridge",
+			mock: func(t *testing.T, mocks consentMocks) {
+				mocks.optOutRepo.EXPECT().Get(mock.Anything, "validuser").Return(nil, sql.ErrNoRows)
+				mocks.optOutRepo.EXPECT().Create(mock.Anything, "validuser", repository.OptOutReasonMarker).
+					Return(&models.OptOut{Username: "validuser", CreatedAt: optedOutAt}, nil)
+				mocks.userRepo.EXPECT().Get(mock.Anything, "validuser").Return(nil, sql.ErrNoRows)
+			},
+			err: "user refused to be bridged",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newConsentService(t, tt.mock)
+			err := service.Check(context.Background(), &gotwitter.UserObj{
+				UserName:    "validuser",
+				Description: tt.description,
+			})
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestConsentService_FilterUsers(t *testing.T) {
+	service := newConsentService(t, func(t *testing.T, mocks consentMocks) {
+		mocks.optOutRepo.EXPECT().Get(mock.Anything, "registered").Return(&models.OptOut{}, nil)
+		mocks.optOutRepo.EXPECT().Get(mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+		mocks.optOutRepo.EXPECT().Create(mock.Anything, "marked", repository.OptOutReasonMarker).
+			Return(&models.OptOut{Username: "marked", CreatedAt: optedOutAt}, nil)
+		mocks.userRepo.EXPECT().Get(mock.Anything, "marked").Return(&models.User{Username: "marked"}, nil)
+		mocks.userRepo.EXPECT().GetFollowers(mock.Anything, mock.Anything).Return(nil, nil)
+
+		fakeTwitterClient := twittermocks.NewTwitterClient(t)
+		fakeTwitterClient.EXPECT().GetUserByIDs(mock.Anything, []string{"1", "3"}).Return([]*gotwitter.UserObj{
+			{ID: "1", UserName: "validuser"},
+			{ID: "3", UserName: "marked", Description: "#nobridge"},
+		}, nil)
+		_ = dic.Register[twitter.TwitterClient](fakeTwitterClient)
+	})
+
+	users, err := service.FilterUsers(context.Background(), models.UserSlice{
+		{ID: "1", Username: "validuser"},
+		{ID: "2", Username: "registered"},
+		{ID: "3", Username: "marked"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, models.UserSlice{{ID: "1", Username: "validuser"}}, users)
+}
+
+func TestConsentService_OptOut(t *testing.T) {
+	tests := []struct {
+		name       string
+		mock       func(t *testing.T, mocks consentMocks)
+		deliveries int
+		err        string
+	}{
+		{
+			name: "user never bridged",
+			mock: func(t *testing.T, mocks consentMocks) {
+				mocks.optOutRepo.EXPECT().Get(mock.Anything, "validuser").Return(nil, sql.ErrNoRows)
+				mocks.optOutRepo.EXPECT().Create(mock.Anything, "validuser", repository.OptOutReasonAdmin).
+					Return(&models.OptOut{Username: "validuser", CreatedAt: optedOutAt}, nil)
+				mocks.userRepo.EXPECT().Get(mock.Anything, "validuser").Return(nil, sql.ErrNoRows)
+			},
+		},
+		{
+			name: "unable to save the opt-out",
+			mock: func(t *testing.T, mocks consentMocks) {
+				mocks.optOutRepo.EXPECT().Get(mock.Anything, "validuser").Return(nil, sql.ErrNoRows)
+				mocks.optOutRepo.EXPECT().Create(mock.Anything, "validuser", repository.OptOutReasonAdmin).
+					Return(nil, errors.New("database error"))
+			},
+			err: "unable to add user to the opt-out registry: database error",
+		},
+		{
+			name: "delete sent again to followers",
+			mock: func(t *testing.T, mocks consentMocks) {
+				mocks.optOutRepo.EXPECT().Get(mock.Anything, "validuser").
+					Return(&models.OptOut{Username: "validuser", Reason: "marker", CreatedAt: optedOutAt}, nil)
+				mocks.userRepo.EXPECT().Get(mock.Anything, "validuser").Return(&models.User{Username: "validuser"}, nil)
+				mocks.userRepo.EXPECT().GetFollowers(mock.Anything, mock.Anything).Return(models.ActorSlice{
+					{URL: "https://another-instance.example.com/users/first", SharedInbox: null.StringFrom("https://another-instance.example.com/inbox")},
+					{URL: "https://small-instance.example.com/users/second"},
+				}, nil)
+				expectDeleteDeliveries(t,
+					"https://another-instance.example.com/inbox",
+					"https://small-instance.example.com/users/second",
+				)
+			},
+			deliveries: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newConsentService(t, tt.mock)
+			deliveries, err := service.OptOut(context.Background(), "validuser")
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.deliveries, deliveries)
+		})
+	}
+}
+
+func TestConsentService_OptIn(t *testing.T) {
+	service := newConsentService(t, func(t *testing.T, mocks consentMocks) {
+		mocks.optOutRepo.EXPECT().Delete(mock.Anything, "validuser").Return(true, nil).Once()
+		mocks.optOutRepo.EXPECT().Delete(mock.Anything, "validuser").Return(false, nil).Once()
+	})
+
+	require.NoError(t, service.OptIn(context.Background(), "validuser"))
+	require.ErrorIs(t, service.OptIn(context.Background(), "validuser"), domain.ErrUserNotOptedOut)
+}
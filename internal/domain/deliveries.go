@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/go-fed/activity/pub"
+	"github.com/go-fed/activity/streams"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	"github.com/estrys/estrys/internal/worker/client"
+	"github.com/estrys/estrys/internal/worker/tasks"
+)
+
+// sendToFollowers schedules the delivery of an activity about the user to every follower inbox,
+// with a task of the given type. It returns the count of scheduled deliveries.
+func sendToFollowers(
+	ctx context.Context,
+	userRepo repository.UserRepository,
+	worker client.BackgroundWorkerClient,
+	user *models.User,
+	taskType string,
+	act pub.Activity,
+) (int, error) {
+	activity, err := streams.Serialize(act)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to serialize activity")
+	}
+
+	actors, err := userRepo.GetFollowers(ctx, user)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to retrieve followers for user")
+	}
+
+	return tasks.ScheduleDeliveries(worker, actors, func(sharedInbox string, actor *models.Actor) (*asynq.Task, error) {
+		if sharedInbox != "" {
+			return tasks.NewSendUserActivityToSharedInbox(ctx, taskType, user.Username, sharedInbox, activity)
+		}
+		return tasks.NewSendUserActivity(ctx, taskType, user.Username, actor, activity)
+	})
+}
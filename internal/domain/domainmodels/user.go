@@ -13,14 +13,31 @@ type UserMetrics struct {
 	Following, Followers, Tweets uint64
 }
 
+// FediverseLink is a verified fediverse account found in the twitter profile of a user.
+type FediverseLink struct {
+	Handle string
+	URL    *url.URL
+}
+
 type User struct {
 	Name            string
 	Username        string
 	Description     string
 	ProfileImageURL *url.URL
-	CreatedAt       time.Time
-	Metrics         UserMetrics
-	PublicKey       crypto.PublicKey
+	// BannerURL is the header image of the twitter profile, if any.
+	BannerURL *url.URL
+	// Website is the link of the twitter profile.
+	Website   *url.URL
+	Location  string
+	CreatedAt time.Time
+	Metrics   UserMetrics
+	PublicKey crypto.PublicKey
+	// ManuallyApprovesFollowers is set when follows of the user are held until they are approved.
+	ManuallyApprovesFollowers bool
+	// MovedTo is the actor the user migrated to, if any.
+	MovedTo *url.URL
+	// FediverseLinks are the fediverse accounts the user links to from its twitter profile.
+	FediverseLinks []FediverseLink
 }
 
 func (u User) PublicKeyPem() string {
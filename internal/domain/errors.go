@@ -2,12 +2,25 @@ package domain
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/pkg/errors"
+
+	internalerrors "github.com/estrys/estrys/internal/errors"
+	"github.com/estrys/estrys/internal/twitter"
 )
 
 var ErrFollowMismatchDomain = errors.New("unable to follow an user outside this instance")
 var ErrUserDoesNotExist = errors.New("user does not exist")
+var ErrUndoActorMismatch = errors.New("unable to undo an activity of another actor")
+var ErrMissingActivityID = errors.New("activity does not have an id")
+var ErrActivityIDMismatch = errors.New("activity id does not belong to the actor instance")
+var ErrMoveActorMismatch = errors.New("unable to move another actor")
+var ErrMoveTargetNotAlias = errors.New("move target does not declare the actor as an alias")
+var ErrReplyAuthorMismatch = errors.New("unable to record a reply of another actor")
+var ErrMissingObjectID = errors.New("object does not have an id")
+var ErrUserOptedOut = errors.New("user refused to be bridged")
+var ErrUserNotOptedOut = errors.New("user is not in the opt-out registry")
 
 type TwitterUserDoesNotExistError struct {
 	Username string
@@ -16,3 +29,30 @@ type TwitterUserDoesNotExistError struct {
 func (e *TwitterUserDoesNotExistError) Error() string {
 	return fmt.Sprintf("@%s does not exist on twitter", e.Username)
 }
+
+// SignerMismatchError is returned when an activity is not signed by its actor.
+type SignerMismatchError struct {
+	Actor  string
+	Signer string
+}
+
+func (e *SignerMismatchError) Error() string {
+	if e.Signer == "" {
+		return fmt.Sprintf("unknown signer for activity of %s", e.Actor)
+	}
+	return fmt.Sprintf("activity of %s signed by %s", e.Actor, e.Signer)
+}
+
+// UserHTTPError maps an error met while fetching a user to the response of the http handlers.
+func UserHTTPError(err error) error {
+	var twitterUserNotFound twitter.UserNotFoundError
+	switch {
+	case errors.As(err, &twitterUserNotFound), errors.Is(err, ErrUserDoesNotExist):
+		return internalerrors.Wrap(err, http.StatusNotFound).
+			WithUserMessage("user not found")
+	case errors.Is(err, ErrUserOptedOut):
+		return internalerrors.Wrap(err, http.StatusGone).
+			WithUserMessage("user refused to be bridged")
+	}
+	return internalerrors.Wrap(err, http.StatusInternalServerError)
+}
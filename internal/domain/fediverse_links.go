@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	gotwitter "github.com/g8rswimmer/go-twitter/v2"
+	"github.com/go-fed/activity/streams"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/estrys/estrys/internal/activitypub"
+	"github.com/estrys/estrys/internal/activitypub/resolver"
+	"github.com/estrys/estrys/internal/logger"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	"github.com/estrys/estrys/internal/router/routes"
+	"github.com/estrys/estrys/internal/router/urlgenerator"
+	"github.com/estrys/estrys/internal/twitter"
+)
+
+var (
+	// fediverseHandleRegexp matches handles such as @alice@mastodon.example, but not email addresses.
+	fediverseHandleRegexp = regexp.MustCompile(`(?:^|[^\w@/])@([\w.-]+)@([a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)+)`)
+	// profilePathRegexp matches the path of mastodon profile pages and actor urls.
+	profilePathRegexp = regexp.MustCompile(`^/(?:@|users/)([\w.-]+)/?$`)
+	// twitterHosts are the hosts of twitter profile pages.
+	twitterHosts = map[string]struct{}{
+		"twitter.com":        {},
+		"www.twitter.com":    {},
+		"mobile.twitter.com": {},
+		"x.com":              {},
+		"www.x.com":          {},
+	}
+)
+
+//go:generate mockery --with-expecter --name=FediverseLinkService
+type FediverseLinkService interface {
+	// Scan looks for fediverse accounts in the twitter profile of the bridged users and records the ones
+	// which could be verified. It returns the count of verified links.
+	Scan(ctx context.Context) (int, error)
+	// ListCandidates returns the verified fediverse accounts of the user, or of every user when username is empty.
+	ListCandidates(ctx context.Context, username string) (models.FediverseLinkSlice, error)
+}
+
+type fediverseLinkService struct {
+	log               logger.Logger
+	userRepo          repository.UserRepository
+	linkRepo          repository.FediverseLinkRepository
+	twitterClient     twitter.TwitterClient
+	webFingerResolver resolver.WebFingerResolver
+	objectResolver    resolver.ObjectResolver
+	urlGenerator      urlgenerator.URLGenerator
+}
+
+func NewFediverseLinkService(
+	log logger.Logger,
+	userRepo repository.UserRepository,
+	linkRepo repository.FediverseLinkRepository,
+	twitterClient twitter.TwitterClient,
+	webFingerResolver resolver.WebFingerResolver,
+	objectResolver resolver.ObjectResolver,
+	urlGenerator urlgenerator.URLGenerator,
+) *fediverseLinkService {
+	return &fediverseLinkService{
+		log:               log,
+		userRepo:          userRepo,
+		linkRepo:          linkRepo,
+		twitterClient:     twitterClient,
+		webFingerResolver: webFingerResolver,
+		objectResolver:    objectResolver,
+		urlGenerator:      urlGenerator,
+	}
+}
+
+func (f *fediverseLinkService) Scan(ctx context.Context) (int, error) {
+	users, err := f.userRepo.GetWithFollowers(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to retrieve bridged users")
+	}
+	verified := 0
+	for _, user := range users {
+		count, err := f.scanUser(ctx, user)
+		if err != nil {
+			// A single profile should not prevent the others from being scanned
+			f.log.WithError(err).WithField("user", user.Username).Error("unable to scan user profile")
+			continue
+		}
+		verified += count
+	}
+	return verified, nil
+}
+
+func (f *fediverseLinkService) ListCandidates(ctx context.Context, username string) (models.FediverseLinkSlice, error) {
+	return f.linkRepo.List(ctx, strings.ToLower(username)) //nolint:wrapcheck
+}
+
+// scanUser records the verified handles found in the profile of the user. Links are kept as long as their
+// handle is in the profile, since a verification failure may only be temporary.
+func (f *fediverseLinkService) scanUser(ctx context.Context, user *models.User) (int, error) {
+	twitterUser, err := f.twitterClient.GetUser(ctx, user.Username)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to fetch twitter user info")
+	}
+	profile, err := f.twitterClient.GetUserProfile(ctx, user.Username)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to fetch twitter profile")
+	}
+
+	handles := findHandles(twitterUser, profile)
+	verified := 0
+	for _, handle := range handles {
+		actorURL, err := f.verify(ctx, user, handle)
+		if err != nil {
+			f.log.WithError(err).WithFields(logrus.Fields{
+				"user":   user.Username,
+				"handle": handle,
+			}).Warn("unable to verify fediverse handle")
+			continue
+		}
+		err = f.linkRepo.Save(ctx, user.Username, handle, actorURL.String())
+		if err != nil {
+			return 0, errors.Wrap(err, "unable to save fediverse link")
+		}
+		verified++
+	}
+
+	err = f.linkRepo.Prune(ctx, user.Username, handles)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to remove outdated fediverse links")
+	}
+	return verified, nil
+}
+
+// findHandles returns the handles written in the description of the user, or linked from its profile.
+func findHandles(twitterUser *gotwitter.UserObj, profile *twitter.UserProfile) []string {
+	var handles []string
+	known := make(map[string]struct{})
+	addHandle := func(handle string) {
+		handle = strings.ToLower(handle)
+		if _, exists := known[handle]; !exists {
+			known[handle] = struct{}{}
+			handles = append(handles, handle)
+		}
+	}
+
+	for _, match := range fediverseHandleRegexp.FindAllStringSubmatch(twitterUser.Description, -1) {
+		addHandle(match[1] + "@" + match[2])
+	}
+
+	// Links of the description are t.co links, their target is given by the profile entities
+	links := profile.DescriptionURLs
+	if profile.Website != "" {
+		links = append(links, profile.Website)
+	}
+	for _, link := range links {
+		linkURL, err := url.Parse(link)
+		if err != nil {
+			continue
+		}
+		matches := profilePathRegexp.FindStringSubmatch(linkURL.Path)
+		if matches == nil || linkURL.Hostname() == "" {
+			continue
+		}
+		addHandle(matches[1] + "@" + linkURL.Host)
+	}
+
+	return handles
+}
+
+// verify makes sure the handle is an existing account, by resolving it with webfinger and then fetching its actor.
+// The account must link back to the twitter profile or to the bridged actor, anyone can write a handle in a bio.
+func (f *fediverseLinkService) verify(ctx context.Context, user *models.User, handle string) (*url.URL, error) {
+	actorURL, err := f.webFingerResolver.Resolve(ctx, handle)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to resolve handle")
+	}
+
+	actorProperty := streams.NewActivityStreamsObjectProperty()
+	actorProperty.AppendIRI(actorURL)
+	actor, err := f.objectResolver.Resolve(ctx, actorProperty.Begin())
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch actor")
+	}
+	username, err := activitypub.GetPreferredUsername(actor)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read actor username")
+	}
+	handleUsername, _, _ := strings.Cut(handle, "@")
+	if !strings.EqualFold(username, handleUsername) {
+		return nil, errors.Errorf("actor username '%s' does not match the handle", username)
+	}
+
+	links, err := activitypub.GetProfileLinks(actor)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read actor links")
+	}
+	for _, link := range links {
+		isBacklink, err := f.isBacklink(user, link)
+		if err != nil {
+			return nil, err
+		}
+		if isBacklink {
+			return actorURL, nil
+		}
+	}
+	return nil, errors.New("actor does not link back to the twitter profile")
+}
+
+// isBacklink tells if the link points to the twitter profile of the user, or to its bridged actor or profile page.
+func (f *fediverseLinkService) isBacklink(user *models.User, link *url.URL) (bool, error) {
+	linkPath := strings.TrimSuffix(link.Path, "/")
+	if _, isTwitter := twitterHosts[strings.ToLower(link.Host)]; isTwitter {
+		return strings.EqualFold(linkPath, "/"+user.Username), nil
+	}
+
+	for _, route := range []string{routes.UserRoute, routes.ProfileRoute} {
+		userURL, err := f.urlGenerator.URL(
+			route,
+			[]string{"username", user.Username},
+			urlgenerator.OptionAbsoluteURL,
+		)
+		if err != nil {
+			return false, errors.Wrap(err, "cannot generate user URL")
+		}
+		if strings.EqualFold(link.Host, userURL.Host) && strings.EqualFold(linkPath, userURL.Path) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
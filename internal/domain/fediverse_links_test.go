@@ -0,0 +1,209 @@
+package domain_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	gotwitter "github.com/g8rswimmer/go-twitter/v2"
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	resolvermocks "github.com/estrys/estrys/internal/activitypub/resolver/mocks"
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/domain"
+	loggermock "github.com/estrys/estrys/internal/logger/mocks"
+	"github.com/estrys/estrys/internal/models"
+	repositorymocks "github.com/estrys/estrys/internal/repository/mocks"
+	"github.com/estrys/estrys/internal/router/urlgenerator"
+	"github.com/estrys/estrys/internal/twitter"
+	twittermocks "github.com/estrys/estrys/internal/twitter/mocks"
+	dic_test "github.com/estrys/estrys/tests/dic"
+)
+
+// newRemoteActor returns a fediverse actor with the given username, which links to the given urls from its profile.
+func newRemoteActor(t *testing.T, actorURL string, username string, links ...string) vocab.Type {
+	t.Helper()
+	attachments := make([]any, 0, len(links))
+	for _, link := range links {
+		attachments = append(attachments, map[string]any{
+			"type":  "PropertyValue",
+			"name":  "Link",
+			"value": `<a href="` + link + `" rel="me">` + link + `</a>`,
+		})
+	}
+	actor, err := streams.ToType(context.Background(), map[string]any{
+		"@context":          "https://www.w3.org/ns/activitystreams",
+		"id":                actorURL,
+		"type":              "Person",
+		"preferredUsername": username,
+		"inbox":             actorURL + "/inbox",
+		"attachment":        attachments,
+	})
+	require.NoError(t, err)
+	return actor
+}
+
+type fediverseLinkMocks struct {
+	userRepo          *repositorymocks.UserRepository
+	linkRepo          *repositorymocks.FediverseLinkRepository
+	twitterClient     *twittermocks.TwitterClient
+	webFingerResolver *resolvermocks.WebFingerResolver
+	objectResolver    *resolvermocks.ObjectResolver
+}
+
+func TestFediverseLinkService_Scan(t *testing.T) {
+	users := models.UserSlice{{Username: "validuser"}}
+
+	tests := []struct {
+		name     string
+		mock     func(t *testing.T, mocks fediverseLinkMocks)
+		verified int
+		err      string
+	}{
+		{
+			name: "no bridged user",
+			mock: func(t *testing.T, mocks fediverseLinkMocks) {
+				mocks.userRepo.EXPECT().GetWithFollowers(mock.Anything).Return(nil, nil)
+			},
+		},
+		{
+			name: "unable to list bridged users",
+			mock: func(t *testing.T, mocks fediverseLinkMocks) {
+				mocks.userRepo.EXPECT().GetWithFollowers(mock.Anything).Return(nil, errors.New("database error"))
+			},
+			err: "unable to retrieve bridged users: database error",
+		},
+		{
+			name: "twitter error does not stop the scan",
+			mock: func(t *testing.T, mocks fediverseLinkMocks) {
+				mocks.userRepo.EXPECT().GetWithFollowers(mock.Anything).
+					Return(models.UserSlice{{Username: "erroruser"}, {Username: "validuser"}}, nil)
+				mocks.twitterClient.EXPECT().GetUser(mock.Anything, "erroruser").Return(nil, errors.New("rate limited"))
+				mocks.twitterClient.EXPECT().GetUser(mock.Anything, "validuser").Return(&gotwitter.UserObj{}, nil)
+				mocks.twitterClient.EXPECT().GetUserProfile(mock.Anything, "validuser").Return(&twitter.UserProfile{}, nil)
+				mocks.linkRepo.EXPECT().Prune(mock.Anything, "validuser", []string(nil)).Return(nil)
+			},
+		},
+		{
+			name: "handles found in the profile",
+			mock: func(t *testing.T, mocks fediverseLinkMocks) {
+				mocks.userRepo.EXPECT().GetWithFollowers(mock.Anything).Return(users, nil)
+				mocks.twitterClient.EXPECT().GetUser(mock.Anything, "validuser").Return(&gotwitter.UserObj{
+					Description: "Moving to @ValidUser@mastodon.example, contact me at validuser@mail.example " +
+						"or see https://t.co/profile and https://t.co/blog",
+					URL: "https://t.co/website",
+				}, nil)
+				mocks.twitterClient.EXPECT().GetUserProfile(mock.Anything, "validuser").Return(&twitter.UserProfile{
+					Website:         "https://example.org/validuser",
+					DescriptionURLs: []string{"https://social.example/@validuser", "https://blog.example/posts/hello"},
+				}, nil)
+				mocks.webFingerResolver.EXPECT().Resolve(mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+				mocks.linkRepo.EXPECT().Prune(mock.Anything, "validuser", []string{
+					"validuser@mastodon.example",
+					"validuser@social.example",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "verified handles are saved",
+			mock: func(t *testing.T, mocks fediverseLinkMocks) {
+				mocks.userRepo.EXPECT().GetWithFollowers(mock.Anything).Return(users, nil)
+				mocks.twitterClient.EXPECT().GetUser(mock.Anything, "validuser").Return(&gotwitter.UserObj{
+					Description: "@validuser@mastodon.example @validuser@social.example @validuser@impostor.example " +
+						"@validuser@forged.example @validuser@bridged.example",
+				}, nil)
+				mocks.twitterClient.EXPECT().GetUserProfile(mock.Anything, "validuser").Return(&twitter.UserProfile{}, nil)
+
+				for handle, actorURL := range map[string]string{
+					"validuser@mastodon.example": "https://mastodon.example/users/validuser",
+					"validuser@impostor.example": "https://impostor.example/users/someone",
+					"validuser@forged.example":   "https://forged.example/users/validuser",
+					"validuser@bridged.example":  "https://bridged.example/users/validuser",
+				} {
+					actorURL, _ := url.Parse(actorURL)
+					mocks.webFingerResolver.EXPECT().Resolve(mock.Anything, handle).Return(actorURL, nil)
+				}
+				mocks.webFingerResolver.EXPECT().Resolve(mock.Anything, "validuser@social.example").
+					Return(nil, errors.New("not found"))
+				mocks.objectResolver.EXPECT().Resolve(mock.Anything, mock.MatchedBy(func(p vocab.ActivityStreamsObjectPropertyIterator) bool {
+					return p.GetIRI().Host == "mastodon.example"
+				})).Return(newRemoteActor(t, "https://mastodon.example/users/validuser", "ValidUser",
+					"https://twitter.com/ValidUser/",
+				), nil)
+				mocks.objectResolver.EXPECT().Resolve(mock.Anything, mock.MatchedBy(func(p vocab.ActivityStreamsObjectPropertyIterator) bool {
+					return p.GetIRI().Host == "impostor.example"
+				})).Return(newRemoteActor(t, "https://impostor.example/users/someone", "someone",
+					"https://twitter.com/validuser",
+				), nil)
+				// The account exists but does not claim the twitter account
+				mocks.objectResolver.EXPECT().Resolve(mock.Anything, mock.MatchedBy(func(p vocab.ActivityStreamsObjectPropertyIterator) bool {
+					return p.GetIRI().Host == "forged.example"
+				})).Return(newRemoteActor(t, "https://forged.example/users/validuser", "validuser",
+					"https://twitter.com/someoneelse",
+					"https://example.com/users/someoneelse",
+				), nil)
+				mocks.objectResolver.EXPECT().Resolve(mock.Anything, mock.MatchedBy(func(p vocab.ActivityStreamsObjectPropertyIterator) bool {
+					return p.GetIRI().Host == "bridged.example"
+				})).Return(newRemoteActor(t, "https://bridged.example/users/validuser", "validuser",
+					"https://example.com/users/validuser",
+				), nil)
+
+				mocks.linkRepo.EXPECT().Save(
+					mock.Anything,
+					"validuser",
+					"validuser@mastodon.example",
+					"https://mastodon.example/users/validuser",
+				).Return(nil)
+				mocks.linkRepo.EXPECT().Save(
+					mock.Anything,
+					"validuser",
+					"validuser@bridged.example",
+					"https://bridged.example/users/validuser",
+				).Return(nil)
+				mocks.linkRepo.EXPECT().Prune(mock.Anything, "validuser", []string{
+					"validuser@mastodon.example",
+					"validuser@social.example",
+					"validuser@impostor.example",
+					"validuser@forged.example",
+					"validuser@bridged.example",
+				}).Return(nil)
+			},
+			verified: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := fediverseLinkMocks{
+				userRepo:          repositorymocks.NewUserRepository(t),
+				linkRepo:          repositorymocks.NewFediverseLinkRepository(t),
+				twitterClient:     twittermocks.NewTwitterClient(t),
+				webFingerResolver: resolvermocks.NewWebFingerResolver(t),
+				objectResolver:    resolvermocks.NewObjectResolver(t),
+			}
+			tt.mock(t, mocks)
+			dic_test.BuildTestContainer(t)
+			defer dic.ResetContainer()
+
+			service := domain.NewFediverseLinkService(
+				loggermock.NewNullLogger(),
+				mocks.userRepo,
+				mocks.linkRepo,
+				mocks.twitterClient,
+				mocks.webFingerResolver,
+				mocks.objectResolver,
+				dic.GetService[urlgenerator.URLGenerator](),
+			)
+			verified, err := service.Scan(context.Background())
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.verified, verified)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/estrys/estrys/internal/activitypub"
+	"github.com/estrys/estrys/internal/logger"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	"github.com/estrys/estrys/internal/worker/client"
+	"github.com/estrys/estrys/internal/worker/tasks"
+)
+
+var ErrFollowRequestNotFound = errors.New("follow request does not exist")
+var ErrFollowRequestNotPending = errors.New("follow request is not pending")
+
+// manuallyApprovesFollowers tells if follows of the user must be approved before being accepted.
+func manuallyApprovesFollowers(manuallyApprovedUsers []string, username string) bool {
+	for _, approvedUser := range manuallyApprovedUsers {
+		if strings.EqualFold(strings.TrimSpace(approvedUser), username) {
+			return true
+		}
+	}
+	return false
+}
+
+//go:generate mockery --with-expecter --name=FollowRequestService
+type FollowRequestService interface {
+	// ListPending returns the follow requests waiting for approval, of every user if the username is empty.
+	ListPending(ctx context.Context, username string) (models.FollowerSlice, error)
+	Approve(ctx context.Context, followID string) error
+	Reject(ctx context.Context, followID string) error
+	// ExpirePending rejects the follow requests which have not been approved in time and returns the count
+	// of rejects scheduled. A follow request which cannot be rejected does not stop the others.
+	ExpirePending(context.Context) (int, error)
+}
+
+type followRequestService struct {
+	log          logger.Logger
+	followerRepo repository.FollowerRepository
+	vocabService activitypub.VocabService
+	worker       client.BackgroundWorkerClient
+	expiration   time.Duration
+}
+
+func NewFollowRequestService(
+	log logger.Logger,
+	followerRepo repository.FollowerRepository,
+	vocabService activitypub.VocabService,
+	worker client.BackgroundWorkerClient,
+	expiration time.Duration,
+) *followRequestService {
+	return &followRequestService{
+		log:          log,
+		followerRepo: followerRepo,
+		vocabService: vocabService,
+		worker:       worker,
+		expiration:   expiration,
+	}
+}
+
+func (f *followRequestService) ListPending(ctx context.Context, username string) (models.FollowerSlice, error) {
+	followers, err := f.followerRepo.ListPending(ctx, repository.ListPendingFollowsRequest{
+		Username: username,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list follow requests")
+	}
+	return followers, nil
+}
+
+func (f *followRequestService) Approve(ctx context.Context, followID string) error {
+	follower, err := f.getPending(ctx, followID)
+	if err != nil {
+		return err
+	}
+	return f.respond(ctx, follower, repository.FollowStateAccepted)
+}
+
+func (f *followRequestService) Reject(ctx context.Context, followID string) error {
+	follower, err := f.getPending(ctx, followID)
+	if err != nil {
+		return err
+	}
+	return f.respond(ctx, follower, repository.FollowStateRejected)
+}
+
+func (f *followRequestService) ExpirePending(ctx context.Context) (int, error) {
+	followers, err := f.followerRepo.ListPending(ctx, repository.ListPendingFollowsRequest{
+		CreatedBefore: time.Now().Add(-f.expiration),
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to list expired follow requests")
+	}
+	expired := 0
+	for _, follower := range followers {
+		// Requests stay pending until the reject task runs, so the next runs list again those still queued.
+		// The task id keeps them from being scheduled twice, the task records whether the reject was delivered.
+		err = f.respond(ctx, follower, repository.FollowStateRejected,
+			asynq.TaskID("reject:"+follower.FollowID.String),
+		)
+		if err != nil {
+			if errors.Is(err, asynq.ErrTaskIDConflict) {
+				continue
+			}
+			f.log.WithError(err).WithField("follow", follower.FollowID.String).Error("unable to expire follow request")
+			continue
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+func (f *followRequestService) getPending(ctx context.Context, followID string) (*models.Follower, error) {
+	follower, err := f.followerRepo.GetByFollowID(ctx, followID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(ErrFollowRequestNotFound)
+		}
+		return nil, err //nolint:wrapcheck
+	}
+	if repository.FollowState(follower.State) != repository.FollowStatePending {
+		return nil, errors.WithStack(ErrFollowRequestNotPending)
+	}
+	return follower, nil
+}
+
+// respond schedules the accept or reject of the follow, the follow state is updated by the task itself.
+func (f *followRequestService) respond(
+	ctx context.Context,
+	follower *models.Follower,
+	state repository.FollowState,
+	opts ...asynq.Option,
+) error {
+	if follower.R == nil || follower.R.FollowerUser == nil || follower.R.FollowerActor == nil {
+		return errors.New("follow request user and actor must be loaded")
+	}
+	user := follower.R.FollowerUser
+	actorURL, err := url.Parse(follower.R.FollowerActor.URL)
+	if err != nil {
+		return errors.Wrap(err, "unable to parse actor url")
+	}
+	followID, err := url.Parse(follower.FollowID.String)
+	if err != nil {
+		return errors.Wrap(err, "unable to parse follow id")
+	}
+	follow, err := f.vocabService.GetFollow(user, actorURL, followID)
+	if err != nil {
+		return errors.Wrap(err, "unable to create follow activity")
+	}
+
+	if state == repository.FollowStateAccepted {
+		acceptFollowTask, err := tasks.NewAcceptFollowTask(ctx, user.Username, follow)
+		if err != nil {
+			return errors.Wrap(err, "unable to create accept follow task")
+		}
+		_, err = f.worker.Enqueue(acceptFollowTask, opts...)
+		if err != nil {
+			return errors.Wrap(err, "unable to schedule accept follow task")
+		}
+	} else {
+		rejectFollowTask, err := tasks.NewRejectFollowTask(ctx, user.Username, follow)
+		if err != nil {
+			return errors.Wrap(err, "unable to create reject follow task")
+		}
+		_, err = f.worker.Enqueue(rejectFollowTask, opts...)
+		if err != nil {
+			return errors.Wrap(err, "unable to schedule reject follow task")
+		}
+	}
+
+	f.log.WithFields(logrus.Fields{
+		"user":  user.Username,
+		"actor": actorURL.String(),
+		"state": state,
+	}).Info("follow request answered")
+	return nil
+}
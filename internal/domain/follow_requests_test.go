@@ -0,0 +1,185 @@
+package domain_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/domain"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	repositorymocks "github.com/estrys/estrys/internal/repository/mocks"
+	"github.com/estrys/estrys/internal/worker/client"
+	clientmocks "github.com/estrys/estrys/internal/worker/client/mocks"
+	"github.com/estrys/estrys/internal/worker/tasks"
+	dic_test "github.com/estrys/estrys/tests/dic"
+)
+
+const fakeFollowID = "https://another-instance.example.com/bdd01ced-d657-4847-a266-2c43e1cd8dc5"
+
+func newPendingFollow(state repository.FollowState) *models.Follower {
+	follower := &models.Follower{
+		User:     "validuser",
+		FollowID: null.StringFrom(fakeFollowID),
+		State:    string(state),
+	}
+	follower.R = follower.R.NewStruct()
+	follower.R.FollowerUser = &models.User{Username: "validuser"}
+	follower.R.FollowerActor = &models.Actor{URL: "https://another-instance.example.com/users/validactor"}
+	return follower
+}
+
+// expectFollowResponse expects a single accept or reject task for the pending follow, enqueued with the given options.
+func expectFollowResponse(
+	t *testing.T,
+	fakeWorker *clientmocks.BackgroundWorkerClient,
+	taskType string,
+	opts ...interface{},
+) *mock.Call {
+	t.Helper()
+	return fakeWorker.EXPECT().Enqueue(mock.MatchedBy(func(task *asynq.Task) bool {
+		expectedInput := tasks.AcceptFollowInput{
+			Username: "validuser",
+			Activity: map[string]interface{}{
+				"@context": "https://www.w3.org/ns/activitystreams",
+				"type":     "Follow",
+				"actor":    "https://another-instance.example.com/users/validactor",
+				"id":       fakeFollowID,
+				"object":   "https://example.com/users/validuser",
+			},
+		}
+		input := tasks.AcceptFollowInput{}
+		err := json.Unmarshal(task.Payload(), &input)
+		return assert.Equal(t, taskType, task.Type()) &&
+			assert.NoError(t, err) &&
+			assert.Equal(t, expectedInput, input)
+	}), opts...).Return(nil, nil).Once()
+}
+
+func TestFollowRequestService_Respond(t *testing.T) {
+	tests := []struct {
+		name     string
+		reject   bool
+		follower *models.Follower
+		getErr   error
+		taskType string
+		err      string
+	}{
+		{
+			name:   "unknown follow request",
+			getErr: errors.Wrap(sql.ErrNoRows, "unable to fetch follower from database"),
+			err:    "follow request does not exist",
+		},
+		{
+			name:     "follow request already answered",
+			follower: newPendingFollow(repository.FollowStateAccepted),
+			err:      "follow request is not pending",
+		},
+		{
+			name:     "approve follow request",
+			follower: newPendingFollow(repository.FollowStatePending),
+			taskType: tasks.TypeAcceptFollow,
+		},
+		{
+			name:     "reject follow request",
+			reject:   true,
+			follower: newPendingFollow(repository.FollowStatePending),
+			taskType: tasks.TypeRejectFollow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+			fakeFollowerRepo.EXPECT().GetByFollowID(mock.Anything, fakeFollowID).Return(tt.follower, tt.getErr)
+			_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+			fakeWorker := clientmocks.NewBackgroundWorkerClient(t)
+			if tt.taskType != "" {
+				expectFollowResponse(t, fakeWorker, tt.taskType)
+			}
+			_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
+
+			dic_test.BuildTestContainer(t)
+			defer dic.ResetContainer()
+
+			followRequestService := dic.GetService[domain.FollowRequestService]()
+			var err error
+			if tt.reject {
+				err = followRequestService.Reject(context.Background(), fakeFollowID)
+			} else {
+				err = followRequestService.Approve(context.Background(), fakeFollowID)
+			}
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFollowRequestService_ExpirePending(t *testing.T) {
+	tests := []struct {
+		name    string
+		pending models.FollowerSlice
+		mock    func(t *testing.T, fakeWorker *clientmocks.BackgroundWorkerClient)
+		expired int
+	}{
+		{
+			name:    "expired request rejected",
+			pending: models.FollowerSlice{newPendingFollow(repository.FollowStatePending)},
+			mock: func(t *testing.T, fakeWorker *clientmocks.BackgroundWorkerClient) {
+				expectFollowResponse(t, fakeWorker, tasks.TypeRejectFollow, asynq.TaskID("reject:"+fakeFollowID))
+			},
+			expired: 1,
+		},
+		{
+			name:    "reject already scheduled",
+			pending: models.FollowerSlice{newPendingFollow(repository.FollowStatePending)},
+			mock: func(t *testing.T, fakeWorker *clientmocks.BackgroundWorkerClient) {
+				expectFollowResponse(t, fakeWorker, tasks.TypeRejectFollow, asynq.TaskID("reject:"+fakeFollowID)).
+					Return(nil, asynq.ErrTaskIDConflict)
+			},
+		},
+		{
+			name: "invalid request does not stop the others",
+			pending: models.FollowerSlice{
+				{User: "validuser", FollowID: null.StringFrom("https://another-instance.example.com/broken")},
+				newPendingFollow(repository.FollowStatePending),
+			},
+			mock: func(t *testing.T, fakeWorker *clientmocks.BackgroundWorkerClient) {
+				expectFollowResponse(t, fakeWorker, tasks.TypeRejectFollow, asynq.TaskID("reject:"+fakeFollowID))
+			},
+			expired: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+			fakeFollowerRepo.EXPECT().ListPending(mock.Anything, mock.MatchedBy(func(request repository.ListPendingFollowsRequest) bool {
+				expectedBefore := time.Now().Add(-7 * 24 * time.Hour)
+				return request.Username == "" && request.CreatedBefore.Sub(expectedBefore).Abs() < time.Minute
+			})).Return(tt.pending, nil)
+			_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+			fakeWorker := clientmocks.NewBackgroundWorkerClient(t)
+			tt.mock(t, fakeWorker)
+			_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
+
+			dic_test.BuildTestContainer(t)
+			defer dic.ResetContainer()
+
+			expired, err := dic.GetService[domain.FollowRequestService]().ExpirePending(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tt.expired, expired)
+		})
+	}
+}
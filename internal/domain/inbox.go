@@ -3,16 +3,21 @@ package domain
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
 
+	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
+	"github.com/hibiken/asynq"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
 	"github.com/estrys/estrys/internal/activitypub"
+	"github.com/estrys/estrys/internal/activitypub/auth"
 	activitypubclient "github.com/estrys/estrys/internal/activitypub/client"
+	"github.com/estrys/estrys/internal/activitypub/resolver"
 	"github.com/estrys/estrys/internal/authorization"
 	"github.com/estrys/estrys/internal/authorization/attributes"
 	"github.com/estrys/estrys/internal/config"
@@ -21,6 +26,8 @@ import (
 	"github.com/estrys/estrys/internal/logger"
 	"github.com/estrys/estrys/internal/models"
 	"github.com/estrys/estrys/internal/repository"
+	twittermodels "github.com/estrys/estrys/internal/twitter/models"
+	twitterrepository "github.com/estrys/estrys/internal/twitter/repository"
 	"github.com/estrys/estrys/internal/worker/client"
 	"github.com/estrys/estrys/internal/worker/tasks"
 )
@@ -55,8 +62,19 @@ type InboxFollowRequest struct {
 }
 
 type InboxService interface {
+	// Receive checks and stores an activity posted to the inbox of the user, then schedules its processing.
+	Receive(ctx context.Context, user *models.User, document map[string]any) error
+	// Process handles an activity previously stored by Receive.
+	Process(context.Context, *models.InboundActivity) error
 	Follow(context.Context, vocab.ActivityStreamsFollow) error
 	UnFollow(context.Context, vocab.ActivityStreamsUndo) error
+	Update(context.Context, vocab.ActivityStreamsUpdate) error
+	Delete(context.Context, vocab.ActivityStreamsDelete) error
+	Block(context.Context, vocab.ActivityStreamsBlock) error
+	Move(context.Context, vocab.ActivityStreamsMove) error
+	Create(context.Context, vocab.ActivityStreamsCreate) error
+	Like(context.Context, vocab.ActivityStreamsLike) error
+	Announce(context.Context, vocab.ActivityStreamsAnnounce) error
 }
 
 type inboxService struct {
@@ -64,11 +82,18 @@ type inboxService struct {
 	database             database.Database
 	actorRepo            repository.ActorRepository
 	userRepo             repository.UserRepository
-	keyService           crypto.KeyManager
+	followerRepo         repository.FollowerRepository
+	inboundActivityRepo  repository.InboundActivityRepository
+	interactionRepo      repository.InteractionRepository
+	tweetRepo            twitterrepository.TweetRepository
+	actorResolver        resolver.ActorResolver
+	objectResolver       resolver.ObjectResolver
 	activityPubClient    activitypubclient.ActivityPubClient
 	vocabService         activitypub.VocabService
 	worker               client.BackgroundWorkerClient
 	authorizationChecker authorization.AuthorizationChecker
+	keyManager           crypto.KeyManager
+	consent              ConsentService
 	config               config.Config
 }
 
@@ -77,11 +102,18 @@ func NewInboxService(
 	database database.Database,
 	actorRepository repository.ActorRepository,
 	userRepo repository.UserRepository,
-	keyService crypto.KeyManager,
+	followerRepo repository.FollowerRepository,
+	inboundActivityRepo repository.InboundActivityRepository,
+	interactionRepo repository.InteractionRepository,
+	tweetRepo twitterrepository.TweetRepository,
+	actorResolver resolver.ActorResolver,
+	objectResolver resolver.ObjectResolver,
 	activityPubClient activitypubclient.ActivityPubClient,
 	vocabService activitypub.VocabService,
 	worker client.BackgroundWorkerClient,
 	authorizationChecker authorization.AuthorizationChecker,
+	keyManager crypto.KeyManager,
+	consent ConsentService,
 	config config.Config,
 ) *inboxService {
 	return &inboxService{
@@ -89,11 +121,18 @@ func NewInboxService(
 		database:             database,
 		actorRepo:            actorRepository,
 		userRepo:             userRepo,
-		keyService:           keyService,
+		followerRepo:         followerRepo,
+		inboundActivityRepo:  inboundActivityRepo,
+		interactionRepo:      interactionRepo,
+		tweetRepo:            tweetRepo,
+		actorResolver:        actorResolver,
+		objectResolver:       objectResolver,
 		activityPubClient:    activityPubClient,
 		vocabService:         vocabService,
 		worker:               worker,
 		authorizationChecker: authorizationChecker,
+		keyManager:           keyManager,
+		consent:              consent,
 		config:               config,
 	}
 }
@@ -109,7 +148,139 @@ func (a *inboxService) getUserFromURL(ctx context.Context, objectURL *url.URL) (
 	return user, nil
 }
 
+// checkSigner makes sure the activity has been signed by its actor,
+// otherwise anyone could act on behalf of someone else.
+func (a *inboxService) checkSigner(ctx context.Context, actorURL *url.URL) error {
+	if a.config.DisableHTTPSignatureVerify {
+		return nil
+	}
+	owner := auth.KeyOwner(ctx)
+	if owner == nil {
+		return errors.WithStack(&SignerMismatchError{Actor: actorURL.String()})
+	}
+	if !strings.EqualFold(owner.Host, actorURL.Host) || owner.String() != actorURL.String() {
+		return errors.WithStack(&SignerMismatchError{Actor: actorURL.String(), Signer: owner.String()})
+	}
+	return nil
+}
+
+// inboundActivity are the activities accepted in inboxes.
+type inboundActivity interface {
+	GetJSONLDId() vocab.JSONLDIdProperty
+	GetActivityStreamsActor() vocab.ActivityStreamsActorProperty
+}
+
+// resolveActivity calls the callback matching the type of the activity document,
+// streams.ErrNoCallbackMatch is returned for unsupported activities.
+func resolveActivity(ctx context.Context, document map[string]any, callbacks ...any) error {
+	jsonResolver, err := streams.NewJSONResolver(callbacks...)
+	if err != nil {
+		return errors.Wrap(err, "unable to create json resolver")
+	}
+	return jsonResolver.Resolve(ctx, document) //nolint:wrapcheck
+}
+
+func (a *inboxService) Receive(ctx context.Context, user *models.User, document map[string]any) error {
+	var activity inboundActivity
+	keep := func(act inboundActivity) error {
+		activity = act
+		return nil
+	}
+	err := resolveActivity(ctx, document,
+		func(_ context.Context, act vocab.ActivityStreamsFollow) error { return keep(act) },
+		func(_ context.Context, act vocab.ActivityStreamsUndo) error { return keep(act) },
+		func(_ context.Context, act vocab.ActivityStreamsUpdate) error { return keep(act) },
+		func(_ context.Context, act vocab.ActivityStreamsDelete) error { return keep(act) },
+		func(_ context.Context, act vocab.ActivityStreamsBlock) error { return keep(act) },
+		func(_ context.Context, act vocab.ActivityStreamsMove) error { return keep(act) },
+		func(_ context.Context, act vocab.ActivityStreamsCreate) error { return keep(act) },
+		func(_ context.Context, act vocab.ActivityStreamsLike) error { return keep(act) },
+		func(_ context.Context, act vocab.ActivityStreamsAnnounce) error { return keep(act) },
+	)
+	if err != nil {
+		return err
+	}
+
+	// The signature can only be checked while we still have the request
+	actorURL, err := activitypub.GetActorURL(activity)
+	if err != nil {
+		return errors.Wrap(err, "unable to get actor url")
+	}
+	err = a.checkSigner(ctx, actorURL)
+	if err != nil {
+		return err
+	}
+
+	if activity.GetJSONLDId() == nil || activity.GetJSONLDId().Get() == nil {
+		return errors.WithStack(ErrMissingActivityID)
+	}
+	// Activities are deduplicated on their id, an actor must not be able to use the ids of another instance
+	if activity.GetJSONLDId().Get().Host != actorURL.Host {
+		return errors.WithStack(ErrActivityIDMismatch)
+	}
+	activityID := activity.GetJSONLDId().Get().String()
+
+	inbound, created, err := a.inboundActivityRepo.Create(ctx, repository.CreateInboundActivityRequest{
+		ActivityID: activityID,
+		Username:   user.Username,
+		Payload:    document,
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to store inbound activity")
+	}
+	if !created && inbound.ProcessedAt.Valid {
+		a.log.WithField("activity", activityID).Debug("ignoring already processed activity")
+		return nil
+	}
+
+	// An activity received again before being processed is scheduled again, in case scheduling failed
+	// the first time. The task id is the one of the stored activity, so it is never processed twice.
+	processTask, err := tasks.NewProcessInboundActivityTask(ctx, inbound)
+	if err != nil {
+		return errors.Wrap(err, "unable to create process inbound activity task")
+	}
+	_, err = a.worker.Enqueue(processTask)
+	if err != nil {
+		if errors.Is(err, asynq.ErrTaskIDConflict) {
+			a.log.WithField("activity", activityID).Debug("ignoring already scheduled activity")
+			return nil
+		}
+		return errors.Wrap(err, "unable to schedule process inbound activity task")
+	}
+
+	a.log.WithFields(logrus.Fields{
+		"activity": activityID,
+		"actor":    actorURL.String(),
+	}).Debug("inbound activity received")
+
+	return nil
+}
+
+func (a *inboxService) Process(ctx context.Context, inbound *models.InboundActivity) error {
+	var document map[string]any
+	err := json.Unmarshal(inbound.Payload, &document)
+	if err != nil {
+		return errors.Wrap(err, "unable to decode inbound activity payload")
+	}
+	return resolveActivity(ctx, document,
+		a.Follow,
+		a.UnFollow,
+		a.Update,
+		a.Delete,
+		a.Block,
+		a.Move,
+		a.Create,
+		a.Like,
+		a.Announce,
+	)
+}
+
 func (a *inboxService) Follow(ctx context.Context, follow vocab.ActivityStreamsFollow) error {
+	actorURL, err := activitypub.GetActorURL(follow)
+	if err != nil {
+		return errors.Wrap(err, "unable to get actor url")
+	}
+
 	objectURL, err := activitypub.GetObjectURL(follow)
 	if err != nil {
 		return errors.Wrap(err, "unable to get object url")
@@ -125,14 +296,38 @@ func (a *inboxService) Follow(ctx context.Context, follow vocab.ActivityStreamsF
 		return err
 	}
 
-	actorURL, err := activitypub.GetActorURL(follow)
+	actor, err := a.actorResolver.Resolve(ctx, actorURL)
 	if err != nil {
-		return errors.Wrap(err, "unable to get actor url")
+		return errors.Wrap(err, "unable to resolve actor")
 	}
 
-	actor, err := a.createActorIfNoExist(ctx, actorURL)
+	if follow.GetJSONLDId() == nil || follow.GetJSONLDId().Get() == nil {
+		return errors.WithStack(ErrMissingActivityID)
+	}
+	follower, err := a.followerRepo.Create(ctx, user, actor, follow.GetJSONLDId().Get().String())
 	if err != nil {
-		return errors.Wrap(err, "unable to create actor")
+		return errors.Wrap(err, "unable to record follow")
+	}
+
+	// Users who refused to be bridged must not gain new followers, the follow is kept
+	// as rejected so the reject task finds it
+	err = a.consent.CheckUser(ctx, user.Username)
+	if err != nil {
+		if !errors.Is(err, ErrUserOptedOut) {
+			return errors.Wrap(err, "unable to check consent of user")
+		}
+		if stateErr := a.followerRepo.SetState(ctx, follower, repository.FollowStateRejected); stateErr != nil {
+			return errors.Wrap(stateErr, "unable to reject follow")
+		}
+		rejectFollowTask, taskErr := tasks.NewRejectFollowTask(ctx, user.Username, follow)
+		if taskErr != nil {
+			return errors.Wrap(taskErr, "unable to create reject follow task")
+		}
+		_, taskErr = a.worker.Enqueue(rejectFollowTask)
+		if taskErr != nil {
+			return errors.Wrap(taskErr, "unable to schedule reject follow task")
+		}
+		return err
 	}
 
 	if !a.authorizationChecker.IsGranted(follow.GetActivityStreamsActor(), attributes.CanFollow) {
@@ -148,9 +343,14 @@ func (a *inboxService) Follow(ctx context.Context, follow vocab.ActivityStreamsF
 		return errors.WithStack(&ActorNotAllowedError{actor: follow.GetActivityStreamsActor()})
 	}
 
-	err = a.userRepo.Follow(ctx, user, actor)
-	if err != nil {
-		return errors.Wrap(err, "unable to follow actor")
+	// Approved followers sending their follow again are accepted again
+	if manuallyApprovesFollowers(a.config.ManuallyApprovedUsers, user.Username) &&
+		repository.FollowState(follower.State) != repository.FollowStateAccepted {
+		a.log.WithFields(logrus.Fields{
+			"actor":  actorURL.String(),
+			"object": objectURL.String(),
+		}).Info("follow request is waiting for approval")
+		return nil
 	}
 
 	acceptFollowTask, err := tasks.NewAcceptFollowTask(ctx, user.Username, follow)
@@ -170,72 +370,502 @@ func (a *inboxService) Follow(ctx context.Context, follow vocab.ActivityStreamsF
 	return nil
 }
 
-func (a *inboxService) createActorIfNoExist(ctx context.Context, url *url.URL) (*models.Actor, error) {
-	actor, err := a.actorRepo.Get(ctx, url)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return nil, errors.Wrap(err, "unable to fetch actor from db")
+func (a *inboxService) UnFollow(ctx context.Context, act vocab.ActivityStreamsUndo) error {
+	actorURL, err := activitypub.GetActorURL(act)
+	if err != nil {
+		return errors.Wrap(err, "unable to get actor url")
+	}
+
+	removed, err := a.removeUndoneInteraction(ctx, act, actorURL)
+	if err != nil || removed {
+		return err
+	}
+
+	follower, err := a.findUndoneFollow(ctx, act, actorURL)
+	if err != nil {
+		return err
+	}
+	if follower == nil {
+		a.log.WithField("actor", actorURL.String()).Debug("ignoring undo of an unknown follow")
+		return nil
+	}
+
+	err = a.followerRepo.SetState(ctx, follower, repository.FollowStateUndone)
+	if err != nil {
+		return errors.Wrap(err, "unable to unfollow user")
+	}
+
+	a.log.WithFields(logrus.Fields{
+		"actor":  actorURL.String(),
+		"object": follower.User,
+	}).Info("successfully handled unfollow request")
+
+	return nil
+}
+
+// removeUndoneInteraction deletes the like or boost undone by a known actor, it reports whether there was one.
+func (a *inboxService) removeUndoneInteraction(
+	ctx context.Context,
+	act vocab.ActivityStreamsUndo,
+	actorURL *url.URL,
+) (bool, error) {
+	undoneURL, err := activitypub.GetObjectURL(act)
+	if err != nil {
+		return false, nil //nolint:nilerr
+	}
+	actor, err := a.actorRepo.Get(ctx, actorURL)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	removed, err := a.interactionRepo.DeleteByActivityID(ctx, actor, undoneURL.String())
+	if err != nil {
+		return false, errors.Wrap(err, "unable to remove interaction")
+	}
+	if removed {
+		a.log.WithField("actor", actorURL.String()).Info("successfully handled undo of an interaction")
+	}
+	return removed, nil
+}
+
+// findUndoneFollow matches the undone follow with its id, and fallbacks on the actor and the object
+// of the follow when we do not know the id. A nil follower is returned if the follow is unknown.
+func (a *inboxService) findUndoneFollow(
+	ctx context.Context,
+	act vocab.ActivityStreamsUndo,
+	actorURL *url.URL,
+) (*models.Follower, error) {
+	followURL, err := activitypub.GetObjectURL(act)
+	if err == nil {
+		follower, err := a.followerRepo.GetByFollowID(ctx, followURL.String())
+		if err == nil {
+			actor, err := a.actorRepo.Get(ctx, actorURL)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+			if actor == nil || actor.ID != follower.Actor {
+				return nil, errors.WithStack(ErrUndoActorMismatch)
+			}
+			return follower, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+
+	object := activitypub.GetObject(act)
+	if object == nil {
+		return nil, errors.New("undo must have a single object")
+	}
+	// Some servers only send the IRI of the undone activity
+	undone, err := a.objectResolver.Resolve(ctx, object)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to resolve undo object")
+	}
+	followActivity, isFollow := undone.(vocab.ActivityStreamsFollow)
+	if !isFollow {
+		return nil, errors.WithStack(&UnsuportedUndoObjectError{undone})
+	}
+	followActorURL, err := activitypub.GetActorURL(followActivity)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get follow actor url")
+	}
+	if followActorURL.String() != actorURL.String() {
+		return nil, errors.WithStack(ErrUndoActorMismatch)
+	}
+
+	userURL, err := activitypub.GetObjectURL(followActivity)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get object URL")
+	}
+	user, err := a.getUserFromURL(ctx, userURL)
+	if err != nil {
+		return nil, err
+	}
+
+	actor, err := a.actorResolver.Resolve(ctx, actorURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to resolve actor")
+	}
+
+	follower, err := a.followerRepo.Get(ctx, user, actor)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return follower, nil
+}
+
+// Update refreshes the stored actor and its key when a known actor updates itself,
+// this is how remote servers announce key rotations. Other updates are ignored.
+func (a *inboxService) Update(ctx context.Context, update vocab.ActivityStreamsUpdate) error {
+	actorURL, err := activitypub.GetActorURL(update)
+	if err != nil {
+		return errors.Wrap(err, "unable to get actor url")
+	}
+
+	objectURL, err := activitypub.GetObjectURL(update)
+	if err != nil {
+		return errors.Wrap(err, "unable to get object url")
+	}
+	if objectURL.String() != actorURL.String() {
+		return nil
+	}
+
+	_, err = a.actorRepo.Get(ctx, actorURL)
+	if err != nil {
+		// Actors are only stored when they interact with our users, no need to keep track of others
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
 	}
 
-	if actor == nil {
-		pubKey, err := a.keyService.FetchKey(ctx, url.String())
+	actor, err := a.actorResolver.Refresh(ctx, actorURL)
+	if err != nil {
+		return errors.Wrap(err, "unable to refresh actor")
+	}
+	if actor.PublicKeyID.Valid {
+		_, err = a.keyManager.RefreshKey(ctx, actor.PublicKeyID.String)
 		if err != nil {
-			return nil, errors.Wrap(err, "unable to fetch actor key")
+			return errors.Wrap(err, "unable to refresh actor key")
+		}
+	}
+
+	a.log.WithField("actor", actorURL.String()).Info("successfully handled actor update")
+
+	return nil
+}
+
+// Delete removes a known actor and its follows when it deletes itself,
+// or the reply of a known actor when it deletes one of its notes.
+func (a *inboxService) Delete(ctx context.Context, del vocab.ActivityStreamsDelete) error {
+	actorURL, err := activitypub.GetActorURL(del)
+	if err != nil {
+		return errors.Wrap(err, "unable to get actor url")
+	}
+
+	objectURL, err := activitypub.GetObjectURL(del)
+	if err != nil {
+		return errors.Wrap(err, "unable to get object url")
+	}
+
+	actor, err := a.actorRepo.Get(ctx, actorURL)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
 		}
-		actor, err = a.actorRepo.Create(ctx, repository.CreateActorRequest{
-			URL:       url,
-			PublicKey: pubKey,
-		})
+		return err
+	}
+
+	if objectURL.String() != actorURL.String() {
+		err = a.interactionRepo.DeleteReplies(ctx, actor, objectURL.String())
 		if err != nil {
-			return nil, errors.Wrap(err, "unable to create actor")
+			return errors.Wrap(err, "unable to delete reply")
 		}
-		a.log.WithField("actor", actor.URL).Debug("new actor created")
+		return nil
+	}
+
+	err = a.actorRepo.Delete(ctx, actor)
+	if err != nil {
+		return errors.Wrap(err, "unable to delete actor")
 	}
 
-	return actor, nil
+	a.log.WithField("actor", actorURL.String()).Info("successfully handled actor deletion")
+
+	return nil
 }
 
-func (a *inboxService) UnFollow(ctx context.Context, act vocab.ActivityStreamsUndo) error {
-	actorURL, err := activitypub.GetActorURL(act)
+// Block stops the deliveries to an actor which blocked one of our users.
+func (a *inboxService) Block(ctx context.Context, block vocab.ActivityStreamsBlock) error {
+	actorURL, err := activitypub.GetActorURL(block)
 	if err != nil {
 		return errors.Wrap(err, "unable to get actor url")
 	}
 
-	object := act.GetActivityStreamsObject()
-	if object.Len() == 0 {
-		return errors.Wrap(err, "no undo object specified")
+	objectURL, err := activitypub.GetObjectURL(block)
+	if err != nil {
+		return errors.Wrap(err, "unable to get object url")
 	}
-	if object.Len() != 1 {
-		return errors.Wrap(err, "unfollowing multiples object not supported yet")
+	if objectURL.Host != a.config.Domain.Host {
+		return nil
 	}
-	obj := object.Begin()
-	if !obj.IsActivityStreamsFollow() {
-		return errors.WithStack(&UnsuportedUndoObjectError{obj.GetType()})
+	user, err := a.getUserFromURL(ctx, objectURL)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.WithStack(ErrUserDoesNotExist)
+		}
+		return err
 	}
 
-	followActivity := obj.GetActivityStreamsFollow()
-	userURL, err := activitypub.GetObjectURL(followActivity)
+	actor, err := a.actorRepo.Get(ctx, actorURL)
 	if err != nil {
-		return errors.Wrap(err, "unable to get object URL")
+		// Nothing is delivered to actors we do not know
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
 	}
-	user, err := a.getUserFromURL(ctx, userURL)
+	follower, err := a.followerRepo.Get(ctx, user, actor)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
 		return err
 	}
 
-	actor, err := a.createActorIfNoExist(ctx, actorURL)
+	err = a.followerRepo.SetState(ctx, follower, repository.FollowStateBlocked)
 	if err != nil {
-		return errors.Wrap(err, "unable to create actor")
+		return errors.Wrap(err, "unable to block follower")
 	}
 
-	err = a.userRepo.UnFollow(ctx, user, actor)
+	a.log.WithFields(logrus.Fields{
+		"actor":  actorURL.String(),
+		"object": user.Username,
+	}).Info("successfully handled block")
+
+	return nil
+}
+
+// Move transfers the follows of an actor to its new account. The new account is always fetched from its
+// origin and must list the previous one in its aliases, otherwise anyone could steal the followers.
+func (a *inboxService) Move(ctx context.Context, move vocab.ActivityStreamsMove) error {
+	actorURL, err := activitypub.GetActorURL(move)
 	if err != nil {
-		return errors.Wrap(err, "unable to unfollow user")
+		return errors.Wrap(err, "unable to get actor url")
+	}
+
+	objectURL, err := activitypub.GetObjectURL(move)
+	if err != nil {
+		return errors.Wrap(err, "unable to get object url")
+	}
+	if objectURL.String() != actorURL.String() {
+		return errors.WithStack(ErrMoveActorMismatch)
+	}
+
+	targetURL, err := activitypub.GetTargetURL(move)
+	if err != nil {
+		return errors.Wrap(err, "unable to get target url")
+	}
+	if targetURL.String() == actorURL.String() {
+		return errors.WithStack(ErrMoveTargetNotAlias)
+	}
+
+	from, err := a.actorRepo.Get(ctx, actorURL)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	targetProperty := streams.NewActivityStreamsObjectProperty()
+	targetProperty.AppendIRI(targetURL)
+	target, err := a.objectResolver.Resolve(ctx, targetProperty.Begin())
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve move target")
+	}
+	aliases, err := activitypub.GetAlsoKnownAs(target)
+	if err != nil {
+		return errors.Wrap(err, "unable to read move target aliases")
+	}
+	isAlias := false
+	for _, alias := range aliases {
+		if alias.String() == actorURL.String() {
+			isAlias = true
+			break
+		}
+	}
+	if !isAlias {
+		return errors.WithStack(ErrMoveTargetNotAlias)
+	}
+
+	to, err := a.actorResolver.Resolve(ctx, targetURL)
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve target actor")
+	}
+
+	moved, err := a.followerRepo.MoveFollows(ctx, from, to)
+	if err != nil {
+		return errors.Wrap(err, "unable to move follows")
 	}
 
 	a.log.WithFields(logrus.Fields{
 		"actor":  actorURL.String(),
-		"object": userURL.String(),
-	}).Info("successfully handled unfollow request")
+		"target": targetURL.String(),
+		"count":  moved,
+	}).Info("successfully handled move")
+
+	return nil
+}
+
+// getTweetFromURL returns the tweet behind one of our status URLs with the user it is bridged by.
+// A nil tweet is returned for any other URL, or when the tweet is no longer known.
+func (a *inboxService) getTweetFromURL(
+	ctx context.Context,
+	statusURL *url.URL,
+) (*models.User, *twittermodels.Tweet, error) {
+	if statusURL.Host != a.config.Domain.Host {
+		return nil, nil, nil
+	}
+	var username, tweetID string
+	splitPath := strings.Split(strings.Trim(statusURL.Path, "/"), "/")
+	switch {
+	case len(splitPath) == 3 && splitPath[0] == "status":
+		username, tweetID = splitPath[1], splitPath[2]
+	case len(splitPath) == 2 && strings.HasPrefix(splitPath[0], "@"):
+		username, tweetID = strings.TrimPrefix(splitPath[0], "@"), splitPath[1]
+	default:
+		return nil, nil, nil
+	}
+
+	tweet, err := a.tweetRepo.GetTweet(ctx, tweetID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to fetch tweet")
+	}
+	if tweet == nil || !tweet.IsAuthoredBy(username) {
+		return nil, nil, nil
+	}
+	user, err := a.userRepo.Get(ctx, tweet.AuthorUsername)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	return user, tweet, nil
+}
+
+// recordInteraction stores the interaction of the actor with one of our statuses,
+// interactions with anything else and from denied actors are ignored.
+func (a *inboxService) recordInteraction(
+	ctx context.Context,
+	act inboundActivity,
+	statusURL *url.URL,
+	input repository.CreateInteractionRequest,
+) error {
+	actorURL, err := activitypub.GetActorURL(act)
+	if err != nil {
+		return errors.Wrap(err, "unable to get actor url")
+	}
+	if act.GetJSONLDId() == nil || act.GetJSONLDId().Get() == nil {
+		return errors.WithStack(ErrMissingActivityID)
+	}
+
+	user, tweet, err := a.getTweetFromURL(ctx, statusURL)
+	if err != nil {
+		return err
+	}
+	if tweet == nil {
+		return nil
+	}
+	if !a.authorizationChecker.IsGranted(actorURL, attributes.CanInteract) {
+		a.log.WithField("actor", actorURL.String()).Debug("ignoring interaction of a denied actor")
+		return nil
+	}
+
+	actor, err := a.actorResolver.Resolve(ctx, actorURL)
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve actor")
+	}
+
+	input.ActivityID = act.GetJSONLDId().Get().String()
+	input.Username = user.Username
+	input.TweetID = tweet.ID
+	input.Actor = actor
+	err = a.interactionRepo.Create(ctx, input)
+	if err != nil {
+		return errors.Wrap(err, "unable to record interaction")
+	}
+
+	a.log.WithFields(logrus.Fields{
+		"actor":  actorURL.String(),
+		"type":   input.Type,
+		"object": statusURL.String(),
+	}).Info("successfully recorded interaction")
 
 	return nil
 }
+
+// Create records the replies to our statuses, other objects are ignored.
+func (a *inboxService) Create(ctx context.Context, create vocab.ActivityStreamsCreate) error {
+	actorURL, err := activitypub.GetActorURL(create)
+	if err != nil {
+		return errors.Wrap(err, "unable to get actor url")
+	}
+	object := activitypub.GetObject(create)
+	if object == nil {
+		return errors.New("create must have a single object")
+	}
+	resolved, err := a.objectResolver.Resolve(ctx, object)
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve created object")
+	}
+	note, isNote := resolved.(vocab.ActivityStreamsNote)
+	if !isNote {
+		return nil
+	}
+
+	inReplyToURL, err := activitypub.GetInReplyToURL(note)
+	if err != nil {
+		return errors.Wrap(err, "unable to get reply url")
+	}
+	if inReplyToURL == nil {
+		return nil
+	}
+
+	authorURL, err := activitypub.GetAttributedToURL(note)
+	if err != nil {
+		return errors.Wrap(err, "unable to get note author")
+	}
+	if authorURL.String() != actorURL.String() {
+		return errors.WithStack(ErrReplyAuthorMismatch)
+	}
+	if note.GetJSONLDId() == nil || note.GetJSONLDId().Get() == nil {
+		return errors.WithStack(ErrMissingObjectID)
+	}
+
+	input := repository.CreateInteractionRequest{
+		Type:     repository.InteractionTypeReply,
+		ObjectID: note.GetJSONLDId().Get().String(),
+	}
+	if noteURL := note.GetActivityStreamsUrl(); noteURL != nil && noteURL.Len() > 0 {
+		if link := noteURL.Begin().GetIRI(); link != nil && (link.Scheme == "https" || link.Scheme == "http") {
+			input.ObjectURL = link.String()
+		}
+	}
+	if content := note.GetActivityStreamsContent(); content != nil && content.Len() > 0 {
+		input.Content = activitypub.HTMLToText(content.Begin().GetXMLSchemaString())
+	}
+	if published := note.GetActivityStreamsPublished(); published != nil {
+		input.PublishedAt = published.Get()
+	}
+
+	return a.recordInteraction(ctx, create, inReplyToURL, input)
+}
+
+// Like records the likes of our statuses.
+func (a *inboxService) Like(ctx context.Context, like vocab.ActivityStreamsLike) error {
+	objectURL, err := activitypub.GetObjectURL(like)
+	if err != nil {
+		return errors.Wrap(err, "unable to get object url")
+	}
+	return a.recordInteraction(ctx, like, objectURL, repository.CreateInteractionRequest{
+		Type: repository.InteractionTypeLike,
+	})
+}
+
+// Announce records the boosts of our statuses.
+func (a *inboxService) Announce(ctx context.Context, announce vocab.ActivityStreamsAnnounce) error {
+	objectURL, err := activitypub.GetObjectURL(announce)
+	if err != nil {
+		return errors.Wrap(err, "unable to get object url")
+	}
+	return a.recordInteraction(ctx, announce, objectURL, repository.CreateInteractionRequest{
+		Type: repository.InteractionTypeShare,
+	})
+}
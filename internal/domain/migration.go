@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/estrys/estrys/internal/activitypub"
+	"github.com/estrys/estrys/internal/activitypub/resolver"
+	"github.com/estrys/estrys/internal/logger"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	"github.com/estrys/estrys/internal/router/routes"
+	"github.com/estrys/estrys/internal/router/urlgenerator"
+	"github.com/estrys/estrys/internal/worker/client"
+	"github.com/estrys/estrys/internal/worker/tasks"
+)
+
+var ErrUserAlreadyMoved = errors.New("user already moved to another account")
+
+//go:generate mockery --with-expecter --name=MigrationService
+type MigrationService interface {
+	// Migrate moves the followers of the user to the target actor, which must list the user in its aliases.
+	// The number of scheduled deliveries is returned. Migrating again to the same target sends a new move
+	// to every follower.
+	Migrate(ctx context.Context, username string, target *url.URL) (int, error)
+}
+
+type migrationService struct {
+	log            logger.Logger
+	userRepo       repository.UserRepository
+	objectResolver resolver.ObjectResolver
+	vocabService   activitypub.VocabService
+	urlGenerator   urlgenerator.URLGenerator
+	worker         client.BackgroundWorkerClient
+}
+
+func NewMigrationService(
+	log logger.Logger,
+	userRepo repository.UserRepository,
+	objectResolver resolver.ObjectResolver,
+	vocabService activitypub.VocabService,
+	urlGenerator urlgenerator.URLGenerator,
+	worker client.BackgroundWorkerClient,
+) *migrationService {
+	return &migrationService{
+		log:            log,
+		userRepo:       userRepo,
+		objectResolver: objectResolver,
+		vocabService:   vocabService,
+		urlGenerator:   urlGenerator,
+		worker:         worker,
+	}
+}
+
+func (m *migrationService) Migrate(ctx context.Context, username string, target *url.URL) (int, error) {
+	user, err := m.userRepo.Get(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errors.WithStack(ErrUserDoesNotExist)
+		}
+		return 0, err //nolint:wrapcheck
+	}
+	if user.MovedTo.Valid && user.MovedTo.String != target.String() {
+		return 0, errors.WithStack(ErrUserAlreadyMoved)
+	}
+
+	err = m.checkAlias(ctx, user, target)
+	if err != nil {
+		return 0, err
+	}
+
+	// The move id comes from the move date, a move sent again gets a new id so deliveries are not
+	// mistaken for the ones already scheduled
+	err = m.userRepo.SetMovedTo(ctx, user, target.String())
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to record user move")
+	}
+
+	deliveries, err := m.sendMove(ctx, user)
+	if err != nil {
+		return 0, err
+	}
+
+	m.log.WithFields(logrus.Fields{
+		"user":       user.Username,
+		"target":     target.String(),
+		"deliveries": deliveries,
+	}).Info("user moved")
+	return deliveries, nil
+}
+
+// checkAlias makes sure the target account belongs to the same person, the target is always fetched from its origin.
+func (m *migrationService) checkAlias(ctx context.Context, user *models.User, target *url.URL) error {
+	userURL, err := m.urlGenerator.URL(
+		routes.UserRoute,
+		[]string{"username", user.Username},
+		urlgenerator.OptionAbsoluteURL,
+	)
+	if err != nil {
+		return errors.Wrap(err, "cannot generate user URL")
+	}
+
+	targetProperty := streams.NewActivityStreamsObjectProperty()
+	targetProperty.AppendIRI(target)
+	targetActor, err := m.objectResolver.Resolve(ctx, targetProperty.Begin())
+	if err != nil {
+		return errors.Wrap(err, "unable to resolve move target")
+	}
+	aliases, err := activitypub.GetAlsoKnownAs(targetActor)
+	if err != nil {
+		return errors.Wrap(err, "unable to read move target aliases")
+	}
+	for _, alias := range aliases {
+		if alias.String() == userURL.String() {
+			return nil
+		}
+	}
+	return errors.WithStack(ErrMoveTargetNotAlias)
+}
+
+// sendMove schedules the delivery of the move to every follower inbox and returns their count.
+func (m *migrationService) sendMove(ctx context.Context, user *models.User) (int, error) {
+	move, err := m.vocabService.GetMove(user)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to create move activity")
+	}
+	return sendToFollowers(ctx, m.userRepo, m.worker, user, tasks.TypeSendMove, move)
+}
@@ -0,0 +1,178 @@
+package domain_test
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/estrys/estrys/internal/activitypub/resolver"
+	resolvermocks "github.com/estrys/estrys/internal/activitypub/resolver/mocks"
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/domain"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	repositorymocks "github.com/estrys/estrys/internal/repository/mocks"
+	"github.com/estrys/estrys/internal/worker/client"
+	clientmocks "github.com/estrys/estrys/internal/worker/client/mocks"
+	"github.com/estrys/estrys/internal/worker/tasks"
+	dic_test "github.com/estrys/estrys/tests/dic"
+)
+
+const fakeMoveTarget = "https://another-instance.example.com/users/validuser"
+
+// newTargetActor returns the fediverse account of validuser, declaring the given aliases.
+func newTargetActor(t *testing.T, aliases ...string) vocab.Type {
+	t.Helper()
+	// Decoded documents only contain generic values
+	alsoKnownAs := make([]any, 0, len(aliases))
+	for _, alias := range aliases {
+		alsoKnownAs = append(alsoKnownAs, alias)
+	}
+	actor, err := streams.ToType(context.Background(), map[string]any{
+		"@context":    "https://www.w3.org/ns/activitystreams",
+		"id":          fakeMoveTarget,
+		"type":        "Person",
+		"inbox":       fakeMoveTarget + "/inbox",
+		"alsoKnownAs": alsoKnownAs,
+	})
+	require.NoError(t, err)
+	return actor
+}
+
+func registerTargetActor(t *testing.T, aliases ...string) {
+	t.Helper()
+	fakeObjectResolver := resolvermocks.NewObjectResolver(t)
+	fakeObjectResolver.EXPECT().Resolve(mock.Anything, mock.Anything).Return(newTargetActor(t, aliases...), nil)
+	_ = dic.Register[resolver.ObjectResolver](fakeObjectResolver)
+}
+
+// expectMoveDeliveries expects a move task with the given id for each recipient.
+func expectMoveDeliveries(t *testing.T, moveID string, recipients []string) {
+	t.Helper()
+	fakeWorker := clientmocks.NewBackgroundWorkerClient(t)
+	for _, recipient := range recipients {
+		recipient := recipient
+		fakeWorker.EXPECT().Enqueue(mock.MatchedBy(func(task *asynq.Task) bool {
+			return task.Type() == tasks.TypeSendMove &&
+				strings.Contains(string(task.Payload()), recipient) &&
+				strings.Contains(string(task.Payload()), moveID)
+		})).Return(nil, nil).Once()
+	}
+	_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
+}
+
+// expectMovedTo expects the move of the user to be recorded at the given date.
+func expectMovedTo(userRepo *repositorymocks.UserRepository, movedAt time.Time) {
+	userRepo.EXPECT().SetMovedTo(mock.Anything, mock.Anything, fakeMoveTarget).
+		Run(func(_ context.Context, user *models.User, movedTo string) {
+			user.MovedTo = null.StringFrom(movedTo)
+			user.MovedAt = null.TimeFrom(movedAt)
+		}).
+		Return(nil)
+}
+
+func TestMigrationService_Migrate(t *testing.T) {
+	movedAt := time.Date(2022, 11, 20, 10, 0, 0, 0, time.UTC)
+	followers := models.ActorSlice{
+		{URL: "https://another-instance.example.com/users/first", SharedInbox: null.StringFrom("https://another-instance.example.com/inbox")},
+		{URL: "https://another-instance.example.com/users/second", SharedInbox: null.StringFrom("https://another-instance.example.com/inbox")},
+		{URL: "https://small-instance.example.com/users/third"},
+	}
+
+	tests := []struct {
+		name       string
+		user       *models.User
+		getErr     error
+		mock       func(t *testing.T, userRepo *repositorymocks.UserRepository)
+		deliveries int
+		err        string
+	}{
+		{
+			name:   "unknown user",
+			getErr: errors.Wrap(sql.ErrNoRows, "unable to fetch user from database"),
+			err:    "user does not exist",
+		},
+		{
+			name: "user already moved to another account",
+			user: &models.User{
+				Username: "validuser",
+				MovedTo:  null.StringFrom("https://elsewhere.example.com/users/validuser"),
+			},
+			err: "user already moved to another account",
+		},
+		{
+			name: "target without alias",
+			user: &models.User{Username: "validuser"},
+			mock: func(t *testing.T, _ *repositorymocks.UserRepository) {
+				registerTargetActor(t, "https://example.com/users/someone")
+			},
+			err: "move target does not declare the actor as an alias",
+		},
+		{
+			name: "valid migration",
+			user: &models.User{Username: "validuser"},
+			mock: func(t *testing.T, userRepo *repositorymocks.UserRepository) {
+				registerTargetActor(t, "https://example.com/users/validuser")
+				expectMovedTo(userRepo, movedAt)
+				userRepo.EXPECT().GetFollowers(mock.Anything, mock.Anything).Return(followers, nil)
+				expectMoveDeliveries(t, "https://example.com/users/validuser#moves/1668938400", []string{
+					"https://another-instance.example.com/inbox",
+					"https://small-instance.example.com/users/third",
+				})
+			},
+			deliveries: 2,
+		},
+		{
+			name: "migration sent again",
+			user: &models.User{
+				Username: "validuser",
+				MovedTo:  null.StringFrom(fakeMoveTarget),
+				MovedAt:  null.TimeFrom(movedAt),
+			},
+			mock: func(t *testing.T, userRepo *repositorymocks.UserRepository) {
+				registerTargetActor(t, "https://example.com/users/validuser")
+				// The move gets a new id so that it is delivered again
+				expectMovedTo(userRepo, movedAt.Add(time.Hour))
+				userRepo.EXPECT().GetFollowers(mock.Anything, mock.Anything).Return(followers, nil)
+				expectMoveDeliveries(t, "https://example.com/users/validuser#moves/1668942000", []string{
+					"https://another-instance.example.com/inbox",
+					"https://small-instance.example.com/users/third",
+				})
+			},
+			deliveries: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeUserRepo := repositorymocks.NewUserRepository(t)
+			fakeUserRepo.EXPECT().Get(mock.Anything, "validuser").Return(tt.user, tt.getErr)
+			_ = dic.Register[repository.UserRepository](fakeUserRepo)
+			if tt.mock != nil {
+				tt.mock(t, fakeUserRepo)
+			}
+
+			dic_test.BuildTestContainer(t)
+			defer dic.ResetContainer()
+
+			target, _ := url.Parse(fakeMoveTarget)
+			deliveries, err := dic.GetService[domain.MigrationService]().Migrate(context.Background(), "validuser", target)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.deliveries, deliveries)
+		})
+	}
+}
@@ -0,0 +1,293 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/estrys/estrys/internal/models"
+
+	twitter "github.com/g8rswimmer/go-twitter/v2"
+)
+
+// ConsentService is an autogenerated mock type for the ConsentService type
+type ConsentService struct {
+	mock.Mock
+}
+
+type ConsentService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ConsentService) EXPECT() *ConsentService_Expecter {
+	return &ConsentService_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function with given fields: ctx, twitterUser
+func (_m *ConsentService) Check(ctx context.Context, twitterUser *twitter.UserObj) error {
+	ret := _m.Called(ctx, twitterUser)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *twitter.UserObj) error); ok {
+		r0 = rf(ctx, twitterUser)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConsentService_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type ConsentService_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+//   - twitterUser *twitter.UserObj
+func (_e *ConsentService_Expecter) Check(ctx interface{}, twitterUser interface{}) *ConsentService_Check_Call {
+	return &ConsentService_Check_Call{Call: _e.mock.On("Check", ctx, twitterUser)}
+}
+
+func (_c *ConsentService_Check_Call) Run(run func(ctx context.Context, twitterUser *twitter.UserObj)) *ConsentService_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*twitter.UserObj))
+	})
+	return _c
+}
+
+func (_c *ConsentService_Check_Call) Return(_a0 error) *ConsentService_Check_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// CheckUser provides a mock function with given fields: ctx, username
+func (_m *ConsentService) CheckUser(ctx context.Context, username string) error {
+	ret := _m.Called(ctx, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConsentService_CheckUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckUser'
+type ConsentService_CheckUser_Call struct {
+	*mock.Call
+}
+
+// CheckUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *ConsentService_Expecter) CheckUser(ctx interface{}, username interface{}) *ConsentService_CheckUser_Call {
+	return &ConsentService_CheckUser_Call{Call: _e.mock.On("CheckUser", ctx, username)}
+}
+
+func (_c *ConsentService_CheckUser_Call) Run(run func(ctx context.Context, username string)) *ConsentService_CheckUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ConsentService_CheckUser_Call) Return(_a0 error) *ConsentService_CheckUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// FilterUsers provides a mock function with given fields: ctx, users
+func (_m *ConsentService) FilterUsers(ctx context.Context, users models.UserSlice) (models.UserSlice, error) {
+	ret := _m.Called(ctx, users)
+
+	var r0 models.UserSlice
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserSlice) models.UserSlice); ok {
+		r0 = rf(ctx, users)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.UserSlice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.UserSlice) error); ok {
+		r1 = rf(ctx, users)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConsentService_FilterUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FilterUsers'
+type ConsentService_FilterUsers_Call struct {
+	*mock.Call
+}
+
+// FilterUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - users models.UserSlice
+func (_e *ConsentService_Expecter) FilterUsers(ctx interface{}, users interface{}) *ConsentService_FilterUsers_Call {
+	return &ConsentService_FilterUsers_Call{Call: _e.mock.On("FilterUsers", ctx, users)}
+}
+
+func (_c *ConsentService_FilterUsers_Call) Run(run func(ctx context.Context, users models.UserSlice)) *ConsentService_FilterUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.UserSlice))
+	})
+	return _c
+}
+
+func (_c *ConsentService_FilterUsers_Call) Return(_a0 models.UserSlice, _a1 error) *ConsentService_FilterUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *ConsentService) List(ctx context.Context) (models.OptOutSlice, error) {
+	ret := _m.Called(ctx)
+
+	var r0 models.OptOutSlice
+	if rf, ok := ret.Get(0).(func(context.Context) models.OptOutSlice); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.OptOutSlice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConsentService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ConsentService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ConsentService_Expecter) List(ctx interface{}) *ConsentService_List_Call {
+	return &ConsentService_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *ConsentService_List_Call) Run(run func(ctx context.Context)) *ConsentService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ConsentService_List_Call) Return(_a0 models.OptOutSlice, _a1 error) *ConsentService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// OptIn provides a mock function with given fields: ctx, username
+func (_m *ConsentService) OptIn(ctx context.Context, username string) error {
+	ret := _m.Called(ctx, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConsentService_OptIn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OptIn'
+type ConsentService_OptIn_Call struct {
+	*mock.Call
+}
+
+// OptIn is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *ConsentService_Expecter) OptIn(ctx interface{}, username interface{}) *ConsentService_OptIn_Call {
+	return &ConsentService_OptIn_Call{Call: _e.mock.On("OptIn", ctx, username)}
+}
+
+func (_c *ConsentService_OptIn_Call) Run(run func(ctx context.Context, username string)) *ConsentService_OptIn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ConsentService_OptIn_Call) Return(_a0 error) *ConsentService_OptIn_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// OptOut provides a mock function with given fields: ctx, username
+func (_m *ConsentService) OptOut(ctx context.Context, username string) (int, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConsentService_OptOut_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OptOut'
+type ConsentService_OptOut_Call struct {
+	*mock.Call
+}
+
+// OptOut is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *ConsentService_Expecter) OptOut(ctx interface{}, username interface{}) *ConsentService_OptOut_Call {
+	return &ConsentService_OptOut_Call{Call: _e.mock.On("OptOut", ctx, username)}
+}
+
+func (_c *ConsentService_OptOut_Call) Run(run func(ctx context.Context, username string)) *ConsentService_OptOut_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ConsentService_OptOut_Call) Return(_a0 int, _a1 error) *ConsentService_OptOut_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewConsentService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewConsentService creates a new instance of ConsentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewConsentService(t mockConstructorTestingTNewConsentService) *ConsentService {
+	mock := &ConsentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,130 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/estrys/estrys/internal/models"
+)
+
+// FediverseLinkService is an autogenerated mock type for the FediverseLinkService type
+type FediverseLinkService struct {
+	mock.Mock
+}
+
+type FediverseLinkService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FediverseLinkService) EXPECT() *FediverseLinkService_Expecter {
+	return &FediverseLinkService_Expecter{mock: &_m.Mock}
+}
+
+// ListCandidates provides a mock function with given fields: ctx, username
+func (_m *FediverseLinkService) ListCandidates(ctx context.Context, username string) (models.FediverseLinkSlice, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 models.FediverseLinkSlice
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.FediverseLinkSlice); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.FediverseLinkSlice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FediverseLinkService_ListCandidates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCandidates'
+type FediverseLinkService_ListCandidates_Call struct {
+	*mock.Call
+}
+
+// ListCandidates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *FediverseLinkService_Expecter) ListCandidates(ctx interface{}, username interface{}) *FediverseLinkService_ListCandidates_Call {
+	return &FediverseLinkService_ListCandidates_Call{Call: _e.mock.On("ListCandidates", ctx, username)}
+}
+
+func (_c *FediverseLinkService_ListCandidates_Call) Run(run func(ctx context.Context, username string)) *FediverseLinkService_ListCandidates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FediverseLinkService_ListCandidates_Call) Return(_a0 models.FediverseLinkSlice, _a1 error) *FediverseLinkService_ListCandidates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Scan provides a mock function with given fields: ctx
+func (_m *FediverseLinkService) Scan(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FediverseLinkService_Scan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Scan'
+type FediverseLinkService_Scan_Call struct {
+	*mock.Call
+}
+
+// Scan is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *FediverseLinkService_Expecter) Scan(ctx interface{}) *FediverseLinkService_Scan_Call {
+	return &FediverseLinkService_Scan_Call{Call: _e.mock.On("Scan", ctx)}
+}
+
+func (_c *FediverseLinkService_Scan_Call) Run(run func(ctx context.Context)) *FediverseLinkService_Scan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *FediverseLinkService_Scan_Call) Return(_a0 int, _a1 error) *FediverseLinkService_Scan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewFediverseLinkService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFediverseLinkService creates a new instance of FediverseLinkService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFediverseLinkService(t mockConstructorTestingTNewFediverseLinkService) *FediverseLinkService {
+	mock := &FediverseLinkService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
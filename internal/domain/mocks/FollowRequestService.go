@@ -0,0 +1,206 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/estrys/estrys/internal/models"
+)
+
+// FollowRequestService is an autogenerated mock type for the FollowRequestService type
+type FollowRequestService struct {
+	mock.Mock
+}
+
+type FollowRequestService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FollowRequestService) EXPECT() *FollowRequestService_Expecter {
+	return &FollowRequestService_Expecter{mock: &_m.Mock}
+}
+
+// Approve provides a mock function with given fields: ctx, followID
+func (_m *FollowRequestService) Approve(ctx context.Context, followID string) error {
+	ret := _m.Called(ctx, followID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, followID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FollowRequestService_Approve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Approve'
+type FollowRequestService_Approve_Call struct {
+	*mock.Call
+}
+
+// Approve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - followID string
+func (_e *FollowRequestService_Expecter) Approve(ctx interface{}, followID interface{}) *FollowRequestService_Approve_Call {
+	return &FollowRequestService_Approve_Call{Call: _e.mock.On("Approve", ctx, followID)}
+}
+
+func (_c *FollowRequestService_Approve_Call) Run(run func(ctx context.Context, followID string)) *FollowRequestService_Approve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FollowRequestService_Approve_Call) Return(_a0 error) *FollowRequestService_Approve_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// ExpirePending provides a mock function with given fields: _a0
+func (_m *FollowRequestService) ExpirePending(_a0 context.Context) (int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FollowRequestService_ExpirePending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpirePending'
+type FollowRequestService_ExpirePending_Call struct {
+	*mock.Call
+}
+
+// ExpirePending is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *FollowRequestService_Expecter) ExpirePending(_a0 interface{}) *FollowRequestService_ExpirePending_Call {
+	return &FollowRequestService_ExpirePending_Call{Call: _e.mock.On("ExpirePending", _a0)}
+}
+
+func (_c *FollowRequestService_ExpirePending_Call) Run(run func(_a0 context.Context)) *FollowRequestService_ExpirePending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *FollowRequestService_ExpirePending_Call) Return(_a0 int, _a1 error) *FollowRequestService_ExpirePending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// ListPending provides a mock function with given fields: ctx, username
+func (_m *FollowRequestService) ListPending(ctx context.Context, username string) (models.FollowerSlice, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 models.FollowerSlice
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.FollowerSlice); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.FollowerSlice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FollowRequestService_ListPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPending'
+type FollowRequestService_ListPending_Call struct {
+	*mock.Call
+}
+
+// ListPending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *FollowRequestService_Expecter) ListPending(ctx interface{}, username interface{}) *FollowRequestService_ListPending_Call {
+	return &FollowRequestService_ListPending_Call{Call: _e.mock.On("ListPending", ctx, username)}
+}
+
+func (_c *FollowRequestService_ListPending_Call) Run(run func(ctx context.Context, username string)) *FollowRequestService_ListPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FollowRequestService_ListPending_Call) Return(_a0 models.FollowerSlice, _a1 error) *FollowRequestService_ListPending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Reject provides a mock function with given fields: ctx, followID
+func (_m *FollowRequestService) Reject(ctx context.Context, followID string) error {
+	ret := _m.Called(ctx, followID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, followID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FollowRequestService_Reject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reject'
+type FollowRequestService_Reject_Call struct {
+	*mock.Call
+}
+
+// Reject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - followID string
+func (_e *FollowRequestService_Expecter) Reject(ctx interface{}, followID interface{}) *FollowRequestService_Reject_Call {
+	return &FollowRequestService_Reject_Call{Call: _e.mock.On("Reject", ctx, followID)}
+}
+
+func (_c *FollowRequestService_Reject_Call) Run(run func(ctx context.Context, followID string)) *FollowRequestService_Reject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FollowRequestService_Reject_Call) Return(_a0 error) *FollowRequestService_Reject_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+type mockConstructorTestingTNewFollowRequestService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFollowRequestService creates a new instance of FollowRequestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFollowRequestService(t mockConstructorTestingTNewFollowRequestService) *FollowRequestService {
+	mock := &FollowRequestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,85 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	url "net/url"
+)
+
+// MigrationService is an autogenerated mock type for the MigrationService type
+type MigrationService struct {
+	mock.Mock
+}
+
+type MigrationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MigrationService) EXPECT() *MigrationService_Expecter {
+	return &MigrationService_Expecter{mock: &_m.Mock}
+}
+
+// Migrate provides a mock function with given fields: ctx, username, target
+func (_m *MigrationService) Migrate(ctx context.Context, username string, target *url.URL) (int, error) {
+	ret := _m.Called(ctx, username, target)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string, *url.URL) int); ok {
+		r0 = rf(ctx, username, target)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *url.URL) error); ok {
+		r1 = rf(ctx, username, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrationService_Migrate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Migrate'
+type MigrationService_Migrate_Call struct {
+	*mock.Call
+}
+
+// Migrate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - target *url.URL
+func (_e *MigrationService_Expecter) Migrate(ctx interface{}, username interface{}, target interface{}) *MigrationService_Migrate_Call {
+	return &MigrationService_Migrate_Call{Call: _e.mock.On("Migrate", ctx, username, target)}
+}
+
+func (_c *MigrationService_Migrate_Call) Run(run func(ctx context.Context, username string, target *url.URL)) *MigrationService_Migrate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*url.URL))
+	})
+	return _c
+}
+
+func (_c *MigrationService_Migrate_Call) Return(_a0 int, _a1 error) *MigrationService_Migrate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewMigrationService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMigrationService creates a new instance of MigrationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMigrationService(t mockConstructorTestingTNewMigrationService) *MigrationService {
+	mock := &MigrationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
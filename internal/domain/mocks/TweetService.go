@@ -8,8 +8,6 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/estrys/estrys/internal/twitter/models"
-
-	twitter "github.com/g8rswimmer/go-twitter/v2"
 )
 
 // TweetService is an autogenerated mock type for the TweetService type
@@ -26,11 +24,11 @@ func (_m *TweetService) EXPECT() *TweetService_Expecter {
 }
 
 // SaveTweetAndReferences provides a mock function with given fields: _a0, _a1
-func (_m *TweetService) SaveTweetAndReferences(_a0 context.Context, _a1 *twitter.TweetObj) (*models.Tweet, error) {
+func (_m *TweetService) SaveTweetAndReferences(_a0 context.Context, _a1 string) (*models.Tweet, error) {
 	ret := _m.Called(_a0, _a1)
 
 	var r0 *models.Tweet
-	if rf, ok := ret.Get(0).(func(context.Context, *twitter.TweetObj) *models.Tweet); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Tweet); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
@@ -39,7 +37,7 @@ func (_m *TweetService) SaveTweetAndReferences(_a0 context.Context, _a1 *twitter
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *twitter.TweetObj) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -55,14 +53,14 @@ type TweetService_SaveTweetAndReferences_Call struct {
 
 // SaveTweetAndReferences is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *twitter.TweetObj
+//   - _a1 string
 func (_e *TweetService_Expecter) SaveTweetAndReferences(_a0 interface{}, _a1 interface{}) *TweetService_SaveTweetAndReferences_Call {
 	return &TweetService_SaveTweetAndReferences_Call{Call: _e.mock.On("SaveTweetAndReferences", _a0, _a1)}
 }
 
-func (_c *TweetService_SaveTweetAndReferences_Call) Run(run func(_a0 context.Context, _a1 *twitter.TweetObj)) *TweetService_SaveTweetAndReferences_Call {
+func (_c *TweetService_SaveTweetAndReferences_Call) Run(run func(_a0 context.Context, _a1 string)) *TweetService_SaveTweetAndReferences_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*twitter.TweetObj))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -0,0 +1,102 @@
+package profile
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/estrys/estrys/internal/activitypub"
+	"github.com/estrys/estrys/internal/activitypub/handlers"
+	"github.com/estrys/estrys/internal/config"
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/domain"
+	"github.com/estrys/estrys/internal/domain/profile/views"
+	internalerrors "github.com/estrys/estrys/internal/errors"
+	"github.com/estrys/estrys/internal/router/routes"
+	"github.com/estrys/estrys/internal/router/urlgenerator"
+	"github.com/estrys/estrys/internal/twitter/repository"
+)
+
+type profileTweet struct {
+	URL       string
+	Text      string
+	Published string
+	IsRetweet bool
+}
+
+func HandleProfile(responseWriter http.ResponseWriter, request *http.Request) error {
+	// Remote servers asking for the profile expect the actor
+	if activitypub.AcceptsActivityJSON(request) {
+		return handlers.HandleUser(responseWriter, request)
+	}
+
+	vars := mux.Vars(request)
+	conf := dic.GetService[config.Config]()
+	userService := dic.GetService[domain.UserService]()
+	tweetRepository := dic.GetService[repository.TweetRepository]()
+	urlGenerator := dic.GetService[urlgenerator.URLGenerator]()
+
+	user, err := userService.GetFullUser(request.Context(), vars["username"])
+	if err != nil {
+		return domain.UserHTTPError(err)
+	}
+	// The profile of a migrated user only points to its new account
+	if user.MovedTo != nil {
+		http.Redirect(responseWriter, request, user.MovedTo.String(), http.StatusMovedPermanently)
+		return nil
+	}
+
+	tweets, err := tweetRepository.GetTimeline(request.Context(), user.Username, repository.TimelineQuery{
+		Limit: conf.OutboxPageSize,
+	})
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+	profileTweets := make([]profileTweet, 0, len(tweets))
+	for _, tweet := range tweets {
+		statusURL, err := urlGenerator.URL(
+			routes.ProfileStatusRoute,
+			[]string{"username", user.Username, "id", tweet.ID},
+			urlgenerator.OptionAbsoluteURL,
+		)
+		if err != nil {
+			return internalerrors.Wrap(err, http.StatusInternalServerError).
+				WithUserMessage("unable to generate status URL")
+		}
+		text := tweet.Text
+		retweet := tweet.Retweet()
+		if retweet != nil {
+			text = retweet.Text
+		}
+		profileTweets = append(profileTweets, profileTweet{
+			URL:       statusURL.String(),
+			Text:      text,
+			Published: tweet.Published.String(),
+			IsRetweet: retweet != nil,
+		})
+	}
+
+	selfURL, err := urlGenerator.URL(
+		routes.ProfileRoute,
+		[]string{"username", user.Username},
+		urlgenerator.OptionAbsoluteURL,
+	)
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError).
+			WithUserMessage("unable to generate profile URL")
+	}
+
+	templateContent, _ := views.Views.ReadFile("profile.html")
+	profileTemplate := template.Must(template.New("profile").Parse(string(templateContent)))
+	responseWriter.Header().Add("content-type", "text/html")
+	err = profileTemplate.Execute(responseWriter, map[string]interface{}{
+		"url":    selfURL.String(),
+		"user":   user,
+		"tweets": profileTweets,
+	})
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package profile_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/domain"
+	"github.com/estrys/estrys/internal/domain/domainmodels"
+	"github.com/estrys/estrys/internal/domain/mocks"
+	"github.com/estrys/estrys/internal/domain/profile"
+	"github.com/estrys/estrys/internal/twitter/models"
+	"github.com/estrys/estrys/internal/twitter/repository"
+	mocks2 "github.com/estrys/estrys/internal/twitter/repository/mocks"
+	"github.com/estrys/estrys/tests"
+)
+
+type ProfileHandlerTestSuite struct {
+	suite.Suite
+	tests.HTTPTestSuite
+}
+
+func TestProfileHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ProfileHandlerTestSuite))
+}
+
+func (suite *ProfileHandlerTestSuite) TestHandleProfile() {
+	fakeProfileImage, _ := url.Parse("https://example.com/image.png")
+	fakeDate, _ := time.Parse(time.RFC3339, "2006-01-02T15:04:05Z")
+	fakeUser := &domainmodels.User{
+		Name:            "Foo Bar",
+		Username:        "foobar",
+		Description:     "foobar description",
+		ProfileImageURL: fakeProfileImage,
+		CreatedAt:       fakeDate,
+	}
+	fakeTweets := []models.Tweet{
+		{
+			ID:             "1234",
+			AuthorUsername: "foobar",
+			Text:           "This is a fake tweet content",
+			Published:      fakeDate,
+		},
+		{
+			ID:             "1233",
+			AuthorUsername: "foobar",
+			Text:           "RT @someone: Retweeted content",
+			Published:      fakeDate,
+			ReferencedTweets: []models.Tweet{
+				{
+					ID:             "42",
+					AuthorUsername: "someone",
+					ReferencedType: models.ReferenceTypeRetweet,
+					Text:           "Retweeted content",
+					Published:      fakeDate,
+				},
+			},
+		},
+	}
+
+	unsafeProfileImage, _ := url.Parse("javascript:alert('image')")
+	fakeUnsafeUser := *fakeUser
+	fakeUnsafeUser.Name = "Foo <b>Bar</b>"
+	fakeUnsafeUser.Description = "<script>alert('bio')</script>"
+	fakeUnsafeUser.ProfileImageURL = unsafeProfileImage
+
+	movedTo, _ := url.Parse("https://another-instance.example.com/@foobar")
+	fakeMovedUser := *fakeUser
+	fakeMovedUser.MovedTo = movedTo
+
+	cases := []tests.HTTPTestCase{
+		{
+			Name: "user not found",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUser.Username}},
+			},
+			Mock: func(t *testing.T) {
+				fakeUserService := mocks.NewUserService(t)
+				fakeUserService.On("GetFullUser", mock.Anything, fakeUser.Username).Return(
+					nil, errors.WithStack(domain.ErrUserDoesNotExist),
+				)
+				_ = dic.Register[domain.UserService](fakeUserService)
+			},
+			StatusCode: http.StatusNotFound,
+			GoldenFile: "errors/user_not_found.json",
+		},
+		{
+			Name: "ok",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUser.Username}},
+			},
+			Mock: func(t *testing.T) {
+				fakeUserService := mocks.NewUserService(t)
+				fakeUserService.On("GetFullUser", mock.Anything, fakeUser.Username).Return(
+					fakeUser, nil,
+				)
+				_ = dic.Register[domain.UserService](fakeUserService)
+
+				fakeTweetRepo := mocks2.NewTweetRepository(t)
+				fakeTweetRepo.On("GetTimeline", mock.Anything, fakeUser.Username, repository.TimelineQuery{
+					Limit: 20,
+				}).Return(fakeTweets, nil)
+				_ = dic.Register[repository.TweetRepository](fakeTweetRepo)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "profile.html",
+		},
+		{
+			Name: "moved user",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUser.Username}},
+			},
+			Mock: func(t *testing.T) {
+				fakeUserService := mocks.NewUserService(t)
+				fakeUserService.On("GetFullUser", mock.Anything, fakeUser.Username).Return(
+					&fakeMovedUser, nil,
+				)
+				_ = dic.Register[domain.UserService](fakeUserService)
+			},
+			StatusCode: http.StatusMovedPermanently,
+		},
+		{
+			Name: "markup is escaped",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUser.Username}},
+			},
+			Mock: func(t *testing.T) {
+				fakeUserService := mocks.NewUserService(t)
+				fakeUserService.On("GetFullUser", mock.Anything, fakeUser.Username).Return(
+					&fakeUnsafeUser, nil,
+				)
+				_ = dic.Register[domain.UserService](fakeUserService)
+
+				fakeTweetRepo := mocks2.NewTweetRepository(t)
+				fakeTweetRepo.On("GetTimeline", mock.Anything, fakeUser.Username, repository.TimelineQuery{
+					Limit: 20,
+				}).Return([]models.Tweet{
+					{
+						ID:             "1234",
+						AuthorUsername: "foobar",
+						Text:           "<img src=x onerror=alert('tweet')>",
+						Published:      fakeDate,
+					},
+				}, nil)
+				_ = dic.Register[repository.TweetRepository](fakeTweetRepo)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "profile_escaped.html",
+		},
+		{
+			Name: "actor_ok",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUser.Username}},
+				tests.RequestHeaders{Headers: http.Header{"Accept": []string{"application/activity+json"}}},
+			},
+			Mock: func(t *testing.T) {
+				fakeUserService := mocks.NewUserService(t)
+				fakeUserService.On("GetFullUser", mock.Anything, fakeUser.Username).Return(
+					fakeUser, nil,
+				)
+				_ = dic.Register[domain.UserService](fakeUserService)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "actor.json",
+		},
+	}
+
+	suite.RunHTTPCases(suite.T(), profile.HandleProfile, cases)
+}
@@ -0,0 +1,18 @@
+package profile
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/estrys/estrys/internal/activitypub/auth"
+	"github.com/estrys/estrys/internal/errors"
+	"github.com/estrys/estrys/internal/router/routes"
+)
+
+func ProfileRouter(rootRouter *mux.Router) {
+	rootRouter.NewRoute().Name(routes.ProfileRoute).
+		Path("/@{username}").
+		Methods(http.MethodGet).
+		Handler(auth.AuthorizedFetchMiddleware(http.HandlerFunc(errors.HTTPErrorHandler(HandleProfile))))
+}
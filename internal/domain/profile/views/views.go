@@ -0,0 +1,6 @@
+package views
+
+import "embed"
+
+//go:embed *.html
+var Views embed.FS
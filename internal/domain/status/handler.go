@@ -1,49 +1,82 @@
 package status
 
 import (
+	"encoding/json"
+	"html/template"
 	"net/http"
-	"text/template"
 
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
 	"github.com/gorilla/mux"
 
+	"github.com/estrys/estrys/internal/activitypub"
+	"github.com/estrys/estrys/internal/activitypub/auth"
 	"github.com/estrys/estrys/internal/dic"
 	"github.com/estrys/estrys/internal/domain"
 	"github.com/estrys/estrys/internal/domain/status/views"
 	internalerrors "github.com/estrys/estrys/internal/errors"
 	"github.com/estrys/estrys/internal/router/routes"
 	"github.com/estrys/estrys/internal/router/urlgenerator"
+	twittermodels "github.com/estrys/estrys/internal/twitter/models"
 	"github.com/estrys/estrys/internal/twitter/repository"
 )
 
-func HandleStatus(responseWriter http.ResponseWriter, request *http.Request) error {
+// getTweet returns the tweet of the status requested, which must be authored by the user of the route.
+func getTweet(request *http.Request) (*twittermodels.Tweet, error) {
 	vars := mux.Vars(request)
 	tweetRepository := dic.GetService[repository.TweetRepository]()
-	userService := dic.GetService[domain.UserService]()
-	urlGenerator := dic.GetService[urlgenerator.URLGenerator]()
 
 	username := vars["username"]
 	tweetID := vars["id"]
 	if username == "" || tweetID == "" {
-		return internalerrors.New("either username or id is not set", http.StatusBadRequest)
+		return nil, internalerrors.New("either username or id is not set", http.StatusBadRequest)
 	}
 
 	tweet, err := tweetRepository.GetTweet(request.Context(), tweetID)
 	if err != nil {
-		return internalerrors.Wrap(err, http.StatusNotFound).
+		return nil, internalerrors.Wrap(err, http.StatusNotFound).
+			WithUserMessage("tweet not found")
+	}
+	if tweet == nil {
+		return nil, internalerrors.New("tweet not found", http.StatusNotFound).
 			WithUserMessage("tweet not found")
 	}
 
 	if !tweet.IsAuthoredBy(username) {
-		return internalerrors.Wrap(err, http.StatusBadRequest).
+		return nil, internalerrors.Wrap(err, http.StatusBadRequest).
 			WithContext("username", username).
 			WithContext("tweet_author_username", tweet.AuthorUsername).
 			WithUserMessage("tweet not found for this user")
 	}
 
+	err = dic.GetService[domain.ConsentService]().CheckUser(request.Context(), tweet.AuthorUsername)
+	if err != nil {
+		return nil, domain.UserHTTPError(err)
+	}
+	return tweet, nil
+}
+
+func HandleStatus(responseWriter http.ResponseWriter, request *http.Request) error {
+	userService := dic.GetService[domain.UserService]()
+	urlGenerator := dic.GetService[urlgenerator.URLGenerator]()
+
+	tweet, err := getTweet(request)
+	if err != nil {
+		return err
+	}
+	tweetID := tweet.ID
+
+	if activitypub.AcceptsActivityJSON(request) {
+		err = auth.CheckAuthorizedFetch(request)
+		if err != nil {
+			return err
+		}
+		return writeActivity(responseWriter, tweet)
+	}
+
 	user, err := userService.GetFullUser(request.Context(), tweet.AuthorUsername)
 	if err != nil {
-		return internalerrors.Wrap(err, http.StatusNotFound).
-			WithUserMessage("user not found")
+		return domain.UserHTTPError(err)
 	}
 
 	templateContent, _ := views.Views.ReadFile("status.html")
@@ -60,11 +93,17 @@ func HandleStatus(responseWriter http.ResponseWriter, request *http.Request) err
 			WithUserMessage("unable to generate status URL")
 	}
 
+	interactions, err := getInteractions(request.Context(), tweetID)
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+
 	templateData := map[string]interface{}{
-		"isRetweet": false,
-		"url":       selfURL.String(),
-		"tweet":     tweet,
-		"user":      user,
+		"isRetweet":    false,
+		"url":          selfURL.String(),
+		"tweet":        tweet,
+		"user":         user,
+		"interactions": interactions,
 	}
 	// If we have a retweet, then fetch the retweet author and replace the current tweet by the retweet
 	if retweet := tweet.Retweet(); retweet != nil {
@@ -82,3 +121,30 @@ func HandleStatus(responseWriter http.ResponseWriter, request *http.Request) err
 	}
 	return nil
 }
+
+// writeActivity serves the Note of a tweet, or the Announce when the tweet is a retweet.
+func writeActivity(responseWriter http.ResponseWriter, tweet *twittermodels.Tweet) error {
+	vocabService := dic.GetService[activitypub.VocabService]()
+
+	var activity vocab.Type
+	var err error
+	if tweet.Retweet() != nil {
+		activity, err = vocabService.GetAnnounceFromTweet(tweet.AuthorUsername, *tweet)
+	} else {
+		activity, err = vocabService.GetNoteFromTweet(tweet.AuthorUsername, *tweet)
+	}
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+	serializedActivity, err := streams.Serialize(activity)
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+
+	responseWriter.Header().Add("content-type", "application/activity+json")
+	err = json.NewEncoder(responseWriter).Encode(serializedActivity)
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+	return nil
+}
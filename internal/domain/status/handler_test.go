@@ -7,14 +7,19 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"github.com/volatiletech/null/v8"
 
 	"github.com/estrys/estrys/internal/dic"
 	"github.com/estrys/estrys/internal/domain"
 	"github.com/estrys/estrys/internal/domain/domainmodels"
 	"github.com/estrys/estrys/internal/domain/mocks"
 	"github.com/estrys/estrys/internal/domain/status"
+	internalmodels "github.com/estrys/estrys/internal/models"
+	internalrepository "github.com/estrys/estrys/internal/repository"
+	repositorymocks "github.com/estrys/estrys/internal/repository/mocks"
 	"github.com/estrys/estrys/internal/twitter/models"
 	"github.com/estrys/estrys/internal/twitter/repository"
 	mocks2 "github.com/estrys/estrys/internal/twitter/repository/mocks"
@@ -47,6 +52,26 @@ func (suite *StatusHandlerTestSuite) TestHandleStatus() {
 		Sensitive:      false,
 	}
 
+	fakePhoto, _ := url.Parse("https://pbs.twimg.com/media/photo.jpg")
+	fakeUnprocessedPhoto, _ := url.Parse("https://pbs.twimg.com/media/unprocessed.jpg")
+	fakeMediaTweet := *fakeTweet
+	fakeMediaTweet.Medias = []models.TweetMedia{
+		{
+			Type:        models.MediaTypePhoto,
+			URL:         fakePhoto,
+			ContentType: "image/jpeg",
+			Width:       300,
+			Height:      200,
+			Blurhash:    "LEHV6nWB2yk8pyo0adR*.7kCMdnj",
+			FocalPoint:  [2]float64{-0.5, 0.25},
+		},
+		// The image could not be processed, no placeholder is sent
+		{
+			Type: models.MediaTypePhoto,
+			URL:  fakeUnprocessedPhoto,
+		},
+	}
+
 	fakeRetweetedUser := &domainmodels.User{
 		Name:            "Foo Bar",
 		Username:        "fakeRTUser",
@@ -67,6 +92,19 @@ func (suite *StatusHandlerTestSuite) TestHandleStatus() {
 		},
 	}
 
+	fakeReply := &internalmodels.Interaction{
+		Type:        string(internalrepository.InteractionTypeReply),
+		ObjectID:    null.StringFrom("https://another-instance.example.com/users/validactor/statuses/1"),
+		ObjectURL:   null.StringFrom("https://another-instance.example.com/@validactor/1"),
+		Content:     null.StringFrom("@foobar <script>alert('reply')</script>"),
+		PublishedAt: null.TimeFrom(fakeDate),
+	}
+	fakeReply.R = fakeReply.R.NewStruct()
+	fakeReply.R.InteractionActor = &internalmodels.Actor{
+		URL:               "https://another-instance.example.com/users/validactor",
+		PreferredUsername: null.StringFrom("validactor"),
+	}
+
 	cases := []tests.HTTPTestCase{
 		{
 			Name: "missing tweet id",
@@ -123,6 +161,7 @@ func (suite *StatusHandlerTestSuite) TestHandleStatus() {
 					fakeTweet, nil,
 				)
 				_ = dic.Register[repository.TweetRepository](fakeTweetRepo)
+				registerConsent(t, fakeUser.Username, nil)
 
 				fakeUserService := mocks.NewUserService(t)
 				fakeUserService.On("GetFullUser", mock.Anything, fakeUser.Username).Return(
@@ -133,6 +172,39 @@ func (suite *StatusHandlerTestSuite) TestHandleStatus() {
 			StatusCode: http.StatusNotFound,
 			GoldenFile: "errors/user_not_found.json",
 		},
+		{
+			Name: "user opted out",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUser.Username, "id": fakeTweet.ID}},
+			},
+			Mock: func(t *testing.T) {
+				fakeTweetRepo := mocks2.NewTweetRepository(t)
+				fakeTweetRepo.On("GetTweet", mock.Anything, fakeTweet.ID).Return(
+					fakeTweet, nil,
+				)
+				_ = dic.Register[repository.TweetRepository](fakeTweetRepo)
+				registerConsent(t, fakeUser.Username, errors.WithStack(domain.ErrUserOptedOut))
+			},
+			StatusCode: http.StatusGone,
+			GoldenFile: "errors/user_opted_out.json",
+		},
+		{
+			Name: "note of a user opted out",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUser.Username, "id": fakeTweet.ID}},
+				tests.RequestHeaders{Headers: http.Header{"Accept": []string{"application/activity+json"}}},
+			},
+			Mock: func(t *testing.T) {
+				fakeTweetRepo := mocks2.NewTweetRepository(t)
+				fakeTweetRepo.On("GetTweet", mock.Anything, fakeTweet.ID).Return(
+					fakeTweet, nil,
+				)
+				_ = dic.Register[repository.TweetRepository](fakeTweetRepo)
+				registerConsent(t, fakeUser.Username, errors.WithStack(domain.ErrUserOptedOut))
+			},
+			StatusCode: http.StatusGone,
+			GoldenFile: "errors/user_opted_out.json",
+		},
 		{
 			Name: "ok",
 			RequestOptions: []tests.RequestOption{
@@ -150,6 +222,17 @@ func (suite *StatusHandlerTestSuite) TestHandleStatus() {
 					fakeTweet, nil,
 				)
 				_ = dic.Register[repository.TweetRepository](fakeTweetRepo)
+				registerConsent(t, fakeUser.Username, nil)
+
+				fakeInteractionRepo := repositorymocks.NewInteractionRepository(t)
+				fakeInteractionRepo.EXPECT().Count(mock.Anything, fakeTweet.ID, internalrepository.InteractionTypeLike).
+					Return(2, nil)
+				fakeInteractionRepo.EXPECT().Count(mock.Anything, fakeTweet.ID, internalrepository.InteractionTypeShare).
+					Return(1, nil)
+				fakeInteractionRepo.EXPECT().ListReplies(mock.Anything, fakeTweet.ID).Return(
+					internalmodels.InteractionSlice{fakeReply}, nil,
+				)
+				_ = dic.Register[internalrepository.InteractionRepository](fakeInteractionRepo)
 			},
 			StatusCode: http.StatusOK,
 			GoldenFile: "status.html",
@@ -175,10 +258,100 @@ func (suite *StatusHandlerTestSuite) TestHandleStatus() {
 
 				_ = dic.Register[domain.UserService](fakeUserService)
 				_ = dic.Register[repository.TweetRepository](fakeTweetRepo)
+				registerConsent(t, fakeRetweetedUser.Username, nil)
+
+				fakeInteractionRepo := repositorymocks.NewInteractionRepository(t)
+				fakeInteractionRepo.EXPECT().Count(mock.Anything, fakeRetweet.ID, mock.Anything).Return(0, nil)
+				fakeInteractionRepo.EXPECT().ListReplies(mock.Anything, fakeRetweet.ID).Return(nil, nil)
+				_ = dic.Register[internalrepository.InteractionRepository](fakeInteractionRepo)
 			},
 			StatusCode: http.StatusOK,
 			GoldenFile: "status_retweet.html",
 		},
+		{
+			Name: "tweet expired",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUser.Username, "id": fakeTweet.ID}},
+			},
+			Mock: func(t *testing.T) {
+				fakeTweetRepo := mocks2.NewTweetRepository(t)
+				fakeTweetRepo.On("GetTweet", mock.Anything, fakeTweet.ID).Return(nil, nil)
+				_ = dic.Register[repository.TweetRepository](fakeTweetRepo)
+			},
+			StatusCode: http.StatusNotFound,
+			GoldenFile: "errors/tweet_not_found.json",
+		},
+		{
+			Name: "note_ok",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUser.Username, "id": fakeTweet.ID}},
+				tests.RequestHeaders{Headers: http.Header{"Accept": []string{"application/activity+json"}}},
+			},
+			Mock: func(t *testing.T) {
+				fakeTweetRepo := mocks2.NewTweetRepository(t)
+				fakeTweetRepo.On("GetTweet", mock.Anything, fakeTweet.ID).Return(
+					fakeTweet, nil,
+				)
+				_ = dic.Register[repository.TweetRepository](fakeTweetRepo)
+				registerConsent(t, fakeUser.Username, nil)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "note.json",
+		},
+		{
+			Name: "note_with_media",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUser.Username, "id": fakeTweet.ID}},
+				tests.RequestHeaders{Headers: http.Header{"Accept": []string{"application/activity+json"}}},
+			},
+			Mock: func(t *testing.T) {
+				fakeTweetRepo := mocks2.NewTweetRepository(t)
+				fakeTweetRepo.On("GetTweet", mock.Anything, fakeTweet.ID).Return(
+					&fakeMediaTweet, nil,
+				)
+				_ = dic.Register[repository.TweetRepository](fakeTweetRepo)
+				registerConsent(t, fakeUser.Username, nil)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "note_media.json",
+		},
+		{
+			Name: "retweet_announce_ok",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeRetweetedUser.Username, "id": fakeRetweet.ID}},
+				tests.RequestHeaders{Headers: http.Header{"Accept": []string{
+					`application/ld+json; profile="https://www.w3.org/ns/activitystreams", text/html;q=0.1`,
+				}}},
+			},
+			Mock: func(t *testing.T) {
+				fakeTweetRepo := mocks2.NewTweetRepository(t)
+				fakeTweetRepo.On("GetTweet", mock.Anything, fakeRetweet.ID).Return(
+					fakeRetweet, nil,
+				)
+				_ = dic.Register[repository.TweetRepository](fakeTweetRepo)
+				registerConsent(t, fakeRetweetedUser.Username, nil)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "announce.json",
+		},
+		{
+			Name: "note_authorized_fetch_unsigned",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": fakeUser.Username, "id": fakeTweet.ID}},
+				tests.RequestHeaders{Headers: http.Header{"Accept": []string{"application/activity+json"}}},
+			},
+			Mock: func(t *testing.T) {
+				viper.Set("authorized_fetch", true)
+				t.Cleanup(func() { viper.Set("authorized_fetch", false) })
+				fakeTweetRepo := mocks2.NewTweetRepository(t)
+				fakeTweetRepo.On("GetTweet", mock.Anything, fakeTweet.ID).Return(
+					fakeTweet, nil,
+				)
+				_ = dic.Register[repository.TweetRepository](fakeTweetRepo)
+				registerConsent(t, fakeUser.Username, nil)
+			},
+			StatusCode: http.StatusUnauthorized,
+		},
 	}
 
 	suite.RunHTTPCases(suite.T(), status.HandleStatus, cases)
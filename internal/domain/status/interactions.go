@@ -0,0 +1,153 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/estrys/estrys/internal/activitypub"
+	"github.com/estrys/estrys/internal/activitypub/auth"
+	"github.com/estrys/estrys/internal/dic"
+	internalerrors "github.com/estrys/estrys/internal/errors"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	"github.com/estrys/estrys/internal/router/routes"
+)
+
+type reply struct {
+	Author    string
+	AuthorURL string
+	URL       string
+	Content   string
+	Published time.Time
+}
+
+// interactions are what fediverse users did with a tweet, shown on the status page.
+type interactions struct {
+	Likes   int64
+	Shares  int64
+	Replies []reply
+}
+
+func replyAuthor(actor *models.Actor) string {
+	actorURL, err := url.Parse(actor.URL)
+	if err != nil || !actor.PreferredUsername.Valid {
+		return actor.URL
+	}
+	return fmt.Sprintf("@%s@%s", actor.PreferredUsername.String, actorURL.Host)
+}
+
+func getInteractions(ctx context.Context, tweetID string) (*interactions, error) {
+	interactionRepo := dic.GetService[repository.InteractionRepository]()
+
+	likes, err := interactionRepo.Count(ctx, tweetID, repository.InteractionTypeLike)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	shares, err := interactionRepo.Count(ctx, tweetID, repository.InteractionTypeShare)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	replies, err := interactionRepo.ListReplies(ctx, tweetID)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	result := &interactions{
+		Likes:   likes,
+		Shares:  shares,
+		Replies: make([]reply, 0, len(replies)),
+	}
+	for _, interaction := range replies {
+		item := reply{
+			URL:       interaction.ObjectURL.String,
+			Content:   interaction.Content.String,
+			Published: interaction.PublishedAt.Time,
+		}
+		if item.URL == "" {
+			item.URL = interaction.ObjectID.String
+		}
+		if interaction.R != nil && interaction.R.InteractionActor != nil {
+			item.Author = replyAuthor(interaction.R.InteractionActor)
+			item.AuthorURL = interaction.R.InteractionActor.URL
+		}
+		result.Replies = append(result.Replies, item)
+	}
+	return result, nil
+}
+
+// HandleReplies serves the fediverse replies to a status.
+func HandleReplies(responseWriter http.ResponseWriter, request *http.Request) error {
+	return handleCollection(responseWriter, request, routes.StatusRepliesRoute)
+}
+
+// HandleLikes serves the likes count of a status.
+func HandleLikes(responseWriter http.ResponseWriter, request *http.Request) error {
+	return handleCollection(responseWriter, request, routes.StatusLikesRoute)
+}
+
+// HandleShares serves the boosts count of a status.
+func HandleShares(responseWriter http.ResponseWriter, request *http.Request) error {
+	return handleCollection(responseWriter, request, routes.StatusSharesRoute)
+}
+
+func handleCollection(responseWriter http.ResponseWriter, request *http.Request, route string) error {
+	err := auth.CheckAuthorizedFetch(request)
+	if err != nil {
+		return err
+	}
+	tweet, err := getTweet(request)
+	if err != nil {
+		return err
+	}
+
+	interactionRepo := dic.GetService[repository.InteractionRepository]()
+	vocabService := dic.GetService[activitypub.VocabService]()
+
+	var totalItems int64
+	var items []*url.URL
+	switch route {
+	case routes.StatusRepliesRoute:
+		replies, err := interactionRepo.ListReplies(request.Context(), tweet.ID)
+		if err != nil {
+			return internalerrors.Wrap(err, http.StatusInternalServerError)
+		}
+		items = make([]*url.URL, 0, len(replies))
+		for _, interaction := range replies {
+			replyURL, err := url.Parse(interaction.ObjectID.String)
+			if err != nil {
+				continue
+			}
+			items = append(items, replyURL)
+		}
+		totalItems = int64(len(items))
+	case routes.StatusLikesRoute:
+		totalItems, err = interactionRepo.Count(request.Context(), tweet.ID, repository.InteractionTypeLike)
+	case routes.StatusSharesRoute:
+		totalItems, err = interactionRepo.Count(request.Context(), tweet.ID, repository.InteractionTypeShare)
+	}
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+
+	collection, err := vocabService.GetStatusCollection(
+		route,
+		tweet.AuthorUsername,
+		tweet.ID,
+		int(totalItems),
+		items,
+	)
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+
+	responseWriter.Header().Add("content-type", "application/activity+json")
+	err = json.NewEncoder(responseWriter).Encode(collection)
+	if err != nil {
+		return internalerrors.Wrap(err, http.StatusInternalServerError)
+	}
+	return nil
+}
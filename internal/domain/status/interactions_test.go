@@ -0,0 +1,114 @@
+package status_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/domain"
+	domainmocks "github.com/estrys/estrys/internal/domain/mocks"
+	"github.com/estrys/estrys/internal/domain/status"
+	internalmodels "github.com/estrys/estrys/internal/models"
+	internalrepository "github.com/estrys/estrys/internal/repository"
+	repositorymocks "github.com/estrys/estrys/internal/repository/mocks"
+	"github.com/estrys/estrys/internal/twitter/models"
+	"github.com/estrys/estrys/internal/twitter/repository"
+	mocks2 "github.com/estrys/estrys/internal/twitter/repository/mocks"
+	"github.com/estrys/estrys/tests"
+)
+
+// registerConsent makes the consent check of the author of the tweet return err.
+func registerConsent(t *testing.T, username string, err error) {
+	t.Helper()
+	fakeConsentService := domainmocks.NewConsentService(t)
+	fakeConsentService.EXPECT().CheckUser(mock.Anything, username).Return(err)
+	_ = dic.Register[domain.ConsentService](fakeConsentService)
+}
+
+func registerFakeTweet(t *testing.T) *models.Tweet {
+	t.Helper()
+	fakeTweet := &models.Tweet{
+		ID:             "1234",
+		AuthorUsername: "foobar",
+		Text:           "This is a fake tweet content",
+	}
+	fakeTweetRepo := mocks2.NewTweetRepository(t)
+	fakeTweetRepo.On("GetTweet", mock.Anything, fakeTweet.ID).Return(fakeTweet, nil)
+	_ = dic.Register[repository.TweetRepository](fakeTweetRepo)
+	registerConsent(t, fakeTweet.AuthorUsername, nil)
+	return fakeTweet
+}
+
+func (suite *StatusHandlerTestSuite) TestHandleReplies() {
+	cases := []tests.HTTPTestCase{
+		{
+			Name: "ok",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": "foobar", "id": "1234"}},
+			},
+			Mock: func(t *testing.T) {
+				fakeTweet := registerFakeTweet(t)
+				fakeInteractionRepo := repositorymocks.NewInteractionRepository(t)
+				fakeInteractionRepo.EXPECT().ListReplies(mock.Anything, fakeTweet.ID).Return(
+					internalmodels.InteractionSlice{
+						{ObjectID: null.StringFrom("https://another-instance.example.com/users/validactor/statuses/1")},
+					}, nil,
+				)
+				_ = dic.Register[internalrepository.InteractionRepository](fakeInteractionRepo)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "replies.json",
+		},
+	}
+
+	suite.RunHTTPCases(suite.T(), status.HandleReplies, cases)
+}
+
+func (suite *StatusHandlerTestSuite) TestHandleLikes() {
+	cases := []tests.HTTPTestCase{
+		{
+			Name: "ok",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": "foobar", "id": "1234"}},
+			},
+			Mock: func(t *testing.T) {
+				fakeTweet := registerFakeTweet(t)
+				fakeInteractionRepo := repositorymocks.NewInteractionRepository(t)
+				fakeInteractionRepo.EXPECT().
+					Count(mock.Anything, fakeTweet.ID, internalrepository.InteractionTypeLike).
+					Return(3, nil)
+				_ = dic.Register[internalrepository.InteractionRepository](fakeInteractionRepo)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "likes.json",
+		},
+	}
+
+	suite.RunHTTPCases(suite.T(), status.HandleLikes, cases)
+}
+
+func (suite *StatusHandlerTestSuite) TestHandleShares() {
+	cases := []tests.HTTPTestCase{
+		{
+			Name: "ok",
+			RequestOptions: []tests.RequestOption{
+				tests.RequestParams{Params: map[string]string{"username": "foobar", "id": "1234"}},
+			},
+			Mock: func(t *testing.T) {
+				fakeTweet := registerFakeTweet(t)
+				fakeInteractionRepo := repositorymocks.NewInteractionRepository(t)
+				fakeInteractionRepo.EXPECT().
+					Count(mock.Anything, fakeTweet.ID, internalrepository.InteractionTypeShare).
+					Return(1, nil)
+				_ = dic.Register[internalrepository.InteractionRepository](fakeInteractionRepo)
+			},
+			StatusCode: http.StatusOK,
+			GoldenFile: "shares.json",
+		},
+	}
+
+	suite.RunHTTPCases(suite.T(), status.HandleShares, cases)
+}
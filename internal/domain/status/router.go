@@ -5,6 +5,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/estrys/estrys/internal/activitypub/auth"
 	"github.com/estrys/estrys/internal/errors"
 	"github.com/estrys/estrys/internal/router/routes"
 )
@@ -14,5 +15,23 @@ func StatusRouter(rootRouter *mux.Router) {
 	userRouter.NewRoute().Name(routes.StatusRoute).
 		Path("/{username}/{id}").
 		Methods(http.MethodGet).
-		HandlerFunc(errors.HTTPErrorHandler(HandleStatus))
+		Handler(auth.AuthorizedFetchMiddleware(http.HandlerFunc(errors.HTTPErrorHandler(HandleStatus))))
+	userRouter.NewRoute().Name(routes.StatusRepliesRoute).
+		Path("/{username}/{id}/replies").
+		Methods(http.MethodGet).
+		Handler(auth.AuthorizedFetchMiddleware(http.HandlerFunc(errors.HTTPErrorHandler(HandleReplies))))
+	userRouter.NewRoute().Name(routes.StatusLikesRoute).
+		Path("/{username}/{id}/likes").
+		Methods(http.MethodGet).
+		Handler(auth.AuthorizedFetchMiddleware(http.HandlerFunc(errors.HTTPErrorHandler(HandleLikes))))
+	userRouter.NewRoute().Name(routes.StatusSharesRoute).
+		Path("/{username}/{id}/shares").
+		Methods(http.MethodGet).
+		Handler(auth.AuthorizedFetchMiddleware(http.HandlerFunc(errors.HTTPErrorHandler(HandleShares))))
+
+	// Same as above, with the mastodon style URL shared with users
+	rootRouter.NewRoute().Name(routes.ProfileStatusRoute).
+		Path("/@{username}/{id}").
+		Methods(http.MethodGet).
+		Handler(auth.AuthorizedFetchMiddleware(http.HandlerFunc(errors.HTTPErrorHandler(HandleStatus))))
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/estrys/estrys/internal/logger"
+	"github.com/estrys/estrys/internal/media"
 	"github.com/estrys/estrys/internal/observability"
 	"github.com/estrys/estrys/internal/twitter"
 	twittermodels "github.com/estrys/estrys/internal/twitter/models"
@@ -24,10 +25,11 @@ type TweetService interface {
 }
 
 type tweetService struct {
-	logger        logger.Logger
-	userService   UserService
-	tweeterClient twitter.TwitterClient
-	tweetRepo     repository.TweetRepository
+	logger         logger.Logger
+	userService    UserService
+	tweeterClient  twitter.TwitterClient
+	tweetRepo      repository.TweetRepository
+	imageProcessor media.ImageProcessor
 }
 
 func NewTweetService(
@@ -35,12 +37,14 @@ func NewTweetService(
 	userService UserService,
 	tweeterClient twitter.TwitterClient,
 	tweetRepo repository.TweetRepository,
+	imageProcessor media.ImageProcessor,
 ) *tweetService {
 	return &tweetService{
-		logger:        logger,
-		userService:   userService,
-		tweeterClient: tweeterClient,
-		tweetRepo:     tweetRepo,
+		logger:         logger,
+		userService:    userService,
+		tweeterClient:  tweeterClient,
+		tweetRepo:      tweetRepo,
+		imageProcessor: imageProcessor,
 	}
 }
 
@@ -96,6 +100,31 @@ func (t *tweetService) convertTweet(
 	return tweetModel, nil
 }
 
+// processMedias fetch and decode each photo of the tweet once, to compute
+// what fediverse clients need to display it nicely before it is loaded.
+// Failing to process an image is not fatal, the media is kept as is.
+func (t *tweetService) processMedias(ctx context.Context, tweet *twittermodels.Tweet) {
+	for i := range tweet.Medias {
+		media := &tweet.Medias[i]
+		if media.Type != twittermodels.MediaTypePhoto || media.URL == nil {
+			continue
+		}
+		info, err := t.imageProcessor.Process(ctx, media.URL)
+		if err != nil {
+			t.logger.WithError(err).
+				WithField("id", tweet.ID).
+				WithField("media", media.URL.String()).
+				Warn("unable to process tweet media")
+			continue
+		}
+		media.ContentType = info.MediaType
+		media.Width = info.Width
+		media.Height = info.Height
+		media.Blurhash = info.Blurhash
+		media.FocalPoint = info.FocalPoint
+	}
+}
+
 func (t *tweetService) fetchReferencedTweets(
 	ctx context.Context,
 	rawTweet *gotwitter.TweetObj,
@@ -133,6 +162,7 @@ func (t *tweetService) fetchReferencedTweets(
 		if err != nil {
 			return nil, err
 		}
+		t.processMedias(ctx, referencedTweet)
 		result = append(result, referencedTweet)
 		t.logger.WithField("id", referencedTweet.ID).Debug("saved referenced tweet")
 	}
@@ -222,6 +252,7 @@ func (t *tweetService) SaveTweetAndReferences(
 	if err != nil {
 		return nil, err
 	}
+	t.processMedias(ctx, tweet)
 
 	if len(rawTweet.Tweets[0].ReferencedTweets) > 0 {
 		referencedTweets, err := t.fetchReferencedTweets(ctx, rawTweet.Tweets[0])
@@ -246,6 +277,10 @@ func (t *tweetService) SaveTweetAndReferences(
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to save tweet")
 	}
+	err = t.tweetRepo.AddToTimeline(ctx, tweet)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to add tweet to author timeline")
+	}
 
 	if span != nil {
 		span.Status = sentry.SpanStatusOK
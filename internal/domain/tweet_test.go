@@ -13,6 +13,8 @@ import (
 
 	"github.com/estrys/estrys/internal/domain/mocks"
 	loggermock "github.com/estrys/estrys/internal/logger/mocks"
+	"github.com/estrys/estrys/internal/media"
+	mediamocks "github.com/estrys/estrys/internal/media/mocks"
 	"github.com/estrys/estrys/internal/models"
 	mockstwitter "github.com/estrys/estrys/internal/twitter/mocks"
 	twittermodels "github.com/estrys/estrys/internal/twitter/models"
@@ -116,12 +118,12 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 		name    string
 		tweetID string
 		output  *twittermodels.Tweet
-		mocks   func(*mocks.UserService, *mockstwitter.TwitterClient, *mockstwitterrepo.TweetRepository)
+		mocks   func(*mocks.UserService, *mockstwitter.TwitterClient, *mockstwitterrepo.TweetRepository, *mediamocks.ImageProcessor)
 		err     string
 	}{
 		{
 			name: "tweet already known",
-			mocks: func(_ *mocks.UserService, _ *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository) {
+			mocks: func(_ *mocks.UserService, _ *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository, _ *mediamocks.ImageProcessor) {
 				repository.EXPECT().GetTweet(mock.Anything, "1234").Return(&twittermodels.Tweet{
 					ID: "1234",
 				}, nil)
@@ -133,7 +135,7 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 		},
 		{
 			name: "error fetching tweet",
-			mocks: func(_ *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository) {
+			mocks: func(_ *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository, _ *mediamocks.ImageProcessor) {
 				repository.EXPECT().GetTweet(mock.Anything, mock.Anything).Return(nil, nil)
 				client.EXPECT().GetTweets(mock.Anything, []string{"1234"}, expectedTweetLookupOpts).
 					Return(nil, errors.New("tweet lookup error"))
@@ -143,7 +145,7 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 		},
 		{
 			name: "no tweets returned",
-			mocks: func(_ *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository) {
+			mocks: func(_ *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository, _ *mediamocks.ImageProcessor) {
 				repository.EXPECT().GetTweet(mock.Anything, mock.Anything).Return(nil, nil)
 				client.EXPECT().GetTweets(mock.Anything, []string{"1234"}, expectedTweetLookupOpts).
 					Return(&gotwitter.TweetLookupResponse{
@@ -156,7 +158,7 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 		{
 			name:    "all referenced tweets already exist",
 			tweetID: "1234",
-			mocks: func(userService *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository) {
+			mocks: func(userService *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository, _ *mediamocks.ImageProcessor) {
 				repository.EXPECT().GetTweet(mock.Anything, "1234").Return(nil, nil)
 				client.EXPECT().GetTweets(mock.Anything, []string{"1234"}, expectedTweetLookupOpts).
 					Return(&gotwitter.TweetLookupResponse{
@@ -187,6 +189,7 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 				// Not super usefull to test the content of the stored tweet here, we are gonna
 				// assert it with the output of this case
 				repository.EXPECT().Store(mock.Anything, mock.Anything).Return(nil)
+				repository.EXPECT().AddToTimeline(mock.Anything, mock.Anything).Return(nil)
 			},
 			output: &twittermodels.Tweet{
 				ID:             fakeCompleteTweet.ID,
@@ -210,7 +213,7 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 		{
 			name:    "batch create users from IDs failed",
 			tweetID: "1234",
-			mocks: func(userService *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository) {
+			mocks: func(userService *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository, _ *mediamocks.ImageProcessor) {
 				repository.EXPECT().GetTweet(mock.Anything, "1234").Return(nil, nil)
 				client.EXPECT().GetTweets(mock.Anything, []string{"1234"}, expectedTweetLookupOpts).
 					Return(&gotwitter.TweetLookupResponse{
@@ -233,7 +236,7 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 		{
 			name:    "unable to store referenced tweet",
 			tweetID: "1234",
-			mocks: func(userService *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository) {
+			mocks: func(userService *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository, _ *mediamocks.ImageProcessor) {
 				repository.EXPECT().GetTweet(mock.Anything, "1234").Return(nil, nil)
 				client.EXPECT().GetTweets(mock.Anything, []string{"1234"}, expectedTweetLookupOpts).
 					Return(&gotwitter.TweetLookupResponse{
@@ -251,7 +254,7 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 		{
 			name:    "unable to store tweet",
 			tweetID: "1234",
-			mocks: func(userService *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository) {
+			mocks: func(userService *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository, _ *mediamocks.ImageProcessor) {
 				repository.EXPECT().GetTweet(mock.Anything, "1234").Return(nil, nil)
 				client.EXPECT().GetTweets(mock.Anything, []string{"1234"}, expectedTweetLookupOpts).
 					Return(&gotwitter.TweetLookupResponse{
@@ -269,10 +272,31 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 			},
 			err: "unable to save tweet: repo error",
 		},
+		{
+			name:    "unable to add tweet to timeline",
+			tweetID: "1234",
+			mocks: func(userService *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository, _ *mediamocks.ImageProcessor) {
+				repository.EXPECT().GetTweet(mock.Anything, "1234").Return(nil, nil)
+				client.EXPECT().GetTweets(mock.Anything, []string{"1234"}, expectedTweetLookupOpts).
+					Return(&gotwitter.TweetLookupResponse{
+						Raw: &gotwitter.TweetRaw{Tweets: []*gotwitter.TweetObj{fakeCompleteTweet}},
+					}, nil)
+				repository.EXPECT().GetTweet(mock.Anything, mock.Anything).Times(2).Return(&twittermodels.Tweet{
+					ID: "fake",
+				}, nil)
+				userService.EXPECT().BatchCreateUsersFromIDs(mock.Anything, []string{"", ""}).
+					Return([]*models.User{}, nil)
+				repository.EXPECT().Store(mock.Anything, mock.Anything).Times(3).Return(nil)
+				userService.EXPECT().BatchCreateUsersFromIDs(mock.Anything, []string{fakeCompleteTweet.AuthorID}).
+					Return([]*models.User{fakeMainAuthor}, nil)
+				repository.EXPECT().AddToTimeline(mock.Anything, mock.Anything).Return(errors.New("redis is down"))
+			},
+			err: "unable to add tweet to author timeline: redis is down",
+		},
 		{
 			name:    "tweeter client error while fetching referenced tweets",
 			tweetID: "1234",
-			mocks: func(_ *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository) {
+			mocks: func(_ *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository, _ *mediamocks.ImageProcessor) {
 				repository.EXPECT().GetTweet(mock.Anything, "1234").Return(nil, nil)
 				client.EXPECT().GetTweets(mock.Anything, []string{"1234"}, expectedTweetLookupOpts).
 					Return(&gotwitter.TweetLookupResponse{
@@ -287,7 +311,7 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 		{
 			name:    "tweeter client error while fetching referenced tweets",
 			tweetID: "1234",
-			mocks: func(_ *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository) {
+			mocks: func(_ *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository, _ *mediamocks.ImageProcessor) {
 				repository.EXPECT().GetTweet(mock.Anything, "1234").Return(nil, nil)
 				client.EXPECT().GetTweets(mock.Anything, []string{"1234"}, expectedTweetLookupOpts).
 					Return(&gotwitter.TweetLookupResponse{
@@ -308,7 +332,7 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 		{
 			name:    "invalid date in returned referenced tweet",
 			tweetID: "1234",
-			mocks: func(_ *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository) {
+			mocks: func(_ *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository, _ *mediamocks.ImageProcessor) {
 				repository.EXPECT().GetTweet(mock.Anything, "1234").Return(nil, nil)
 				client.EXPECT().GetTweets(mock.Anything, []string{"1234"}, expectedTweetLookupOpts).
 					Return(&gotwitter.TweetLookupResponse{
@@ -334,7 +358,7 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 		{
 			name:    "unable to fetch author for tweet",
 			tweetID: "1234",
-			mocks: func(userService *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository) {
+			mocks: func(userService *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository, _ *mediamocks.ImageProcessor) {
 				repository.EXPECT().GetTweet(mock.Anything, "1234").Return(nil, nil)
 				client.EXPECT().GetTweets(mock.Anything, []string{"1234"}, expectedTweetLookupOpts).
 					Return(&gotwitter.TweetLookupResponse{
@@ -372,8 +396,15 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 		{
 			name:    "ok",
 			tweetID: "1234",
-			mocks: func(userService *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository) {
+			mocks: func(userService *mocks.UserService, client *mockstwitter.TwitterClient, repository *mockstwitterrepo.TweetRepository, imageProcessor *mediamocks.ImageProcessor) {
 				repository.EXPECT().GetTweet(mock.Anything, "1234").Return(nil, nil)
+				imageProcessor.EXPECT().Process(mock.Anything, fakeMediaURL).Return(&media.ImageInfo{
+					MediaType:  "image/jpeg",
+					Width:      800,
+					Height:     600,
+					Blurhash:   "LEHV6nWB2yk8pyo0adR*.7kCMdnj",
+					FocalPoint: [2]float64{-0.5, 0.25},
+				}, nil)
 				client.EXPECT().GetTweets(mock.Anything, []string{"1234"}, expectedTweetLookupOpts).
 					Return(&gotwitter.TweetLookupResponse{
 						Raw: &gotwitter.TweetRaw{
@@ -424,6 +455,7 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 				// Not super usefull to test the content of the stored tweet here, we are gonna
 				// assert it with the output of this case
 				repository.EXPECT().Store(mock.Anything, mock.Anything).Return(nil)
+				repository.EXPECT().AddToTimeline(mock.Anything, mock.Anything).Return(nil)
 			},
 			output: &twittermodels.Tweet{
 				ID:             fakeCompleteTweet.ID,
@@ -433,7 +465,15 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 				Published:      fakeDate,
 				Sensitive:      fakeCompleteTweet.PossiblySensitive,
 				Medias: []twittermodels.TweetMedia{
-					fakeMedia,
+					{
+						Type:        fakeMedia.Type,
+						URL:         fakeMedia.URL,
+						ContentType: "image/jpeg",
+						Width:       800,
+						Height:      600,
+						Blurhash:    "LEHV6nWB2yk8pyo0adR*.7kCMdnj",
+						FocalPoint:  [2]float64{-0.5, 0.25},
+					},
 				},
 				ReferencedTweets: []twittermodels.Tweet{
 					*fakeReferencedTweetModel4321,
@@ -450,9 +490,10 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 			fakeUserService := mocks.NewUserService(t)
 			fakeTwitterClient := mockstwitter.NewTwitterClient(t)
 			fateTweetRepo := mockstwitterrepo.NewTweetRepository(t)
+			fakeImageProcessor := mediamocks.NewImageProcessor(t)
 
 			if c.mocks != nil {
-				c.mocks(fakeUserService, fakeTwitterClient, fateTweetRepo)
+				c.mocks(fakeUserService, fakeTwitterClient, fateTweetRepo, fakeImageProcessor)
 			}
 
 			tweetSvc := NewTweetService(
@@ -460,6 +501,7 @@ func TestTweetService_SaveTweetAndReferences(t *testing.T) {
 				fakeUserService,
 				fakeTwitterClient,
 				fateTweetRepo,
+				fakeImageProcessor,
 			)
 
 			tweet, err := tweetSvc.SaveTweetAndReferences(context.TODO(), c.tweetID)
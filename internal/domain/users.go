@@ -27,23 +27,32 @@ type UserService interface {
 }
 
 type userService struct {
-	log           logger.Logger
-	repo          repository.UserRepository
-	keyManager    crypto.KeyManager
-	twitterClient twitter.TwitterClient
+	log                   logger.Logger
+	repo                  repository.UserRepository
+	linkRepo              repository.FediverseLinkRepository
+	keyManager            crypto.KeyManager
+	twitterClient         twitter.TwitterClient
+	consent               ConsentService
+	manuallyApprovedUsers []string
 }
 
 func NewUserService(
 	log logger.Logger,
 	manager crypto.KeyManager,
 	userRepo repository.UserRepository,
+	linkRepo repository.FediverseLinkRepository,
 	client twitter.TwitterClient,
+	consent ConsentService,
+	manuallyApprovedUsers []string,
 ) *userService {
 	return &userService{
-		repo:          userRepo,
-		log:           log,
-		keyManager:    manager,
-		twitterClient: client,
+		repo:                  userRepo,
+		linkRepo:              linkRepo,
+		log:                   log,
+		keyManager:            manager,
+		twitterClient:         client,
+		consent:               consent,
+		manuallyApprovedUsers: manuallyApprovedUsers,
 	}
 }
 
@@ -62,6 +71,9 @@ func (u *userService) GetFullUser(ctx context.Context, username string) (*domain
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to fetch twitter user info")
 	}
+	if err := u.consent.Check(ctx, twitterUser); err != nil {
+		return nil, err
+	}
 
 	profileImage, err := url.Parse(strings.ReplaceAll(
 		twitterUser.ProfileImageURL,
@@ -83,12 +95,53 @@ func (u *userService) GetFullUser(ctx context.Context, username string) (*domain
 		Description:     twitterUser.Description,
 		CreatedAt:       user.CreatedAt,
 		ProfileImageURL: profileImage,
+		Location:        twitterUser.Location,
 		Metrics: domainmodels.UserMetrics{
 			Following: uint64(twitterUser.PublicMetrics.Following),
 			Followers: uint64(twitterUser.PublicMetrics.Followers),
 			Tweets:    uint64(twitterUser.PublicMetrics.Tweets),
 		},
-		PublicKey: privateKey.Public(),
+		PublicKey:                 privateKey.Public(),
+		ManuallyApprovesFollowers: manuallyApprovesFollowers(u.manuallyApprovedUsers, user.Username),
+	}
+	// The banner and the links are extras of the profile, the actor is still served without them
+	profile, err := u.twitterClient.GetUserProfile(ctx, username)
+	if err != nil {
+		u.log.WithError(err).WithField("username", username).Warn("unable to fetch twitter profile")
+		profile = &twitter.UserProfile{}
+	}
+	if profile.BannerURL != "" {
+		domainUser.BannerURL, err = url.Parse(profile.BannerURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse banner url")
+		}
+	}
+	if profile.Website != "" {
+		domainUser.Website, err = url.Parse(profile.Website)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse website url")
+		}
+	}
+	if user.MovedTo.Valid {
+		domainUser.MovedTo, err = url.Parse(user.MovedTo.String)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse moved to url")
+		}
+	}
+
+	links, err := u.linkRepo.List(ctx, user.Username)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch fediverse links")
+	}
+	for _, link := range links {
+		actorURL, err := url.Parse(link.ActorURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse fediverse link url")
+		}
+		domainUser.FediverseLinks = append(domainUser.FediverseLinks, domainmodels.FediverseLink{
+			Handle: link.Handle,
+			URL:    actorURL,
+		})
 	}
 
 	return domainUser, nil
@@ -131,6 +184,14 @@ func (u *userService) BatchCreateUsers(ctx context.Context, usernames []string)
 		if err != nil {
 			return err
 		}
+		err = u.consent.Check(ctx, twitterUser)
+		if errors.Is(err, ErrUserOptedOut) {
+			u.log.WithField("username", username).Warn("user refused to be bridged, skipping it")
+			continue
+		}
+		if err != nil {
+			return err
+		}
 		if user == nil {
 			u.log.WithField("username", username).Debug("user not found in database, creating it")
 			_, err = u.createUserFromTwitter(ctx, twitterUser)
@@ -26,8 +26,40 @@ func Test_userService_BatchCreateUsers(t *testing.T) {
 		name                string
 		allowedTwitterUsers []string
 		mocks               func(*mockstwitter.TwitterClient, *mocksuser.UserRepository)
-		err                 error
+		// optOuts defaults to an empty opt-out registry
+		optOuts func(*mocksuser.OptOutRepository)
+		err     error
 	}{
+		{
+			name:                "users who opted out are skipped",
+			allowedTwitterUsers: []string{"user1", "user2"},
+			mocks: func(twitterClient *mockstwitter.TwitterClient, repository *mocksuser.UserRepository) {
+				repository.On("Get", mock.Anything, "user1").
+					Return(
+						nil, sql.ErrNoRows,
+					)
+				twitterClient.On("GetUser", mock.Anything, "user1").Return(&gotwitter.UserObj{
+					UserName:    "user1",
+					Description: "Please do not bridge me #NoBridge",
+					CreatedAt:   "2006-01-02T15:04:05Z",
+				}, nil)
+				repository.On("Get", mock.Anything, "user2").
+					Once().
+					Return(
+						&models.User{}, nil,
+					)
+				twitterClient.On("GetUser", mock.Anything, "user2").Return(&gotwitter.UserObj{
+					UserName:  "user2",
+					CreatedAt: "2006-01-02T15:04:05Z",
+				}, nil)
+			},
+			optOuts: func(optOutRepo *mocksuser.OptOutRepository) {
+				optOutRepo.EXPECT().Get(mock.Anything, "user2").Return(&models.OptOut{}, nil)
+				optOutRepo.EXPECT().Get(mock.Anything, "user1").Return(nil, sql.ErrNoRows)
+				optOutRepo.EXPECT().Create(mock.Anything, "user1", userrepository.OptOutReasonMarker).
+					Return(&models.OptOut{Username: "user1"}, nil)
+			},
+		},
 		{
 			name:                "users already exist",
 			allowedTwitterUsers: []string{"user1", "user2"},
@@ -167,12 +199,21 @@ func Test_userService_BatchCreateUsers(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeTwitter := mockstwitter.NewTwitterClient(t)
 			fakeUserRepo := mocksuser.NewUserRepository(t)
+			fakeOptOutRepo := mocksuser.NewOptOutRepository(t)
 			tt.mocks(fakeTwitter, fakeUserRepo)
+			if tt.optOuts != nil {
+				tt.optOuts(fakeOptOutRepo)
+			} else {
+				fakeOptOutRepo.EXPECT().Get(mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows).Maybe()
+			}
 			u := NewUserService(
 				log,
-				crypto.NewKeyManager(log, httpmock.NewClient(t)),
+				crypto.NewKeyManager(log, httpmock.NewClient(t), nil),
 				fakeUserRepo,
+				mocksuser.NewFediverseLinkRepository(t),
 				fakeTwitter,
+				NewConsentService(log, fakeUserRepo, fakeOptOutRepo, fakeTwitter, nil, nil, []string{"#nobridge"}),
+				nil,
 			)
 			err := u.BatchCreateUsers(context.TODO(), tt.allowedTwitterUsers)
 			if tt.err != nil {
@@ -312,9 +353,12 @@ func Test_userService_BatchCreateUsersFromIDs(t *testing.T) {
 			tt.mocks(fakeTwitter, fakeUserRepo)
 			u := NewUserService(
 				log,
-				crypto.NewKeyManager(log, httpmock.NewClient(t)),
+				crypto.NewKeyManager(log, httpmock.NewClient(t), nil),
 				fakeUserRepo,
+				mocksuser.NewFediverseLinkRepository(t),
 				fakeTwitter,
+				nil,
+				nil,
 			)
 			users, err := u.BatchCreateUsersFromIDs(context.TODO(), tt.IDs)
 			if tt.err != "" {
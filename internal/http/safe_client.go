@@ -0,0 +1,192 @@
+package http
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultConnectTimeout = 5 * time.Second
+	defaultTimeout        = 30 * time.Second
+	defaultMaxBodySize    = 5 * 1024 * 1024
+)
+
+var (
+	ErrForbiddenAddress = errors.New("address is not allowed")
+	ErrForbiddenScheme  = errors.New("scheme is not allowed")
+	ErrBodyTooLarge     = errors.New("response body is too large")
+)
+
+// SafeClientOptions configures the client used to reach remote servers.
+// Zero values fallback to sensible defaults.
+type SafeClientOptions struct {
+	// AllowPrivateNetworks disables the address checks and allows plain http,
+	// it should only be used for local development.
+	AllowPrivateNetworks bool
+	AllowedSchemes       []string
+	ConnectTimeout       time.Duration
+	Timeout              time.Duration
+	MaxBodySize          int64
+	UserAgent            string
+}
+
+// NewSafeClient creates a client suitable to fetch urls coming from remote servers,
+// such as key ids or actors. Since those are attacker controlled, the client refuses to
+// connect to private, loopback and link-local addresses, once the host has been resolved.
+func NewSafeClient(options SafeClientOptions) *http.Client {
+	if options.ConnectTimeout == 0 {
+		options.ConnectTimeout = defaultConnectTimeout
+	}
+	if options.Timeout == 0 {
+		options.Timeout = defaultTimeout
+	}
+	if options.MaxBodySize == 0 {
+		options.MaxBodySize = defaultMaxBodySize
+	}
+	if len(options.AllowedSchemes) == 0 {
+		options.AllowedSchemes = []string{"https"}
+		if options.AllowPrivateNetworks {
+			options.AllowedSchemes = []string{"https", "http"}
+		}
+	}
+
+	dialer := &net.Dialer{
+		Timeout: options.ConnectTimeout,
+	}
+	if !options.AllowPrivateNetworks {
+		dialer.Control = checkDialedAddress
+	}
+	transport := &http.Transport{
+		// No proxy from the environment, it would bypass the address checks
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   options.ConnectTimeout,
+		ResponseHeaderTimeout: options.Timeout,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return &http.Client{
+		Timeout: options.Timeout,
+		Transport: &safeRoundTripper{
+			next:           transport,
+			allowedSchemes: options.AllowedSchemes,
+			maxBodySize:    options.MaxBodySize,
+			userAgent:      options.UserAgent,
+		},
+	}
+}
+
+// reservedNetworks are not routable on the internet, but are not covered by the net.IP helpers.
+var reservedNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),     // "this" network
+	mustParseCIDR("100.64.0.0/10"), // carrier-grade NAT
+	mustParseCIDR("198.18.0.0/15"), // benchmarking
+	mustParseCIDR("64:ff9b::/96"),  // NAT64, it translates to any ipv4 address including private ones
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// checkDialedAddress is called with the resolved ip, this also protects against dns rebinding.
+func checkDialedAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrap(err, "invalid dialed address")
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return errors.Wrapf(ErrForbiddenAddress, "refusing to connect to %s", host)
+	}
+	return nil
+}
+
+// IsPublicIP tells if the ip is routable on the internet.
+func IsPublicIP(ip net.IP) bool {
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return !(ip.IsPrivate() ||
+		ip.IsLoopback() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified())
+}
+
+type safeRoundTripper struct {
+	next           http.RoundTripper
+	allowedSchemes []string
+	maxBodySize    int64
+	userAgent      string
+}
+
+func (t *safeRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if !t.isSchemeAllowed(request.URL.Scheme) {
+		return nil, errors.Wrapf(ErrForbiddenScheme, "refusing to fetch %s", request.URL.String())
+	}
+	if t.userAgent != "" && request.Header.Get("user-agent") == "" {
+		request = request.Clone(request.Context())
+		request.Header.Set("user-agent", t.userAgent)
+	}
+
+	response, err := t.next.RoundTrip(request)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if response.ContentLength > t.maxBodySize {
+		_ = response.Body.Close()
+		return nil, errors.Wrapf(ErrBodyTooLarge, "response of %d bytes", response.ContentLength)
+	}
+	response.Body = &limitedBody{
+		ReadCloser: response.Body,
+		remaining:  t.maxBodySize,
+	}
+	return response, nil
+}
+
+func (t *safeRoundTripper) isSchemeAllowed(scheme string) bool {
+	for _, allowed := range t.allowedSchemes {
+		if scheme == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// limitedBody fails instead of silently truncating bodies larger than the limit.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, ErrBodyTooLarge
+	}
+	// Read one more byte than allowed to detect oversized bodies
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), ErrBodyTooLarge
+	}
+	return n, err //nolint:wrapcheck
+}
@@ -0,0 +1,105 @@
+package http_test
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	_http "github.com/estrys/estrys/internal/http"
+)
+
+func TestNewSafeClient(t *testing.T) {
+	var userAgent string
+	fakeServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		userAgent = request.Header.Get("user-agent")
+		if request.URL.Path == "/large" {
+			writer.Header().Set("content-length", "100")
+		}
+		if request.URL.Path == "/stream" {
+			writer.(http.Flusher).Flush()
+		}
+		_, _ = writer.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer fakeServer.Close()
+
+	t.Run("loopback refused", func(t *testing.T) {
+		client := _http.NewSafeClient(_http.SafeClientOptions{AllowedSchemes: []string{"http"}})
+		_, err := client.Get(fakeServer.URL) //nolint:noctx
+		require.ErrorIs(t, err, _http.ErrForbiddenAddress)
+	})
+
+	t.Run("scheme refused", func(t *testing.T) {
+		client := _http.NewSafeClient(_http.SafeClientOptions{})
+		_, err := client.Get(fakeServer.URL) //nolint:noctx
+		require.ErrorIs(t, err, _http.ErrForbiddenScheme)
+	})
+
+	t.Run("private networks allowed", func(t *testing.T) {
+		client := _http.NewSafeClient(_http.SafeClientOptions{
+			AllowPrivateNetworks: true,
+			UserAgent:            "Estrys (+https://example.com)",
+		})
+		response, err := client.Get(fakeServer.URL) //nolint:noctx
+		require.NoError(t, err)
+		defer response.Body.Close()
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		require.Len(t, body, 100)
+		require.Equal(t, "Estrys (+https://example.com)", userAgent)
+	})
+
+	t.Run("announced body too large", func(t *testing.T) {
+		client := _http.NewSafeClient(_http.SafeClientOptions{AllowPrivateNetworks: true, MaxBodySize: 10})
+		_, err := client.Get(fakeServer.URL + "/large") //nolint:noctx
+		require.ErrorIs(t, err, _http.ErrBodyTooLarge)
+	})
+
+	t.Run("streamed body too large", func(t *testing.T) {
+		client := _http.NewSafeClient(_http.SafeClientOptions{AllowPrivateNetworks: true, MaxBodySize: 10})
+		response, err := client.Get(fakeServer.URL + "/stream") //nolint:noctx
+		require.NoError(t, err)
+		defer response.Body.Close()
+		body, err := io.ReadAll(response.Body)
+		require.ErrorIs(t, err, _http.ErrBodyTooLarge)
+		require.Len(t, body, 10)
+	})
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip     string
+		public bool
+	}{
+		{ip: "93.184.216.34", public: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", public: true},
+		{ip: "127.0.0.1"},
+		{ip: "::1"},
+		{ip: "10.0.0.1"},
+		{ip: "172.16.0.1"},
+		{ip: "192.168.1.1"},
+		{ip: "169.254.169.254"},
+		{ip: "fe80::1"},
+		{ip: "fd00::1"},
+		{ip: "0.0.0.0"},
+		{ip: "::ffff:127.0.0.1"},
+		{ip: "0.1.2.3"},
+		{ip: "100.64.0.1"},
+		{ip: "100.127.255.254"},
+		{ip: "100.128.0.1", public: true},
+		{ip: "198.18.0.1"},
+		{ip: "198.19.255.254"},
+		{ip: "198.20.0.1", public: true},
+		{ip: "64:ff9b::a00:1"},
+		{ip: "64:ff9b::5db8:d822"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			require.Equal(t, tt.public, _http.IsPublicIP(net.ParseIP(tt.ip)))
+		})
+	}
+}
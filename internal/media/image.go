@@ -0,0 +1,176 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	_ "image/gif"  // register gif decoder
+	_ "image/jpeg" // register jpeg decoder
+	_ "image/png"  // register png decoder
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+
+	"github.com/buckket/go-blurhash"
+	"github.com/pkg/errors"
+
+	_http "github.com/estrys/estrys/internal/http"
+)
+
+const (
+	// Same components count as mastodon, that is enough to get a nice preview
+	blurhashXComponents = 4
+	blurhashYComponents = 3
+	// Images are downscaled before computing blurhash and focal point
+	// because we do not need full resolution for those.
+	thumbnailSize = 64
+	// MaxImageSize is the largest image downloaded, in bytes.
+	MaxImageSize = 20 << 20
+	// maxImagePixels bounds the memory used to decode an image, whatever its compressed size.
+	maxImagePixels = 5000 * 5000
+)
+
+type ImageInfo struct {
+	MediaType string
+	Width     int
+	Height    int
+	Blurhash  string
+	// FocalPoint use mastodon coordinates, x and y are in the range [-1, 1]
+	// with (0, 0) being the center of the image and (-1, 1) the top left corner.
+	FocalPoint [2]float64
+}
+
+//go:generate mockery --with-expecter --name=ImageProcessor
+type ImageProcessor interface {
+	Process(context.Context, *url.URL) (*ImageInfo, error)
+}
+
+type imageProcessor struct {
+	client _http.Client
+}
+
+func NewImageProcessor(client _http.Client) *imageProcessor {
+	return &imageProcessor{client: client}
+}
+
+func (p *imageProcessor) Process(ctx context.Context, imageURL *url.URL) (*ImageInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create image request")
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch image")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d while fetching image", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, MaxImageSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read image")
+	}
+	// Dimensions are checked before decoding, a small file can declare a huge image
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode image")
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, errors.Errorf("image of %dx%d pixels is too large", config.Width, config.Height)
+	}
+	img, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode image")
+	}
+
+	thumbnail := newThumbnail(img, thumbnailSize)
+	hash, err := blurhash.Encode(blurhashXComponents, blurhashYComponents, thumbnail)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to compute blurhash")
+	}
+
+	return &ImageInfo{
+		MediaType:  "image/" + format,
+		Width:      img.Bounds().Dx(),
+		Height:     img.Bounds().Dy(),
+		Blurhash:   hash,
+		FocalPoint: focalPoint(thumbnail),
+	}, nil
+}
+
+// thumbnail is a nearest neighbour downscaled view of an image.
+type thumbnail struct {
+	src           image.Image
+	width, height int
+}
+
+func newThumbnail(src image.Image, size int) *thumbnail {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > size || height > size {
+		if width > height {
+			height = int(math.Max(1, float64(height*size/width)))
+			width = size
+		} else {
+			width = int(math.Max(1, float64(width*size/height)))
+			height = size
+		}
+	}
+	return &thumbnail{src: src, width: width, height: height}
+}
+
+func (t *thumbnail) ColorModel() color.Model {
+	return t.src.ColorModel()
+}
+
+func (t *thumbnail) Bounds() image.Rectangle {
+	return image.Rect(0, 0, t.width, t.height)
+}
+
+func (t *thumbnail) At(x, y int) color.Color {
+	bounds := t.src.Bounds()
+	return t.src.At(
+		bounds.Min.X+x*bounds.Dx()/t.width,
+		bounds.Min.Y+y*bounds.Dy()/t.height,
+	)
+}
+
+func luminance(c color.Color) float64 {
+	r, g, b, _ := c.RGBA()
+	return 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+}
+
+// focalPoint computes the barycenter of the image details, using the gradient
+// magnitude as weight. An image without any detail will be focused on its center.
+func focalPoint(img image.Image) [2]float64 {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width < 2 || height < 2 {
+		return [2]float64{0, 0}
+	}
+
+	var total, sumX, sumY float64
+	for y := 0; y < height-1; y++ {
+		for x := 0; x < width-1; x++ {
+			current := luminance(img.At(x, y))
+			gradient := math.Abs(luminance(img.At(x+1, y))-current) +
+				math.Abs(luminance(img.At(x, y+1))-current)
+			total += gradient
+			sumX += gradient * (float64(x) + 0.5)
+			sumY += gradient * (float64(y) + 0.5)
+		}
+	}
+	if total == 0 {
+		return [2]float64{0, 0}
+	}
+
+	focalX := 2*(sumX/total)/float64(width-1) - 1
+	focalY := 1 - 2*(sumY/total)/float64(height-1)
+	return [2]float64{
+		math.Round(focalX*100) / 100,
+		math.Round(focalY*100) / 100,
+	}
+}
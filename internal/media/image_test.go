@@ -0,0 +1,143 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	httpmock "github.com/estrys/estrys/internal/http/mocks"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func Test_imageProcessor_Process(t *testing.T) {
+	fakeURL, _ := url.Parse("https://pbs.twimg.com/media/photo.png")
+
+	uniformImage := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	for x := 0; x < 200; x++ {
+		for y := 0; y < 100; y++ {
+			uniformImage.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+
+	// A white image with a black square in its top left corner
+	detailedImage := image.NewRGBA(image.Rect(0, 0, 300, 300))
+	for x := 0; x < 300; x++ {
+		for y := 0; y < 300; y++ {
+			c := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+			if x >= 30 && x < 90 && y >= 30 && y < 90 {
+				c = color.RGBA{A: 255}
+			}
+			detailedImage.Set(x, y, c)
+		}
+	}
+
+	tests := []struct {
+		name string
+		mock func(client *httpmock.Client)
+		want *ImageInfo
+		err  string
+	}{
+		{
+			name: "uniform image",
+			mock: func(client *httpmock.Client) {
+				client.EXPECT().Do(mock.Anything).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(encodePNG(t, uniformImage))),
+				}, nil)
+			},
+			want: &ImageInfo{
+				MediaType:  "image/png",
+				Width:      200,
+				Height:     100,
+				Blurhash:   "L9TI:j,YfQ,Y|cjtfQjtfQfQfQfQ",
+				FocalPoint: [2]float64{0, 0},
+			},
+		},
+		{
+			name: "focal point on details",
+			mock: func(client *httpmock.Client) {
+				client.EXPECT().Do(mock.Anything).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(encodePNG(t, detailedImage))),
+				}, nil)
+			},
+			want: &ImageInfo{
+				MediaType:  "image/png",
+				Width:      300,
+				Height:     300,
+				Blurhash:   "L8S$ov9FD%~q9F00IU%MD%IURjt7",
+				FocalPoint: [2]float64{-0.58, 0.58},
+			},
+		},
+		{
+			name: "error during http call",
+			mock: func(client *httpmock.Client) {
+				client.EXPECT().Do(mock.Anything).Return(nil, errors.New("fatal error"))
+			},
+			err: "unable to fetch image: fatal error",
+		},
+		{
+			name: "not found",
+			mock: func(client *httpmock.Client) {
+				client.EXPECT().Do(mock.Anything).Return(&http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(bytes.NewReader(nil)),
+				}, nil)
+			},
+			err: "unexpected status code 404 while fetching image",
+		},
+		{
+			name: "not an image",
+			mock: func(client *httpmock.Client) {
+				client.EXPECT().Do(mock.Anything).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte("<html></html>"))),
+				}, nil)
+			},
+			err: "unable to decode image: image: unknown format",
+		},
+		{
+			name: "too many pixels",
+			mock: func(client *httpmock.Client) {
+				// A few bytes are enough to declare a 65535x65535 gif
+				client.EXPECT().Do(mock.Anything).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte("GIF89a\xff\xff\xff\xff\x00\x00\x00"))),
+				}, nil)
+			},
+			err: "image of 65535x65535 pixels is too large",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := httpmock.NewClient(t)
+			tt.mock(httpClient)
+
+			processor := NewImageProcessor(httpClient)
+			got, err := processor.Process(context.Background(), fakeURL)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				require.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
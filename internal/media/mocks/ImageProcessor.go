@@ -0,0 +1,87 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	media "github.com/estrys/estrys/internal/media"
+	mock "github.com/stretchr/testify/mock"
+
+	url "net/url"
+)
+
+// ImageProcessor is an autogenerated mock type for the ImageProcessor type
+type ImageProcessor struct {
+	mock.Mock
+}
+
+type ImageProcessor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ImageProcessor) EXPECT() *ImageProcessor_Expecter {
+	return &ImageProcessor_Expecter{mock: &_m.Mock}
+}
+
+// Process provides a mock function with given fields: _a0, _a1
+func (_m *ImageProcessor) Process(_a0 context.Context, _a1 *url.URL) (*media.ImageInfo, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *media.ImageInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *url.URL) *media.ImageInfo); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*media.ImageInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *url.URL) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImageProcessor_Process_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Process'
+type ImageProcessor_Process_Call struct {
+	*mock.Call
+}
+
+// Process is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *url.URL
+func (_e *ImageProcessor_Expecter) Process(_a0 interface{}, _a1 interface{}) *ImageProcessor_Process_Call {
+	return &ImageProcessor_Process_Call{Call: _e.mock.On("Process", _a0, _a1)}
+}
+
+func (_c *ImageProcessor_Process_Call) Run(run func(_a0 context.Context, _a1 *url.URL)) *ImageProcessor_Process_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*url.URL))
+	})
+	return _c
+}
+
+func (_c *ImageProcessor_Process_Call) Return(_a0 *media.ImageInfo, _a1 error) *ImageProcessor_Process_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewImageProcessor interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewImageProcessor creates a new instance of ImageProcessor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewImageProcessor(t mockConstructorTestingTNewImageProcessor) *ImageProcessor {
+	mock := &ImageProcessor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
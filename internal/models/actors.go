@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,32 +24,57 @@ import (
 
 // Actor is an object representing the database table.
 type Actor struct {
-	ID        string `boil:"id" json:"id" toml:"id" yaml:"id"`
-	URL       string `boil:"url" json:"url" toml:"url" yaml:"url"`
-	PublicKey []byte `boil:"public_key" json:"public_key" toml:"public_key" yaml:"public_key"`
+	ID                string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	URL               string      `boil:"url" json:"url" toml:"url" yaml:"url"`
+	PublicKey         []byte      `boil:"public_key" json:"public_key" toml:"public_key" yaml:"public_key"`
+	Inbox             null.String `boil:"inbox" json:"inbox,omitempty" toml:"inbox" yaml:"inbox,omitempty"`
+	SharedInbox       null.String `boil:"shared_inbox" json:"shared_inbox,omitempty" toml:"shared_inbox" yaml:"shared_inbox,omitempty"`
+	PreferredUsername null.String `boil:"preferred_username" json:"preferred_username,omitempty" toml:"preferred_username" yaml:"preferred_username,omitempty"`
+	PublicKeyID       null.String `boil:"public_key_id" json:"public_key_id,omitempty" toml:"public_key_id" yaml:"public_key_id,omitempty"`
+	FetchedAt         null.Time   `boil:"fetched_at" json:"fetched_at,omitempty" toml:"fetched_at" yaml:"fetched_at,omitempty"`
 
 	R *actorR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L actorL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ActorColumns = struct {
-	ID        string
-	URL       string
-	PublicKey string
+	ID                string
+	URL               string
+	PublicKey         string
+	Inbox             string
+	SharedInbox       string
+	PreferredUsername string
+	PublicKeyID       string
+	FetchedAt         string
 }{
-	ID:        "id",
-	URL:       "url",
-	PublicKey: "public_key",
+	ID:                "id",
+	URL:               "url",
+	PublicKey:         "public_key",
+	Inbox:             "inbox",
+	SharedInbox:       "shared_inbox",
+	PreferredUsername: "preferred_username",
+	PublicKeyID:       "public_key_id",
+	FetchedAt:         "fetched_at",
 }
 
 var ActorTableColumns = struct {
-	ID        string
-	URL       string
-	PublicKey string
+	ID                string
+	URL               string
+	PublicKey         string
+	Inbox             string
+	SharedInbox       string
+	PreferredUsername string
+	PublicKeyID       string
+	FetchedAt         string
 }{
-	ID:        "actors.id",
-	URL:       "actors.url",
-	PublicKey: "actors.public_key",
+	ID:                "actors.id",
+	URL:               "actors.url",
+	PublicKey:         "actors.public_key",
+	Inbox:             "actors.inbox",
+	SharedInbox:       "actors.shared_inbox",
+	PreferredUsername: "actors.preferred_username",
+	PublicKeyID:       "actors.public_key_id",
+	FetchedAt:         "actors.fetched_at",
 }
 
 // Generated where
@@ -85,26 +111,101 @@ func (w whereHelper__byte) LTE(x []byte) qm.QueryMod { return qmhelper.Where(w.f
 func (w whereHelper__byte) GT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
 func (w whereHelper__byte) GTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Time struct{ field string }
+
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var ActorWhere = struct {
-	ID        whereHelperstring
-	URL       whereHelperstring
-	PublicKey whereHelper__byte
+	ID                whereHelperstring
+	URL               whereHelperstring
+	PublicKey         whereHelper__byte
+	Inbox             whereHelpernull_String
+	SharedInbox       whereHelpernull_String
+	PreferredUsername whereHelpernull_String
+	PublicKeyID       whereHelpernull_String
+	FetchedAt         whereHelpernull_Time
 }{
-	ID:        whereHelperstring{field: "\"actors\".\"id\""},
-	URL:       whereHelperstring{field: "\"actors\".\"url\""},
-	PublicKey: whereHelper__byte{field: "\"actors\".\"public_key\""},
+	ID:                whereHelperstring{field: "\"actors\".\"id\""},
+	URL:               whereHelperstring{field: "\"actors\".\"url\""},
+	PublicKey:         whereHelper__byte{field: "\"actors\".\"public_key\""},
+	Inbox:             whereHelpernull_String{field: "\"actors\".\"inbox\""},
+	SharedInbox:       whereHelpernull_String{field: "\"actors\".\"shared_inbox\""},
+	PreferredUsername: whereHelpernull_String{field: "\"actors\".\"preferred_username\""},
+	PublicKeyID:       whereHelpernull_String{field: "\"actors\".\"public_key_id\""},
+	FetchedAt:         whereHelpernull_Time{field: "\"actors\".\"fetched_at\""},
 }
 
 // ActorRels is where relationship names are stored.
 var ActorRels = struct {
-	Users string
+	Followers    string
+	Interactions string
 }{
-	Users: "Users",
+	Followers:    "Followers",
+	Interactions: "Interactions",
 }
 
 // actorR is where relationships are stored.
 type actorR struct {
-	Users UserSlice `boil:"Users" json:"Users" toml:"Users" yaml:"Users"`
+	Followers    FollowerSlice    `boil:"Followers" json:"Followers" toml:"Followers" yaml:"Followers"`
+	Interactions InteractionSlice `boil:"Interactions" json:"Interactions" toml:"Interactions" yaml:"Interactions"`
 }
 
 // NewStruct creates a new relationship struct
@@ -112,20 +213,27 @@ func (*actorR) NewStruct() *actorR {
 	return &actorR{}
 }
 
-func (r *actorR) GetUsers() UserSlice {
+func (r *actorR) GetFollowers() FollowerSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Followers
+}
+
+func (r *actorR) GetInteractions() InteractionSlice {
 	if r == nil {
 		return nil
 	}
-	return r.Users
+	return r.Interactions
 }
 
 // actorL is where Load methods for each relationship are stored.
 type actorL struct{}
 
 var (
-	actorAllColumns            = []string{"id", "url", "public_key"}
+	actorAllColumns            = []string{"id", "url", "public_key", "inbox", "shared_inbox", "preferred_username", "public_key_id", "fetched_at"}
 	actorColumnsWithoutDefault = []string{"id", "url", "public_key"}
-	actorColumnsWithDefault    = []string{}
+	actorColumnsWithDefault    = []string{"inbox", "shared_inbox", "preferred_username", "public_key_id", "fetched_at"}
 	actorPrimaryKeyColumns     = []string{"id"}
 	actorGeneratedColumns      = []string{}
 )
@@ -408,24 +516,37 @@ func (q actorQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool
 	return count > 0, nil
 }
 
-// Users retrieves all the user's Users with an executor.
-func (o *Actor) Users(mods ...qm.QueryMod) userQuery {
+// Followers retrieves all the follower's Followers with an executor.
+func (o *Actor) Followers(mods ...qm.QueryMod) followerQuery {
 	var queryMods []qm.QueryMod
 	if len(mods) != 0 {
 		queryMods = append(queryMods, mods...)
 	}
 
 	queryMods = append(queryMods,
-		qm.InnerJoin("\"followers\" on \"users\".\"username\" = \"followers\".\"user\""),
 		qm.Where("\"followers\".\"actor\"=?", o.ID),
 	)
 
-	return Users(queryMods...)
+	return Followers(queryMods...)
+}
+
+// Interactions retrieves all the interaction's Interactions with an executor.
+func (o *Actor) Interactions(mods ...qm.QueryMod) interactionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"interactions\".\"actor\"=?", o.ID),
+	)
+
+	return Interactions(queryMods...)
 }
 
-// LoadUsers allows an eager lookup of values, cached into the
+// LoadFollowers allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (actorL) LoadUsers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeActor interface{}, mods queries.Applicator) error {
+func (actorL) LoadFollowers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeActor interface{}, mods queries.Applicator) error {
 	var slice []*Actor
 	var object *Actor
 
@@ -479,10 +600,8 @@ func (actorL) LoadUsers(ctx context.Context, e boil.ContextExecutor, singular bo
 	}
 
 	query := NewQuery(
-		qm.Select("\"users\".\"username\", \"users\".\"id\", \"users\".\"private_key\", \"users\".\"created_at\", \"a\".\"actor\""),
-		qm.From("\"users\""),
-		qm.InnerJoin("\"followers\" as \"a\" on \"users\".\"username\" = \"a\".\"user\""),
-		qm.WhereIn("\"a\".\"actor\" in ?", args...),
+		qm.From(`followers`),
+		qm.WhereIn(`followers.actor in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -490,36 +609,136 @@ func (actorL) LoadUsers(ctx context.Context, e boil.ContextExecutor, singular bo
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load users")
+		return errors.Wrap(err, "failed to eager load followers")
 	}
 
-	var resultSlice []*User
+	var resultSlice []*Follower
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice followers")
+	}
 
-	var localJoinCols []string
-	for results.Next() {
-		one := new(User)
-		var localJoinCol string
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on followers")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for followers")
+	}
 
-		err = results.Scan(&one.Username, &one.ID, &one.PrivateKey, &one.CreatedAt, &localJoinCol)
-		if err != nil {
-			return errors.Wrap(err, "failed to scan eager loaded results for users")
+	if len(followerAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
 		}
-		if err = results.Err(); err != nil {
-			return errors.Wrap(err, "failed to plebian-bind eager loaded slice users")
+	}
+	if singular {
+		object.R.Followers = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &followerR{}
+			}
+			foreign.R.FollowerActor = object
 		}
+		return nil
+	}
 
-		resultSlice = append(resultSlice, one)
-		localJoinCols = append(localJoinCols, localJoinCol)
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.Actor {
+				local.R.Followers = append(local.R.Followers, foreign)
+				if foreign.R == nil {
+					foreign.R = &followerR{}
+				}
+				foreign.R.FollowerActor = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadInteractions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (actorL) LoadInteractions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeActor interface{}, mods queries.Applicator) error {
+	var slice []*Actor
+	var object *Actor
+
+	if singular {
+		var ok bool
+		object, ok = maybeActor.(*Actor)
+		if !ok {
+			object = new(Actor)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeActor)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeActor))
+			}
+		}
+	} else {
+		s, ok := maybeActor.(*[]*Actor)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeActor)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeActor))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &actorR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &actorR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`interactions`),
+		qm.WhereIn(`interactions.actor in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load interactions")
+	}
+
+	var resultSlice []*Interaction
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice interactions")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on users")
+		return errors.Wrap(err, "failed to close results in eager load on interactions")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for interactions")
 	}
 
-	if len(userAfterSelectHooks) != 0 {
+	if len(interactionAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -527,25 +746,24 @@ func (actorL) LoadUsers(ctx context.Context, e boil.ContextExecutor, singular bo
 		}
 	}
 	if singular {
-		object.R.Users = resultSlice
+		object.R.Interactions = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &userR{}
+				foreign.R = &interactionR{}
 			}
-			foreign.R.Actors = append(foreign.R.Actors, object)
+			foreign.R.InteractionActor = object
 		}
 		return nil
 	}
 
-	for i, foreign := range resultSlice {
-		localJoinCol := localJoinCols[i]
+	for _, foreign := range resultSlice {
 		for _, local := range slice {
-			if local.ID == localJoinCol {
-				local.R.Users = append(local.R.Users, foreign)
+			if local.ID == foreign.Actor {
+				local.R.Interactions = append(local.R.Interactions, foreign)
 				if foreign.R == nil {
-					foreign.R = &userR{}
+					foreign.R = &interactionR{}
 				}
-				foreign.R.Actors = append(foreign.R.Actors, local)
+				foreign.R.InteractionActor = local
 				break
 			}
 		}
@@ -554,149 +772,110 @@ func (actorL) LoadUsers(ctx context.Context, e boil.ContextExecutor, singular bo
 	return nil
 }
 
-// AddUsers adds the given related objects to the existing relationships
+// AddFollowers adds the given related objects to the existing relationships
 // of the actor, optionally inserting them as new records.
-// Appends related to o.R.Users.
-// Sets related.R.Actors appropriately.
-func (o *Actor) AddUsers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*User) error {
+// Appends related to o.R.Followers.
+// Sets related.R.FollowerActor appropriately.
+func (o *Actor) AddFollowers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Follower) error {
 	var err error
 	for _, rel := range related {
 		if insert {
+			rel.Actor = o.ID
 			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
 				return errors.Wrap(err, "failed to insert into foreign table")
 			}
-		}
-	}
-
-	for _, rel := range related {
-		query := "insert into \"followers\" (\"actor\", \"user\") values ($1, $2)"
-		values := []interface{}{o.ID, rel.Username}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"followers\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"actor"}),
+				strmangle.WhereClause("\"", "\"", 2, followerPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.User, rel.Actor}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
 
-		if boil.IsDebug(ctx) {
-			writer := boil.DebugWriterFrom(ctx)
-			fmt.Fprintln(writer, query)
-			fmt.Fprintln(writer, values)
-		}
-		_, err = exec.ExecContext(ctx, query, values...)
-		if err != nil {
-			return errors.Wrap(err, "failed to insert into join table")
+			rel.Actor = o.ID
 		}
 	}
+
 	if o.R == nil {
 		o.R = &actorR{
-			Users: related,
+			Followers: related,
 		}
 	} else {
-		o.R.Users = append(o.R.Users, related...)
+		o.R.Followers = append(o.R.Followers, related...)
 	}
 
 	for _, rel := range related {
 		if rel.R == nil {
-			rel.R = &userR{
-				Actors: ActorSlice{o},
+			rel.R = &followerR{
+				FollowerActor: o,
 			}
 		} else {
-			rel.R.Actors = append(rel.R.Actors, o)
+			rel.R.FollowerActor = o
 		}
 	}
 	return nil
 }
 
-// SetUsers removes all previously related items of the
-// actor replacing them completely with the passed
-// in related items, optionally inserting them as new records.
-// Sets o.R.Actors's Users accordingly.
-// Replaces o.R.Users with related.
-// Sets related.R.Actors's Users accordingly.
-func (o *Actor) SetUsers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*User) error {
-	query := "delete from \"followers\" where \"actor\" = $1"
-	values := []interface{}{o.ID}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, query)
-		fmt.Fprintln(writer, values)
-	}
-	_, err := exec.ExecContext(ctx, query, values...)
-	if err != nil {
-		return errors.Wrap(err, "failed to remove relationships before set")
-	}
-
-	removeUsersFromActorsSlice(o, related)
-	if o.R != nil {
-		o.R.Users = nil
-	}
-
-	return o.AddUsers(ctx, exec, insert, related...)
-}
-
-// RemoveUsers relationships from objects passed in.
-// Removes related items from R.Users (uses pointer comparison, removal does not keep order)
-// Sets related.R.Actors.
-func (o *Actor) RemoveUsers(ctx context.Context, exec boil.ContextExecutor, related ...*User) error {
-	if len(related) == 0 {
-		return nil
-	}
-
+// AddInteractions adds the given related objects to the existing relationships
+// of the actor, optionally inserting them as new records.
+// Appends related to o.R.Interactions.
+// Sets related.R.InteractionActor appropriately.
+func (o *Actor) AddInteractions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Interaction) error {
 	var err error
-	query := fmt.Sprintf(
-		"delete from \"followers\" where \"actor\" = $1 and \"user\" in (%s)",
-		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
-	)
-	values := []interface{}{o.ID}
-	for _, rel := range related {
-		values = append(values, rel.Username)
-	}
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, query)
-		fmt.Fprintln(writer, values)
-	}
-	_, err = exec.ExecContext(ctx, query, values...)
-	if err != nil {
-		return errors.Wrap(err, "failed to remove relationships before set")
-	}
-	removeUsersFromActorsSlice(o, related)
-	if o.R == nil {
-		return nil
-	}
-
 	for _, rel := range related {
-		for i, ri := range o.R.Users {
-			if rel != ri {
-				continue
+		if insert {
+			rel.Actor = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
 			}
-
-			ln := len(o.R.Users)
-			if ln > 1 && i < ln-1 {
-				o.R.Users[i] = o.R.Users[ln-1]
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"interactions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"actor"}),
+				strmangle.WhereClause("\"", "\"", 2, interactionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
 			}
-			o.R.Users = o.R.Users[:ln-1]
-			break
+
+			rel.Actor = o.ID
 		}
 	}
 
-	return nil
-}
+	if o.R == nil {
+		o.R = &actorR{
+			Interactions: related,
+		}
+	} else {
+		o.R.Interactions = append(o.R.Interactions, related...)
+	}
 
-func removeUsersFromActorsSlice(o *Actor, related []*User) {
 	for _, rel := range related {
 		if rel.R == nil {
-			continue
-		}
-		for i, ri := range rel.R.Actors {
-			if o.ID != ri.ID {
-				continue
-			}
-
-			ln := len(rel.R.Actors)
-			if ln > 1 && i < ln-1 {
-				rel.R.Actors[i] = rel.R.Actors[ln-1]
+			rel.R = &interactionR{
+				InteractionActor: o,
 			}
-			rel.R.Actors = rel.R.Actors[:ln-1]
-			break
+		} else {
+			rel.R.InteractionActor = o
 		}
 	}
+	return nil
 }
 
 // Actors retrieves all the records using an executor.
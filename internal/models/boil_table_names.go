@@ -4,11 +4,21 @@
 package models
 
 var TableNames = struct {
-	Actors    string
-	Followers string
-	Users     string
+	Actors            string
+	FediverseLinks    string
+	Followers         string
+	InboundActivities string
+	InstanceKeys      string
+	Interactions      string
+	OptOuts           string
+	Users             string
 }{
-	Actors:    "actors",
-	Followers: "followers",
-	Users:     "users",
+	Actors:            "actors",
+	FediverseLinks:    "fediverse_links",
+	Followers:         "followers",
+	InboundActivities: "inbound_activities",
+	InstanceKeys:      "instance_keys",
+	Interactions:      "interactions",
+	OptOuts:           "opt_outs",
+	Users:             "users",
 }
@@ -0,0 +1,1142 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// FediverseLink is an object representing the database table.
+type FediverseLink struct {
+	ID         string    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	User       string    `boil:"user" json:"user" toml:"user" yaml:"user"`
+	Handle     string    `boil:"handle" json:"handle" toml:"handle" yaml:"handle"`
+	ActorURL   string    `boil:"actor_url" json:"actor_url" toml:"actor_url" yaml:"actor_url"`
+	VerifiedAt time.Time `boil:"verified_at" json:"verified_at" toml:"verified_at" yaml:"verified_at"`
+	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *fediverseLinkR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L fediverseLinkL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var FediverseLinkColumns = struct {
+	ID         string
+	User       string
+	Handle     string
+	ActorURL   string
+	VerifiedAt string
+	CreatedAt  string
+}{
+	ID:         "id",
+	User:       "user",
+	Handle:     "handle",
+	ActorURL:   "actor_url",
+	VerifiedAt: "verified_at",
+	CreatedAt:  "created_at",
+}
+
+var FediverseLinkTableColumns = struct {
+	ID         string
+	User       string
+	Handle     string
+	ActorURL   string
+	VerifiedAt string
+	CreatedAt  string
+}{
+	ID:         "fediverse_links.id",
+	User:       "fediverse_links.user",
+	Handle:     "fediverse_links.handle",
+	ActorURL:   "fediverse_links.actor_url",
+	VerifiedAt: "fediverse_links.verified_at",
+	CreatedAt:  "fediverse_links.created_at",
+}
+
+// Generated where
+
+type whereHelpertime_Time struct{ field string }
+
+func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var FediverseLinkWhere = struct {
+	ID         whereHelperstring
+	User       whereHelperstring
+	Handle     whereHelperstring
+	ActorURL   whereHelperstring
+	VerifiedAt whereHelpertime_Time
+	CreatedAt  whereHelpertime_Time
+}{
+	ID:         whereHelperstring{field: "\"fediverse_links\".\"id\""},
+	User:       whereHelperstring{field: "\"fediverse_links\".\"user\""},
+	Handle:     whereHelperstring{field: "\"fediverse_links\".\"handle\""},
+	ActorURL:   whereHelperstring{field: "\"fediverse_links\".\"actor_url\""},
+	VerifiedAt: whereHelpertime_Time{field: "\"fediverse_links\".\"verified_at\""},
+	CreatedAt:  whereHelpertime_Time{field: "\"fediverse_links\".\"created_at\""},
+}
+
+// FediverseLinkRels is where relationship names are stored.
+var FediverseLinkRels = struct {
+	FediverseLinkUser string
+}{
+	FediverseLinkUser: "FediverseLinkUser",
+}
+
+// fediverseLinkR is where relationships are stored.
+type fediverseLinkR struct {
+	FediverseLinkUser *User `boil:"FediverseLinkUser" json:"FediverseLinkUser" toml:"FediverseLinkUser" yaml:"FediverseLinkUser"`
+}
+
+// NewStruct creates a new relationship struct
+func (*fediverseLinkR) NewStruct() *fediverseLinkR {
+	return &fediverseLinkR{}
+}
+
+func (r *fediverseLinkR) GetFediverseLinkUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.FediverseLinkUser
+}
+
+// fediverseLinkL is where Load methods for each relationship are stored.
+type fediverseLinkL struct{}
+
+var (
+	fediverseLinkAllColumns            = []string{"id", "user", "handle", "actor_url", "verified_at", "created_at"}
+	fediverseLinkColumnsWithoutDefault = []string{"id", "user", "handle", "actor_url", "verified_at"}
+	fediverseLinkColumnsWithDefault    = []string{"created_at"}
+	fediverseLinkPrimaryKeyColumns     = []string{"id"}
+	fediverseLinkGeneratedColumns      = []string{}
+)
+
+type (
+	// FediverseLinkSlice is an alias for a slice of pointers to FediverseLink.
+	// This should almost always be used instead of []FediverseLink.
+	FediverseLinkSlice []*FediverseLink
+	// FediverseLinkHook is the signature for custom FediverseLink hook methods
+	FediverseLinkHook func(context.Context, boil.ContextExecutor, *FediverseLink) error
+
+	fediverseLinkQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	fediverseLinkType                 = reflect.TypeOf(&FediverseLink{})
+	fediverseLinkMapping              = queries.MakeStructMapping(fediverseLinkType)
+	fediverseLinkPrimaryKeyMapping, _ = queries.BindMapping(fediverseLinkType, fediverseLinkMapping, fediverseLinkPrimaryKeyColumns)
+	fediverseLinkInsertCacheMut       sync.RWMutex
+	fediverseLinkInsertCache          = make(map[string]insertCache)
+	fediverseLinkUpdateCacheMut       sync.RWMutex
+	fediverseLinkUpdateCache          = make(map[string]updateCache)
+	fediverseLinkUpsertCacheMut       sync.RWMutex
+	fediverseLinkUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var fediverseLinkAfterSelectHooks []FediverseLinkHook
+
+var fediverseLinkBeforeInsertHooks []FediverseLinkHook
+var fediverseLinkAfterInsertHooks []FediverseLinkHook
+
+var fediverseLinkBeforeUpdateHooks []FediverseLinkHook
+var fediverseLinkAfterUpdateHooks []FediverseLinkHook
+
+var fediverseLinkBeforeDeleteHooks []FediverseLinkHook
+var fediverseLinkAfterDeleteHooks []FediverseLinkHook
+
+var fediverseLinkBeforeUpsertHooks []FediverseLinkHook
+var fediverseLinkAfterUpsertHooks []FediverseLinkHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *FediverseLink) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fediverseLinkAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *FediverseLink) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fediverseLinkBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *FediverseLink) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fediverseLinkAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *FediverseLink) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fediverseLinkBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *FediverseLink) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fediverseLinkAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *FediverseLink) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fediverseLinkBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *FediverseLink) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fediverseLinkAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *FediverseLink) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fediverseLinkBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *FediverseLink) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fediverseLinkAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddFediverseLinkHook registers your hook function for all future operations.
+func AddFediverseLinkHook(hookPoint boil.HookPoint, fediverseLinkHook FediverseLinkHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		fediverseLinkAfterSelectHooks = append(fediverseLinkAfterSelectHooks, fediverseLinkHook)
+	case boil.BeforeInsertHook:
+		fediverseLinkBeforeInsertHooks = append(fediverseLinkBeforeInsertHooks, fediverseLinkHook)
+	case boil.AfterInsertHook:
+		fediverseLinkAfterInsertHooks = append(fediverseLinkAfterInsertHooks, fediverseLinkHook)
+	case boil.BeforeUpdateHook:
+		fediverseLinkBeforeUpdateHooks = append(fediverseLinkBeforeUpdateHooks, fediverseLinkHook)
+	case boil.AfterUpdateHook:
+		fediverseLinkAfterUpdateHooks = append(fediverseLinkAfterUpdateHooks, fediverseLinkHook)
+	case boil.BeforeDeleteHook:
+		fediverseLinkBeforeDeleteHooks = append(fediverseLinkBeforeDeleteHooks, fediverseLinkHook)
+	case boil.AfterDeleteHook:
+		fediverseLinkAfterDeleteHooks = append(fediverseLinkAfterDeleteHooks, fediverseLinkHook)
+	case boil.BeforeUpsertHook:
+		fediverseLinkBeforeUpsertHooks = append(fediverseLinkBeforeUpsertHooks, fediverseLinkHook)
+	case boil.AfterUpsertHook:
+		fediverseLinkAfterUpsertHooks = append(fediverseLinkAfterUpsertHooks, fediverseLinkHook)
+	}
+}
+
+// One returns a single fediverseLink record from the query.
+func (q fediverseLinkQuery) One(ctx context.Context, exec boil.ContextExecutor) (*FediverseLink, error) {
+	o := &FediverseLink{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for fediverse_links")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all FediverseLink records from the query.
+func (q fediverseLinkQuery) All(ctx context.Context, exec boil.ContextExecutor) (FediverseLinkSlice, error) {
+	var o []*FediverseLink
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to FediverseLink slice")
+	}
+
+	if len(fediverseLinkAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all FediverseLink records in the query.
+func (q fediverseLinkQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count fediverse_links rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q fediverseLinkQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if fediverse_links exists")
+	}
+
+	return count > 0, nil
+}
+
+// FediverseLinkUser pointed to by the foreign key.
+func (o *FediverseLink) FediverseLinkUser(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"username\" = ?", o.User),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// LoadFediverseLinkUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (fediverseLinkL) LoadFediverseLinkUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeFediverseLink interface{}, mods queries.Applicator) error {
+	var slice []*FediverseLink
+	var object *FediverseLink
+
+	if singular {
+		var ok bool
+		object, ok = maybeFediverseLink.(*FediverseLink)
+		if !ok {
+			object = new(FediverseLink)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeFediverseLink)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeFediverseLink))
+			}
+		}
+	} else {
+		s, ok := maybeFediverseLink.(*[]*FediverseLink)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeFediverseLink)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeFediverseLink))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &fediverseLinkR{}
+		}
+		args = append(args, object.User)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &fediverseLinkR{}
+			}
+
+			for _, a := range args {
+				if a == obj.User {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.User)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.username in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(fediverseLinkAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.FediverseLinkUser = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.FediverseLinks = append(foreign.R.FediverseLinks, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.User == foreign.Username {
+				local.R.FediverseLinkUser = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.FediverseLinks = append(foreign.R.FediverseLinks, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetFediverseLinkUser of the fediverseLink to the related item.
+// Sets o.R.FediverseLinkUser to related.
+// Adds o to related.R.FediverseLinks.
+func (o *FediverseLink) SetFediverseLinkUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"fediverse_links\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user"}),
+		strmangle.WhereClause("\"", "\"", 2, fediverseLinkPrimaryKeyColumns),
+	)
+	values := []interface{}{related.Username, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.User = related.Username
+	if o.R == nil {
+		o.R = &fediverseLinkR{
+			FediverseLinkUser: related,
+		}
+	} else {
+		o.R.FediverseLinkUser = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			FediverseLinks: FediverseLinkSlice{o},
+		}
+	} else {
+		related.R.FediverseLinks = append(related.R.FediverseLinks, o)
+	}
+
+	return nil
+}
+
+// FediverseLinks retrieves all the records using an executor.
+func FediverseLinks(mods ...qm.QueryMod) fediverseLinkQuery {
+	mods = append(mods, qm.From("\"fediverse_links\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"fediverse_links\".*"})
+	}
+
+	return fediverseLinkQuery{q}
+}
+
+// FindFediverseLink retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindFediverseLink(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*FediverseLink, error) {
+	fediverseLinkObj := &FediverseLink{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"fediverse_links\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, fediverseLinkObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from fediverse_links")
+	}
+
+	if err = fediverseLinkObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return fediverseLinkObj, err
+	}
+
+	return fediverseLinkObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *FediverseLink) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no fediverse_links provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(fediverseLinkColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	fediverseLinkInsertCacheMut.RLock()
+	cache, cached := fediverseLinkInsertCache[key]
+	fediverseLinkInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			fediverseLinkAllColumns,
+			fediverseLinkColumnsWithDefault,
+			fediverseLinkColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(fediverseLinkType, fediverseLinkMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(fediverseLinkType, fediverseLinkMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"fediverse_links\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"fediverse_links\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into fediverse_links")
+	}
+
+	if !cached {
+		fediverseLinkInsertCacheMut.Lock()
+		fediverseLinkInsertCache[key] = cache
+		fediverseLinkInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the FediverseLink.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *FediverseLink) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	fediverseLinkUpdateCacheMut.RLock()
+	cache, cached := fediverseLinkUpdateCache[key]
+	fediverseLinkUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			fediverseLinkAllColumns,
+			fediverseLinkPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update fediverse_links, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"fediverse_links\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, fediverseLinkPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(fediverseLinkType, fediverseLinkMapping, append(wl, fediverseLinkPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update fediverse_links row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for fediverse_links")
+	}
+
+	if !cached {
+		fediverseLinkUpdateCacheMut.Lock()
+		fediverseLinkUpdateCache[key] = cache
+		fediverseLinkUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q fediverseLinkQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for fediverse_links")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for fediverse_links")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o FediverseLinkSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), fediverseLinkPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"fediverse_links\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, fediverseLinkPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in fediverseLink slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all fediverseLink")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *FediverseLink) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no fediverse_links provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(fediverseLinkColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	fediverseLinkUpsertCacheMut.RLock()
+	cache, cached := fediverseLinkUpsertCache[key]
+	fediverseLinkUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			fediverseLinkAllColumns,
+			fediverseLinkColumnsWithDefault,
+			fediverseLinkColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			fediverseLinkAllColumns,
+			fediverseLinkPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert fediverse_links, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(fediverseLinkPrimaryKeyColumns))
+			copy(conflict, fediverseLinkPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"fediverse_links\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(fediverseLinkType, fediverseLinkMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(fediverseLinkType, fediverseLinkMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert fediverse_links")
+	}
+
+	if !cached {
+		fediverseLinkUpsertCacheMut.Lock()
+		fediverseLinkUpsertCache[key] = cache
+		fediverseLinkUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single FediverseLink record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *FediverseLink) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no FediverseLink provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), fediverseLinkPrimaryKeyMapping)
+	sql := "DELETE FROM \"fediverse_links\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from fediverse_links")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for fediverse_links")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q fediverseLinkQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no fediverseLinkQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from fediverse_links")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for fediverse_links")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o FediverseLinkSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(fediverseLinkBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), fediverseLinkPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"fediverse_links\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, fediverseLinkPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from fediverseLink slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for fediverse_links")
+	}
+
+	if len(fediverseLinkAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *FediverseLink) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindFediverseLink(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *FediverseLinkSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := FediverseLinkSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), fediverseLinkPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"fediverse_links\".* FROM \"fediverse_links\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, fediverseLinkPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in FediverseLinkSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// FediverseLinkExists checks if the FediverseLink row exists.
+func FediverseLinkExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"fediverse_links\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if fediverse_links exists")
+	}
+
+	return exists, nil
+}
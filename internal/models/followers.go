@@ -0,0 +1,1334 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Follower is an object representing the database table.
+type Follower struct {
+	User                string      `boil:"user" json:"user" toml:"user" yaml:"user"`
+	Actor               string      `boil:"actor" json:"actor" toml:"actor" yaml:"actor"`
+	FollowID            null.String `boil:"follow_id" json:"follow_id,omitempty" toml:"follow_id" yaml:"follow_id,omitempty"`
+	State               string      `boil:"state" json:"state" toml:"state" yaml:"state"`
+	CreatedAt           time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt           time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ResponseDeliveredAt null.Time   `boil:"response_delivered_at" json:"response_delivered_at,omitempty" toml:"response_delivered_at" yaml:"response_delivered_at,omitempty"`
+	ResponseError       null.String `boil:"response_error" json:"response_error,omitempty" toml:"response_error" yaml:"response_error,omitempty"`
+
+	R *followerR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L followerL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var FollowerColumns = struct {
+	User                string
+	Actor               string
+	FollowID            string
+	State               string
+	CreatedAt           string
+	UpdatedAt           string
+	ResponseDeliveredAt string
+	ResponseError       string
+}{
+	User:                "user",
+	Actor:               "actor",
+	FollowID:            "follow_id",
+	State:               "state",
+	CreatedAt:           "created_at",
+	UpdatedAt:           "updated_at",
+	ResponseDeliveredAt: "response_delivered_at",
+	ResponseError:       "response_error",
+}
+
+var FollowerTableColumns = struct {
+	User                string
+	Actor               string
+	FollowID            string
+	State               string
+	CreatedAt           string
+	UpdatedAt           string
+	ResponseDeliveredAt string
+	ResponseError       string
+}{
+	User:                "followers.user",
+	Actor:               "followers.actor",
+	FollowID:            "followers.follow_id",
+	State:               "followers.state",
+	CreatedAt:           "followers.created_at",
+	UpdatedAt:           "followers.updated_at",
+	ResponseDeliveredAt: "followers.response_delivered_at",
+	ResponseError:       "followers.response_error",
+}
+
+// Generated where
+
+var FollowerWhere = struct {
+	User                whereHelperstring
+	Actor               whereHelperstring
+	FollowID            whereHelpernull_String
+	State               whereHelperstring
+	CreatedAt           whereHelpertime_Time
+	UpdatedAt           whereHelpertime_Time
+	ResponseDeliveredAt whereHelpernull_Time
+	ResponseError       whereHelpernull_String
+}{
+	User:                whereHelperstring{field: "\"followers\".\"user\""},
+	Actor:               whereHelperstring{field: "\"followers\".\"actor\""},
+	FollowID:            whereHelpernull_String{field: "\"followers\".\"follow_id\""},
+	State:               whereHelperstring{field: "\"followers\".\"state\""},
+	CreatedAt:           whereHelpertime_Time{field: "\"followers\".\"created_at\""},
+	UpdatedAt:           whereHelpertime_Time{field: "\"followers\".\"updated_at\""},
+	ResponseDeliveredAt: whereHelpernull_Time{field: "\"followers\".\"response_delivered_at\""},
+	ResponseError:       whereHelpernull_String{field: "\"followers\".\"response_error\""},
+}
+
+// FollowerRels is where relationship names are stored.
+var FollowerRels = struct {
+	FollowerActor string
+	FollowerUser  string
+}{
+	FollowerActor: "FollowerActor",
+	FollowerUser:  "FollowerUser",
+}
+
+// followerR is where relationships are stored.
+type followerR struct {
+	FollowerActor *Actor `boil:"FollowerActor" json:"FollowerActor" toml:"FollowerActor" yaml:"FollowerActor"`
+	FollowerUser  *User  `boil:"FollowerUser" json:"FollowerUser" toml:"FollowerUser" yaml:"FollowerUser"`
+}
+
+// NewStruct creates a new relationship struct
+func (*followerR) NewStruct() *followerR {
+	return &followerR{}
+}
+
+func (r *followerR) GetFollowerActor() *Actor {
+	if r == nil {
+		return nil
+	}
+	return r.FollowerActor
+}
+
+func (r *followerR) GetFollowerUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.FollowerUser
+}
+
+// followerL is where Load methods for each relationship are stored.
+type followerL struct{}
+
+var (
+	followerAllColumns            = []string{"user", "actor", "follow_id", "state", "created_at", "updated_at", "response_delivered_at", "response_error"}
+	followerColumnsWithoutDefault = []string{"user", "actor"}
+	followerColumnsWithDefault    = []string{"follow_id", "state", "created_at", "updated_at", "response_delivered_at", "response_error"}
+	followerPrimaryKeyColumns     = []string{"user", "actor"}
+	followerGeneratedColumns      = []string{}
+)
+
+type (
+	// FollowerSlice is an alias for a slice of pointers to Follower.
+	// This should almost always be used instead of []Follower.
+	FollowerSlice []*Follower
+	// FollowerHook is the signature for custom Follower hook methods
+	FollowerHook func(context.Context, boil.ContextExecutor, *Follower) error
+
+	followerQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	followerType                 = reflect.TypeOf(&Follower{})
+	followerMapping              = queries.MakeStructMapping(followerType)
+	followerPrimaryKeyMapping, _ = queries.BindMapping(followerType, followerMapping, followerPrimaryKeyColumns)
+	followerInsertCacheMut       sync.RWMutex
+	followerInsertCache          = make(map[string]insertCache)
+	followerUpdateCacheMut       sync.RWMutex
+	followerUpdateCache          = make(map[string]updateCache)
+	followerUpsertCacheMut       sync.RWMutex
+	followerUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var followerAfterSelectHooks []FollowerHook
+
+var followerBeforeInsertHooks []FollowerHook
+var followerAfterInsertHooks []FollowerHook
+
+var followerBeforeUpdateHooks []FollowerHook
+var followerAfterUpdateHooks []FollowerHook
+
+var followerBeforeDeleteHooks []FollowerHook
+var followerAfterDeleteHooks []FollowerHook
+
+var followerBeforeUpsertHooks []FollowerHook
+var followerAfterUpsertHooks []FollowerHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Follower) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range followerAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Follower) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range followerBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Follower) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range followerAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Follower) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range followerBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Follower) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range followerAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Follower) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range followerBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Follower) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range followerAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Follower) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range followerBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Follower) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range followerAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddFollowerHook registers your hook function for all future operations.
+func AddFollowerHook(hookPoint boil.HookPoint, followerHook FollowerHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		followerAfterSelectHooks = append(followerAfterSelectHooks, followerHook)
+	case boil.BeforeInsertHook:
+		followerBeforeInsertHooks = append(followerBeforeInsertHooks, followerHook)
+	case boil.AfterInsertHook:
+		followerAfterInsertHooks = append(followerAfterInsertHooks, followerHook)
+	case boil.BeforeUpdateHook:
+		followerBeforeUpdateHooks = append(followerBeforeUpdateHooks, followerHook)
+	case boil.AfterUpdateHook:
+		followerAfterUpdateHooks = append(followerAfterUpdateHooks, followerHook)
+	case boil.BeforeDeleteHook:
+		followerBeforeDeleteHooks = append(followerBeforeDeleteHooks, followerHook)
+	case boil.AfterDeleteHook:
+		followerAfterDeleteHooks = append(followerAfterDeleteHooks, followerHook)
+	case boil.BeforeUpsertHook:
+		followerBeforeUpsertHooks = append(followerBeforeUpsertHooks, followerHook)
+	case boil.AfterUpsertHook:
+		followerAfterUpsertHooks = append(followerAfterUpsertHooks, followerHook)
+	}
+}
+
+// One returns a single follower record from the query.
+func (q followerQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Follower, error) {
+	o := &Follower{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for followers")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Follower records from the query.
+func (q followerQuery) All(ctx context.Context, exec boil.ContextExecutor) (FollowerSlice, error) {
+	var o []*Follower
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Follower slice")
+	}
+
+	if len(followerAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Follower records in the query.
+func (q followerQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count followers rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q followerQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if followers exists")
+	}
+
+	return count > 0, nil
+}
+
+// FollowerActor pointed to by the foreign key.
+func (o *Follower) FollowerActor(mods ...qm.QueryMod) actorQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.Actor),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Actors(queryMods...)
+}
+
+// FollowerUser pointed to by the foreign key.
+func (o *Follower) FollowerUser(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"username\" = ?", o.User),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// LoadFollowerActor allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (followerL) LoadFollowerActor(ctx context.Context, e boil.ContextExecutor, singular bool, maybeFollower interface{}, mods queries.Applicator) error {
+	var slice []*Follower
+	var object *Follower
+
+	if singular {
+		var ok bool
+		object, ok = maybeFollower.(*Follower)
+		if !ok {
+			object = new(Follower)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeFollower)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeFollower))
+			}
+		}
+	} else {
+		s, ok := maybeFollower.(*[]*Follower)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeFollower)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeFollower))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &followerR{}
+		}
+		args = append(args, object.Actor)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &followerR{}
+			}
+
+			for _, a := range args {
+				if a == obj.Actor {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.Actor)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`actors`),
+		qm.WhereIn(`actors.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Actor")
+	}
+
+	var resultSlice []*Actor
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Actor")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for actors")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for actors")
+	}
+
+	if len(followerAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.FollowerActor = foreign
+		if foreign.R == nil {
+			foreign.R = &actorR{}
+		}
+		foreign.R.Followers = append(foreign.R.Followers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.Actor == foreign.ID {
+				local.R.FollowerActor = foreign
+				if foreign.R == nil {
+					foreign.R = &actorR{}
+				}
+				foreign.R.Followers = append(foreign.R.Followers, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadFollowerUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (followerL) LoadFollowerUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeFollower interface{}, mods queries.Applicator) error {
+	var slice []*Follower
+	var object *Follower
+
+	if singular {
+		var ok bool
+		object, ok = maybeFollower.(*Follower)
+		if !ok {
+			object = new(Follower)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeFollower)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeFollower))
+			}
+		}
+	} else {
+		s, ok := maybeFollower.(*[]*Follower)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeFollower)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeFollower))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &followerR{}
+		}
+		args = append(args, object.User)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &followerR{}
+			}
+
+			for _, a := range args {
+				if a == obj.User {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.User)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.username in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(followerAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.FollowerUser = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.Followers = append(foreign.R.Followers, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.User == foreign.Username {
+				local.R.FollowerUser = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.Followers = append(foreign.R.Followers, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetFollowerActor of the follower to the related item.
+// Sets o.R.FollowerActor to related.
+// Adds o to related.R.Followers.
+func (o *Follower) SetFollowerActor(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Actor) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"followers\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"actor"}),
+		strmangle.WhereClause("\"", "\"", 2, followerPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.User, o.Actor}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.Actor = related.ID
+	if o.R == nil {
+		o.R = &followerR{
+			FollowerActor: related,
+		}
+	} else {
+		o.R.FollowerActor = related
+	}
+
+	if related.R == nil {
+		related.R = &actorR{
+			Followers: FollowerSlice{o},
+		}
+	} else {
+		related.R.Followers = append(related.R.Followers, o)
+	}
+
+	return nil
+}
+
+// SetFollowerUser of the follower to the related item.
+// Sets o.R.FollowerUser to related.
+// Adds o to related.R.Followers.
+func (o *Follower) SetFollowerUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"followers\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user"}),
+		strmangle.WhereClause("\"", "\"", 2, followerPrimaryKeyColumns),
+	)
+	values := []interface{}{related.Username, o.User, o.Actor}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.User = related.Username
+	if o.R == nil {
+		o.R = &followerR{
+			FollowerUser: related,
+		}
+	} else {
+		o.R.FollowerUser = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			Followers: FollowerSlice{o},
+		}
+	} else {
+		related.R.Followers = append(related.R.Followers, o)
+	}
+
+	return nil
+}
+
+// Followers retrieves all the records using an executor.
+func Followers(mods ...qm.QueryMod) followerQuery {
+	mods = append(mods, qm.From("\"followers\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"followers\".*"})
+	}
+
+	return followerQuery{q}
+}
+
+// FindFollower retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindFollower(ctx context.Context, exec boil.ContextExecutor, user string, actor string, selectCols ...string) (*Follower, error) {
+	followerObj := &Follower{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"followers\" where \"user\"=$1 AND \"actor\"=$2", sel,
+	)
+
+	q := queries.Raw(query, user, actor)
+
+	err := q.Bind(ctx, exec, followerObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from followers")
+	}
+
+	if err = followerObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return followerObj, err
+	}
+
+	return followerObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Follower) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no followers provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(followerColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	followerInsertCacheMut.RLock()
+	cache, cached := followerInsertCache[key]
+	followerInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			followerAllColumns,
+			followerColumnsWithDefault,
+			followerColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(followerType, followerMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(followerType, followerMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"followers\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"followers\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into followers")
+	}
+
+	if !cached {
+		followerInsertCacheMut.Lock()
+		followerInsertCache[key] = cache
+		followerInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Follower.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Follower) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	followerUpdateCacheMut.RLock()
+	cache, cached := followerUpdateCache[key]
+	followerUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			followerAllColumns,
+			followerPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update followers, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"followers\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, followerPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(followerType, followerMapping, append(wl, followerPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update followers row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for followers")
+	}
+
+	if !cached {
+		followerUpdateCacheMut.Lock()
+		followerUpdateCache[key] = cache
+		followerUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q followerQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for followers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for followers")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o FollowerSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), followerPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"followers\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, followerPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in follower slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all follower")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Follower) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no followers provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(followerColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	followerUpsertCacheMut.RLock()
+	cache, cached := followerUpsertCache[key]
+	followerUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			followerAllColumns,
+			followerColumnsWithDefault,
+			followerColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			followerAllColumns,
+			followerPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert followers, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(followerPrimaryKeyColumns))
+			copy(conflict, followerPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"followers\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(followerType, followerMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(followerType, followerMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert followers")
+	}
+
+	if !cached {
+		followerUpsertCacheMut.Lock()
+		followerUpsertCache[key] = cache
+		followerUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Follower record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Follower) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Follower provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), followerPrimaryKeyMapping)
+	sql := "DELETE FROM \"followers\" WHERE \"user\"=$1 AND \"actor\"=$2"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from followers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for followers")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q followerQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no followerQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from followers")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for followers")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o FollowerSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(followerBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), followerPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"followers\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, followerPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from follower slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for followers")
+	}
+
+	if len(followerAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Follower) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindFollower(ctx, exec, o.User, o.Actor)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *FollowerSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := FollowerSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), followerPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"followers\".* FROM \"followers\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, followerPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in FollowerSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// FollowerExists checks if the Follower row exists.
+func FollowerExists(ctx context.Context, exec boil.ContextExecutor, user string, actor string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"followers\" where \"user\"=$1 AND \"actor\"=$2 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, user, actor)
+	}
+	row := exec.QueryRowContext(ctx, sql, user, actor)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if followers exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,1137 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// InboundActivity is an object representing the database table.
+type InboundActivity struct {
+	ID          string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ActivityID  string      `boil:"activity_id" json:"activity_id" toml:"activity_id" yaml:"activity_id"`
+	User        string      `boil:"user" json:"user" toml:"user" yaml:"user"`
+	Payload     types.JSON  `boil:"payload" json:"payload" toml:"payload" yaml:"payload"`
+	ReceivedAt  time.Time   `boil:"received_at" json:"received_at" toml:"received_at" yaml:"received_at"`
+	ProcessedAt null.Time   `boil:"processed_at" json:"processed_at,omitempty" toml:"processed_at" yaml:"processed_at,omitempty"`
+	Error       null.String `boil:"error" json:"error,omitempty" toml:"error" yaml:"error,omitempty"`
+
+	R *inboundActivityR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L inboundActivityL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var InboundActivityColumns = struct {
+	ID          string
+	ActivityID  string
+	User        string
+	Payload     string
+	ReceivedAt  string
+	ProcessedAt string
+	Error       string
+}{
+	ID:          "id",
+	ActivityID:  "activity_id",
+	User:        "user",
+	Payload:     "payload",
+	ReceivedAt:  "received_at",
+	ProcessedAt: "processed_at",
+	Error:       "error",
+}
+
+var InboundActivityTableColumns = struct {
+	ID          string
+	ActivityID  string
+	User        string
+	Payload     string
+	ReceivedAt  string
+	ProcessedAt string
+	Error       string
+}{
+	ID:          "inbound_activities.id",
+	ActivityID:  "inbound_activities.activity_id",
+	User:        "inbound_activities.user",
+	Payload:     "inbound_activities.payload",
+	ReceivedAt:  "inbound_activities.received_at",
+	ProcessedAt: "inbound_activities.processed_at",
+	Error:       "inbound_activities.error",
+}
+
+// Generated where
+
+type whereHelpertypes_JSON struct{ field string }
+
+func (w whereHelpertypes_JSON) EQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_JSON) NEQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_JSON) LT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_JSON) LTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_JSON) GT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_JSON) GTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var InboundActivityWhere = struct {
+	ID          whereHelperstring
+	ActivityID  whereHelperstring
+	User        whereHelperstring
+	Payload     whereHelpertypes_JSON
+	ReceivedAt  whereHelpertime_Time
+	ProcessedAt whereHelpernull_Time
+	Error       whereHelpernull_String
+}{
+	ID:          whereHelperstring{field: "\"inbound_activities\".\"id\""},
+	ActivityID:  whereHelperstring{field: "\"inbound_activities\".\"activity_id\""},
+	User:        whereHelperstring{field: "\"inbound_activities\".\"user\""},
+	Payload:     whereHelpertypes_JSON{field: "\"inbound_activities\".\"payload\""},
+	ReceivedAt:  whereHelpertime_Time{field: "\"inbound_activities\".\"received_at\""},
+	ProcessedAt: whereHelpernull_Time{field: "\"inbound_activities\".\"processed_at\""},
+	Error:       whereHelpernull_String{field: "\"inbound_activities\".\"error\""},
+}
+
+// InboundActivityRels is where relationship names are stored.
+var InboundActivityRels = struct {
+	InboundActivityUser string
+}{
+	InboundActivityUser: "InboundActivityUser",
+}
+
+// inboundActivityR is where relationships are stored.
+type inboundActivityR struct {
+	InboundActivityUser *User `boil:"InboundActivityUser" json:"InboundActivityUser" toml:"InboundActivityUser" yaml:"InboundActivityUser"`
+}
+
+// NewStruct creates a new relationship struct
+func (*inboundActivityR) NewStruct() *inboundActivityR {
+	return &inboundActivityR{}
+}
+
+func (r *inboundActivityR) GetInboundActivityUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.InboundActivityUser
+}
+
+// inboundActivityL is where Load methods for each relationship are stored.
+type inboundActivityL struct{}
+
+var (
+	inboundActivityAllColumns            = []string{"id", "activity_id", "user", "payload", "received_at", "processed_at", "error"}
+	inboundActivityColumnsWithoutDefault = []string{"id", "activity_id", "user", "payload", "received_at"}
+	inboundActivityColumnsWithDefault    = []string{"processed_at", "error"}
+	inboundActivityPrimaryKeyColumns     = []string{"id"}
+	inboundActivityGeneratedColumns      = []string{}
+)
+
+type (
+	// InboundActivitySlice is an alias for a slice of pointers to InboundActivity.
+	// This should almost always be used instead of []InboundActivity.
+	InboundActivitySlice []*InboundActivity
+	// InboundActivityHook is the signature for custom InboundActivity hook methods
+	InboundActivityHook func(context.Context, boil.ContextExecutor, *InboundActivity) error
+
+	inboundActivityQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	inboundActivityType                 = reflect.TypeOf(&InboundActivity{})
+	inboundActivityMapping              = queries.MakeStructMapping(inboundActivityType)
+	inboundActivityPrimaryKeyMapping, _ = queries.BindMapping(inboundActivityType, inboundActivityMapping, inboundActivityPrimaryKeyColumns)
+	inboundActivityInsertCacheMut       sync.RWMutex
+	inboundActivityInsertCache          = make(map[string]insertCache)
+	inboundActivityUpdateCacheMut       sync.RWMutex
+	inboundActivityUpdateCache          = make(map[string]updateCache)
+	inboundActivityUpsertCacheMut       sync.RWMutex
+	inboundActivityUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var inboundActivityAfterSelectHooks []InboundActivityHook
+
+var inboundActivityBeforeInsertHooks []InboundActivityHook
+var inboundActivityAfterInsertHooks []InboundActivityHook
+
+var inboundActivityBeforeUpdateHooks []InboundActivityHook
+var inboundActivityAfterUpdateHooks []InboundActivityHook
+
+var inboundActivityBeforeDeleteHooks []InboundActivityHook
+var inboundActivityAfterDeleteHooks []InboundActivityHook
+
+var inboundActivityBeforeUpsertHooks []InboundActivityHook
+var inboundActivityAfterUpsertHooks []InboundActivityHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *InboundActivity) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range inboundActivityAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *InboundActivity) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range inboundActivityBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *InboundActivity) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range inboundActivityAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *InboundActivity) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range inboundActivityBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *InboundActivity) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range inboundActivityAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *InboundActivity) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range inboundActivityBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *InboundActivity) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range inboundActivityAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *InboundActivity) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range inboundActivityBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *InboundActivity) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range inboundActivityAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddInboundActivityHook registers your hook function for all future operations.
+func AddInboundActivityHook(hookPoint boil.HookPoint, inboundActivityHook InboundActivityHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		inboundActivityAfterSelectHooks = append(inboundActivityAfterSelectHooks, inboundActivityHook)
+	case boil.BeforeInsertHook:
+		inboundActivityBeforeInsertHooks = append(inboundActivityBeforeInsertHooks, inboundActivityHook)
+	case boil.AfterInsertHook:
+		inboundActivityAfterInsertHooks = append(inboundActivityAfterInsertHooks, inboundActivityHook)
+	case boil.BeforeUpdateHook:
+		inboundActivityBeforeUpdateHooks = append(inboundActivityBeforeUpdateHooks, inboundActivityHook)
+	case boil.AfterUpdateHook:
+		inboundActivityAfterUpdateHooks = append(inboundActivityAfterUpdateHooks, inboundActivityHook)
+	case boil.BeforeDeleteHook:
+		inboundActivityBeforeDeleteHooks = append(inboundActivityBeforeDeleteHooks, inboundActivityHook)
+	case boil.AfterDeleteHook:
+		inboundActivityAfterDeleteHooks = append(inboundActivityAfterDeleteHooks, inboundActivityHook)
+	case boil.BeforeUpsertHook:
+		inboundActivityBeforeUpsertHooks = append(inboundActivityBeforeUpsertHooks, inboundActivityHook)
+	case boil.AfterUpsertHook:
+		inboundActivityAfterUpsertHooks = append(inboundActivityAfterUpsertHooks, inboundActivityHook)
+	}
+}
+
+// One returns a single inboundActivity record from the query.
+func (q inboundActivityQuery) One(ctx context.Context, exec boil.ContextExecutor) (*InboundActivity, error) {
+	o := &InboundActivity{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for inbound_activities")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all InboundActivity records from the query.
+func (q inboundActivityQuery) All(ctx context.Context, exec boil.ContextExecutor) (InboundActivitySlice, error) {
+	var o []*InboundActivity
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to InboundActivity slice")
+	}
+
+	if len(inboundActivityAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all InboundActivity records in the query.
+func (q inboundActivityQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count inbound_activities rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q inboundActivityQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if inbound_activities exists")
+	}
+
+	return count > 0, nil
+}
+
+// InboundActivityUser pointed to by the foreign key.
+func (o *InboundActivity) InboundActivityUser(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"username\" = ?", o.User),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// LoadInboundActivityUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (inboundActivityL) LoadInboundActivityUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInboundActivity interface{}, mods queries.Applicator) error {
+	var slice []*InboundActivity
+	var object *InboundActivity
+
+	if singular {
+		var ok bool
+		object, ok = maybeInboundActivity.(*InboundActivity)
+		if !ok {
+			object = new(InboundActivity)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInboundActivity)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInboundActivity))
+			}
+		}
+	} else {
+		s, ok := maybeInboundActivity.(*[]*InboundActivity)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInboundActivity)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInboundActivity))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &inboundActivityR{}
+		}
+		args = append(args, object.User)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &inboundActivityR{}
+			}
+
+			for _, a := range args {
+				if a == obj.User {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.User)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.username in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(inboundActivityAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.InboundActivityUser = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.InboundActivities = append(foreign.R.InboundActivities, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.User == foreign.Username {
+				local.R.InboundActivityUser = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.InboundActivities = append(foreign.R.InboundActivities, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetInboundActivityUser of the inboundActivity to the related item.
+// Sets o.R.InboundActivityUser to related.
+// Adds o to related.R.InboundActivities.
+func (o *InboundActivity) SetInboundActivityUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"inbound_activities\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user"}),
+		strmangle.WhereClause("\"", "\"", 2, inboundActivityPrimaryKeyColumns),
+	)
+	values := []interface{}{related.Username, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.User = related.Username
+	if o.R == nil {
+		o.R = &inboundActivityR{
+			InboundActivityUser: related,
+		}
+	} else {
+		o.R.InboundActivityUser = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			InboundActivities: InboundActivitySlice{o},
+		}
+	} else {
+		related.R.InboundActivities = append(related.R.InboundActivities, o)
+	}
+
+	return nil
+}
+
+// InboundActivities retrieves all the records using an executor.
+func InboundActivities(mods ...qm.QueryMod) inboundActivityQuery {
+	mods = append(mods, qm.From("\"inbound_activities\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"inbound_activities\".*"})
+	}
+
+	return inboundActivityQuery{q}
+}
+
+// FindInboundActivity retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindInboundActivity(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*InboundActivity, error) {
+	inboundActivityObj := &InboundActivity{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"inbound_activities\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, inboundActivityObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from inbound_activities")
+	}
+
+	if err = inboundActivityObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return inboundActivityObj, err
+	}
+
+	return inboundActivityObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *InboundActivity) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no inbound_activities provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(inboundActivityColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	inboundActivityInsertCacheMut.RLock()
+	cache, cached := inboundActivityInsertCache[key]
+	inboundActivityInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			inboundActivityAllColumns,
+			inboundActivityColumnsWithDefault,
+			inboundActivityColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(inboundActivityType, inboundActivityMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(inboundActivityType, inboundActivityMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"inbound_activities\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"inbound_activities\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into inbound_activities")
+	}
+
+	if !cached {
+		inboundActivityInsertCacheMut.Lock()
+		inboundActivityInsertCache[key] = cache
+		inboundActivityInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the InboundActivity.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *InboundActivity) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	inboundActivityUpdateCacheMut.RLock()
+	cache, cached := inboundActivityUpdateCache[key]
+	inboundActivityUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			inboundActivityAllColumns,
+			inboundActivityPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update inbound_activities, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"inbound_activities\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, inboundActivityPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(inboundActivityType, inboundActivityMapping, append(wl, inboundActivityPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update inbound_activities row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for inbound_activities")
+	}
+
+	if !cached {
+		inboundActivityUpdateCacheMut.Lock()
+		inboundActivityUpdateCache[key] = cache
+		inboundActivityUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q inboundActivityQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for inbound_activities")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for inbound_activities")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o InboundActivitySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), inboundActivityPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"inbound_activities\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, inboundActivityPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in inboundActivity slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all inboundActivity")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *InboundActivity) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no inbound_activities provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(inboundActivityColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	inboundActivityUpsertCacheMut.RLock()
+	cache, cached := inboundActivityUpsertCache[key]
+	inboundActivityUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			inboundActivityAllColumns,
+			inboundActivityColumnsWithDefault,
+			inboundActivityColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			inboundActivityAllColumns,
+			inboundActivityPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert inbound_activities, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(inboundActivityPrimaryKeyColumns))
+			copy(conflict, inboundActivityPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"inbound_activities\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(inboundActivityType, inboundActivityMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(inboundActivityType, inboundActivityMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert inbound_activities")
+	}
+
+	if !cached {
+		inboundActivityUpsertCacheMut.Lock()
+		inboundActivityUpsertCache[key] = cache
+		inboundActivityUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single InboundActivity record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *InboundActivity) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no InboundActivity provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), inboundActivityPrimaryKeyMapping)
+	sql := "DELETE FROM \"inbound_activities\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from inbound_activities")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for inbound_activities")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q inboundActivityQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no inboundActivityQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from inbound_activities")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for inbound_activities")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o InboundActivitySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(inboundActivityBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), inboundActivityPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"inbound_activities\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, inboundActivityPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from inboundActivity slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for inbound_activities")
+	}
+
+	if len(inboundActivityAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *InboundActivity) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindInboundActivity(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *InboundActivitySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := InboundActivitySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), inboundActivityPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"inbound_activities\".* FROM \"inbound_activities\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, inboundActivityPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in InboundActivitySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// InboundActivityExists checks if the InboundActivity row exists.
+func InboundActivityExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"inbound_activities\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if inbound_activities exists")
+	}
+
+	return exists, nil
+}
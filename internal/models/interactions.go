@@ -0,0 +1,1345 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Interaction is an object representing the database table.
+type Interaction struct {
+	ID          string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ActivityID  string      `boil:"activity_id" json:"activity_id" toml:"activity_id" yaml:"activity_id"`
+	Type        string      `boil:"type" json:"type" toml:"type" yaml:"type"`
+	User        string      `boil:"user" json:"user" toml:"user" yaml:"user"`
+	TweetID     string      `boil:"tweet_id" json:"tweet_id" toml:"tweet_id" yaml:"tweet_id"`
+	Actor       string      `boil:"actor" json:"actor" toml:"actor" yaml:"actor"`
+	ObjectID    null.String `boil:"object_id" json:"object_id,omitempty" toml:"object_id" yaml:"object_id,omitempty"`
+	ObjectURL   null.String `boil:"object_url" json:"object_url,omitempty" toml:"object_url" yaml:"object_url,omitempty"`
+	Content     null.String `boil:"content" json:"content,omitempty" toml:"content" yaml:"content,omitempty"`
+	PublishedAt null.Time   `boil:"published_at" json:"published_at,omitempty" toml:"published_at" yaml:"published_at,omitempty"`
+	CreatedAt   time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *interactionR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L interactionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var InteractionColumns = struct {
+	ID          string
+	ActivityID  string
+	Type        string
+	User        string
+	TweetID     string
+	Actor       string
+	ObjectID    string
+	ObjectURL   string
+	Content     string
+	PublishedAt string
+	CreatedAt   string
+}{
+	ID:          "id",
+	ActivityID:  "activity_id",
+	Type:        "type",
+	User:        "user",
+	TweetID:     "tweet_id",
+	Actor:       "actor",
+	ObjectID:    "object_id",
+	ObjectURL:   "object_url",
+	Content:     "content",
+	PublishedAt: "published_at",
+	CreatedAt:   "created_at",
+}
+
+var InteractionTableColumns = struct {
+	ID          string
+	ActivityID  string
+	Type        string
+	User        string
+	TweetID     string
+	Actor       string
+	ObjectID    string
+	ObjectURL   string
+	Content     string
+	PublishedAt string
+	CreatedAt   string
+}{
+	ID:          "interactions.id",
+	ActivityID:  "interactions.activity_id",
+	Type:        "interactions.type",
+	User:        "interactions.user",
+	TweetID:     "interactions.tweet_id",
+	Actor:       "interactions.actor",
+	ObjectID:    "interactions.object_id",
+	ObjectURL:   "interactions.object_url",
+	Content:     "interactions.content",
+	PublishedAt: "interactions.published_at",
+	CreatedAt:   "interactions.created_at",
+}
+
+// Generated where
+
+var InteractionWhere = struct {
+	ID          whereHelperstring
+	ActivityID  whereHelperstring
+	Type        whereHelperstring
+	User        whereHelperstring
+	TweetID     whereHelperstring
+	Actor       whereHelperstring
+	ObjectID    whereHelpernull_String
+	ObjectURL   whereHelpernull_String
+	Content     whereHelpernull_String
+	PublishedAt whereHelpernull_Time
+	CreatedAt   whereHelpertime_Time
+}{
+	ID:          whereHelperstring{field: "\"interactions\".\"id\""},
+	ActivityID:  whereHelperstring{field: "\"interactions\".\"activity_id\""},
+	Type:        whereHelperstring{field: "\"interactions\".\"type\""},
+	User:        whereHelperstring{field: "\"interactions\".\"user\""},
+	TweetID:     whereHelperstring{field: "\"interactions\".\"tweet_id\""},
+	Actor:       whereHelperstring{field: "\"interactions\".\"actor\""},
+	ObjectID:    whereHelpernull_String{field: "\"interactions\".\"object_id\""},
+	ObjectURL:   whereHelpernull_String{field: "\"interactions\".\"object_url\""},
+	Content:     whereHelpernull_String{field: "\"interactions\".\"content\""},
+	PublishedAt: whereHelpernull_Time{field: "\"interactions\".\"published_at\""},
+	CreatedAt:   whereHelpertime_Time{field: "\"interactions\".\"created_at\""},
+}
+
+// InteractionRels is where relationship names are stored.
+var InteractionRels = struct {
+	InteractionActor string
+	InteractionUser  string
+}{
+	InteractionActor: "InteractionActor",
+	InteractionUser:  "InteractionUser",
+}
+
+// interactionR is where relationships are stored.
+type interactionR struct {
+	InteractionActor *Actor `boil:"InteractionActor" json:"InteractionActor" toml:"InteractionActor" yaml:"InteractionActor"`
+	InteractionUser  *User  `boil:"InteractionUser" json:"InteractionUser" toml:"InteractionUser" yaml:"InteractionUser"`
+}
+
+// NewStruct creates a new relationship struct
+func (*interactionR) NewStruct() *interactionR {
+	return &interactionR{}
+}
+
+func (r *interactionR) GetInteractionActor() *Actor {
+	if r == nil {
+		return nil
+	}
+	return r.InteractionActor
+}
+
+func (r *interactionR) GetInteractionUser() *User {
+	if r == nil {
+		return nil
+	}
+	return r.InteractionUser
+}
+
+// interactionL is where Load methods for each relationship are stored.
+type interactionL struct{}
+
+var (
+	interactionAllColumns            = []string{"id", "activity_id", "type", "user", "tweet_id", "actor", "object_id", "object_url", "content", "published_at", "created_at"}
+	interactionColumnsWithoutDefault = []string{"id", "activity_id", "type", "user", "tweet_id", "actor"}
+	interactionColumnsWithDefault    = []string{"object_id", "object_url", "content", "published_at", "created_at"}
+	interactionPrimaryKeyColumns     = []string{"id"}
+	interactionGeneratedColumns      = []string{}
+)
+
+type (
+	// InteractionSlice is an alias for a slice of pointers to Interaction.
+	// This should almost always be used instead of []Interaction.
+	InteractionSlice []*Interaction
+	// InteractionHook is the signature for custom Interaction hook methods
+	InteractionHook func(context.Context, boil.ContextExecutor, *Interaction) error
+
+	interactionQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	interactionType                 = reflect.TypeOf(&Interaction{})
+	interactionMapping              = queries.MakeStructMapping(interactionType)
+	interactionPrimaryKeyMapping, _ = queries.BindMapping(interactionType, interactionMapping, interactionPrimaryKeyColumns)
+	interactionInsertCacheMut       sync.RWMutex
+	interactionInsertCache          = make(map[string]insertCache)
+	interactionUpdateCacheMut       sync.RWMutex
+	interactionUpdateCache          = make(map[string]updateCache)
+	interactionUpsertCacheMut       sync.RWMutex
+	interactionUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var interactionAfterSelectHooks []InteractionHook
+
+var interactionBeforeInsertHooks []InteractionHook
+var interactionAfterInsertHooks []InteractionHook
+
+var interactionBeforeUpdateHooks []InteractionHook
+var interactionAfterUpdateHooks []InteractionHook
+
+var interactionBeforeDeleteHooks []InteractionHook
+var interactionAfterDeleteHooks []InteractionHook
+
+var interactionBeforeUpsertHooks []InteractionHook
+var interactionAfterUpsertHooks []InteractionHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Interaction) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range interactionAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Interaction) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range interactionBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Interaction) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range interactionAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Interaction) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range interactionBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Interaction) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range interactionAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Interaction) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range interactionBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Interaction) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range interactionAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Interaction) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range interactionBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Interaction) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range interactionAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddInteractionHook registers your hook function for all future operations.
+func AddInteractionHook(hookPoint boil.HookPoint, interactionHook InteractionHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		interactionAfterSelectHooks = append(interactionAfterSelectHooks, interactionHook)
+	case boil.BeforeInsertHook:
+		interactionBeforeInsertHooks = append(interactionBeforeInsertHooks, interactionHook)
+	case boil.AfterInsertHook:
+		interactionAfterInsertHooks = append(interactionAfterInsertHooks, interactionHook)
+	case boil.BeforeUpdateHook:
+		interactionBeforeUpdateHooks = append(interactionBeforeUpdateHooks, interactionHook)
+	case boil.AfterUpdateHook:
+		interactionAfterUpdateHooks = append(interactionAfterUpdateHooks, interactionHook)
+	case boil.BeforeDeleteHook:
+		interactionBeforeDeleteHooks = append(interactionBeforeDeleteHooks, interactionHook)
+	case boil.AfterDeleteHook:
+		interactionAfterDeleteHooks = append(interactionAfterDeleteHooks, interactionHook)
+	case boil.BeforeUpsertHook:
+		interactionBeforeUpsertHooks = append(interactionBeforeUpsertHooks, interactionHook)
+	case boil.AfterUpsertHook:
+		interactionAfterUpsertHooks = append(interactionAfterUpsertHooks, interactionHook)
+	}
+}
+
+// One returns a single interaction record from the query.
+func (q interactionQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Interaction, error) {
+	o := &Interaction{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for interactions")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Interaction records from the query.
+func (q interactionQuery) All(ctx context.Context, exec boil.ContextExecutor) (InteractionSlice, error) {
+	var o []*Interaction
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Interaction slice")
+	}
+
+	if len(interactionAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Interaction records in the query.
+func (q interactionQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count interactions rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q interactionQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if interactions exists")
+	}
+
+	return count > 0, nil
+}
+
+// InteractionActor pointed to by the foreign key.
+func (o *Interaction) InteractionActor(mods ...qm.QueryMod) actorQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.Actor),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Actors(queryMods...)
+}
+
+// InteractionUser pointed to by the foreign key.
+func (o *Interaction) InteractionUser(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"username\" = ?", o.User),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// LoadInteractionActor allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (interactionL) LoadInteractionActor(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInteraction interface{}, mods queries.Applicator) error {
+	var slice []*Interaction
+	var object *Interaction
+
+	if singular {
+		var ok bool
+		object, ok = maybeInteraction.(*Interaction)
+		if !ok {
+			object = new(Interaction)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInteraction)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInteraction))
+			}
+		}
+	} else {
+		s, ok := maybeInteraction.(*[]*Interaction)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInteraction)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInteraction))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &interactionR{}
+		}
+		args = append(args, object.Actor)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &interactionR{}
+			}
+
+			for _, a := range args {
+				if a == obj.Actor {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.Actor)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`actors`),
+		qm.WhereIn(`actors.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Actor")
+	}
+
+	var resultSlice []*Actor
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Actor")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for actors")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for actors")
+	}
+
+	if len(interactionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.InteractionActor = foreign
+		if foreign.R == nil {
+			foreign.R = &actorR{}
+		}
+		foreign.R.Interactions = append(foreign.R.Interactions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.Actor == foreign.ID {
+				local.R.InteractionActor = foreign
+				if foreign.R == nil {
+					foreign.R = &actorR{}
+				}
+				foreign.R.Interactions = append(foreign.R.Interactions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadInteractionUser allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (interactionL) LoadInteractionUser(ctx context.Context, e boil.ContextExecutor, singular bool, maybeInteraction interface{}, mods queries.Applicator) error {
+	var slice []*Interaction
+	var object *Interaction
+
+	if singular {
+		var ok bool
+		object, ok = maybeInteraction.(*Interaction)
+		if !ok {
+			object = new(Interaction)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeInteraction)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeInteraction))
+			}
+		}
+	} else {
+		s, ok := maybeInteraction.(*[]*Interaction)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeInteraction)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeInteraction))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &interactionR{}
+		}
+		args = append(args, object.User)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &interactionR{}
+			}
+
+			for _, a := range args {
+				if a == obj.User {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.User)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`users`),
+		qm.WhereIn(`users.username in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(interactionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.InteractionUser = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.Interactions = append(foreign.R.Interactions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.User == foreign.Username {
+				local.R.InteractionUser = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.Interactions = append(foreign.R.Interactions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetInteractionActor of the interaction to the related item.
+// Sets o.R.InteractionActor to related.
+// Adds o to related.R.Interactions.
+func (o *Interaction) SetInteractionActor(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Actor) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"interactions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"actor"}),
+		strmangle.WhereClause("\"", "\"", 2, interactionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.Actor = related.ID
+	if o.R == nil {
+		o.R = &interactionR{
+			InteractionActor: related,
+		}
+	} else {
+		o.R.InteractionActor = related
+	}
+
+	if related.R == nil {
+		related.R = &actorR{
+			Interactions: InteractionSlice{o},
+		}
+	} else {
+		related.R.Interactions = append(related.R.Interactions, o)
+	}
+
+	return nil
+}
+
+// SetInteractionUser of the interaction to the related item.
+// Sets o.R.InteractionUser to related.
+// Adds o to related.R.Interactions.
+func (o *Interaction) SetInteractionUser(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"interactions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"user"}),
+		strmangle.WhereClause("\"", "\"", 2, interactionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.Username, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.User = related.Username
+	if o.R == nil {
+		o.R = &interactionR{
+			InteractionUser: related,
+		}
+	} else {
+		o.R.InteractionUser = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			Interactions: InteractionSlice{o},
+		}
+	} else {
+		related.R.Interactions = append(related.R.Interactions, o)
+	}
+
+	return nil
+}
+
+// Interactions retrieves all the records using an executor.
+func Interactions(mods ...qm.QueryMod) interactionQuery {
+	mods = append(mods, qm.From("\"interactions\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"interactions\".*"})
+	}
+
+	return interactionQuery{q}
+}
+
+// FindInteraction retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindInteraction(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*Interaction, error) {
+	interactionObj := &Interaction{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"interactions\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, interactionObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from interactions")
+	}
+
+	if err = interactionObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return interactionObj, err
+	}
+
+	return interactionObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Interaction) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no interactions provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(interactionColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	interactionInsertCacheMut.RLock()
+	cache, cached := interactionInsertCache[key]
+	interactionInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			interactionAllColumns,
+			interactionColumnsWithDefault,
+			interactionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(interactionType, interactionMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(interactionType, interactionMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"interactions\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"interactions\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into interactions")
+	}
+
+	if !cached {
+		interactionInsertCacheMut.Lock()
+		interactionInsertCache[key] = cache
+		interactionInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Interaction.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Interaction) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	interactionUpdateCacheMut.RLock()
+	cache, cached := interactionUpdateCache[key]
+	interactionUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			interactionAllColumns,
+			interactionPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update interactions, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"interactions\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, interactionPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(interactionType, interactionMapping, append(wl, interactionPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update interactions row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for interactions")
+	}
+
+	if !cached {
+		interactionUpdateCacheMut.Lock()
+		interactionUpdateCache[key] = cache
+		interactionUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q interactionQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for interactions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for interactions")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o InteractionSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), interactionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"interactions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, interactionPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in interaction slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all interaction")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Interaction) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no interactions provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(interactionColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	interactionUpsertCacheMut.RLock()
+	cache, cached := interactionUpsertCache[key]
+	interactionUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			interactionAllColumns,
+			interactionColumnsWithDefault,
+			interactionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			interactionAllColumns,
+			interactionPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert interactions, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(interactionPrimaryKeyColumns))
+			copy(conflict, interactionPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"interactions\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(interactionType, interactionMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(interactionType, interactionMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert interactions")
+	}
+
+	if !cached {
+		interactionUpsertCacheMut.Lock()
+		interactionUpsertCache[key] = cache
+		interactionUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Interaction record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Interaction) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Interaction provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), interactionPrimaryKeyMapping)
+	sql := "DELETE FROM \"interactions\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from interactions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for interactions")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q interactionQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no interactionQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from interactions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for interactions")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o InteractionSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(interactionBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), interactionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"interactions\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, interactionPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from interaction slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for interactions")
+	}
+
+	if len(interactionAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Interaction) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindInteraction(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *InteractionSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := InteractionSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), interactionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"interactions\".* FROM \"interactions\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, interactionPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in InteractionSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// InteractionExists checks if the Interaction row exists.
+func InteractionExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"interactions\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if interactions exists")
+	}
+
+	return exists, nil
+}
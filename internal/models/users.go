@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,10 +24,12 @@ import (
 
 // User is an object representing the database table.
 type User struct {
-	Username   string    `boil:"username" json:"username" toml:"username" yaml:"username"`
-	ID         string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	PrivateKey []byte    `boil:"private_key" json:"private_key" toml:"private_key" yaml:"private_key"`
-	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	Username   string      `boil:"username" json:"username" toml:"username" yaml:"username"`
+	ID         string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	PrivateKey []byte      `boil:"private_key" json:"private_key" toml:"private_key" yaml:"private_key"`
+	CreatedAt  time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	MovedTo    null.String `boil:"moved_to" json:"moved_to,omitempty" toml:"moved_to" yaml:"moved_to,omitempty"`
+	MovedAt    null.Time   `boil:"moved_at" json:"moved_at,omitempty" toml:"moved_at" yaml:"moved_at,omitempty"`
 
 	R *userR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L userL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -37,11 +40,15 @@ var UserColumns = struct {
 	ID         string
 	PrivateKey string
 	CreatedAt  string
+	MovedTo    string
+	MovedAt    string
 }{
 	Username:   "username",
 	ID:         "id",
 	PrivateKey: "private_key",
 	CreatedAt:  "created_at",
+	MovedTo:    "moved_to",
+	MovedAt:    "moved_at",
 }
 
 var UserTableColumns = struct {
@@ -49,58 +56,54 @@ var UserTableColumns = struct {
 	ID         string
 	PrivateKey string
 	CreatedAt  string
+	MovedTo    string
+	MovedAt    string
 }{
 	Username:   "users.username",
 	ID:         "users.id",
 	PrivateKey: "users.private_key",
 	CreatedAt:  "users.created_at",
+	MovedTo:    "users.moved_to",
+	MovedAt:    "users.moved_at",
 }
 
 // Generated where
 
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 var UserWhere = struct {
 	Username   whereHelperstring
 	ID         whereHelperstring
 	PrivateKey whereHelper__byte
 	CreatedAt  whereHelpertime_Time
+	MovedTo    whereHelpernull_String
+	MovedAt    whereHelpernull_Time
 }{
 	Username:   whereHelperstring{field: "\"users\".\"username\""},
 	ID:         whereHelperstring{field: "\"users\".\"id\""},
 	PrivateKey: whereHelper__byte{field: "\"users\".\"private_key\""},
 	CreatedAt:  whereHelpertime_Time{field: "\"users\".\"created_at\""},
+	MovedTo:    whereHelpernull_String{field: "\"users\".\"moved_to\""},
+	MovedAt:    whereHelpernull_Time{field: "\"users\".\"moved_at\""},
 }
 
 // UserRels is where relationship names are stored.
 var UserRels = struct {
-	Actors string
+	FediverseLinks    string
+	Followers         string
+	InboundActivities string
+	Interactions      string
 }{
-	Actors: "Actors",
+	FediverseLinks:    "FediverseLinks",
+	Followers:         "Followers",
+	InboundActivities: "InboundActivities",
+	Interactions:      "Interactions",
 }
 
 // userR is where relationships are stored.
 type userR struct {
-	Actors ActorSlice `boil:"Actors" json:"Actors" toml:"Actors" yaml:"Actors"`
+	FediverseLinks    FediverseLinkSlice   `boil:"FediverseLinks" json:"FediverseLinks" toml:"FediverseLinks" yaml:"FediverseLinks"`
+	Followers         FollowerSlice        `boil:"Followers" json:"Followers" toml:"Followers" yaml:"Followers"`
+	InboundActivities InboundActivitySlice `boil:"InboundActivities" json:"InboundActivities" toml:"InboundActivities" yaml:"InboundActivities"`
+	Interactions      InteractionSlice     `boil:"Interactions" json:"Interactions" toml:"Interactions" yaml:"Interactions"`
 }
 
 // NewStruct creates a new relationship struct
@@ -108,20 +111,41 @@ func (*userR) NewStruct() *userR {
 	return &userR{}
 }
 
-func (r *userR) GetActors() ActorSlice {
+func (r *userR) GetFediverseLinks() FediverseLinkSlice {
 	if r == nil {
 		return nil
 	}
-	return r.Actors
+	return r.FediverseLinks
+}
+
+func (r *userR) GetFollowers() FollowerSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Followers
+}
+
+func (r *userR) GetInboundActivities() InboundActivitySlice {
+	if r == nil {
+		return nil
+	}
+	return r.InboundActivities
+}
+
+func (r *userR) GetInteractions() InteractionSlice {
+	if r == nil {
+		return nil
+	}
+	return r.Interactions
 }
 
 // userL is where Load methods for each relationship are stored.
 type userL struct{}
 
 var (
-	userAllColumns            = []string{"username", "id", "private_key", "created_at"}
+	userAllColumns            = []string{"username", "id", "private_key", "created_at", "moved_to", "moved_at"}
 	userColumnsWithoutDefault = []string{"username", "id", "private_key", "created_at"}
-	userColumnsWithDefault    = []string{}
+	userColumnsWithDefault    = []string{"moved_to", "moved_at"}
 	userPrimaryKeyColumns     = []string{"username"}
 	userGeneratedColumns      = []string{}
 )
@@ -404,24 +428,65 @@ func (q userQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool,
 	return count > 0, nil
 }
 
-// Actors retrieves all the actor's Actors with an executor.
-func (o *User) Actors(mods ...qm.QueryMod) actorQuery {
+// FediverseLinks retrieves all the fediverse_link's FediverseLinks with an executor.
+func (o *User) FediverseLinks(mods ...qm.QueryMod) fediverseLinkQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"fediverse_links\".\"user\"=?", o.Username),
+	)
+
+	return FediverseLinks(queryMods...)
+}
+
+// Followers retrieves all the follower's Followers with an executor.
+func (o *User) Followers(mods ...qm.QueryMod) followerQuery {
 	var queryMods []qm.QueryMod
 	if len(mods) != 0 {
 		queryMods = append(queryMods, mods...)
 	}
 
 	queryMods = append(queryMods,
-		qm.InnerJoin("\"followers\" on \"actors\".\"id\" = \"followers\".\"actor\""),
 		qm.Where("\"followers\".\"user\"=?", o.Username),
 	)
 
-	return Actors(queryMods...)
+	return Followers(queryMods...)
+}
+
+// InboundActivities retrieves all the inbound_activity's InboundActivities with an executor.
+func (o *User) InboundActivities(mods ...qm.QueryMod) inboundActivityQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"inbound_activities\".\"user\"=?", o.Username),
+	)
+
+	return InboundActivities(queryMods...)
+}
+
+// Interactions retrieves all the interaction's Interactions with an executor.
+func (o *User) Interactions(mods ...qm.QueryMod) interactionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"interactions\".\"user\"=?", o.Username),
+	)
+
+	return Interactions(queryMods...)
 }
 
-// LoadActors allows an eager lookup of values, cached into the
+// LoadFediverseLinks allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userL) LoadActors(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+func (userL) LoadFediverseLinks(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
 	var slice []*User
 	var object *User
 
@@ -475,10 +540,8 @@ func (userL) LoadActors(ctx context.Context, e boil.ContextExecutor, singular bo
 	}
 
 	query := NewQuery(
-		qm.Select("\"actors\".\"id\", \"actors\".\"url\", \"actors\".\"public_key\", \"a\".\"user\""),
-		qm.From("\"actors\""),
-		qm.InnerJoin("\"followers\" as \"a\" on \"actors\".\"id\" = \"a\".\"actor\""),
-		qm.WhereIn("\"a\".\"user\" in ?", args...),
+		qm.From(`fediverse_links`),
+		qm.WhereIn(`fediverse_links.user in ?`, args...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -486,36 +549,136 @@ func (userL) LoadActors(ctx context.Context, e boil.ContextExecutor, singular bo
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load actors")
+		return errors.Wrap(err, "failed to eager load fediverse_links")
 	}
 
-	var resultSlice []*Actor
+	var resultSlice []*FediverseLink
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice fediverse_links")
+	}
 
-	var localJoinCols []string
-	for results.Next() {
-		one := new(Actor)
-		var localJoinCol string
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on fediverse_links")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for fediverse_links")
+	}
 
-		err = results.Scan(&one.ID, &one.URL, &one.PublicKey, &localJoinCol)
-		if err != nil {
-			return errors.Wrap(err, "failed to scan eager loaded results for actors")
+	if len(fediverseLinkAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.FediverseLinks = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &fediverseLinkR{}
+			}
+			foreign.R.FediverseLinkUser = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.Username == foreign.User {
+				local.R.FediverseLinks = append(local.R.FediverseLinks, foreign)
+				if foreign.R == nil {
+					foreign.R = &fediverseLinkR{}
+				}
+				foreign.R.FediverseLinkUser = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadFollowers allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadFollowers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
 		}
-		if err = results.Err(); err != nil {
-			return errors.Wrap(err, "failed to plebian-bind eager loaded slice actors")
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
 		}
+		args = append(args, object.Username)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
 
-		resultSlice = append(resultSlice, one)
-		localJoinCols = append(localJoinCols, localJoinCol)
+			for _, a := range args {
+				if a == obj.Username {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.Username)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`followers`),
+		qm.WhereIn(`followers.user in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load followers")
+	}
+
+	var resultSlice []*Follower
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice followers")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on actors")
+		return errors.Wrap(err, "failed to close results in eager load on followers")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for actors")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for followers")
 	}
 
-	if len(actorAfterSelectHooks) != 0 {
+	if len(followerAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -523,25 +686,24 @@ func (userL) LoadActors(ctx context.Context, e boil.ContextExecutor, singular bo
 		}
 	}
 	if singular {
-		object.R.Actors = resultSlice
+		object.R.Followers = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &actorR{}
+				foreign.R = &followerR{}
 			}
-			foreign.R.Users = append(foreign.R.Users, object)
+			foreign.R.FollowerUser = object
 		}
 		return nil
 	}
 
-	for i, foreign := range resultSlice {
-		localJoinCol := localJoinCols[i]
+	for _, foreign := range resultSlice {
 		for _, local := range slice {
-			if local.Username == localJoinCol {
-				local.R.Actors = append(local.R.Actors, foreign)
+			if local.Username == foreign.User {
+				local.R.Followers = append(local.R.Followers, foreign)
 				if foreign.R == nil {
-					foreign.R = &actorR{}
+					foreign.R = &followerR{}
 				}
-				foreign.R.Users = append(foreign.R.Users, local)
+				foreign.R.FollowerUser = local
 				break
 			}
 		}
@@ -550,149 +712,444 @@ func (userL) LoadActors(ctx context.Context, e boil.ContextExecutor, singular bo
 	return nil
 }
 
-// AddActors adds the given related objects to the existing relationships
-// of the user, optionally inserting them as new records.
-// Appends related to o.R.Actors.
-// Sets related.R.Users appropriately.
-func (o *User) AddActors(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Actor) error {
-	var err error
-	for _, rel := range related {
-		if insert {
-			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
-				return errors.Wrap(err, "failed to insert into foreign table")
+// LoadInboundActivities allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadInboundActivities(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
 			}
 		}
 	}
 
-	for _, rel := range related {
-		query := "insert into \"followers\" (\"user\", \"actor\") values ($1, $2)"
-		values := []interface{}{o.Username, rel.ID}
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.Username)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
 
-		if boil.IsDebug(ctx) {
-			writer := boil.DebugWriterFrom(ctx)
-			fmt.Fprintln(writer, query)
-			fmt.Fprintln(writer, values)
+			for _, a := range args {
+				if a == obj.Username {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.Username)
 		}
-		_, err = exec.ExecContext(ctx, query, values...)
-		if err != nil {
-			return errors.Wrap(err, "failed to insert into join table")
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`inbound_activities`),
+		qm.WhereIn(`inbound_activities.user in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load inbound_activities")
+	}
+
+	var resultSlice []*InboundActivity
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice inbound_activities")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on inbound_activities")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for inbound_activities")
+	}
+
+	if len(inboundActivityAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
 		}
 	}
-	if o.R == nil {
-		o.R = &userR{
-			Actors: related,
+	if singular {
+		object.R.InboundActivities = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &inboundActivityR{}
+			}
+			foreign.R.InboundActivityUser = object
 		}
-	} else {
-		o.R.Actors = append(o.R.Actors, related...)
+		return nil
 	}
 
-	for _, rel := range related {
-		if rel.R == nil {
-			rel.R = &actorR{
-				Users: UserSlice{o},
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.Username == foreign.User {
+				local.R.InboundActivities = append(local.R.InboundActivities, foreign)
+				if foreign.R == nil {
+					foreign.R = &inboundActivityR{}
+				}
+				foreign.R.InboundActivityUser = local
+				break
 			}
-		} else {
-			rel.R.Users = append(rel.R.Users, o)
 		}
 	}
+
 	return nil
 }
 
-// SetActors removes all previously related items of the
-// user replacing them completely with the passed
-// in related items, optionally inserting them as new records.
-// Sets o.R.Users's Actors accordingly.
-// Replaces o.R.Actors with related.
-// Sets related.R.Users's Actors accordingly.
-func (o *User) SetActors(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Actor) error {
-	query := "delete from \"followers\" where \"user\" = $1"
-	values := []interface{}{o.Username}
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, query)
-		fmt.Fprintln(writer, values)
+// LoadInteractions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadInteractions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.Username)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.Username {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.Username)
+		}
 	}
-	_, err := exec.ExecContext(ctx, query, values...)
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`interactions`),
+		qm.WhereIn(`interactions.user in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to remove relationships before set")
+		return errors.Wrap(err, "failed to eager load interactions")
 	}
 
-	removeActorsFromUsersSlice(o, related)
-	if o.R != nil {
-		o.R.Actors = nil
+	var resultSlice []*Interaction
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice interactions")
 	}
 
-	return o.AddActors(ctx, exec, insert, related...)
-}
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on interactions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for interactions")
+	}
 
-// RemoveActors relationships from objects passed in.
-// Removes related items from R.Actors (uses pointer comparison, removal does not keep order)
-// Sets related.R.Users.
-func (o *User) RemoveActors(ctx context.Context, exec boil.ContextExecutor, related ...*Actor) error {
-	if len(related) == 0 {
+	if len(interactionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Interactions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &interactionR{}
+			}
+			foreign.R.InteractionUser = object
+		}
 		return nil
 	}
 
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.Username == foreign.User {
+				local.R.Interactions = append(local.R.Interactions, foreign)
+				if foreign.R == nil {
+					foreign.R = &interactionR{}
+				}
+				foreign.R.InteractionUser = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddFediverseLinks adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.FediverseLinks.
+// Sets related.R.FediverseLinkUser appropriately.
+func (o *User) AddFediverseLinks(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*FediverseLink) error {
 	var err error
-	query := fmt.Sprintf(
-		"delete from \"followers\" where \"user\" = $1 and \"actor\" in (%s)",
-		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
-	)
-	values := []interface{}{o.Username}
 	for _, rel := range related {
-		values = append(values, rel.ID)
-	}
+		if insert {
+			rel.User = o.Username
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"fediverse_links\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user"}),
+				strmangle.WhereClause("\"", "\"", 2, fediverseLinkPrimaryKeyColumns),
+			)
+			values := []interface{}{o.Username, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
 
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, query)
-		fmt.Fprintln(writer, values)
-	}
-	_, err = exec.ExecContext(ctx, query, values...)
-	if err != nil {
-		return errors.Wrap(err, "failed to remove relationships before set")
+			rel.User = o.Username
+		}
 	}
-	removeActorsFromUsersSlice(o, related)
+
 	if o.R == nil {
-		return nil
+		o.R = &userR{
+			FediverseLinks: related,
+		}
+	} else {
+		o.R.FediverseLinks = append(o.R.FediverseLinks, related...)
 	}
 
 	for _, rel := range related {
-		for i, ri := range o.R.Actors {
-			if rel != ri {
-				continue
+		if rel.R == nil {
+			rel.R = &fediverseLinkR{
+				FediverseLinkUser: o,
 			}
+		} else {
+			rel.R.FediverseLinkUser = o
+		}
+	}
+	return nil
+}
 
-			ln := len(o.R.Actors)
-			if ln > 1 && i < ln-1 {
-				o.R.Actors[i] = o.R.Actors[ln-1]
+// AddFollowers adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Followers.
+// Sets related.R.FollowerUser appropriately.
+func (o *User) AddFollowers(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Follower) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.User = o.Username
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"followers\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user"}),
+				strmangle.WhereClause("\"", "\"", 2, followerPrimaryKeyColumns),
+			)
+			values := []interface{}{o.Username, rel.User, rel.Actor}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
 			}
-			o.R.Actors = o.R.Actors[:ln-1]
-			break
+
+			rel.User = o.Username
 		}
 	}
 
+	if o.R == nil {
+		o.R = &userR{
+			Followers: related,
+		}
+	} else {
+		o.R.Followers = append(o.R.Followers, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &followerR{
+				FollowerUser: o,
+			}
+		} else {
+			rel.R.FollowerUser = o
+		}
+	}
 	return nil
 }
 
-func removeActorsFromUsersSlice(o *User, related []*Actor) {
+// AddInboundActivities adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.InboundActivities.
+// Sets related.R.InboundActivityUser appropriately.
+func (o *User) AddInboundActivities(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*InboundActivity) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.User = o.Username
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"inbound_activities\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user"}),
+				strmangle.WhereClause("\"", "\"", 2, inboundActivityPrimaryKeyColumns),
+			)
+			values := []interface{}{o.Username, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.User = o.Username
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			InboundActivities: related,
+		}
+	} else {
+		o.R.InboundActivities = append(o.R.InboundActivities, related...)
+	}
+
 	for _, rel := range related {
 		if rel.R == nil {
-			continue
+			rel.R = &inboundActivityR{
+				InboundActivityUser: o,
+			}
+		} else {
+			rel.R.InboundActivityUser = o
 		}
-		for i, ri := range rel.R.Users {
-			if o.Username != ri.Username {
-				continue
+	}
+	return nil
+}
+
+// AddInteractions adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Interactions.
+// Sets related.R.InteractionUser appropriately.
+func (o *User) AddInteractions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Interaction) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.User = o.Username
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"interactions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user"}),
+				strmangle.WhereClause("\"", "\"", 2, interactionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.Username, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
 			}
 
-			ln := len(rel.R.Users)
-			if ln > 1 && i < ln-1 {
-				rel.R.Users[i] = rel.R.Users[ln-1]
+			rel.User = o.Username
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Interactions: related,
+		}
+	} else {
+		o.R.Interactions = append(o.R.Interactions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &interactionR{
+				InteractionUser: o,
 			}
-			rel.R.Users = rel.R.Users[:ln-1]
-			break
+		} else {
+			rel.R.InteractionUser = o
 		}
 	}
+	return nil
 }
 
 // Users retrieves all the records using an executor.
@@ -4,25 +4,44 @@ import (
 	"context"
 	"crypto"
 	"crypto/x509"
+	"database/sql"
 	"net/url"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 
 	"github.com/estrys/estrys/internal/database"
 	"github.com/estrys/estrys/internal/models"
 )
 
+// ActorDetails are the resolved informations of a remote actor document.
+type ActorDetails struct {
+	PublicKey         crypto.PublicKey
+	PublicKeyID       string
+	Inbox             *url.URL
+	SharedInbox       *url.URL
+	PreferredUsername string
+}
+
 type CreateActorRequest struct {
-	URL       *url.URL
-	PublicKey crypto.PublicKey
+	URL *url.URL
+	ActorDetails
 }
 
 //go:generate mockery --with-expecter --name ActorRepository
 type ActorRepository interface {
 	Get(ctx context.Context, url *url.URL) (*models.Actor, error)
+	// GetByKeyID returns the actor whose key was stored with the given id,
+	// an error is returned when several actors claim the same key.
+	GetByKeyID(ctx context.Context, keyID string) (*models.Actor, error)
 	Create(context.Context, CreateActorRequest) (*models.Actor, error)
+	Update(context.Context, *models.Actor, ActorDetails) error
+	// Delete removes the actor along with its follows.
+	Delete(context.Context, *models.Actor) error
 }
 
 type actorRepo struct {
@@ -33,22 +52,40 @@ func NewActorRepository(database database.Database) *actorRepo {
 	return &actorRepo{db: database}
 }
 
-func (u *actorRepo) Create(ctx context.Context, input CreateActorRequest) (*models.Actor, error) {
-	pubKey, err := x509.MarshalPKIXPublicKey(input.PublicKey)
+func (u *actorRepo) setDetails(actor *models.Actor, details ActorDetails) error {
+	pubKey, err := x509.MarshalPKIXPublicKey(details.PublicKey)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to decode actor public key")
+		return errors.Wrap(err, "unable to decode actor public key")
+	}
+	actor.PublicKey = pubKey
+	actor.PublicKeyID = null.NewString(details.PublicKeyID, details.PublicKeyID != "")
+	actor.Inbox = null.String{}
+	if details.Inbox != nil {
+		actor.Inbox = null.StringFrom(details.Inbox.String())
 	}
+	actor.SharedInbox = null.String{}
+	if details.SharedInbox != nil {
+		actor.SharedInbox = null.StringFrom(details.SharedInbox.String())
+	}
+	actor.PreferredUsername = null.NewString(details.PreferredUsername, details.PreferredUsername != "")
+	actor.FetchedAt = null.TimeFrom(time.Now().UTC())
+	return nil
+}
 
+func (u *actorRepo) Create(ctx context.Context, input CreateActorRequest) (*models.Actor, error) {
 	id, err := uuid.NewV4()
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to generate a valid UUIDv4 for actor")
 	}
 	actor := &models.Actor{
-		ID:        id.String(),
-		URL:       input.URL.String(),
-		PublicKey: pubKey,
+		ID:  id.String(),
+		URL: input.URL.String(),
 	}
-	err = actor.Insert(ctx, u.db.DB(), boil.Infer())
+	err = u.setDetails(actor, input.ActorDetails)
+	if err != nil {
+		return nil, err
+	}
+	err = actor.Insert(ctx, getExecutor(ctx, u.db.DB()), boil.Infer())
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to save actor in db")
 	}
@@ -56,6 +93,26 @@ func (u *actorRepo) Create(ctx context.Context, input CreateActorRequest) (*mode
 	return actor, nil
 }
 
+func (u *actorRepo) Update(ctx context.Context, actor *models.Actor, details ActorDetails) error {
+	err := u.setDetails(actor, details)
+	if err != nil {
+		return err
+	}
+	_, err = actor.Update(ctx, getExecutor(ctx, u.db.DB()), boil.Infer())
+	if err != nil {
+		return errors.Wrap(err, "unable to update actor in db")
+	}
+	return nil
+}
+
+func (u *actorRepo) Delete(ctx context.Context, actor *models.Actor) error {
+	_, err := actor.Delete(ctx, getExecutor(ctx, u.db.DB()))
+	if err != nil {
+		return errors.Wrap(err, "unable to delete actor from db")
+	}
+	return nil
+}
+
 func (u *actorRepo) Get(ctx context.Context, url *url.URL) (*models.Actor, error) {
 	actor, err := models.Actors(models.ActorWhere.URL.EQ(url.String())).One(ctx, u.db.DB())
 	if err != nil {
@@ -63,3 +120,22 @@ func (u *actorRepo) Get(ctx context.Context, url *url.URL) (*models.Actor, error
 	}
 	return actor, nil
 }
+
+func (u *actorRepo) GetByKeyID(ctx context.Context, keyID string) (*models.Actor, error) {
+	// Key ids are not unique in the table, a key claimed by several actors can not be trusted
+	actors, err := models.Actors(
+		models.ActorWhere.PublicKeyID.EQ(null.StringFrom(keyID)),
+		qm.Limit(2),
+	).All(ctx, u.db.DB())
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch actor from db")
+	}
+	switch len(actors) {
+	case 0:
+		return nil, errors.Wrap(sql.ErrNoRows, "unable to fetch actor from db")
+	case 1:
+		return actors[0], nil
+	default:
+		return nil, errors.Errorf("key %s is used by several actors", keyID)
+	}
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/estrys/estrys/internal/database"
+	"github.com/estrys/estrys/internal/models"
+)
+
+//go:generate mockery --with-expecter --name=FediverseLinkRepository
+type FediverseLinkRepository interface {
+	// Save stores a verified fediverse account of the user, or refreshes its verification.
+	Save(ctx context.Context, username string, handle string, actorURL string) error
+	// Prune removes the links of the user whose handle is not in the given list anymore.
+	Prune(ctx context.Context, username string, handles []string) error
+	// List returns the links of the user, or of every user when username is empty, oldest first.
+	List(ctx context.Context, username string) (models.FediverseLinkSlice, error)
+}
+
+type fediverseLinkRepo struct {
+	db database.Database
+}
+
+func NewFediverseLinkRepository(database database.Database) *fediverseLinkRepo {
+	return &fediverseLinkRepo{db: database}
+}
+
+func (f *fediverseLinkRepo) Save(ctx context.Context, username string, handle string, actorURL string) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "unable to generate a valid UUIDv4 for fediverse link")
+	}
+	link := &models.FediverseLink{
+		ID:         id.String(),
+		User:       username,
+		Handle:     handle,
+		ActorURL:   actorURL,
+		VerifiedAt: time.Now(),
+	}
+	err = link.Upsert(
+		ctx,
+		getExecutor(ctx, f.db.DB()),
+		true,
+		[]string{models.FediverseLinkColumns.User, models.FediverseLinkColumns.Handle},
+		boil.Whitelist(models.FediverseLinkColumns.ActorURL, models.FediverseLinkColumns.VerifiedAt),
+		boil.Infer(),
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to save fediverse link")
+	}
+	return nil
+}
+
+func (f *fediverseLinkRepo) Prune(ctx context.Context, username string, handles []string) error {
+	mods := []qm.QueryMod{
+		models.FediverseLinkWhere.User.EQ(username),
+	}
+	if len(handles) > 0 {
+		mods = append(mods, models.FediverseLinkWhere.Handle.NIN(handles))
+	}
+	_, err := models.FediverseLinks(mods...).DeleteAll(ctx, getExecutor(ctx, f.db.DB()))
+	if err != nil {
+		return errors.Wrap(err, "unable to delete fediverse links")
+	}
+	return nil
+}
+
+func (f *fediverseLinkRepo) List(ctx context.Context, username string) (models.FediverseLinkSlice, error) {
+	mods := []qm.QueryMod{
+		qm.OrderBy(models.FediverseLinkColumns.CreatedAt),
+	}
+	if username != "" {
+		mods = append(mods, models.FediverseLinkWhere.User.EQ(username))
+	}
+	links, err := models.FediverseLinks(mods...).All(ctx, getExecutor(ctx, f.db.DB()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch fediverse links from database")
+	}
+	return links, nil
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/estrys/estrys/internal/database"
+	"github.com/estrys/estrys/internal/models"
+)
+
+type FollowState string
+
+const (
+	FollowStatePending  FollowState = "pending"
+	FollowStateAccepted FollowState = "accepted"
+	FollowStateRejected FollowState = "rejected"
+	FollowStateUndone   FollowState = "undone"
+	// FollowStateBlocked is set when the follower blocked the user, nothing must be delivered to it anymore.
+	FollowStateBlocked FollowState = "blocked"
+)
+
+// ListPendingFollowsRequest filters pending follows, zero values match everything.
+type ListPendingFollowsRequest struct {
+	Username      string
+	CreatedBefore time.Time
+}
+
+//go:generate mockery --with-expecter --name=FollowerRepository
+type FollowerRepository interface {
+	Get(context.Context, *models.User, *models.Actor) (*models.Follower, error)
+	// GetByFollowID returns the follow with the given activity id, with its user and actor loaded.
+	GetByFollowID(ctx context.Context, followID string) (*models.Follower, error)
+	// Create records a pending follow of the user, it replaces the previous follow of the same actor if any.
+	// An accepted follow sent again stays accepted, only its activity id is updated.
+	Create(ctx context.Context, user *models.User, actor *models.Actor, followID string) (*models.Follower, error)
+	// ListPending returns the follows waiting for a response, oldest first, with their user and actor loaded.
+	ListPending(context.Context, ListPendingFollowsRequest) (models.FollowerSlice, error)
+	SetState(context.Context, *models.Follower, FollowState) error
+	// MoveFollows transfers the accepted follows of an actor to the actor it moved to,
+	// the follows of the previous actor are marked as undone. It returns the count of moved follows.
+	MoveFollows(ctx context.Context, from *models.Actor, to *models.Actor) (int64, error)
+	// SetResponseDelivery records the result of the delivery of the Accept or Reject sent in response to the follow.
+	SetResponseDelivery(ctx context.Context, follower *models.Follower, deliveryErr error) error
+}
+
+// upsertFollowQuery records a follow, a new follow of the same actor starts over since the previous
+// one has been undone or is replaced, unless it was accepted.
+const upsertFollowQuery = `INSERT INTO followers ("user", actor, follow_id, state, created_at, updated_at)
+VALUES ($1, $2, $3, $4, now(), now())
+ON CONFLICT ("user", actor) DO UPDATE SET
+	follow_id = EXCLUDED.follow_id,
+	state = CASE WHEN followers.state = $5 THEN followers.state ELSE EXCLUDED.state END,
+	created_at = CASE WHEN followers.state = $5 THEN followers.created_at ELSE EXCLUDED.created_at END,
+	updated_at = EXCLUDED.updated_at,
+	response_delivered_at = NULL,
+	response_error = NULL
+RETURNING *`
+
+type followerRepo struct {
+	db database.Database
+}
+
+func NewFollowerRepository(database database.Database) *followerRepo {
+	return &followerRepo{db: database}
+}
+
+func (f *followerRepo) Get(ctx context.Context, user *models.User, actor *models.Actor) (*models.Follower, error) {
+	follower, err := models.FindFollower(ctx, getExecutor(ctx, f.db.DB()), user.Username, actor.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch follower from database")
+	}
+	return follower, nil
+}
+
+func (f *followerRepo) GetByFollowID(ctx context.Context, followID string) (*models.Follower, error) {
+	follower, err := models.Followers(
+		models.FollowerWhere.FollowID.EQ(null.StringFrom(followID)),
+		qm.Load(models.FollowerRels.FollowerUser),
+		qm.Load(models.FollowerRels.FollowerActor),
+	).One(ctx, getExecutor(ctx, f.db.DB()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch follower from database")
+	}
+	return follower, nil
+}
+
+func (f *followerRepo) Create(
+	ctx context.Context,
+	user *models.User,
+	actor *models.Actor,
+	followID string,
+) (*models.Follower, error) {
+	follower := &models.Follower{}
+	err := queries.Raw(
+		upsertFollowQuery,
+		user.Username,
+		actor.ID,
+		followID,
+		string(FollowStatePending),
+		string(FollowStateAccepted),
+	).Bind(ctx, getExecutor(ctx, f.db.DB()), follower)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to save follower")
+	}
+	return follower, nil
+}
+
+func (f *followerRepo) ListPending(
+	ctx context.Context,
+	request ListPendingFollowsRequest,
+) (models.FollowerSlice, error) {
+	mods := []qm.QueryMod{
+		models.FollowerWhere.State.EQ(string(FollowStatePending)),
+		qm.Load(models.FollowerRels.FollowerUser),
+		qm.Load(models.FollowerRels.FollowerActor),
+		qm.OrderBy(models.FollowerColumns.CreatedAt),
+	}
+	if request.Username != "" {
+		mods = append(mods, models.FollowerWhere.User.EQ(request.Username))
+	}
+	if !request.CreatedBefore.IsZero() {
+		mods = append(mods, models.FollowerWhere.CreatedAt.LT(request.CreatedBefore))
+	}
+	followers, err := models.Followers(mods...).All(ctx, getExecutor(ctx, f.db.DB()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch pending follows from database")
+	}
+	return followers, nil
+}
+
+func (f *followerRepo) SetState(ctx context.Context, follower *models.Follower, state FollowState) error {
+	follower.State = string(state)
+	_, err := follower.Update(
+		ctx,
+		getExecutor(ctx, f.db.DB()),
+		boil.Whitelist(models.FollowerColumns.State, models.FollowerColumns.UpdatedAt),
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to update follower state")
+	}
+	return nil
+}
+
+// moveFollowsQuery moves the follows in a single statement, the new actor may already follow some of the users.
+const moveFollowsQuery = `WITH moved AS (
+	UPDATE followers SET state = $3, updated_at = now()
+	WHERE actor = $1 AND state = $4
+	RETURNING "user", created_at
+)
+INSERT INTO followers ("user", actor, state, created_at, updated_at)
+SELECT "user", $2, $4, created_at, now() FROM moved
+ON CONFLICT ("user", actor) DO UPDATE SET state = $4, updated_at = now()`
+
+func (f *followerRepo) MoveFollows(ctx context.Context, from *models.Actor, to *models.Actor) (int64, error) {
+	result, err := queries.Raw(
+		moveFollowsQuery,
+		from.ID,
+		to.ID,
+		string(FollowStateUndone),
+		string(FollowStateAccepted),
+	).ExecContext(ctx, getExecutor(ctx, f.db.DB()))
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to move follows")
+	}
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to count moved follows")
+	}
+	return moved, nil
+}
+
+func (f *followerRepo) SetResponseDelivery(
+	ctx context.Context,
+	follower *models.Follower,
+	deliveryErr error,
+) error {
+	follower.ResponseDeliveredAt = null.Time{}
+	follower.ResponseError = null.String{}
+	if deliveryErr != nil {
+		follower.ResponseError = null.StringFrom(deliveryErr.Error())
+	} else {
+		follower.ResponseDeliveredAt = null.TimeFrom(time.Now().UTC())
+	}
+	_, err := follower.Update(
+		ctx,
+		getExecutor(ctx, f.db.DB()),
+		boil.Whitelist(
+			models.FollowerColumns.ResponseDeliveredAt,
+			models.FollowerColumns.ResponseError,
+			models.FollowerColumns.UpdatedAt,
+		),
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to update follower response delivery")
+	}
+	return nil
+}
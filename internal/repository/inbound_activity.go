@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/estrys/estrys/internal/database"
+	"github.com/estrys/estrys/internal/models"
+)
+
+type CreateInboundActivityRequest struct {
+	ActivityID string
+	Username   string
+	Payload    map[string]any
+}
+
+//go:generate mockery --with-expecter --name=InboundActivityRepository
+type InboundActivityRepository interface {
+	Get(ctx context.Context, id string) (*models.InboundActivity, error)
+	// Create stores the activity unless one with the same activity id was already received,
+	// the boolean tells if the activity is a new one.
+	Create(context.Context, CreateInboundActivityRequest) (*models.InboundActivity, bool, error)
+	// MarkProcessed records the end of the processing of the activity, with its error if it failed.
+	MarkProcessed(ctx context.Context, activity *models.InboundActivity, processErr error) error
+}
+
+type inboundActivityRepo struct {
+	db database.Database
+}
+
+func NewInboundActivityRepository(database database.Database) *inboundActivityRepo {
+	return &inboundActivityRepo{db: database}
+}
+
+func (i *inboundActivityRepo) Get(ctx context.Context, id string) (*models.InboundActivity, error) {
+	activity, err := models.FindInboundActivity(ctx, getExecutor(ctx, i.db.DB()), id)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch inbound activity from database")
+	}
+	return activity, nil
+}
+
+func (i *inboundActivityRepo) Create(
+	ctx context.Context,
+	input CreateInboundActivityRequest,
+) (*models.InboundActivity, bool, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, false, errors.Wrap(err, "unable to generate a valid UUIDv4 for inbound activity")
+	}
+	payload, err := json.Marshal(input.Payload)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "unable to encode inbound activity payload")
+	}
+	activity := &models.InboundActivity{
+		ID:         id.String(),
+		ActivityID: input.ActivityID,
+		User:       input.Username,
+		Payload:    payload,
+		ReceivedAt: time.Now().UTC(),
+	}
+	// Remote servers may deliver the same activity several times, keep the first one
+	err = activity.Upsert(
+		ctx,
+		getExecutor(ctx, i.db.DB()),
+		false,
+		[]string{models.InboundActivityColumns.ActivityID},
+		boil.None(),
+		boil.Infer(),
+	)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "unable to save inbound activity")
+	}
+
+	stored, err := models.InboundActivities(
+		models.InboundActivityWhere.ActivityID.EQ(input.ActivityID),
+	).One(ctx, getExecutor(ctx, i.db.DB()))
+	if err != nil {
+		return nil, false, errors.Wrap(err, "unable to fetch inbound activity from database")
+	}
+	return stored, stored.ID == activity.ID, nil
+}
+
+func (i *inboundActivityRepo) MarkProcessed(
+	ctx context.Context,
+	activity *models.InboundActivity,
+	processErr error,
+) error {
+	activity.ProcessedAt = null.TimeFrom(time.Now().UTC())
+	activity.Error = null.String{}
+	if processErr != nil {
+		activity.Error = null.StringFrom(processErr.Error())
+	}
+	_, err := activity.Update(
+		ctx,
+		getExecutor(ctx, i.db.DB()),
+		boil.Whitelist(models.InboundActivityColumns.ProcessedAt, models.InboundActivityColumns.Error),
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to update inbound activity")
+	}
+	return nil
+}
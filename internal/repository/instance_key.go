@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/estrys/estrys/internal/database"
+	"github.com/estrys/estrys/internal/models"
+)
+
+//go:generate mockery --with-expecter --name=InstanceKeyRepository
+type InstanceKeyRepository interface {
+	Get(ctx context.Context, name string) (*models.InstanceKey, error)
+	// Create stores the key unless one already exists with the same name, the stored key is returned.
+	Create(ctx context.Context, name string, key *rsa.PrivateKey) (*models.InstanceKey, error)
+}
+
+type instanceKeyRepo struct {
+	db database.Database
+}
+
+func NewInstanceKeyRepository(database database.Database) *instanceKeyRepo {
+	return &instanceKeyRepo{db: database}
+}
+
+func (i *instanceKeyRepo) Get(ctx context.Context, name string) (*models.InstanceKey, error) {
+	key, err := models.FindInstanceKey(ctx, getExecutor(ctx, i.db.DB()), name)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch instance key from database")
+	}
+	return key, nil
+}
+
+func (i *instanceKeyRepo) Create(
+	ctx context.Context,
+	name string,
+	privateKey *rsa.PrivateKey,
+) (*models.InstanceKey, error) {
+	key := &models.InstanceKey{
+		Name:       name,
+		PrivateKey: x509.MarshalPKCS1PrivateKey(privateKey),
+		CreatedAt:  time.Now(),
+	}
+	// Another process may have created the key concurrently, keep the first one
+	err := key.Upsert(
+		ctx,
+		getExecutor(ctx, i.db.DB()),
+		false,
+		[]string{models.InstanceKeyColumns.Name},
+		boil.None(),
+		boil.Infer(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to save instance key")
+	}
+	return i.Get(ctx, name)
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/estrys/estrys/internal/database"
+	"github.com/estrys/estrys/internal/models"
+)
+
+type InteractionType string
+
+const (
+	InteractionTypeReply InteractionType = "reply"
+	InteractionTypeLike  InteractionType = "like"
+	InteractionTypeShare InteractionType = "share"
+)
+
+// CreateInteractionRequest describes an interaction of a remote actor with a tweet,
+// the object fields are only set for replies.
+type CreateInteractionRequest struct {
+	ActivityID  string
+	Type        InteractionType
+	Username    string
+	TweetID     string
+	Actor       *models.Actor
+	ObjectID    string
+	ObjectURL   string
+	Content     string
+	PublishedAt time.Time
+}
+
+//go:generate mockery --with-expecter --name=InteractionRepository
+type InteractionRepository interface {
+	// Create stores the interaction unless its activity was already recorded. An actor has a single like
+	// and a single boost per tweet, which refer to its latest activity.
+	Create(context.Context, CreateInteractionRequest) error
+	// DeleteByActivityID removes the interaction recorded for the activity of the actor,
+	// false is returned if there was none.
+	DeleteByActivityID(ctx context.Context, actor *models.Actor, activityID string) (bool, error)
+	// DeleteReplies removes the replies of the actor with the given object id.
+	DeleteReplies(ctx context.Context, actor *models.Actor, objectID string) error
+	Count(ctx context.Context, tweetID string, interactionType InteractionType) (int64, error)
+	// ListReplies returns the replies to the tweet, oldest first, with their actor loaded.
+	ListReplies(ctx context.Context, tweetID string) (models.InteractionSlice, error)
+}
+
+// upsertReactionQuery records a like or a boost, sending it again only updates its activity id
+// so that undoing the latest activity removes it.
+const upsertReactionQuery = `INSERT INTO interactions (id, activity_id, type, "user", tweet_id, actor)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (actor, tweet_id, type) WHERE type IN ('like', 'share')
+DO UPDATE SET activity_id = EXCLUDED.activity_id`
+
+type interactionRepo struct {
+	db database.Database
+}
+
+func NewInteractionRepository(database database.Database) *interactionRepo {
+	return &interactionRepo{db: database}
+}
+
+func (i *interactionRepo) Create(ctx context.Context, input CreateInteractionRequest) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "unable to generate a valid UUIDv4 for interaction")
+	}
+	interaction := &models.Interaction{
+		ID:          id.String(),
+		ActivityID:  input.ActivityID,
+		Type:        string(input.Type),
+		User:        input.Username,
+		TweetID:     input.TweetID,
+		Actor:       input.Actor.ID,
+		ObjectID:    null.NewString(input.ObjectID, input.ObjectID != ""),
+		ObjectURL:   null.NewString(input.ObjectURL, input.ObjectURL != ""),
+		Content:     null.NewString(input.Content, input.Content != ""),
+		PublishedAt: null.NewTime(input.PublishedAt, !input.PublishedAt.IsZero()),
+	}
+	if input.Type == InteractionTypeLike || input.Type == InteractionTypeShare {
+		_, err = queries.Raw(
+			upsertReactionQuery,
+			interaction.ID,
+			interaction.ActivityID,
+			interaction.Type,
+			interaction.User,
+			interaction.TweetID,
+			interaction.Actor,
+		).ExecContext(ctx, getExecutor(ctx, i.db.DB()))
+	} else {
+		err = interaction.Upsert(
+			ctx,
+			getExecutor(ctx, i.db.DB()),
+			false,
+			[]string{models.InteractionColumns.ActivityID},
+			boil.None(),
+			boil.Infer(),
+		)
+	}
+	if err != nil {
+		return errors.Wrap(err, "unable to save interaction")
+	}
+	return nil
+}
+
+func (i *interactionRepo) DeleteByActivityID(
+	ctx context.Context,
+	actor *models.Actor,
+	activityID string,
+) (bool, error) {
+	deleted, err := models.Interactions(
+		models.InteractionWhere.ActivityID.EQ(activityID),
+		models.InteractionWhere.Actor.EQ(actor.ID),
+	).DeleteAll(ctx, getExecutor(ctx, i.db.DB()))
+	if err != nil {
+		return false, errors.Wrap(err, "unable to delete interaction")
+	}
+	return deleted > 0, nil
+}
+
+func (i *interactionRepo) DeleteReplies(ctx context.Context, actor *models.Actor, objectID string) error {
+	_, err := models.Interactions(
+		models.InteractionWhere.Type.EQ(string(InteractionTypeReply)),
+		models.InteractionWhere.ObjectID.EQ(null.StringFrom(objectID)),
+		models.InteractionWhere.Actor.EQ(actor.ID),
+	).DeleteAll(ctx, getExecutor(ctx, i.db.DB()))
+	if err != nil {
+		return errors.Wrap(err, "unable to delete replies")
+	}
+	return nil
+}
+
+func (i *interactionRepo) Count(
+	ctx context.Context,
+	tweetID string,
+	interactionType InteractionType,
+) (int64, error) {
+	count, err := models.Interactions(
+		models.InteractionWhere.TweetID.EQ(tweetID),
+		models.InteractionWhere.Type.EQ(string(interactionType)),
+	).Count(ctx, getExecutor(ctx, i.db.DB()))
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to count interactions")
+	}
+	return count, nil
+}
+
+func (i *interactionRepo) ListReplies(ctx context.Context, tweetID string) (models.InteractionSlice, error) {
+	replies, err := models.Interactions(
+		models.InteractionWhere.TweetID.EQ(tweetID),
+		models.InteractionWhere.Type.EQ(string(InteractionTypeReply)),
+		qm.Load(models.InteractionRels.InteractionActor),
+		qm.OrderBy(models.InteractionColumns.PublishedAt+", "+models.InteractionColumns.CreatedAt),
+	).All(ctx, getExecutor(ctx, i.db.DB()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch replies from database")
+	}
+	return replies, nil
+}
@@ -73,6 +73,44 @@ func (_c *ActorRepository_Create_Call) Return(_a0 *models.Actor, _a1 error) *Act
 	return _c
 }
 
+// Delete provides a mock function with given fields: _a0, _a1
+func (_m *ActorRepository) Delete(_a0 context.Context, _a1 *models.Actor) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Actor) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ActorRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type ActorRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.Actor
+func (_e *ActorRepository_Expecter) Delete(_a0 interface{}, _a1 interface{}) *ActorRepository_Delete_Call {
+	return &ActorRepository_Delete_Call{Call: _e.mock.On("Delete", _a0, _a1)}
+}
+
+func (_c *ActorRepository_Delete_Call) Run(run func(_a0 context.Context, _a1 *models.Actor)) *ActorRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Actor))
+	})
+	return _c
+}
+
+func (_c *ActorRepository_Delete_Call) Return(_a0 error) *ActorRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
 // Get provides a mock function with given fields: ctx, _a1
 func (_m *ActorRepository) Get(ctx context.Context, _a1 *url.URL) (*models.Actor, error) {
 	ret := _m.Called(ctx, _a1)
@@ -120,6 +158,92 @@ func (_c *ActorRepository_Get_Call) Return(_a0 *models.Actor, _a1 error) *ActorR
 	return _c
 }
 
+// GetByKeyID provides a mock function with given fields: ctx, keyID
+func (_m *ActorRepository) GetByKeyID(ctx context.Context, keyID string) (*models.Actor, error) {
+	ret := _m.Called(ctx, keyID)
+
+	var r0 *models.Actor
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Actor); ok {
+		r0 = rf(ctx, keyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Actor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, keyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ActorRepository_GetByKeyID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByKeyID'
+type ActorRepository_GetByKeyID_Call struct {
+	*mock.Call
+}
+
+// GetByKeyID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keyID string
+func (_e *ActorRepository_Expecter) GetByKeyID(ctx interface{}, keyID interface{}) *ActorRepository_GetByKeyID_Call {
+	return &ActorRepository_GetByKeyID_Call{Call: _e.mock.On("GetByKeyID", ctx, keyID)}
+}
+
+func (_c *ActorRepository_GetByKeyID_Call) Run(run func(ctx context.Context, keyID string)) *ActorRepository_GetByKeyID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ActorRepository_GetByKeyID_Call) Return(_a0 *models.Actor, _a1 error) *ActorRepository_GetByKeyID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Update provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ActorRepository) Update(_a0 context.Context, _a1 *models.Actor, _a2 repository.ActorDetails) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Actor, repository.ActorDetails) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ActorRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type ActorRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.Actor
+//   - _a2 repository.ActorDetails
+func (_e *ActorRepository_Expecter) Update(_a0 interface{}, _a1 interface{}, _a2 interface{}) *ActorRepository_Update_Call {
+	return &ActorRepository_Update_Call{Call: _e.mock.On("Update", _a0, _a1, _a2)}
+}
+
+func (_c *ActorRepository_Update_Call) Run(run func(_a0 context.Context, _a1 *models.Actor, _a2 repository.ActorDetails)) *ActorRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Actor), args[2].(repository.ActorDetails))
+	})
+	return _c
+}
+
+func (_c *ActorRepository_Update_Call) Return(_a0 error) *ActorRepository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
 type mockConstructorTestingTNewActorRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,164 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/estrys/estrys/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FediverseLinkRepository is an autogenerated mock type for the FediverseLinkRepository type
+type FediverseLinkRepository struct {
+	mock.Mock
+}
+
+type FediverseLinkRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FediverseLinkRepository) EXPECT() *FediverseLinkRepository_Expecter {
+	return &FediverseLinkRepository_Expecter{mock: &_m.Mock}
+}
+
+// List provides a mock function with given fields: ctx, username
+func (_m *FediverseLinkRepository) List(ctx context.Context, username string) (models.FediverseLinkSlice, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 models.FediverseLinkSlice
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.FediverseLinkSlice); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.FediverseLinkSlice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FediverseLinkRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type FediverseLinkRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *FediverseLinkRepository_Expecter) List(ctx interface{}, username interface{}) *FediverseLinkRepository_List_Call {
+	return &FediverseLinkRepository_List_Call{Call: _e.mock.On("List", ctx, username)}
+}
+
+func (_c *FediverseLinkRepository_List_Call) Run(run func(ctx context.Context, username string)) *FediverseLinkRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FediverseLinkRepository_List_Call) Return(_a0 models.FediverseLinkSlice, _a1 error) *FediverseLinkRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Prune provides a mock function with given fields: ctx, username, handles
+func (_m *FediverseLinkRepository) Prune(ctx context.Context, username string, handles []string) error {
+	ret := _m.Called(ctx, username, handles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, username, handles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FediverseLinkRepository_Prune_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Prune'
+type FediverseLinkRepository_Prune_Call struct {
+	*mock.Call
+}
+
+// Prune is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - handles []string
+func (_e *FediverseLinkRepository_Expecter) Prune(ctx interface{}, username interface{}, handles interface{}) *FediverseLinkRepository_Prune_Call {
+	return &FediverseLinkRepository_Prune_Call{Call: _e.mock.On("Prune", ctx, username, handles)}
+}
+
+func (_c *FediverseLinkRepository_Prune_Call) Run(run func(ctx context.Context, username string, handles []string)) *FediverseLinkRepository_Prune_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *FediverseLinkRepository_Prune_Call) Return(_a0 error) *FediverseLinkRepository_Prune_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, username, handle, actorURL
+func (_m *FediverseLinkRepository) Save(ctx context.Context, username string, handle string, actorURL string) error {
+	ret := _m.Called(ctx, username, handle, actorURL)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, username, handle, actorURL)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FediverseLinkRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type FediverseLinkRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - handle string
+//   - actorURL string
+func (_e *FediverseLinkRepository_Expecter) Save(ctx interface{}, username interface{}, handle interface{}, actorURL interface{}) *FediverseLinkRepository_Save_Call {
+	return &FediverseLinkRepository_Save_Call{Call: _e.mock.On("Save", ctx, username, handle, actorURL)}
+}
+
+func (_c *FediverseLinkRepository_Save_Call) Run(run func(ctx context.Context, username string, handle string, actorURL string)) *FediverseLinkRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *FediverseLinkRepository_Save_Call) Return(_a0 error) *FediverseLinkRepository_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+type mockConstructorTestingTNewFediverseLinkRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFediverseLinkRepository creates a new instance of FediverseLinkRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFediverseLinkRepository(t mockConstructorTestingTNewFediverseLinkRepository) *FediverseLinkRepository {
+	mock := &FediverseLinkRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,355 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/estrys/estrys/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/estrys/estrys/internal/repository"
+)
+
+// FollowerRepository is an autogenerated mock type for the FollowerRepository type
+type FollowerRepository struct {
+	mock.Mock
+}
+
+type FollowerRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FollowerRepository) EXPECT() *FollowerRepository_Expecter {
+	return &FollowerRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, user, actor, followID
+func (_m *FollowerRepository) Create(ctx context.Context, user *models.User, actor *models.Actor, followID string) (*models.Follower, error) {
+	ret := _m.Called(ctx, user, actor, followID)
+
+	var r0 *models.Follower
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.Actor, string) *models.Follower); ok {
+		r0 = rf(ctx, user, actor, followID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Follower)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User, *models.Actor, string) error); ok {
+		r1 = rf(ctx, user, actor, followID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FollowerRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type FollowerRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *models.User
+//   - actor *models.Actor
+//   - followID string
+func (_e *FollowerRepository_Expecter) Create(ctx interface{}, user interface{}, actor interface{}, followID interface{}) *FollowerRepository_Create_Call {
+	return &FollowerRepository_Create_Call{Call: _e.mock.On("Create", ctx, user, actor, followID)}
+}
+
+func (_c *FollowerRepository_Create_Call) Run(run func(ctx context.Context, user *models.User, actor *models.Actor, followID string)) *FollowerRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.User), args[2].(*models.Actor), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *FollowerRepository_Create_Call) Return(_a0 *models.Follower, _a1 error) *FollowerRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Get provides a mock function with given fields: _a0, _a1, _a2
+func (_m *FollowerRepository) Get(_a0 context.Context, _a1 *models.User, _a2 *models.Actor) (*models.Follower, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *models.Follower
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.Actor) *models.Follower); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Follower)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User, *models.Actor) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FollowerRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type FollowerRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.User
+//   - _a2 *models.Actor
+func (_e *FollowerRepository_Expecter) Get(_a0 interface{}, _a1 interface{}, _a2 interface{}) *FollowerRepository_Get_Call {
+	return &FollowerRepository_Get_Call{Call: _e.mock.On("Get", _a0, _a1, _a2)}
+}
+
+func (_c *FollowerRepository_Get_Call) Run(run func(_a0 context.Context, _a1 *models.User, _a2 *models.Actor)) *FollowerRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.User), args[2].(*models.Actor))
+	})
+	return _c
+}
+
+func (_c *FollowerRepository_Get_Call) Return(_a0 *models.Follower, _a1 error) *FollowerRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// GetByFollowID provides a mock function with given fields: ctx, followID
+func (_m *FollowerRepository) GetByFollowID(ctx context.Context, followID string) (*models.Follower, error) {
+	ret := _m.Called(ctx, followID)
+
+	var r0 *models.Follower
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Follower); ok {
+		r0 = rf(ctx, followID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Follower)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, followID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FollowerRepository_GetByFollowID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByFollowID'
+type FollowerRepository_GetByFollowID_Call struct {
+	*mock.Call
+}
+
+// GetByFollowID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - followID string
+func (_e *FollowerRepository_Expecter) GetByFollowID(ctx interface{}, followID interface{}) *FollowerRepository_GetByFollowID_Call {
+	return &FollowerRepository_GetByFollowID_Call{Call: _e.mock.On("GetByFollowID", ctx, followID)}
+}
+
+func (_c *FollowerRepository_GetByFollowID_Call) Run(run func(ctx context.Context, followID string)) *FollowerRepository_GetByFollowID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FollowerRepository_GetByFollowID_Call) Return(_a0 *models.Follower, _a1 error) *FollowerRepository_GetByFollowID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// ListPending provides a mock function with given fields: _a0, _a1
+func (_m *FollowerRepository) ListPending(_a0 context.Context, _a1 repository.ListPendingFollowsRequest) (models.FollowerSlice, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 models.FollowerSlice
+	if rf, ok := ret.Get(0).(func(context.Context, repository.ListPendingFollowsRequest) models.FollowerSlice); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.FollowerSlice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repository.ListPendingFollowsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FollowerRepository_ListPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPending'
+type FollowerRepository_ListPending_Call struct {
+	*mock.Call
+}
+
+// ListPending is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 repository.ListPendingFollowsRequest
+func (_e *FollowerRepository_Expecter) ListPending(_a0 interface{}, _a1 interface{}) *FollowerRepository_ListPending_Call {
+	return &FollowerRepository_ListPending_Call{Call: _e.mock.On("ListPending", _a0, _a1)}
+}
+
+func (_c *FollowerRepository_ListPending_Call) Run(run func(_a0 context.Context, _a1 repository.ListPendingFollowsRequest)) *FollowerRepository_ListPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.ListPendingFollowsRequest))
+	})
+	return _c
+}
+
+func (_c *FollowerRepository_ListPending_Call) Return(_a0 models.FollowerSlice, _a1 error) *FollowerRepository_ListPending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// MoveFollows provides a mock function with given fields: ctx, from, to
+func (_m *FollowerRepository) MoveFollows(ctx context.Context, from *models.Actor, to *models.Actor) (int64, error) {
+	ret := _m.Called(ctx, from, to)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Actor, *models.Actor) int64); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Actor, *models.Actor) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FollowerRepository_MoveFollows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveFollows'
+type FollowerRepository_MoveFollows_Call struct {
+	*mock.Call
+}
+
+// MoveFollows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from *models.Actor
+//   - to *models.Actor
+func (_e *FollowerRepository_Expecter) MoveFollows(ctx interface{}, from interface{}, to interface{}) *FollowerRepository_MoveFollows_Call {
+	return &FollowerRepository_MoveFollows_Call{Call: _e.mock.On("MoveFollows", ctx, from, to)}
+}
+
+func (_c *FollowerRepository_MoveFollows_Call) Run(run func(ctx context.Context, from *models.Actor, to *models.Actor)) *FollowerRepository_MoveFollows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Actor), args[2].(*models.Actor))
+	})
+	return _c
+}
+
+func (_c *FollowerRepository_MoveFollows_Call) Return(_a0 int64, _a1 error) *FollowerRepository_MoveFollows_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// SetResponseDelivery provides a mock function with given fields: ctx, follower, deliveryErr
+func (_m *FollowerRepository) SetResponseDelivery(ctx context.Context, follower *models.Follower, deliveryErr error) error {
+	ret := _m.Called(ctx, follower, deliveryErr)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Follower, error) error); ok {
+		r0 = rf(ctx, follower, deliveryErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FollowerRepository_SetResponseDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetResponseDelivery'
+type FollowerRepository_SetResponseDelivery_Call struct {
+	*mock.Call
+}
+
+// SetResponseDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - follower *models.Follower
+//   - deliveryErr error
+func (_e *FollowerRepository_Expecter) SetResponseDelivery(ctx interface{}, follower interface{}, deliveryErr interface{}) *FollowerRepository_SetResponseDelivery_Call {
+	return &FollowerRepository_SetResponseDelivery_Call{Call: _e.mock.On("SetResponseDelivery", ctx, follower, deliveryErr)}
+}
+
+func (_c *FollowerRepository_SetResponseDelivery_Call) Run(run func(ctx context.Context, follower *models.Follower, deliveryErr error)) *FollowerRepository_SetResponseDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Follower), args[2].(error))
+	})
+	return _c
+}
+
+func (_c *FollowerRepository_SetResponseDelivery_Call) Return(_a0 error) *FollowerRepository_SetResponseDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// SetState provides a mock function with given fields: _a0, _a1, _a2
+func (_m *FollowerRepository) SetState(_a0 context.Context, _a1 *models.Follower, _a2 repository.FollowState) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Follower, repository.FollowState) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FollowerRepository_SetState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetState'
+type FollowerRepository_SetState_Call struct {
+	*mock.Call
+}
+
+// SetState is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.Follower
+//   - _a2 repository.FollowState
+func (_e *FollowerRepository_Expecter) SetState(_a0 interface{}, _a1 interface{}, _a2 interface{}) *FollowerRepository_SetState_Call {
+	return &FollowerRepository_SetState_Call{Call: _e.mock.On("SetState", _a0, _a1, _a2)}
+}
+
+func (_c *FollowerRepository_SetState_Call) Run(run func(_a0 context.Context, _a1 *models.Follower, _a2 repository.FollowState)) *FollowerRepository_SetState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Follower), args[2].(repository.FollowState))
+	})
+	return _c
+}
+
+func (_c *FollowerRepository_SetState_Call) Return(_a0 error) *FollowerRepository_SetState_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+type mockConstructorTestingTNewFollowerRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFollowerRepository creates a new instance of FollowerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFollowerRepository(t mockConstructorTestingTNewFollowerRepository) *FollowerRepository {
+	mock := &FollowerRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,180 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/estrys/estrys/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/estrys/estrys/internal/repository"
+)
+
+// InboundActivityRepository is an autogenerated mock type for the InboundActivityRepository type
+type InboundActivityRepository struct {
+	mock.Mock
+}
+
+type InboundActivityRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InboundActivityRepository) EXPECT() *InboundActivityRepository_Expecter {
+	return &InboundActivityRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *InboundActivityRepository) Create(_a0 context.Context, _a1 repository.CreateInboundActivityRequest) (*models.InboundActivity, bool, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *models.InboundActivity
+	if rf, ok := ret.Get(0).(func(context.Context, repository.CreateInboundActivityRequest) *models.InboundActivity); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.InboundActivity)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, repository.CreateInboundActivityRequest) bool); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, repository.CreateInboundActivityRequest) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// InboundActivityRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type InboundActivityRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 repository.CreateInboundActivityRequest
+func (_e *InboundActivityRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *InboundActivityRepository_Create_Call {
+	return &InboundActivityRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *InboundActivityRepository_Create_Call) Run(run func(_a0 context.Context, _a1 repository.CreateInboundActivityRequest)) *InboundActivityRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateInboundActivityRequest))
+	})
+	return _c
+}
+
+func (_c *InboundActivityRepository_Create_Call) Return(_a0 *models.InboundActivity, _a1 bool, _a2 error) *InboundActivityRepository_Create_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *InboundActivityRepository) Get(ctx context.Context, id string) (*models.InboundActivity, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.InboundActivity
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.InboundActivity); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.InboundActivity)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InboundActivityRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type InboundActivityRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *InboundActivityRepository_Expecter) Get(ctx interface{}, id interface{}) *InboundActivityRepository_Get_Call {
+	return &InboundActivityRepository_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *InboundActivityRepository_Get_Call) Run(run func(ctx context.Context, id string)) *InboundActivityRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *InboundActivityRepository_Get_Call) Return(_a0 *models.InboundActivity, _a1 error) *InboundActivityRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// MarkProcessed provides a mock function with given fields: ctx, activity, processErr
+func (_m *InboundActivityRepository) MarkProcessed(ctx context.Context, activity *models.InboundActivity, processErr error) error {
+	ret := _m.Called(ctx, activity, processErr)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.InboundActivity, error) error); ok {
+		r0 = rf(ctx, activity, processErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InboundActivityRepository_MarkProcessed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkProcessed'
+type InboundActivityRepository_MarkProcessed_Call struct {
+	*mock.Call
+}
+
+// MarkProcessed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - activity *models.InboundActivity
+//   - processErr error
+func (_e *InboundActivityRepository_Expecter) MarkProcessed(ctx interface{}, activity interface{}, processErr interface{}) *InboundActivityRepository_MarkProcessed_Call {
+	return &InboundActivityRepository_MarkProcessed_Call{Call: _e.mock.On("MarkProcessed", ctx, activity, processErr)}
+}
+
+func (_c *InboundActivityRepository_MarkProcessed_Call) Run(run func(ctx context.Context, activity *models.InboundActivity, processErr error)) *InboundActivityRepository_MarkProcessed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.InboundActivity), args[2].(error))
+	})
+	return _c
+}
+
+func (_c *InboundActivityRepository_MarkProcessed_Call) Return(_a0 error) *InboundActivityRepository_MarkProcessed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+type mockConstructorTestingTNewInboundActivityRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewInboundActivityRepository creates a new instance of InboundActivityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewInboundActivityRepository(t mockConstructorTestingTNewInboundActivityRepository) *InboundActivityRepository {
+	mock := &InboundActivityRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,135 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/estrys/estrys/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	rsa "crypto/rsa"
+)
+
+// InstanceKeyRepository is an autogenerated mock type for the InstanceKeyRepository type
+type InstanceKeyRepository struct {
+	mock.Mock
+}
+
+type InstanceKeyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InstanceKeyRepository) EXPECT() *InstanceKeyRepository_Expecter {
+	return &InstanceKeyRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, name, key
+func (_m *InstanceKeyRepository) Create(ctx context.Context, name string, key *rsa.PrivateKey) (*models.InstanceKey, error) {
+	ret := _m.Called(ctx, name, key)
+
+	var r0 *models.InstanceKey
+	if rf, ok := ret.Get(0).(func(context.Context, string, *rsa.PrivateKey) *models.InstanceKey); ok {
+		r0 = rf(ctx, name, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.InstanceKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *rsa.PrivateKey) error); ok {
+		r1 = rf(ctx, name, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InstanceKeyRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type InstanceKeyRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - key *rsa.PrivateKey
+func (_e *InstanceKeyRepository_Expecter) Create(ctx interface{}, name interface{}, key interface{}) *InstanceKeyRepository_Create_Call {
+	return &InstanceKeyRepository_Create_Call{Call: _e.mock.On("Create", ctx, name, key)}
+}
+
+func (_c *InstanceKeyRepository_Create_Call) Run(run func(ctx context.Context, name string, key *rsa.PrivateKey)) *InstanceKeyRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*rsa.PrivateKey))
+	})
+	return _c
+}
+
+func (_c *InstanceKeyRepository_Create_Call) Return(_a0 *models.InstanceKey, _a1 error) *InstanceKeyRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, name
+func (_m *InstanceKeyRepository) Get(ctx context.Context, name string) (*models.InstanceKey, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *models.InstanceKey
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.InstanceKey); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.InstanceKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InstanceKeyRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type InstanceKeyRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *InstanceKeyRepository_Expecter) Get(ctx interface{}, name interface{}) *InstanceKeyRepository_Get_Call {
+	return &InstanceKeyRepository_Get_Call{Call: _e.mock.On("Get", ctx, name)}
+}
+
+func (_c *InstanceKeyRepository_Get_Call) Run(run func(ctx context.Context, name string)) *InstanceKeyRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *InstanceKeyRepository_Get_Call) Return(_a0 *models.InstanceKey, _a1 error) *InstanceKeyRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewInstanceKeyRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewInstanceKeyRepository creates a new instance of InstanceKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewInstanceKeyRepository(t mockConstructorTestingTNewInstanceKeyRepository) *InstanceKeyRepository {
+	mock := &InstanceKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
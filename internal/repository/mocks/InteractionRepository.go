@@ -0,0 +1,256 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/estrys/estrys/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/estrys/estrys/internal/repository"
+)
+
+// InteractionRepository is an autogenerated mock type for the InteractionRepository type
+type InteractionRepository struct {
+	mock.Mock
+}
+
+type InteractionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *InteractionRepository) EXPECT() *InteractionRepository_Expecter {
+	return &InteractionRepository_Expecter{mock: &_m.Mock}
+}
+
+// Count provides a mock function with given fields: ctx, tweetID, interactionType
+func (_m *InteractionRepository) Count(ctx context.Context, tweetID string, interactionType repository.InteractionType) (int64, error) {
+	ret := _m.Called(ctx, tweetID, interactionType)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, repository.InteractionType) int64); ok {
+		r0 = rf(ctx, tweetID, interactionType)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, repository.InteractionType) error); ok {
+		r1 = rf(ctx, tweetID, interactionType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InteractionRepository_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type InteractionRepository_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tweetID string
+//   - interactionType repository.InteractionType
+func (_e *InteractionRepository_Expecter) Count(ctx interface{}, tweetID interface{}, interactionType interface{}) *InteractionRepository_Count_Call {
+	return &InteractionRepository_Count_Call{Call: _e.mock.On("Count", ctx, tweetID, interactionType)}
+}
+
+func (_c *InteractionRepository_Count_Call) Run(run func(ctx context.Context, tweetID string, interactionType repository.InteractionType)) *InteractionRepository_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(repository.InteractionType))
+	})
+	return _c
+}
+
+func (_c *InteractionRepository_Count_Call) Return(_a0 int64, _a1 error) *InteractionRepository_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Create provides a mock function with given fields: _a0, _a1
+func (_m *InteractionRepository) Create(_a0 context.Context, _a1 repository.CreateInteractionRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.CreateInteractionRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InteractionRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type InteractionRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 repository.CreateInteractionRequest
+func (_e *InteractionRepository_Expecter) Create(_a0 interface{}, _a1 interface{}) *InteractionRepository_Create_Call {
+	return &InteractionRepository_Create_Call{Call: _e.mock.On("Create", _a0, _a1)}
+}
+
+func (_c *InteractionRepository_Create_Call) Run(run func(_a0 context.Context, _a1 repository.CreateInteractionRequest)) *InteractionRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.CreateInteractionRequest))
+	})
+	return _c
+}
+
+func (_c *InteractionRepository_Create_Call) Return(_a0 error) *InteractionRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// DeleteByActivityID provides a mock function with given fields: ctx, actor, activityID
+func (_m *InteractionRepository) DeleteByActivityID(ctx context.Context, actor *models.Actor, activityID string) (bool, error) {
+	ret := _m.Called(ctx, actor, activityID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Actor, string) bool); ok {
+		r0 = rf(ctx, actor, activityID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Actor, string) error); ok {
+		r1 = rf(ctx, actor, activityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InteractionRepository_DeleteByActivityID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByActivityID'
+type InteractionRepository_DeleteByActivityID_Call struct {
+	*mock.Call
+}
+
+// DeleteByActivityID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor *models.Actor
+//   - activityID string
+func (_e *InteractionRepository_Expecter) DeleteByActivityID(ctx interface{}, actor interface{}, activityID interface{}) *InteractionRepository_DeleteByActivityID_Call {
+	return &InteractionRepository_DeleteByActivityID_Call{Call: _e.mock.On("DeleteByActivityID", ctx, actor, activityID)}
+}
+
+func (_c *InteractionRepository_DeleteByActivityID_Call) Run(run func(ctx context.Context, actor *models.Actor, activityID string)) *InteractionRepository_DeleteByActivityID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Actor), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *InteractionRepository_DeleteByActivityID_Call) Return(_a0 bool, _a1 error) *InteractionRepository_DeleteByActivityID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// DeleteReplies provides a mock function with given fields: ctx, actor, objectID
+func (_m *InteractionRepository) DeleteReplies(ctx context.Context, actor *models.Actor, objectID string) error {
+	ret := _m.Called(ctx, actor, objectID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Actor, string) error); ok {
+		r0 = rf(ctx, actor, objectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InteractionRepository_DeleteReplies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReplies'
+type InteractionRepository_DeleteReplies_Call struct {
+	*mock.Call
+}
+
+// DeleteReplies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actor *models.Actor
+//   - objectID string
+func (_e *InteractionRepository_Expecter) DeleteReplies(ctx interface{}, actor interface{}, objectID interface{}) *InteractionRepository_DeleteReplies_Call {
+	return &InteractionRepository_DeleteReplies_Call{Call: _e.mock.On("DeleteReplies", ctx, actor, objectID)}
+}
+
+func (_c *InteractionRepository_DeleteReplies_Call) Run(run func(ctx context.Context, actor *models.Actor, objectID string)) *InteractionRepository_DeleteReplies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Actor), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *InteractionRepository_DeleteReplies_Call) Return(_a0 error) *InteractionRepository_DeleteReplies_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// ListReplies provides a mock function with given fields: ctx, tweetID
+func (_m *InteractionRepository) ListReplies(ctx context.Context, tweetID string) (models.InteractionSlice, error) {
+	ret := _m.Called(ctx, tweetID)
+
+	var r0 models.InteractionSlice
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.InteractionSlice); ok {
+		r0 = rf(ctx, tweetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.InteractionSlice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tweetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InteractionRepository_ListReplies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReplies'
+type InteractionRepository_ListReplies_Call struct {
+	*mock.Call
+}
+
+// ListReplies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tweetID string
+func (_e *InteractionRepository_Expecter) ListReplies(ctx interface{}, tweetID interface{}) *InteractionRepository_ListReplies_Call {
+	return &InteractionRepository_ListReplies_Call{Call: _e.mock.On("ListReplies", ctx, tweetID)}
+}
+
+func (_c *InteractionRepository_ListReplies_Call) Run(run func(ctx context.Context, tweetID string)) *InteractionRepository_ListReplies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *InteractionRepository_ListReplies_Call) Return(_a0 models.InteractionSlice, _a1 error) *InteractionRepository_ListReplies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewInteractionRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewInteractionRepository creates a new instance of InteractionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewInteractionRepository(t mockConstructorTestingTNewInteractionRepository) *InteractionRepository {
+	mock := &InteractionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,226 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/estrys/estrys/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/estrys/estrys/internal/repository"
+)
+
+// OptOutRepository is an autogenerated mock type for the OptOutRepository type
+type OptOutRepository struct {
+	mock.Mock
+}
+
+type OptOutRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OptOutRepository) EXPECT() *OptOutRepository_Expecter {
+	return &OptOutRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, username, reason
+func (_m *OptOutRepository) Create(ctx context.Context, username string, reason repository.OptOutReason) (*models.OptOut, error) {
+	ret := _m.Called(ctx, username, reason)
+
+	var r0 *models.OptOut
+	if rf, ok := ret.Get(0).(func(context.Context, string, repository.OptOutReason) *models.OptOut); ok {
+		r0 = rf(ctx, username, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OptOut)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, repository.OptOutReason) error); ok {
+		r1 = rf(ctx, username, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OptOutRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type OptOutRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+//   - reason repository.OptOutReason
+func (_e *OptOutRepository_Expecter) Create(ctx interface{}, username interface{}, reason interface{}) *OptOutRepository_Create_Call {
+	return &OptOutRepository_Create_Call{Call: _e.mock.On("Create", ctx, username, reason)}
+}
+
+func (_c *OptOutRepository_Create_Call) Run(run func(ctx context.Context, username string, reason repository.OptOutReason)) *OptOutRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(repository.OptOutReason))
+	})
+	return _c
+}
+
+func (_c *OptOutRepository_Create_Call) Return(_a0 *models.OptOut, _a1 error) *OptOutRepository_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, username
+func (_m *OptOutRepository) Delete(ctx context.Context, username string) (bool, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OptOutRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type OptOutRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *OptOutRepository_Expecter) Delete(ctx interface{}, username interface{}) *OptOutRepository_Delete_Call {
+	return &OptOutRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, username)}
+}
+
+func (_c *OptOutRepository_Delete_Call) Run(run func(ctx context.Context, username string)) *OptOutRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OptOutRepository_Delete_Call) Return(_a0 bool, _a1 error) *OptOutRepository_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, username
+func (_m *OptOutRepository) Get(ctx context.Context, username string) (*models.OptOut, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 *models.OptOut
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.OptOut); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OptOut)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OptOutRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type OptOutRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *OptOutRepository_Expecter) Get(ctx interface{}, username interface{}) *OptOutRepository_Get_Call {
+	return &OptOutRepository_Get_Call{Call: _e.mock.On("Get", ctx, username)}
+}
+
+func (_c *OptOutRepository_Get_Call) Run(run func(ctx context.Context, username string)) *OptOutRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OptOutRepository_Get_Call) Return(_a0 *models.OptOut, _a1 error) *OptOutRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *OptOutRepository) List(ctx context.Context) (models.OptOutSlice, error) {
+	ret := _m.Called(ctx)
+
+	var r0 models.OptOutSlice
+	if rf, ok := ret.Get(0).(func(context.Context) models.OptOutSlice); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.OptOutSlice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OptOutRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type OptOutRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *OptOutRepository_Expecter) List(ctx interface{}) *OptOutRepository_List_Call {
+	return &OptOutRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *OptOutRepository_List_Call) Run(run func(ctx context.Context)) *OptOutRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *OptOutRepository_List_Call) Return(_a0 models.OptOutSlice, _a1 error) *OptOutRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewOptOutRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewOptOutRepository creates a new instance of OptOutRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewOptOutRepository(t mockConstructorTestingTNewOptOutRepository) *OptOutRepository {
+	mock := &OptOutRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -24,21 +24,19 @@ func (_m *UserRepository) EXPECT() *UserRepository_Expecter {
 	return &UserRepository_Expecter{mock: &_m.Mock}
 }
 
-// CreateUser provides a mock function with given fields: _a0, _a1
-func (_m *UserRepository) CreateUser(_a0 context.Context, _a1 repository.CreateUserRequest) (*models.User, error) {
+// CountFollowers provides a mock function with given fields: _a0, _a1
+func (_m *UserRepository) CountFollowers(_a0 context.Context, _a1 *models.User) (int64, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(context.Context, repository.CreateUserRequest) *models.User); ok {
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) int64); ok {
 		r0 = rf(_a0, _a1)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.User)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, repository.CreateUserRequest) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -47,66 +45,74 @@ func (_m *UserRepository) CreateUser(_a0 context.Context, _a1 repository.CreateU
 	return r0, r1
 }
 
-// UserRepository_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
-type UserRepository_CreateUser_Call struct {
+// UserRepository_CountFollowers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountFollowers'
+type UserRepository_CountFollowers_Call struct {
 	*mock.Call
 }
 
-// CreateUser is a helper method to define mock.On call
+// CountFollowers is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 repository.CreateUserRequest
-func (_e *UserRepository_Expecter) CreateUser(_a0 interface{}, _a1 interface{}) *UserRepository_CreateUser_Call {
-	return &UserRepository_CreateUser_Call{Call: _e.mock.On("CreateUser", _a0, _a1)}
+//   - _a1 *models.User
+func (_e *UserRepository_Expecter) CountFollowers(_a0 interface{}, _a1 interface{}) *UserRepository_CountFollowers_Call {
+	return &UserRepository_CountFollowers_Call{Call: _e.mock.On("CountFollowers", _a0, _a1)}
 }
 
-func (_c *UserRepository_CreateUser_Call) Run(run func(_a0 context.Context, _a1 repository.CreateUserRequest)) *UserRepository_CreateUser_Call {
+func (_c *UserRepository_CountFollowers_Call) Run(run func(_a0 context.Context, _a1 *models.User)) *UserRepository_CountFollowers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(repository.CreateUserRequest))
+		run(args[0].(context.Context), args[1].(*models.User))
 	})
 	return _c
 }
 
-func (_c *UserRepository_CreateUser_Call) Return(_a0 *models.User, _a1 error) *UserRepository_CreateUser_Call {
+func (_c *UserRepository_CountFollowers_Call) Return(_a0 int64, _a1 error) *UserRepository_CountFollowers_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-// Follow provides a mock function with given fields: _a0, _a1, _a2
-func (_m *UserRepository) Follow(_a0 context.Context, _a1 *models.User, _a2 *models.Actor) error {
-	ret := _m.Called(_a0, _a1, _a2)
+// CreateUser provides a mock function with given fields: _a0, _a1
+func (_m *UserRepository) CreateUser(_a0 context.Context, _a1 repository.CreateUserRequest) (*models.User, error) {
+	ret := _m.Called(_a0, _a1)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.Actor) error); ok {
-		r0 = rf(_a0, _a1, _a2)
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(context.Context, repository.CreateUserRequest) *models.User); ok {
+		r0 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, repository.CreateUserRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// UserRepository_Follow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Follow'
-type UserRepository_Follow_Call struct {
+// UserRepository_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type UserRepository_CreateUser_Call struct {
 	*mock.Call
 }
 
-// Follow is a helper method to define mock.On call
+// CreateUser is a helper method to define mock.On call
 //   - _a0 context.Context
-//   - _a1 *models.User
-//   - _a2 *models.Actor
-func (_e *UserRepository_Expecter) Follow(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_Follow_Call {
-	return &UserRepository_Follow_Call{Call: _e.mock.On("Follow", _a0, _a1, _a2)}
+//   - _a1 repository.CreateUserRequest
+func (_e *UserRepository_Expecter) CreateUser(_a0 interface{}, _a1 interface{}) *UserRepository_CreateUser_Call {
+	return &UserRepository_CreateUser_Call{Call: _e.mock.On("CreateUser", _a0, _a1)}
 }
 
-func (_c *UserRepository_Follow_Call) Run(run func(_a0 context.Context, _a1 *models.User, _a2 *models.Actor)) *UserRepository_Follow_Call {
+func (_c *UserRepository_CreateUser_Call) Run(run func(_a0 context.Context, _a1 repository.CreateUserRequest)) *UserRepository_CreateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*models.User), args[2].(*models.Actor))
+		run(args[0].(context.Context), args[1].(repository.CreateUserRequest))
 	})
 	return _c
 }
 
-func (_c *UserRepository_Follow_Call) Return(_a0 error) *UserRepository_Follow_Call {
-	_c.Call.Return(_a0)
+func (_c *UserRepository_CreateUser_Call) Return(_a0 *models.User, _a1 error) *UserRepository_CreateUser_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
@@ -204,6 +210,55 @@ func (_c *UserRepository_GetFollowers_Call) Return(_a0 models.ActorSlice, _a1 er
 	return _c
 }
 
+// GetFollowersPage provides a mock function with given fields: ctx, user, offset, limit
+func (_m *UserRepository) GetFollowersPage(ctx context.Context, user *models.User, offset int, limit int) (models.ActorSlice, error) {
+	ret := _m.Called(ctx, user, offset, limit)
+
+	var r0 models.ActorSlice
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, int, int) models.ActorSlice); ok {
+		r0 = rf(ctx, user, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.ActorSlice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User, int, int) error); ok {
+		r1 = rf(ctx, user, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_GetFollowersPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFollowersPage'
+type UserRepository_GetFollowersPage_Call struct {
+	*mock.Call
+}
+
+// GetFollowersPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *models.User
+//   - offset int
+//   - limit int
+func (_e *UserRepository_Expecter) GetFollowersPage(ctx interface{}, user interface{}, offset interface{}, limit interface{}) *UserRepository_GetFollowersPage_Call {
+	return &UserRepository_GetFollowersPage_Call{Call: _e.mock.On("GetFollowersPage", ctx, user, offset, limit)}
+}
+
+func (_c *UserRepository_GetFollowersPage_Call) Run(run func(ctx context.Context, user *models.User, offset int, limit int)) *UserRepository_GetFollowersPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.User), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetFollowersPage_Call) Return(_a0 models.ActorSlice, _a1 error) *UserRepository_GetFollowersPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // GetWithFollowers provides a mock function with given fields: ctx
 func (_m *UserRepository) GetWithFollowers(ctx context.Context) (models.UserSlice, error) {
 	ret := _m.Called(ctx)
@@ -250,13 +305,13 @@ func (_c *UserRepository_GetWithFollowers_Call) Return(_a0 models.UserSlice, _a1
 	return _c
 }
 
-// UnFollow provides a mock function with given fields: _a0, _a1, _a2
-func (_m *UserRepository) UnFollow(_a0 context.Context, _a1 *models.User, _a2 *models.Actor) error {
-	ret := _m.Called(_a0, _a1, _a2)
+// SetMovedTo provides a mock function with given fields: ctx, user, movedTo
+func (_m *UserRepository) SetMovedTo(ctx context.Context, user *models.User, movedTo string) error {
+	ret := _m.Called(ctx, user, movedTo)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.Actor) error); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, string) error); ok {
+		r0 = rf(ctx, user, movedTo)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -264,27 +319,27 @@ func (_m *UserRepository) UnFollow(_a0 context.Context, _a1 *models.User, _a2 *m
 	return r0
 }
 
-// UserRepository_UnFollow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnFollow'
-type UserRepository_UnFollow_Call struct {
+// UserRepository_SetMovedTo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMovedTo'
+type UserRepository_SetMovedTo_Call struct {
 	*mock.Call
 }
 
-// UnFollow is a helper method to define mock.On call
-//   - _a0 context.Context
-//   - _a1 *models.User
-//   - _a2 *models.Actor
-func (_e *UserRepository_Expecter) UnFollow(_a0 interface{}, _a1 interface{}, _a2 interface{}) *UserRepository_UnFollow_Call {
-	return &UserRepository_UnFollow_Call{Call: _e.mock.On("UnFollow", _a0, _a1, _a2)}
+// SetMovedTo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *models.User
+//   - movedTo string
+func (_e *UserRepository_Expecter) SetMovedTo(ctx interface{}, user interface{}, movedTo interface{}) *UserRepository_SetMovedTo_Call {
+	return &UserRepository_SetMovedTo_Call{Call: _e.mock.On("SetMovedTo", ctx, user, movedTo)}
 }
 
-func (_c *UserRepository_UnFollow_Call) Run(run func(_a0 context.Context, _a1 *models.User, _a2 *models.Actor)) *UserRepository_UnFollow_Call {
+func (_c *UserRepository_SetMovedTo_Call) Run(run func(ctx context.Context, user *models.User, movedTo string)) *UserRepository_SetMovedTo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*models.User), args[2].(*models.Actor))
+		run(args[0].(context.Context), args[1].(*models.User), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *UserRepository_UnFollow_Call) Return(_a0 error) *UserRepository_UnFollow_Call {
+func (_c *UserRepository_SetMovedTo_Call) Return(_a0 error) *UserRepository_SetMovedTo_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
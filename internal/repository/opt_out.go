@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/estrys/estrys/internal/database"
+	"github.com/estrys/estrys/internal/models"
+)
+
+type OptOutReason string
+
+const (
+	// OptOutReasonAdmin is set on the accounts added to the registry by an admin.
+	OptOutReasonAdmin OptOutReason = "admin"
+	// OptOutReasonMarker is set on the accounts which have an opt-out marker in their bio.
+	OptOutReasonMarker OptOutReason = "marker"
+)
+
+//go:generate mockery --with-expecter --name=OptOutRepository
+type OptOutRepository interface {
+	// Get returns the opt-out of the twitter user, sql.ErrNoRows is returned if the user did not opt out.
+	Get(ctx context.Context, username string) (*models.OptOut, error)
+	Create(ctx context.Context, username string, reason OptOutReason) (*models.OptOut, error)
+	// Delete removes the user from the registry, false is returned if it was not there.
+	Delete(ctx context.Context, username string) (bool, error)
+	// List returns the whole registry, oldest first.
+	List(ctx context.Context) (models.OptOutSlice, error)
+}
+
+type optOutRepo struct {
+	db database.Database
+}
+
+func NewOptOutRepository(database database.Database) *optOutRepo {
+	return &optOutRepo{db: database}
+}
+
+func (o *optOutRepo) Get(ctx context.Context, username string) (*models.OptOut, error) {
+	optOut, err := models.FindOptOut(ctx, getExecutor(ctx, o.db.DB()), strings.ToLower(username))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch opt-out from database")
+	}
+	return optOut, nil
+}
+
+func (o *optOutRepo) Create(ctx context.Context, username string, reason OptOutReason) (*models.OptOut, error) {
+	optOut := &models.OptOut{
+		Username: strings.ToLower(username),
+		Reason:   string(reason),
+	}
+	err := optOut.Insert(ctx, getExecutor(ctx, o.db.DB()), boil.Infer())
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to save opt-out")
+	}
+	return optOut, nil
+}
+
+func (o *optOutRepo) Delete(ctx context.Context, username string) (bool, error) {
+	deleted, err := models.OptOuts(
+		models.OptOutWhere.Username.EQ(strings.ToLower(username)),
+	).DeleteAll(ctx, getExecutor(ctx, o.db.DB()))
+	if err != nil {
+		return false, errors.Wrap(err, "unable to delete opt-out")
+	}
+	return deleted > 0, nil
+}
+
+func (o *optOutRepo) List(ctx context.Context) (models.OptOutSlice, error) {
+	optOuts, err := models.OptOuts(
+		qm.OrderBy(models.OptOutColumns.CreatedAt),
+	).All(ctx, getExecutor(ctx, o.db.DB()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch opt-outs from database")
+	}
+	return optOuts, nil
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 
@@ -29,10 +30,13 @@ type CreateUserRequest struct {
 type UserRepository interface {
 	Get(context.Context, string) (*models.User, error)
 	GetFollowers(context.Context, *models.User) (models.ActorSlice, error)
-	Follow(context.Context, *models.User, *models.Actor) error
-	UnFollow(context.Context, *models.User, *models.Actor) error
+	GetFollowersPage(ctx context.Context, user *models.User, offset, limit int) (models.ActorSlice, error)
+	CountFollowers(context.Context, *models.User) (int64, error)
 	CreateUser(context.Context, CreateUserRequest) (*models.User, error)
+	// GetWithFollowers returns the users which have followers and have not moved to another account.
 	GetWithFollowers(ctx context.Context) (models.UserSlice, error)
+	// SetMovedTo records that the user migrated to the given actor.
+	SetMovedTo(ctx context.Context, user *models.User, movedTo string) error
 }
 
 type userRepo struct {
@@ -59,22 +63,6 @@ func (u *userRepo) CreateUser(ctx context.Context, input CreateUserRequest) (*mo
 	return user, nil
 }
 
-func (u *userRepo) Follow(ctx context.Context, user *models.User, actor *models.Actor) error {
-	err := user.AddActors(ctx, getExecutor(ctx, u.db.DB()), false, actor)
-	if err != nil {
-		return errors.Wrap(err, "unable to add actor to user")
-	}
-	return nil
-}
-
-func (u *userRepo) UnFollow(ctx context.Context, user *models.User, actor *models.Actor) error {
-	err := user.RemoveActors(ctx, getExecutor(ctx, u.db.DB()), actor)
-	if err != nil {
-		return errors.Wrap(err, "unable to remove actor from user")
-	}
-	return nil
-}
-
 func (u *userRepo) Get(ctx context.Context, usernameOrID string) (*models.User, error) {
 	usernameOrID = strings.ToLower(usernameOrID)
 	var err error
@@ -99,14 +87,67 @@ func (u *userRepo) Get(ctx context.Context, usernameOrID string) (*models.User,
 
 func (u *userRepo) GetWithFollowers(ctx context.Context) (models.UserSlice, error) {
 	mods := []qm.QueryMod{
+		// Users are joined once per follower
+		qm.Distinct(fmt.Sprintf("%s.*", models.TableNames.Users)),
 		qm.InnerJoin(fmt.Sprintf("%[1]s on %[1]s.user = %s",
 			models.TableNames.Followers,
 			models.UserTableColumns.Username,
 		)),
+		models.FollowerWhere.State.EQ(string(FollowStateAccepted)),
+		models.UserWhere.MovedTo.IsNull(),
 	}
 	return models.Users(mods...).All(ctx, getExecutor(ctx, u.db.DB()))
 }
 
+func (u *userRepo) SetMovedTo(ctx context.Context, user *models.User, movedTo string) error {
+	user.MovedTo = null.StringFrom(movedTo)
+	user.MovedAt = null.TimeFrom(time.Now())
+	_, err := user.Update(ctx, getExecutor(ctx, u.db.DB()), boil.Whitelist(
+		models.UserColumns.MovedTo,
+		models.UserColumns.MovedAt,
+	))
+	if err != nil {
+		return errors.Wrap(err, "unable to update user")
+	}
+	return nil
+}
+
+// acceptedFollowers selects the actors whose follow of the user has been accepted.
+func acceptedFollowers(user *models.User, mods ...qm.QueryMod) []qm.QueryMod {
+	return append([]qm.QueryMod{
+		qm.InnerJoin(fmt.Sprintf("%[1]s on %[1]s.actor = %s",
+			models.TableNames.Followers,
+			models.ActorTableColumns.ID,
+		)),
+		models.FollowerWhere.User.EQ(user.Username),
+		models.FollowerWhere.State.EQ(string(FollowStateAccepted)),
+	}, mods...)
+}
+
 func (u *userRepo) GetFollowers(ctx context.Context, user *models.User) (models.ActorSlice, error) {
-	return user.Actors().All(ctx, getExecutor(ctx, u.db.DB()))
+	return models.Actors(acceptedFollowers(user)...).All(ctx, getExecutor(ctx, u.db.DB()))
+}
+
+func (u *userRepo) GetFollowersPage(
+	ctx context.Context,
+	user *models.User,
+	offset, limit int,
+) (models.ActorSlice, error) {
+	actors, err := models.Actors(acceptedFollowers(user,
+		qm.OrderBy(models.ActorTableColumns.URL),
+		qm.Offset(offset),
+		qm.Limit(limit),
+	)...).All(ctx, getExecutor(ctx, u.db.DB()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch followers page")
+	}
+	return actors, nil
+}
+
+func (u *userRepo) CountFollowers(ctx context.Context, user *models.User) (int64, error) {
+	count, err := models.Actors(acceptedFollowers(user)...).Count(ctx, getExecutor(ctx, u.db.DB()))
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to count followers")
+	}
+	return count, nil
 }
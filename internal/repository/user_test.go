@@ -0,0 +1,51 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/estrys/estrys/internal/repository"
+)
+
+type fakeDatabase struct {
+	db *sql.DB
+}
+
+func (f *fakeDatabase) Connect() error {
+	return nil
+}
+
+func (f *fakeDatabase) DB() *sql.DB {
+	return f.db
+}
+
+func (f *fakeDatabase) StartTransaction(ctx context.Context) (*sql.Tx, context.Context, error) {
+	tx, err := f.db.BeginTx(ctx, nil)
+	return tx, ctx, err
+}
+
+func TestUserRepo_GetWithFollowers(t *testing.T) {
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	// validuser has two accepted followers, it must be returned only once
+	dbMock.ExpectQuery(`SELECT DISTINCT users\.\* FROM "users" INNER JOIN followers on followers\.user = users\.username WHERE`).
+		WithArgs("accepted").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"username", "id", "private_key", "created_at"}).
+				AddRow("validuser", "1234", []byte{}, time.Now()),
+		)
+
+	userRepo := repository.NewUserRepository(&fakeDatabase{db: db})
+	users, err := userRepo.GetWithFollowers(context.Background())
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, "validuser", users[0].Username)
+	require.NoError(t, dbMock.ExpectationsWereMet())
+}
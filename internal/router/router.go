@@ -6,6 +6,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/estrys/estrys/internal/activitypub/routes"
+	"github.com/estrys/estrys/internal/domain/profile"
 	"github.com/estrys/estrys/internal/domain/status"
 )
 
@@ -17,5 +18,6 @@ func GetRouter() *mux.Router {
 		responseWriter.WriteHeader(http.StatusFound)
 	})
 	status.StatusRouter(newRouter)
+	profile.ProfileRouter(newRouter)
 	return newRouter
 }
@@ -9,4 +9,11 @@ const (
 	UserOutboxRoute    string = "user_outbox"
 	UserInboxRoute     string = "user_inbox"
 	StatusRoute        string = "status"
+	StatusRepliesRoute string = "status_replies"
+	StatusLikesRoute   string = "status_likes"
+	StatusSharesRoute  string = "status_shares"
+	ProfileRoute       string = "profile"
+	ProfileStatusRoute string = "profile_status"
+	InstanceActorRoute string = "instance_actor"
+	InstanceInboxRoute string = "instance_inbox"
 )
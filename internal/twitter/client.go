@@ -26,6 +26,7 @@ const (
 
 	cacheKeyUsername = "twitter/user/by-username/%s"
 	cacheKeyID       = "twitter/user/by-id/%s"
+	cacheKeyProfile  = "twitter/profile/by-username/%s"
 )
 
 type UserNotFoundError struct {
@@ -50,6 +51,7 @@ type Backend interface {
 	) (*twitter.UserTweetTimelineResponse, error)
 	TweetLookup(ctx context.Context, ids []string, opts twitter.TweetLookupOpts) (*twitter.TweetLookupResponse, error)
 	UserLookup(ctx context.Context, ids []string, opts twitter.UserLookupOpts) (*twitter.UserLookupResponse, error)
+	UserProfile(ctx context.Context, username string) (*UserProfile, error)
 }
 
 //go:generate mockery --with-expecter --name=TwitterClient
@@ -58,19 +60,28 @@ type TwitterClient interface {
 	GetTweets(context.Context, []string, twitter.TweetLookupOpts) (*twitter.TweetLookupResponse, error)
 	GetUser(ctx context.Context, username string) (*twitter.UserObj, error)
 	GetUserByIDs(context.Context, []string) ([]*twitter.UserObj, error)
+	// GetUserProfile returns the banner and the expanded links of the profile of the user.
+	GetUserProfile(ctx context.Context, username string) (*UserProfile, error)
 }
 
 type twitterClient struct {
-	twitter   Backend
-	log       logger.Logger
-	userCache cache.Cache[twitter.UserObj]
+	twitter      Backend
+	log          logger.Logger
+	userCache    cache.Cache[twitter.UserObj]
+	profileCache cache.Cache[UserProfile]
 }
 
-func NewClient(log logger.Logger, cache cache.Cache[twitter.UserObj], backend Backend) *twitterClient {
+func NewClient(
+	log logger.Logger,
+	cache cache.Cache[twitter.UserObj],
+	profileCache cache.Cache[UserProfile],
+	backend Backend,
+) *twitterClient {
 	return &twitterClient{
-		userCache: cache,
-		log:       log,
-		twitter:   backend,
+		userCache:    cache,
+		profileCache: profileCache,
+		log:          log,
+		twitter:      backend,
 	}
 }
 
@@ -128,6 +139,8 @@ func (c *twitterClient) GetUser(ctx context.Context, username string) (*twitter.
 			twitter.UserFieldProfileImageURL,
 			twitter.UserFieldCreatedAt,
 			twitter.UserFieldPublicMetrics,
+			twitter.UserFieldURL,
+			twitter.UserFieldLocation,
 		},
 	})
 	if err != nil {
@@ -190,6 +203,8 @@ func (c *twitterClient) GetUserByIDs(ctx context.Context, ids []string) ([]*twit
 					twitter.UserFieldProfileImageURL,
 					twitter.UserFieldCreatedAt,
 					twitter.UserFieldPublicMetrics,
+					twitter.UserFieldURL,
+					twitter.UserFieldLocation,
 				},
 			},
 		)
@@ -204,3 +219,26 @@ func (c *twitterClient) GetUserByIDs(ctx context.Context, ids []string) ([]*twit
 
 	return results, nil
 }
+
+func (c *twitterClient) GetUserProfile(ctx context.Context, username string) (*UserProfile, error) {
+	cacheKey := strings.ReplaceAll(cacheKeyProfile, "%s", username)
+	fromCache, err := c.profileCache.Get(ctx, cacheKey)
+	if err == nil {
+		c.log.WithField("key", cacheKey).Trace("twitter profile cache hit")
+		return fromCache, nil
+	}
+	if !errors.Is(err, cache.ErrMiss) {
+		return nil, errors.Wrap(err, "error while retrieving twitter profile from cache")
+	}
+	c.log.WithField("key", cacheKey).Trace("twitter profile cache miss")
+
+	profile, err := c.twitter.UserProfile(ctx, username)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch twitter profile")
+	}
+	err = c.profileCache.Set(ctx, cacheKey, *profile)
+	if err != nil {
+		c.log.WithError(err).Warn("unable to save twitter profile to cache")
+	}
+	return profile, nil
+}
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	internaltwitter "github.com/estrys/estrys/internal/twitter"
 	mock "github.com/stretchr/testify/mock"
 
 	twitter "github.com/g8rswimmer/go-twitter/v2"
@@ -167,6 +168,53 @@ func (_c *Backend_UserNameLookup_Call) Return(_a0 *twitter.UserLookupResponse, _
 	return _c
 }
 
+// UserProfile provides a mock function with given fields: ctx, username
+func (_m *Backend) UserProfile(ctx context.Context, username string) (*internaltwitter.UserProfile, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 *internaltwitter.UserProfile
+	if rf, ok := ret.Get(0).(func(context.Context, string) *internaltwitter.UserProfile); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*internaltwitter.UserProfile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Backend_UserProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserProfile'
+type Backend_UserProfile_Call struct {
+	*mock.Call
+}
+
+// UserProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *Backend_Expecter) UserProfile(ctx interface{}, username interface{}) *Backend_UserProfile_Call {
+	return &Backend_UserProfile_Call{Call: _e.mock.On("UserProfile", ctx, username)}
+}
+
+func (_c *Backend_UserProfile_Call) Run(run func(ctx context.Context, username string)) *Backend_UserProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Backend_UserProfile_Call) Return(_a0 *internaltwitter.UserProfile, _a1 error) *Backend_UserProfile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // UserTweetTimeline provides a mock function with given fields: ctx, userID, opts
 func (_m *Backend) UserTweetTimeline(ctx context.Context, userID string, opts twitter.UserTweetTimelineOpts) (*twitter.UserTweetTimelineResponse, error) {
 	ret := _m.Called(ctx, userID, opts)
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	internaltwitter "github.com/estrys/estrys/internal/twitter"
 	mock "github.com/stretchr/testify/mock"
 
 	twitter "github.com/g8rswimmer/go-twitter/v2"
@@ -165,6 +166,53 @@ func (_c *TwitterClient_GetUserByIDs_Call) Return(_a0 []*twitter.UserObj, _a1 er
 	return _c
 }
 
+// GetUserProfile provides a mock function with given fields: ctx, username
+func (_m *TwitterClient) GetUserProfile(ctx context.Context, username string) (*internaltwitter.UserProfile, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 *internaltwitter.UserProfile
+	if rf, ok := ret.Get(0).(func(context.Context, string) *internaltwitter.UserProfile); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*internaltwitter.UserProfile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TwitterClient_GetUserProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserProfile'
+type TwitterClient_GetUserProfile_Call struct {
+	*mock.Call
+}
+
+// GetUserProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *TwitterClient_Expecter) GetUserProfile(ctx interface{}, username interface{}) *TwitterClient_GetUserProfile_Call {
+	return &TwitterClient_GetUserProfile_Call{Call: _e.mock.On("GetUserProfile", ctx, username)}
+}
+
+func (_c *TwitterClient_GetUserProfile_Call) Run(run func(ctx context.Context, username string)) *TwitterClient_GetUserProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TwitterClient_GetUserProfile_Call) Return(_a0 *internaltwitter.UserProfile, _a1 error) *TwitterClient_GetUserProfile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // GetUserTweets provides a mock function with given fields: _a0, _a1, _a2
 func (_m *TwitterClient) GetUserTweets(_a0 context.Context, _a1 string, _a2 twitter.UserTweetTimelineOpts) (*twitter.UserTweetTimelineResponse, error) {
 	ret := _m.Called(_a0, _a1, _a2)
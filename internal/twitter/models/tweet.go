@@ -21,7 +21,10 @@ const (
 type TweetMedia struct {
 	Type          MediaType
 	URL           *url.URL
+	ContentType   string
 	Width, Height int
+	Blurhash      string
+	FocalPoint    [2]float64
 }
 
 type Tweet struct {
@@ -9,6 +9,7 @@ import (
 	"github.com/getsentry/sentry-go"
 	"github.com/pkg/errors"
 
+	"github.com/estrys/estrys/internal/domain"
 	"github.com/estrys/estrys/internal/logger"
 	"github.com/estrys/estrys/internal/models"
 	"github.com/estrys/estrys/internal/observability"
@@ -27,6 +28,7 @@ type twitterPoller struct {
 	twitter     twitter.TwitterClient
 	repo        repository.UserRepository
 	worker      client.BackgroundWorkerClient
+	consent     domain.ConsentService
 	users       models.UserSlice
 	userCursors map[string]string
 	userIndex   int
@@ -47,23 +49,33 @@ func NewPoller(
 	client twitter.TwitterClient,
 	repo repository.UserRepository,
 	worker client.BackgroundWorkerClient,
+	consent domain.ConsentService,
 ) *twitterPoller {
 	return &twitterPoller{
 		log:         log,
 		twitter:     client,
 		repo:        repo,
 		worker:      worker,
+		consent:     consent,
 		userCursors: map[string]string{},
 	}
 }
 
 func (c *twitterPoller) RefreshUserList(ctx context.Context) error {
-	var err error
-	c.users, err = c.repo.GetWithFollowers(ctx)
+	users, err := c.repo.GetWithFollowers(ctx)
 	if err != nil {
 		return err
 	}
-	if c.users == nil {
+	if users == nil {
+		c.users = nil
+		return ErrNoUserToPoll
+	}
+	// Users who refused to be bridged since the last refresh are not polled anymore
+	c.users, err = c.consent.FilterUsers(ctx, users)
+	if err != nil {
+		return errors.Wrap(err, "unable to check consent of users")
+	}
+	if len(c.users) == 0 {
 		return ErrNoUserToPoll
 	}
 	return nil
@@ -137,19 +149,13 @@ func (c *twitterPoller) handleTweet(
 	user *models.User,
 	rawTweet *gotwitter.TweetObj,
 ) error {
-	actors, err := c.repo.GetFollowers(ctx, user)
+	ingestTweetTask, err := tasks.NewIngestTweet(ctx, user, rawTweet.ID)
 	if err != nil {
-		return errors.Wrap(err, "unable to retrieve followers for user")
+		return errors.Wrap(err, "unable to create ingest tweet task")
 	}
-	for _, actor := range actors {
-		sendTweetTask, err := tasks.NewSendTweet(ctx, user, actor, rawTweet.ID)
-		if err != nil {
-			return errors.Wrap(err, "unable to create send tweet task")
-		}
-		_, err = c.worker.Enqueue(sendTweetTask)
-		if err != nil {
-			return errors.Wrap(err, "unable to schedule send tweet task")
-		}
+	_, err = c.worker.Enqueue(ingestTweetTask)
+	if err != nil {
+		return errors.Wrap(err, "unable to schedule ingest tweet task")
 	}
 	return nil
 }
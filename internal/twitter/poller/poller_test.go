@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/getsentry/sentry-go"
-	"reflect"
 	"testing"
 	"time"
 
@@ -29,6 +28,8 @@ func Test_twitterPoller_Start(t *testing.T) {
 		name      string
 		assertErr func(*testing.T, error)
 		mocks     func(*mockstwitter.TwitterClient, *mocksuser.UserRepository, *mocksdomain.TweetService, context.CancelFunc, *mocksworker.BackgroundWorkerClient)
+		// consent defaults to letting all users be polled
+		consent func(*mocksdomain.ConsentService)
 	}{
 		{
 			name: "error while fetching users for the first time",
@@ -142,48 +143,19 @@ func Test_twitterPoller_Start(t *testing.T) {
 						nil,
 					)
 
-				fakeRepo.On("GetFollowers", mock.Anything, &models.User{
-					ID:       "123",
-					Username: "foobar",
-				}).Once().Return(models.ActorSlice{
-					{
-						URL: "https://example.com/actor_url",
-					},
-					{
-						URL: "https://example.com/another_actor_url",
-					},
-				}, nil)
-
-				worker.On("Enqueue", mock.MatchedBy(func(task *asynq.Task) bool {
-					payload := map[string]any{}
-					_ = json.Unmarshal(task.Payload(), &payload)
-					expectedPayload := map[string]any{
-						"from":     "foobar",
-						"to":       "https://example.com/actor_url",
-						"tweet_id": "1337",
-					}
-					match := task.Type() == tasks.TypeSendTweet &&
-						expectedPayload["from"] == payload["from"] &&
-						expectedPayload["to"] == payload["to"] &&
-						reflect.DeepEqual(expectedPayload["tweet"], payload["tweet"]) &&
-						payload["trace_id"] != ""
-					return match
-				})).Return(nil, nil)
 				worker.On("Enqueue", mock.MatchedBy(func(task *asynq.Task) bool {
 					payload := map[string]any{}
 					_ = json.Unmarshal(task.Payload(), &payload)
 					expectedPayload := map[string]any{
 						"from":     "foobar",
-						"to":       "https://example.com/another_actor_url",
 						"tweet_id": "1337",
 					}
-					match := task.Type() == tasks.TypeSendTweet &&
+					match := task.Type() == tasks.TypeIngestTweet &&
 						expectedPayload["from"] == payload["from"] &&
-						expectedPayload["to"] == payload["to"] &&
-						reflect.DeepEqual(expectedPayload["tweet"], payload["tweet"]) &&
+						expectedPayload["tweet_id"] == payload["tweet_id"] &&
 						payload["trace_id"] != ""
 					return match
-				})).Return(nil, nil)
+				})).Once().Return(nil, nil)
 
 				fakeTwitter.On("GetUserTweets", mock.Anything, "123", gotwitter.UserTweetTimelineOpts{
 					MaxResults: 100,
@@ -395,6 +367,56 @@ func Test_twitterPoller_Start(t *testing.T) {
 				require.NoError(t, err)
 			},
 		},
+		{
+			name: "users who opted out are not polled",
+			mocks: func(fakeTwitter *mockstwitter.TwitterClient, fakeRepo *mocksuser.UserRepository, _ *mocksdomain.TweetService, cancel context.CancelFunc, _ *mocksworker.BackgroundWorkerClient) {
+				fakeRepo.On("GetWithFollowers", mock.Anything).
+					Once().
+					Return(
+						models.UserSlice{
+							{
+								ID:       "123",
+								Username: "foobar",
+							},
+							{
+								ID:       "124",
+								Username: "barbaz",
+							},
+						},
+						nil,
+					)
+				fakeTwitter.On("GetUserTweets", mock.Anything, "124", mock.Anything).
+					Once().
+					Return(
+						&gotwitter.UserTweetTimelineResponse{Meta: &gotwitter.UserTimelineMeta{ResultCount: 0}},
+						nil,
+					).
+					Run(func(args mock.Arguments) {
+						cancel()
+					})
+			},
+			consent: func(fakeConsent *mocksdomain.ConsentService) {
+				fakeConsent.EXPECT().FilterUsers(mock.Anything, mock.Anything).
+					Return(models.UserSlice{{ID: "124", Username: "barbaz"}}, nil)
+			},
+			assertErr: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "error while checking consent of users",
+			mocks: func(fakeTwitter *mockstwitter.TwitterClient, fakeRepo *mocksuser.UserRepository, _ *mocksdomain.TweetService, _ context.CancelFunc, _ *mocksworker.BackgroundWorkerClient) {
+				fakeRepo.On("GetWithFollowers", mock.Anything).
+					Return(models.UserSlice{{ID: "123", Username: "foobar"}}, nil)
+			},
+			consent: func(fakeConsent *mocksdomain.ConsentService) {
+				fakeConsent.EXPECT().FilterUsers(mock.Anything, mock.Anything).
+					Return(nil, errors.New("rate limited"))
+			},
+			assertErr: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "unable to check consent of users: rate limited")
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -404,7 +426,15 @@ func Test_twitterPoller_Start(t *testing.T) {
 			fakeUserRepo := mocksuser.NewUserRepository(t)
 			fakeTweetService := mocksdomain.NewTweetService(t)
 			fakeWorker := mocksworker.NewBackgroundWorkerClient(t)
+			fakeConsent := mocksdomain.NewConsentService(t)
 			c.mocks(fakeTwitterClient, fakeUserRepo, fakeTweetService, cancel, fakeWorker)
+			if c.consent != nil {
+				c.consent(fakeConsent)
+			} else {
+				fakeConsent.On("FilterUsers", mock.Anything, mock.Anything).
+					Return(func(_ context.Context, users models.UserSlice) models.UserSlice { return users }, nil).
+					Maybe()
+			}
 
 			// Workaround for https://github.com/getsentry/sentry-go/issues/518
 			_ = sentry.Init(sentry.ClientOptions{})
@@ -414,6 +444,7 @@ func Test_twitterPoller_Start(t *testing.T) {
 				fakeTwitterClient,
 				fakeUserRepo,
 				fakeWorker,
+				fakeConsent,
 			)
 			err := poller.Start(fakeContext)
 			c.assertErr(t, err)
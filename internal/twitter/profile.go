@@ -0,0 +1,98 @@
+package twitter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/g8rswimmer/go-twitter/v2"
+	"github.com/pkg/errors"
+)
+
+// maxProfileSize is the largest profile document read from twitter.
+const maxProfileSize = 1 << 20
+
+// UserProfile holds the parts of a twitter profile the v2 client does not give us:
+// the banner, and the expanded links of the profile which are otherwise only t.co links.
+type UserProfile struct {
+	BannerURL string `json:"banner_url,omitempty"`
+	// Website is the expanded link of the profile.
+	Website string `json:"website,omitempty"`
+	// DescriptionURLs are the expanded links of the bio.
+	DescriptionURLs []string `json:"description_urls,omitempty"`
+}
+
+type urlEntities struct {
+	URLs []struct {
+		ExpandedURL string `json:"expanded_url"`
+	} `json:"urls"`
+}
+
+func (u urlEntities) expandedURLs() []string {
+	urls := make([]string, 0, len(u.URLs))
+	for _, entity := range u.URLs {
+		if entity.ExpandedURL != "" {
+			urls = append(urls, entity.ExpandedURL)
+		}
+	}
+	return urls
+}
+
+type userShowResponse struct {
+	ProfileBannerURL string `json:"profile_banner_url"`
+	Entities         struct {
+		URL         urlEntities `json:"url"`
+		Description urlEntities `json:"description"`
+	} `json:"entities"`
+}
+
+// backend completes the v2 client with the v1.1 user lookup, which is the only one returning the banner.
+type backend struct {
+	*twitter.Client
+}
+
+func NewBackend(client *twitter.Client) *backend {
+	return &backend{Client: client}
+}
+
+func (b *backend) UserProfile(ctx context.Context, username string) (*UserProfile, error) {
+	endpoint := b.Host + "/1.1/users/show.json?" + url.Values{
+		"screen_name":      []string{username},
+		"include_entities": []string{"true"},
+	}.Encode()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create user profile request")
+	}
+	b.Authorizer.Add(request)
+
+	response, err := b.Client.Client.Do(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch user profile")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, UserNotFoundError{username}
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, &twitter.ErrorResponse{StatusCode: response.StatusCode}
+	}
+
+	var show userShowResponse
+	err = json.NewDecoder(io.LimitReader(response.Body, maxProfileSize)).Decode(&show)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode user profile")
+	}
+
+	profile := &UserProfile{
+		BannerURL:       show.ProfileBannerURL,
+		DescriptionURLs: show.Entities.Description.expandedURLs(),
+	}
+	if websites := show.Entities.URL.expandedURLs(); len(websites) > 0 {
+		profile.Website = websites[0]
+	}
+	return profile, nil
+}
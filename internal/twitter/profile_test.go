@@ -0,0 +1,87 @@
+package twitter_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gotwitter "github.com/g8rswimmer/go-twitter/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/estrys/estrys/internal/twitter"
+)
+
+func TestBackend_UserProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected *twitter.UserProfile
+		err      string
+	}{
+		{
+			name:   "profile with banner and links",
+			status: http.StatusOK,
+			body: `{
+				"screen_name": "foobar",
+				"url": "https://t.co/website",
+				"profile_banner_url": "https://pbs.twimg.com/profile_banners/12345/1669000000",
+				"entities": {
+					"url": {"urls": [{"url": "https://t.co/website", "expanded_url": "https://example.org/foobar"}]},
+					"description": {"urls": [
+						{"url": "https://t.co/first", "expanded_url": "https://another-instance.example.com/@foobar"},
+						{"url": "https://t.co/second"}
+					]}
+				}
+			}`,
+			expected: &twitter.UserProfile{
+				BannerURL:       "https://pbs.twimg.com/profile_banners/12345/1669000000",
+				Website:         "https://example.org/foobar",
+				DescriptionURLs: []string{"https://another-instance.example.com/@foobar"},
+			},
+		},
+		{
+			name:     "profile without banner nor links",
+			status:   http.StatusOK,
+			body:     `{"screen_name": "foobar", "entities": {"description": {"urls": []}}}`,
+			expected: &twitter.UserProfile{DescriptionURLs: []string{}},
+		},
+		{
+			name:   "user not found",
+			status: http.StatusNotFound,
+			body:   `{"errors": [{"code": 50, "message": "User not found."}]}`,
+			err:    "twitter user foobar not found",
+		},
+		{
+			name:   "rate limited",
+			status: http.StatusTooManyRequests,
+			err:    "twitter callout status 429 :",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				require.Equal(t, "/1.1/users/show.json", request.URL.Path)
+				require.Equal(t, "foobar", request.URL.Query().Get("screen_name"))
+				require.Equal(t, "Bearer token", request.Header.Get("Authorization"))
+				writer.WriteHeader(tt.status)
+				_, _ = writer.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			backend := twitter.NewBackend(&gotwitter.Client{
+				Authorizer: twitter.Authorizer{Token: "token"},
+				Client:     server.Client(),
+				Host:       server.URL,
+			})
+			profile, err := backend.UserProfile(context.Background(), "foobar")
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, profile)
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	models "github.com/estrys/estrys/internal/twitter/models"
 	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/estrys/estrys/internal/twitter/repository"
 )
 
 // TweetRepository is an autogenerated mock type for the TweetRepository type
@@ -22,6 +24,92 @@ func (_m *TweetRepository) EXPECT() *TweetRepository_Expecter {
 	return &TweetRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddToTimeline provides a mock function with given fields: _a0, _a1
+func (_m *TweetRepository) AddToTimeline(_a0 context.Context, _a1 *models.Tweet) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Tweet) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TweetRepository_AddToTimeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddToTimeline'
+type TweetRepository_AddToTimeline_Call struct {
+	*mock.Call
+}
+
+// AddToTimeline is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *models.Tweet
+func (_e *TweetRepository_Expecter) AddToTimeline(_a0 interface{}, _a1 interface{}) *TweetRepository_AddToTimeline_Call {
+	return &TweetRepository_AddToTimeline_Call{Call: _e.mock.On("AddToTimeline", _a0, _a1)}
+}
+
+func (_c *TweetRepository_AddToTimeline_Call) Run(run func(_a0 context.Context, _a1 *models.Tweet)) *TweetRepository_AddToTimeline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Tweet))
+	})
+	return _c
+}
+
+func (_c *TweetRepository_AddToTimeline_Call) Return(_a0 error) *TweetRepository_AddToTimeline_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// GetTimeline provides a mock function with given fields: _a0, _a1, _a2
+func (_m *TweetRepository) GetTimeline(_a0 context.Context, _a1 string, _a2 repository.TimelineQuery) ([]models.Tweet, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []models.Tweet
+	if rf, ok := ret.Get(0).(func(context.Context, string, repository.TimelineQuery) []models.Tweet); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Tweet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, repository.TimelineQuery) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TweetRepository_GetTimeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTimeline'
+type TweetRepository_GetTimeline_Call struct {
+	*mock.Call
+}
+
+// GetTimeline is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+//   - _a2 repository.TimelineQuery
+func (_e *TweetRepository_Expecter) GetTimeline(_a0 interface{}, _a1 interface{}, _a2 interface{}) *TweetRepository_GetTimeline_Call {
+	return &TweetRepository_GetTimeline_Call{Call: _e.mock.On("GetTimeline", _a0, _a1, _a2)}
+}
+
+func (_c *TweetRepository_GetTimeline_Call) Run(run func(_a0 context.Context, _a1 string, _a2 repository.TimelineQuery)) *TweetRepository_GetTimeline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(repository.TimelineQuery))
+	})
+	return _c
+}
+
+func (_c *TweetRepository_GetTimeline_Call) Return(_a0 []models.Tweet, _a1 error) *TweetRepository_GetTimeline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // GetTweet provides a mock function with given fields: _a0, _a1
 func (_m *TweetRepository) GetTweet(_a0 context.Context, _a1 string) (*models.Tweet, error) {
 	ret := _m.Called(_a0, _a1)
@@ -2,27 +2,57 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/go-redis/redis/v9"
 	"github.com/pkg/errors"
 
 	"github.com/estrys/estrys/internal/cache"
+	"github.com/estrys/estrys/internal/observability"
 	"github.com/estrys/estrys/internal/twitter/models"
 )
 
+// TimelineQuery select a page of an author timeline.
+// MaxID and MinID are exclusive bounds, if MinID is set the page is made of the
+// oldest tweets newer than MinID. Tweets are always returned newest first.
+type TimelineQuery struct {
+	MaxID string
+	MinID string
+	Limit int
+}
+
 //go:generate mockery --with-expecter --name=TweetRepository
 type TweetRepository interface {
 	GetTweet(context.Context, string) (*models.Tweet, error)
 	Store(context.Context, *models.Tweet) error
+	AddToTimeline(context.Context, *models.Tweet) error
+	GetTimeline(context.Context, string, TimelineQuery) ([]models.Tweet, error)
 }
 
+// twitterEpoch is the time tweet ids count from, in milliseconds.
+// https://developer.twitter.com/en/docs/twitter-ids
+const twitterEpoch = 1288834974657
+
 type redisTweetRepository struct {
 	cache cache.Cache[models.Tweet]
+	redis redis.Cmdable
+	// lifetime is how long tweets are kept in cache, timelines do not reference older tweets.
+	// Tweets are kept forever when it is zero.
+	lifetime time.Duration
 }
 
-func NewRedisTweetRepository(cache cache.Cache[models.Tweet]) *redisTweetRepository {
+func NewRedisTweetRepository(
+	cache cache.Cache[models.Tweet],
+	redis redis.Cmdable,
+	lifetime time.Duration,
+) *redisTweetRepository {
 	return &redisTweetRepository{
-		cache: cache,
+		cache:    cache,
+		redis:    redis,
+		lifetime: lifetime,
 	}
 }
 
@@ -30,6 +60,40 @@ func (r *redisTweetRepository) getTweetCacheKey(id string) string {
 	return strings.Join([]string{"twitter", "tweets", id}, "/")
 }
 
+func (r *redisTweetRepository) getTimelineKey(username string) string {
+	return strings.Join([]string{"twitter", "timelines", strings.ToLower(username)}, "/")
+}
+
+// Timelines are sorted sets where all members share the same score, so they are ordered
+// lexicographically. Tweet ids are padded to make this order match the numeric one.
+func (r *redisTweetRepository) timelineMember(tweetID string) string {
+	return fmt.Sprintf("%020s", tweetID)
+}
+
+// oldestTweetID returns the id a tweet posted at the given time would have,
+// tweet ids start with their creation time.
+func oldestTweetID(since time.Time) string {
+	millis := since.UnixMilli() - twitterEpoch
+	if millis < 0 {
+		return "0"
+	}
+	return strconv.FormatInt(millis<<22, 10)
+}
+
+// trimTimeline removes the tweets older than the cache lifetime from a timeline. Tweets are stored
+// after they are posted, so they are still in cache up to this point and pages never reference expired tweets.
+func (r *redisTweetRepository) trimTimeline(ctx context.Context, key string) error {
+	if r.lifetime <= 0 {
+		return nil
+	}
+	oldest := r.timelineMember(oldestTweetID(time.Now().Add(-r.lifetime)))
+	err := r.redis.ZRemRangeByLex(ctx, key, "-", "("+oldest).Err()
+	if err != nil {
+		return errors.Wrap(err, "unable to trim timeline")
+	}
+	return nil
+}
+
 func (r *redisTweetRepository) GetTweet(ctx context.Context, tweetID string) (*models.Tweet, error) {
 	tweet, err := r.cache.Get(ctx, r.getTweetCacheKey(tweetID))
 	if err != nil && !errors.Is(err, cache.ErrMiss) {
@@ -49,3 +113,73 @@ func (r *redisTweetRepository) Store(ctx context.Context, tweet *models.Tweet) e
 	}
 	return nil
 }
+
+// AddToTimeline index the tweet in its author timeline.
+func (r *redisTweetRepository) AddToTimeline(ctx context.Context, tweet *models.Tweet) error {
+	key := r.getTimelineKey(tweet.AuthorUsername)
+	span := observability.StartSpan(ctx, "cache.save", map[string]any{"db.system": "redis", "cache.key": key})
+	err := r.redis.ZAdd(ctx, key, redis.Z{Member: r.timelineMember(tweet.ID)}).Err()
+	if err == nil && r.lifetime > 0 {
+		// Timelines of users who stopped tweeting go away along with their last tweet
+		err = r.redis.Expire(ctx, key, r.lifetime).Err()
+	}
+	observability.FinishSpan(span)
+	if err != nil {
+		return errors.Wrap(err, "unable to add tweet to timeline")
+	}
+	return r.trimTimeline(ctx, key)
+}
+
+func (r *redisTweetRepository) GetTimeline(
+	ctx context.Context,
+	username string,
+	query TimelineQuery,
+) ([]models.Tweet, error) {
+	key := r.getTimelineKey(username)
+	err := r.trimTimeline(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	span := observability.StartSpan(ctx, "cache.get_item", map[string]any{"db.system": "redis", "cache.key": key})
+	var members []string
+	if query.MinID != "" {
+		members, err = r.redis.ZRangeByLex(ctx, key, &redis.ZRangeBy{
+			Min:   "(" + r.timelineMember(query.MinID),
+			Max:   "+",
+			Count: int64(query.Limit),
+		}).Result()
+		// Pages are always ordered newest first
+		for i, j := 0, len(members)-1; i < j; i, j = i+1, j-1 {
+			members[i], members[j] = members[j], members[i]
+		}
+	} else {
+		maxBound := "+"
+		if query.MaxID != "" {
+			maxBound = "(" + r.timelineMember(query.MaxID)
+		}
+		members, err = r.redis.ZRevRangeByLex(ctx, key, &redis.ZRangeBy{
+			Min:   "-",
+			Max:   maxBound,
+			Count: int64(query.Limit),
+		}).Result()
+	}
+	observability.FinishSpan(span)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read timeline")
+	}
+
+	tweets := make([]models.Tweet, 0, len(members))
+	for _, member := range members {
+		tweet, err := r.GetTweet(ctx, strings.TrimLeft(member, "0"))
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read timeline tweet")
+		}
+		// The tweet may have expired from the cache, the timeline is kept anyway
+		if tweet == nil {
+			continue
+		}
+		tweets = append(tweets, *tweet)
+	}
+	return tweets, nil
+}
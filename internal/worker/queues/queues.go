@@ -3,4 +3,5 @@ package queues
 const (
 	QueueFollows = "follows"
 	QueueTweets  = "tweets"
+	QueueInbox   = "inbox"
 )
@@ -14,6 +14,7 @@ import (
 
 	"github.com/estrys/estrys/internal/activitypub"
 	activitypubclient "github.com/estrys/estrys/internal/activitypub/client"
+	"github.com/estrys/estrys/internal/activitypub/resolver"
 	"github.com/estrys/estrys/internal/dic"
 	"github.com/estrys/estrys/internal/logger"
 	"github.com/estrys/estrys/internal/observability"
@@ -54,7 +55,8 @@ func HandleAcceptFollow(ctx context.Context, task *asynq.Task) error {
 	log := dic.GetService[logger.Logger]()
 	vocabService := dic.GetService[activitypub.VocabService]()
 	userRepo := dic.GetService[repository.UserRepository]()
-	actorRepo := dic.GetService[repository.ActorRepository]()
+	followerRepo := dic.GetService[repository.FollowerRepository]()
+	actorResolver := dic.GetService[resolver.ActorResolver]()
 	activityPubClient := dic.GetService[activitypubclient.ActivityPubClient]()
 
 	var input AcceptFollowInput
@@ -85,16 +87,38 @@ func HandleAcceptFollow(ctx context.Context, task *asynq.Task) error {
 	if err != nil {
 		return errors.Errorf("unable parse actor URL : %v: %s", err, asynq.SkipRetry)
 	}
-	actor, err := actorRepo.Get(ctx, actorURL)
+	actor, err := actorResolver.Resolve(ctx, actorURL)
 	if err != nil {
 		return errors.Wrap(err, "unable to retrieve actor")
 	}
 
+	follower, shouldRespond, err := updateFollowState(ctx, followerRepo, user, actor, repository.FollowStateAccepted)
+	if err != nil {
+		return err
+	}
+	if !shouldRespond {
+		log.WithFields(logrus.Fields{
+			"user":  input.Username,
+			"actor": actorURL.String(),
+			"state": follower.State,
+		}).Info("follow is not pending anymore, not sending accept")
+		return nil
+	}
+
+	inbox, err := activitypubclient.ActorInbox(actor)
+	if err != nil {
+		return errors.Errorf("unable to find actor inbox : %v: %s", err, asynq.SkipRetry)
+	}
+
 	acceptFollow, err := vocabService.GetAccept(user, follow)
 	if err != nil {
 		return errors.Errorf("unable to create an accept request : %v: %s", err, asynq.SkipRetry)
 	}
-	err = activityPubClient.PostInbox(ctx, actor, user, acceptFollow)
+	err = activityPubClient.PostInbox(ctx, inbox, user, acceptFollow)
+	deliveryErr := followerRepo.SetResponseDelivery(ctx, follower, err)
+	if deliveryErr != nil {
+		log.WithError(deliveryErr).Error("unable to record the delivery of the follow response")
+	}
 	if err != nil {
 		var isNotAcceptedErr *activitypubclient.InboxNotAcceptedError
 		if errors.As(err, &isNotAcceptedErr) {
@@ -109,7 +133,7 @@ func HandleAcceptFollow(ctx context.Context, task *asynq.Task) error {
 
 	log.WithFields(logrus.Fields{
 		"user":  input.Username,
-		"inbox": actorURL.String(),
+		"inbox": inbox.String(),
 	}).Info("sent accept follow to inbox")
 	return nil
 }
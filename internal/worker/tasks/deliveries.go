@@ -0,0 +1,55 @@
+package tasks
+
+import (
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/worker/client"
+	taskerrors "github.com/estrys/estrys/internal/worker/errors"
+)
+
+// NewDeliveryTask creates the delivery of an activity to a shared inbox,
+// or to the inbox of the actor when sharedInbox is empty.
+type NewDeliveryTask func(sharedInbox string, actor *models.Actor) (*asynq.Task, error)
+
+// ScheduleDeliveries schedules a delivery task for each follower inbox and returns the count of scheduled deliveries.
+// Followers sharing an inbox are on the same server, a single delivery addressed to the followers collection
+// is enough for all of them.
+func ScheduleDeliveries(
+	worker client.BackgroundWorkerClient,
+	followers models.ActorSlice,
+	newTask NewDeliveryTask,
+) (int, error) {
+	deliveries := 0
+	sharedInboxes := make(map[string]struct{}, len(followers))
+	for _, actor := range followers {
+		sharedInbox := ""
+		if actor.SharedInbox.Valid {
+			if _, alreadyScheduled := sharedInboxes[actor.SharedInbox.String]; alreadyScheduled {
+				continue
+			}
+			sharedInboxes[actor.SharedInbox.String] = struct{}{}
+			sharedInbox = actor.SharedInbox.String
+		}
+		task, err := newTask(sharedInbox, actor)
+		if err != nil {
+			return deliveries, taskerrors.TaskError{
+				SkipRetry: true,
+				Err:       errors.Wrap(err, "unable to create delivery task"),
+			}
+		}
+		_, err = worker.Enqueue(task)
+		if err != nil {
+			// Deliveries already scheduled by a previous run are not sent twice
+			if errors.Is(err, asynq.ErrTaskIDConflict) {
+				continue
+			}
+			return deliveries, taskerrors.TaskError{
+				Err: errors.Wrap(err, "unable to schedule delivery task"),
+			}
+		}
+		deliveries++
+	}
+	return deliveries, nil
+}
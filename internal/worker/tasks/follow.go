@@ -0,0 +1,38 @@
+package tasks
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+)
+
+// updateFollowState moves a pending follow to the state of the response we are about to send.
+// False is returned when the follow has been undone or answered in the meantime, then nothing should be sent.
+func updateFollowState(
+	ctx context.Context,
+	followerRepo repository.FollowerRepository,
+	user *models.User,
+	actor *models.Actor,
+	state repository.FollowState,
+) (*models.Follower, bool, error) {
+	follower, err := followerRepo.Get(ctx, user, actor)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "unable to fetch follow")
+	}
+	switch repository.FollowState(follower.State) {
+	case state:
+		// The task is retried, the state has already been updated
+		return follower, true, nil
+	case repository.FollowStatePending:
+	default:
+		return follower, false, nil
+	}
+	err = followerRepo.SetState(ctx, follower, state)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "unable to update follow state")
+	}
+	return follower, true, nil
+}
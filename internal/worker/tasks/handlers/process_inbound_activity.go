@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/estrys/estrys/internal/activitypub"
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/domain"
+	"github.com/estrys/estrys/internal/logger"
+	"github.com/estrys/estrys/internal/repository"
+	taskerrors "github.com/estrys/estrys/internal/worker/errors"
+	"github.com/estrys/estrys/internal/worker/tasks"
+)
+
+// HandleProcessInboundActivity handles an activity stored by the inbox. Activities that can never succeed
+// are marked as failed right away, the others are retried and only marked as failed on the last attempt.
+func HandleProcessInboundActivity(ctx context.Context, task *asynq.Task) error {
+	log := dic.GetService[logger.Logger]()
+	inboxService := dic.GetService[domain.InboxService]()
+	inboundActivityRepo := dic.GetService[repository.InboundActivityRepository]()
+
+	var input tasks.ProcessInboundActivityInput
+	if err := json.Unmarshal(task.Payload(), &input); err != nil {
+		log.WithError(err).Error("unable to deserialize task input")
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to deserialize task input"),
+		}
+	}
+
+	activity, err := inboundActivityRepo.Get(ctx, input.ActivityID)
+	if err != nil {
+		return taskerrors.TaskError{
+			Err: errors.Wrap(err, "unable to fetch inbound activity"),
+		}
+	}
+	if activity.ProcessedAt.Valid {
+		log.WithField("activity", activity.ActivityID).Debug("inbound activity already processed")
+		return nil
+	}
+
+	err = inboxService.Process(ctx, activity)
+	if err != nil {
+		permanent := isPermanentInboxError(err)
+		if permanent || isLastAttempt(ctx) {
+			markErr := inboundActivityRepo.MarkProcessed(ctx, activity, err)
+			if markErr != nil {
+				log.WithError(markErr).Error("unable to mark inbound activity as failed")
+			}
+		}
+		return taskerrors.TaskError{
+			SkipRetry: permanent,
+			Err:       errors.Wrap(err, "unable to process inbound activity"),
+		}
+	}
+
+	err = inboundActivityRepo.MarkProcessed(ctx, activity, nil)
+	if err != nil {
+		// The activity has been handled, retrying would process it twice
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to mark inbound activity as processed"),
+		}
+	}
+
+	log.WithFields(logrus.Fields{
+		"activity": activity.ActivityID,
+		"user":     activity.User,
+	}).Info("inbound activity processed")
+	return nil
+}
+
+// isPermanentInboxError tells if the activity has been refused, retrying it would give the same result.
+func isPermanentInboxError(err error) bool {
+	var notAllowedErr *domain.ActorNotAllowedError
+	var notAllowedUndo *domain.UnsuportedUndoObjectError
+	var signerMismatch *domain.SignerMismatchError
+	return errors.As(err, &notAllowedErr) ||
+		errors.As(err, &notAllowedUndo) ||
+		errors.As(err, &signerMismatch) ||
+		errors.Is(err, streams.ErrNoCallbackMatch) ||
+		errors.Is(err, domain.ErrFollowMismatchDomain) ||
+		errors.Is(err, domain.ErrUndoActorMismatch) ||
+		errors.Is(err, domain.ErrMoveActorMismatch) ||
+		errors.Is(err, domain.ErrMoveTargetNotAlias) ||
+		errors.Is(err, domain.ErrReplyAuthorMismatch) ||
+		errors.Is(err, domain.ErrMissingObjectID) ||
+		errors.Is(err, domain.ErrUserDoesNotExist) ||
+		errors.Is(err, domain.ErrUserOptedOut) ||
+		errors.Is(err, activitypub.ErrMissingActor) ||
+		errors.Is(err, activitypub.ErrMissingObject) ||
+		errors.Is(err, activitypub.ErrMissingTarget)
+}
+
+func isLastAttempt(ctx context.Context) bool {
+	retried, hasRetryCount := asynq.GetRetryCount(ctx)
+	maxRetry, hasMaxRetry := asynq.GetMaxRetry(ctx)
+	return hasRetryCount && hasMaxRetry && retried >= maxRetry
+}
@@ -0,0 +1,948 @@
+package handlers_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/go-fed/activity/pub"
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	activitypubclient "github.com/estrys/estrys/internal/activitypub/client"
+	activitypubclientmocks "github.com/estrys/estrys/internal/activitypub/client/mocks"
+	"github.com/estrys/estrys/internal/activitypub/resolver"
+	resolvermocks "github.com/estrys/estrys/internal/activitypub/resolver/mocks"
+	"github.com/estrys/estrys/internal/crypto"
+	cryptomocks "github.com/estrys/estrys/internal/crypto/mocks"
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/domain"
+	domainmocks "github.com/estrys/estrys/internal/domain/mocks"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	repositorymocks "github.com/estrys/estrys/internal/repository/mocks"
+	twittermodels "github.com/estrys/estrys/internal/twitter/models"
+	twitterrepository "github.com/estrys/estrys/internal/twitter/repository"
+	twitterrepositorymocks "github.com/estrys/estrys/internal/twitter/repository/mocks"
+	"github.com/estrys/estrys/internal/worker/client"
+	clientmocks "github.com/estrys/estrys/internal/worker/client/mocks"
+	taskerrors "github.com/estrys/estrys/internal/worker/errors"
+	"github.com/estrys/estrys/internal/worker/tasks"
+	"github.com/estrys/estrys/internal/worker/tasks/handlers"
+	dic_test "github.com/estrys/estrys/tests/dic"
+)
+
+const (
+	fakeInboundActivityID = "0b9c1d7e-4a5f-4c3e-9b2a-8d7e6f5a4b3c"
+	fakeFollowID          = "https://another-instance.example.com/bdd01ced-d657-4847-a266-2c43e1cd8dc5"
+	validActorID          = "9f3b2c1d-8e7a-4b6c-a5d4-e3f2a1b0c9d8"
+)
+
+func allowActor(t *testing.T, actor string) {
+	t.Helper()
+	viper.Set("allowed_users", actor)
+	t.Cleanup(func() {
+		viper.Set("allowed_users", "")
+	})
+}
+
+// registerUnknownFollowID registers a follower repository without any follow matching fakeFollowID.
+func registerUnknownFollowID(t *testing.T) *repositorymocks.FollowerRepository {
+	t.Helper()
+	fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+	fakeFollowerRepo.EXPECT().GetByFollowID(mock.Anything, fakeFollowID).
+		Return(nil, errors.Wrap(sql.ErrNoRows, "unable to fetch follower from database"))
+	_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+	return fakeFollowerRepo
+}
+
+// registerUnknownActor registers an actor repository without any stored actor.
+func registerUnknownActor(t *testing.T) {
+	t.Helper()
+	fakeActorRepo := repositorymocks.NewActorRepository(t)
+	fakeActorRepo.EXPECT().Get(mock.Anything, mock.Anything).
+		Return(nil, errors.Wrap(sql.ErrNoRows, "unable to fetch actor from db"))
+	_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+}
+
+// registerNoInteraction registers an interaction repository without any interaction matching activityID.
+func registerNoInteraction(t *testing.T, actor *models.Actor, activityID string) {
+	t.Helper()
+	fakeInteractionRepo := repositorymocks.NewInteractionRepository(t)
+	fakeInteractionRepo.EXPECT().DeleteByActivityID(mock.Anything, actor, activityID).Return(false, nil)
+	_ = dic.Register[repository.InteractionRepository](fakeInteractionRepo)
+}
+
+// registerFakeTweet registers the tweet of validuser the interaction fixtures are about.
+func registerFakeTweet(t *testing.T) *twittermodels.Tweet {
+	t.Helper()
+	fakeTweet := &twittermodels.Tweet{ID: "1234", AuthorUsername: "validuser"}
+	fakeTweetRepo := twitterrepositorymocks.NewTweetRepository(t)
+	fakeTweetRepo.EXPECT().GetTweet(mock.Anything, fakeTweet.ID).Return(fakeTweet, nil)
+	_ = dic.Register[twitterrepository.TweetRepository](fakeTweetRepo)
+	return fakeTweet
+}
+
+// newReply returns the note of the create_reply fixture, written by author.
+func newReply(t *testing.T, author string) vocab.Type {
+	t.Helper()
+	reply, err := streams.ToType(context.Background(), map[string]any{
+		"@context":     "https://www.w3.org/ns/activitystreams",
+		"id":           "https://another-instance.example.com/users/validactor/statuses/1",
+		"type":         "Note",
+		"url":          "https://another-instance.example.com/@validactor/1",
+		"attributedTo": author,
+		"inReplyTo":    "https://example.com/status/validuser/1234",
+		"published":    "2022-11-20T10:00:00Z",
+		"content":      "<p>@validuser nice one</p>",
+	})
+	require.NoError(t, err)
+	return reply
+}
+
+func newFollow(actor, object string) vocab.ActivityStreamsFollow {
+	follow := streams.NewActivityStreamsFollow()
+	actorURL, _ := url.Parse(actor)
+	actorProperty := streams.NewActivityStreamsActorProperty()
+	actorProperty.AppendIRI(actorURL)
+	follow.SetActivityStreamsActor(actorProperty)
+	objectURL, _ := url.Parse(object)
+	objectProperty := streams.NewActivityStreamsObjectProperty()
+	objectProperty.AppendIRI(objectURL)
+	follow.SetActivityStreamsObject(objectProperty)
+	return follow
+}
+
+// newMovedActor returns the actor targeted by the move fixtures, declaring the given aliases.
+func newMovedActor(t *testing.T, aliases ...string) vocab.Type {
+	t.Helper()
+	// Decoded documents only contain generic values
+	alsoKnownAs := make([]any, 0, len(aliases))
+	for _, alias := range aliases {
+		alsoKnownAs = append(alsoKnownAs, alias)
+	}
+	actor, err := streams.ToType(context.Background(), map[string]any{
+		"@context":    "https://www.w3.org/ns/activitystreams",
+		"id":          "https://new-instance.example.com/users/validactor",
+		"type":        "Person",
+		"inbox":       "https://new-instance.example.com/users/validactor/inbox",
+		"alsoKnownAs": alsoKnownAs,
+	})
+	require.NoError(t, err)
+	return actor
+}
+
+func TestHandleProcessInboundActivity(t *testing.T) {
+	validActorURL, _ := url.Parse("https://another-instance.example.com/users/validactor")
+	movedActorURL, _ := url.Parse("https://new-instance.example.com/users/validactor")
+	validUser := &models.User{Username: "validuser"}
+
+	tests := []struct {
+		name      string
+		inputFile string
+		processed bool
+		Mock      func(t *testing.T)
+		// marked tells if the activity is expected to be marked as processed
+		marked    bool
+		err       string
+		skipRetry bool
+	}{
+		{
+			name:      "already processed",
+			inputFile: "valid_follow",
+			processed: true,
+		},
+		{
+			name:      "unsupported activity",
+			inputFile: "unsupported_activity",
+			marked:    true,
+			err:       "unable to process inbound activity: activity stream did not match the callback function",
+			skipRetry: true,
+		},
+		{
+			name:      "follow object domain mismatch",
+			inputFile: "domain_mismatch",
+			marked:    true,
+			err:       "unable to process inbound activity: unable to follow an user outside this instance",
+			skipRetry: true,
+		},
+		{
+			name:      "follow of an user not in db",
+			inputFile: "no_user_in_db",
+			Mock: func(t *testing.T) {
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "notfound").Return(nil, sql.ErrNoRows)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+			},
+			marked:    true,
+			err:       "unable to process inbound activity: user does not exist",
+			skipRetry: true,
+		},
+		{
+			name:      "follow of an user opted out",
+			inputFile: "valid_follow",
+			Mock: func(t *testing.T) {
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActor := &models.Actor{}
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.On("Resolve", mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+
+				fakeFollower := &models.Follower{State: string(repository.FollowStatePending)}
+				fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+				fakeFollowerRepo.EXPECT().Create(mock.Anything, validUser, fakeActor, fakeFollowID).
+					Return(fakeFollower, nil)
+				fakeFollowerRepo.EXPECT().SetState(mock.Anything, fakeFollower, repository.FollowStateRejected).
+					Return(nil)
+				_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+
+				fakeConsentService := domainmocks.NewConsentService(t)
+				fakeConsentService.EXPECT().CheckUser(mock.Anything, "validuser").
+					Return(errors.WithStack(domain.ErrUserOptedOut))
+				_ = dic.Register[domain.ConsentService](fakeConsentService)
+
+				fakeWorker := clientmocks.NewBackgroundWorkerClient(t)
+				fakeWorker.On("Enqueue", mock.MatchedBy(func(task *asynq.Task) bool {
+					reject := tasks.RejectFollowInput{}
+					err := json.Unmarshal(task.Payload(), &reject)
+					return assert.NoError(t, err) &&
+						assert.Equal(t, tasks.TypeRejectFollow, task.Type()) &&
+						assert.Equal(t, "validuser", reject.Username)
+				})).Return(nil, nil)
+				_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
+			},
+			marked:    true,
+			err:       "unable to process inbound activity: user refused to be bridged",
+			skipRetry: true,
+		},
+		{
+			name:      "unable to resolve actor",
+			inputFile: "valid_follow",
+			Mock: func(t *testing.T) {
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.On("Resolve", mock.Anything, validActorURL).
+					Return(nil, errors.New("remote server is down"))
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+			},
+			err: "unable to process inbound activity: unable to resolve actor: remote server is down",
+		},
+		{
+			name:      "actor not allowed",
+			inputFile: "valid_follow",
+			Mock: func(t *testing.T) {
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.On("Resolve", mock.Anything, validActorURL).Return(&models.Actor{}, nil)
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+
+				fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+				fakeFollowerRepo.EXPECT().Create(mock.Anything, validUser, &models.Actor{}, fakeFollowID).
+					Return(&models.Follower{}, nil)
+				_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+
+				fakeWorker := clientmocks.NewBackgroundWorkerClient(t)
+				fakeWorker.On("Enqueue", mock.MatchedBy(func(task *asynq.Task) bool {
+					reject := tasks.RejectFollowInput{}
+					err := json.Unmarshal(task.Payload(), &reject)
+					return assert.NoError(t, err) &&
+						assert.Equal(t, tasks.TypeRejectFollow, task.Type()) &&
+						assert.Equal(t, "validuser", reject.Username)
+				})).Return(nil, nil)
+				_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
+			},
+			marked:    true,
+			err:       "unable to process inbound activity: user not allowed on instance",
+			skipRetry: true,
+		},
+		{
+			name:      "valid follow",
+			inputFile: "valid_follow",
+			Mock: func(t *testing.T) {
+				fakeActor := &models.Actor{}
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+				fakeFollowerRepo.EXPECT().Create(mock.Anything, validUser, fakeActor, fakeFollowID).
+					Return(&models.Follower{}, nil)
+				_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.On("Resolve", mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+
+				fakeWorker := clientmocks.NewBackgroundWorkerClient(t)
+				fakeWorker.On("Enqueue", mock.MatchedBy(func(task *asynq.Task) bool {
+					expectedAccept := tasks.AcceptFollowInput{
+						Username: "validuser",
+						Activity: map[string]interface{}{
+							"@context": "https://www.w3.org/ns/activitystreams",
+							"type":     "Follow",
+							"actor":    "https://another-instance.example.com/users/validactor",
+							"id":       "https://another-instance.example.com/bdd01ced-d657-4847-a266-2c43e1cd8dc5",
+							"object":   "https://example.com/users/validuser",
+						},
+					}
+					accept := tasks.AcceptFollowInput{}
+					err := json.Unmarshal(task.Payload(), &accept)
+					return assert.NoError(t, err) && assert.Equal(t, expectedAccept, accept)
+				})).Return(nil, nil)
+				_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
+
+				allowActor(t, "validactor@another-instance.example.com")
+			},
+			marked: true,
+		},
+		{
+			name:      "follow waiting for approval",
+			inputFile: "valid_follow",
+			Mock: func(t *testing.T) {
+				fakeActor := &models.Actor{}
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+				fakeFollowerRepo.EXPECT().Create(mock.Anything, validUser, fakeActor, fakeFollowID).
+					Return(&models.Follower{}, nil)
+				_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.On("Resolve", mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+
+				// No accept is sent until the follow is approved
+				_ = dic.Register[client.BackgroundWorkerClient](clientmocks.NewBackgroundWorkerClient(t))
+
+				allowActor(t, "validactor@another-instance.example.com")
+				viper.Set("manually_approved_users", "validuser")
+				t.Cleanup(func() {
+					viper.Set("manually_approved_users", "")
+				})
+			},
+			marked: true,
+		},
+		{
+			name:      "approved follower following again",
+			inputFile: "valid_follow",
+			Mock: func(t *testing.T) {
+				fakeActor := &models.Actor{}
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+				fakeFollowerRepo.EXPECT().Create(mock.Anything, validUser, fakeActor, fakeFollowID).
+					Return(&models.Follower{State: string(repository.FollowStateAccepted)}, nil)
+				_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.On("Resolve", mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+
+				// The follow was already approved, it is accepted again right away
+				fakeWorker := clientmocks.NewBackgroundWorkerClient(t)
+				fakeWorker.On("Enqueue", mock.MatchedBy(func(task *asynq.Task) bool {
+					return assert.Equal(t, tasks.TypeAcceptFollow, task.Type())
+				})).Return(nil, nil)
+				_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
+
+				allowActor(t, "validactor@another-instance.example.com")
+				viper.Set("manually_approved_users", "validuser")
+				t.Cleanup(func() {
+					viper.Set("manually_approved_users", "")
+				})
+			},
+			marked: true,
+		},
+		{
+			name:      "can only undo follow",
+			inputFile: "invalid_undo_reject",
+			Mock: func(t *testing.T) {
+				registerUnknownActor(t)
+				registerUnknownFollowID(t)
+			},
+			marked:    true,
+			err:       "unable to process inbound activity: do not support undo on 'Reject' activities",
+			skipRetry: true,
+		},
+		{
+			name:      "undo follow of another actor",
+			inputFile: "undo_follow_of_another_actor",
+			Mock: func(t *testing.T) {
+				registerUnknownActor(t)
+				registerUnknownFollowID(t)
+			},
+			marked:    true,
+			err:       "unable to process inbound activity: unable to undo an activity of another actor",
+			skipRetry: true,
+		},
+		{
+			name:      "undo follow id of another actor",
+			inputFile: "undo_follow_of_another_actor",
+			Mock: func(t *testing.T) {
+				fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+				fakeFollowerRepo.EXPECT().GetByFollowID(mock.Anything, fakeFollowID).
+					Return(&models.Follower{Actor: validActorID}, nil)
+				_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+
+				malloryURL, _ := url.Parse("https://another-instance.example.com/users/mallory")
+				fakeMallory := &models.Actor{ID: "5c2d8a9e-6f1b-4e3a-8d7c-1b2a3c4d5e6f"}
+				fakeActorRepo := repositorymocks.NewActorRepository(t)
+				fakeActorRepo.EXPECT().Get(mock.Anything, malloryURL).Return(fakeMallory, nil)
+				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+				registerNoInteraction(t, fakeMallory, fakeFollowID)
+			},
+			marked:    true,
+			err:       "unable to process inbound activity: unable to undo an activity of another actor",
+			skipRetry: true,
+		},
+		{
+			name:      "valid undo follow",
+			inputFile: "valid_undo_follow",
+			Mock: func(t *testing.T) {
+				fakeFollower := &models.Follower{User: "validuser", Actor: validActorID}
+				fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+				fakeFollowerRepo.EXPECT().GetByFollowID(mock.Anything, fakeFollowID).Return(fakeFollower, nil)
+				fakeFollowerRepo.EXPECT().SetState(mock.Anything, fakeFollower, repository.FollowStateUndone).Return(nil)
+				_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+
+				fakeActor := &models.Actor{ID: validActorID}
+				fakeActorRepo := repositorymocks.NewActorRepository(t)
+				fakeActorRepo.EXPECT().Get(mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+				registerNoInteraction(t, fakeActor, fakeFollowID)
+			},
+			marked: true,
+		},
+		{
+			name:      "undo of a follow without known id",
+			inputFile: "undo_follow_iri",
+			Mock: func(t *testing.T) {
+				registerUnknownActor(t)
+				fakeActor := &models.Actor{ID: validActorID}
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeFollower := &models.Follower{User: "validuser", Actor: validActorID}
+				fakeFollowerRepo := registerUnknownFollowID(t)
+				fakeFollowerRepo.EXPECT().Get(mock.Anything, validUser, fakeActor).Return(fakeFollower, nil)
+				fakeFollowerRepo.EXPECT().SetState(mock.Anything, fakeFollower, repository.FollowStateUndone).Return(nil)
+
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.On("Resolve", mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+
+				fakeObjectResolver := resolvermocks.NewObjectResolver(t)
+				fakeObjectResolver.On("Resolve", mock.Anything, mock.MatchedBy(func(property pub.IdProperty) bool {
+					return property.IsIRI() && property.GetIRI().String() == fakeFollowID
+				})).Return(newFollow(validActorURL.String(), "https://example.com/users/validuser"), nil)
+				_ = dic.Register[resolver.ObjectResolver](fakeObjectResolver)
+			},
+			marked: true,
+		},
+		{
+			name:      "undo of an unknown follow",
+			inputFile: "valid_undo_follow",
+			Mock: func(t *testing.T) {
+				registerUnknownActor(t)
+				fakeActor := &models.Actor{ID: validActorID}
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeFollowerRepo := registerUnknownFollowID(t)
+				fakeFollowerRepo.EXPECT().Get(mock.Anything, validUser, fakeActor).
+					Return(nil, errors.Wrap(sql.ErrNoRows, "unable to fetch follower from database"))
+
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.On("Resolve", mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+			},
+			marked: true,
+		},
+		{
+			name:      "update of another object",
+			inputFile: "update_note",
+			marked:    true,
+		},
+		{
+			name:      "update of an unknown actor",
+			inputFile: "update_actor",
+			Mock: func(t *testing.T) {
+				fakeActorRepo := repositorymocks.NewActorRepository(t)
+				fakeActorRepo.EXPECT().Get(mock.Anything, validActorURL).Return(
+					nil, errors.Wrap(sql.ErrNoRows, "unable to fetch actor from db"),
+				)
+				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+			},
+			marked: true,
+		},
+		{
+			name:      "valid actor update",
+			inputFile: "update_actor",
+			Mock: func(t *testing.T) {
+				fakeActor := &models.Actor{
+					URL:         validActorURL.String(),
+					PublicKeyID: null.StringFrom(validActorURL.String() + "#main-key"),
+				}
+				fakeActorRepo := repositorymocks.NewActorRepository(t)
+				fakeActorRepo.EXPECT().Get(mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.EXPECT().Refresh(mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+
+				fakeKeyManager := cryptomocks.NewKeyManager(t)
+				fakeKeyManager.EXPECT().
+					RefreshKey(mock.Anything, validActorURL.String()+"#main-key").
+					Return(&crypto.PublicKey{}, nil)
+				_ = dic.Register[crypto.KeyManager](fakeKeyManager)
+			},
+			marked: true,
+		},
+		{
+			name:      "delete of an object of an unknown actor",
+			inputFile: "delete_note",
+			Mock: func(t *testing.T) {
+				registerUnknownActor(t)
+			},
+			marked: true,
+		},
+		{
+			name:      "delete of a reply",
+			inputFile: "delete_note",
+			Mock: func(t *testing.T) {
+				fakeActor := &models.Actor{ID: validActorID}
+				fakeActorRepo := repositorymocks.NewActorRepository(t)
+				fakeActorRepo.EXPECT().Get(mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+
+				fakeInteractionRepo := repositorymocks.NewInteractionRepository(t)
+				fakeInteractionRepo.EXPECT().
+					DeleteReplies(mock.Anything, fakeActor, "https://another-instance.example.com/users/validactor/statuses/1234").
+					Return(nil)
+				_ = dic.Register[repository.InteractionRepository](fakeInteractionRepo)
+			},
+			marked: true,
+		},
+		{
+			name:      "delete of an unknown actor",
+			inputFile: "delete_actor",
+			Mock: func(t *testing.T) {
+				fakeActorRepo := repositorymocks.NewActorRepository(t)
+				fakeActorRepo.EXPECT().Get(mock.Anything, validActorURL).Return(
+					nil, errors.Wrap(sql.ErrNoRows, "unable to fetch actor from db"),
+				)
+				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+			},
+			marked: true,
+		},
+		{
+			name:      "valid actor delete",
+			inputFile: "delete_actor",
+			Mock: func(t *testing.T) {
+				fakeActor := &models.Actor{ID: validActorID, URL: validActorURL.String()}
+				fakeActorRepo := repositorymocks.NewActorRepository(t)
+				fakeActorRepo.EXPECT().Get(mock.Anything, validActorURL).Return(fakeActor, nil)
+				fakeActorRepo.EXPECT().Delete(mock.Anything, fakeActor).Return(nil)
+				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+			},
+			marked: true,
+		},
+		{
+			name:      "block from an actor not following",
+			inputFile: "block",
+			Mock: func(t *testing.T) {
+				fakeActor := &models.Actor{ID: validActorID}
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActorRepo := repositorymocks.NewActorRepository(t)
+				fakeActorRepo.EXPECT().Get(mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+
+				fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+				fakeFollowerRepo.EXPECT().Get(mock.Anything, validUser, fakeActor).
+					Return(nil, errors.Wrap(sql.ErrNoRows, "unable to fetch follower from database"))
+				_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+			},
+			marked: true,
+		},
+		{
+			name:      "valid block",
+			inputFile: "block",
+			Mock: func(t *testing.T) {
+				fakeActor := &models.Actor{ID: validActorID}
+				fakeFollower := &models.Follower{User: "validuser", Actor: validActorID}
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActorRepo := repositorymocks.NewActorRepository(t)
+				fakeActorRepo.EXPECT().Get(mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+
+				fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+				fakeFollowerRepo.EXPECT().Get(mock.Anything, validUser, fakeActor).Return(fakeFollower, nil)
+				fakeFollowerRepo.EXPECT().SetState(mock.Anything, fakeFollower, repository.FollowStateBlocked).
+					Return(nil)
+				_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+			},
+			marked: true,
+		},
+		{
+			name:      "move of another actor",
+			inputFile: "move_another_actor",
+			marked:    true,
+			err:       "unable to process inbound activity: unable to move another actor",
+			skipRetry: true,
+		},
+		{
+			name:      "move to an actor without alias",
+			inputFile: "move",
+			Mock: func(t *testing.T) {
+				fakeActorRepo := repositorymocks.NewActorRepository(t)
+				fakeActorRepo.EXPECT().Get(mock.Anything, validActorURL).Return(&models.Actor{ID: validActorID}, nil)
+				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+
+				fakeObjectResolver := resolvermocks.NewObjectResolver(t)
+				fakeObjectResolver.EXPECT().Resolve(mock.Anything, mock.MatchedBy(func(property pub.IdProperty) bool {
+					return property.IsIRI() && property.GetIRI().String() == movedActorURL.String()
+				})).Return(newMovedActor(t, "https://another-instance.example.com/users/someoneelse"), nil)
+				_ = dic.Register[resolver.ObjectResolver](fakeObjectResolver)
+			},
+			marked:    true,
+			err:       "unable to process inbound activity: move target does not declare the actor as an alias",
+			skipRetry: true,
+		},
+		{
+			name:      "valid move",
+			inputFile: "move",
+			Mock: func(t *testing.T) {
+				fakeActor := &models.Actor{ID: validActorID}
+				fakeMovedActor := &models.Actor{ID: "5e4d3c2b-1a09-4f8e-8d7c-6b5a49382716"}
+				fakeActorRepo := repositorymocks.NewActorRepository(t)
+				fakeActorRepo.EXPECT().Get(mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+
+				fakeObjectResolver := resolvermocks.NewObjectResolver(t)
+				fakeObjectResolver.EXPECT().Resolve(mock.Anything, mock.MatchedBy(func(property pub.IdProperty) bool {
+					return property.IsIRI() && property.GetIRI().String() == movedActorURL.String()
+				})).Return(newMovedActor(t, validActorURL.String()), nil)
+				_ = dic.Register[resolver.ObjectResolver](fakeObjectResolver)
+
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.EXPECT().Resolve(mock.Anything, movedActorURL).Return(fakeMovedActor, nil)
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+
+				fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+				fakeFollowerRepo.EXPECT().MoveFollows(mock.Anything, fakeActor, fakeMovedActor).Return(2, nil)
+				_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+			},
+			marked: true,
+		},
+		{
+			name:      "reply to a status",
+			inputFile: "create_reply",
+			Mock: func(t *testing.T) {
+				registerFakeTweet(t)
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.EXPECT().Get(mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeObjectResolver := resolvermocks.NewObjectResolver(t)
+				fakeObjectResolver.EXPECT().Resolve(mock.Anything, mock.Anything).
+					Return(newReply(t, validActorURL.String()), nil)
+				_ = dic.Register[resolver.ObjectResolver](fakeObjectResolver)
+
+				fakeActor := &models.Actor{ID: validActorID}
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.EXPECT().Resolve(mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+
+				published, _ := time.Parse(time.RFC3339, "2022-11-20T10:00:00Z")
+				fakeInteractionRepo := repositorymocks.NewInteractionRepository(t)
+				fakeInteractionRepo.EXPECT().Create(mock.Anything, repository.CreateInteractionRequest{
+					ActivityID:  "https://another-instance.example.com/users/validactor/statuses/1/activity",
+					Type:        repository.InteractionTypeReply,
+					Username:    "validuser",
+					TweetID:     "1234",
+					Actor:       fakeActor,
+					ObjectID:    "https://another-instance.example.com/users/validactor/statuses/1",
+					ObjectURL:   "https://another-instance.example.com/@validactor/1",
+					Content:     "@validuser nice one",
+					PublishedAt: published,
+				}).Return(nil)
+				_ = dic.Register[repository.InteractionRepository](fakeInteractionRepo)
+			},
+			marked: true,
+		},
+		{
+			name:      "reply written by another actor",
+			inputFile: "create_reply",
+			Mock: func(t *testing.T) {
+				fakeObjectResolver := resolvermocks.NewObjectResolver(t)
+				fakeObjectResolver.EXPECT().Resolve(mock.Anything, mock.Anything).
+					Return(newReply(t, "https://another-instance.example.com/users/mallory"), nil)
+				_ = dic.Register[resolver.ObjectResolver](fakeObjectResolver)
+			},
+			marked:    true,
+			err:       "unable to process inbound activity: unable to record a reply of another actor",
+			skipRetry: true,
+		},
+		{
+			name:      "like of an expired tweet",
+			inputFile: "like",
+			Mock: func(t *testing.T) {
+				fakeTweetRepo := twitterrepositorymocks.NewTweetRepository(t)
+				fakeTweetRepo.EXPECT().GetTweet(mock.Anything, "1234").Return(nil, nil)
+				_ = dic.Register[twitterrepository.TweetRepository](fakeTweetRepo)
+			},
+			marked: true,
+		},
+		{
+			name:      "like from a blocked domain",
+			inputFile: "like",
+			Mock: func(t *testing.T) {
+				viper.Set("blocked_domains", "another-instance.example.com")
+				t.Cleanup(func() {
+					viper.Set("blocked_domains", "")
+				})
+				registerFakeTweet(t)
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.EXPECT().Get(mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+			},
+			marked: true,
+		},
+		{
+			name:      "like of a status",
+			inputFile: "like",
+			Mock: func(t *testing.T) {
+				registerFakeTweet(t)
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.EXPECT().Get(mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActor := &models.Actor{ID: validActorID}
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.EXPECT().Resolve(mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+
+				fakeInteractionRepo := repositorymocks.NewInteractionRepository(t)
+				fakeInteractionRepo.EXPECT().Create(mock.Anything, repository.CreateInteractionRequest{
+					ActivityID: "https://another-instance.example.com/users/validactor#likes/1",
+					Type:       repository.InteractionTypeLike,
+					Username:   "validuser",
+					TweetID:    "1234",
+					Actor:      fakeActor,
+				}).Return(nil)
+				_ = dic.Register[repository.InteractionRepository](fakeInteractionRepo)
+			},
+			marked: true,
+		},
+		{
+			name:      "boost of a status",
+			inputFile: "announce",
+			Mock: func(t *testing.T) {
+				registerFakeTweet(t)
+				fakeUserRepo := repositorymocks.NewUserRepository(t)
+				fakeUserRepo.EXPECT().Get(mock.Anything, "validuser").Return(validUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActor := &models.Actor{ID: validActorID}
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.EXPECT().Resolve(mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+
+				fakeInteractionRepo := repositorymocks.NewInteractionRepository(t)
+				fakeInteractionRepo.EXPECT().Create(mock.Anything, repository.CreateInteractionRequest{
+					ActivityID: "https://another-instance.example.com/users/validactor/statuses/2/activity",
+					Type:       repository.InteractionTypeShare,
+					Username:   "validuser",
+					TweetID:    "1234",
+					Actor:      fakeActor,
+				}).Return(nil)
+				_ = dic.Register[repository.InteractionRepository](fakeInteractionRepo)
+			},
+			marked: true,
+		},
+		{
+			name:      "undo of a like",
+			inputFile: "undo_like",
+			Mock: func(t *testing.T) {
+				fakeActor := &models.Actor{ID: validActorID}
+				fakeActorRepo := repositorymocks.NewActorRepository(t)
+				fakeActorRepo.EXPECT().Get(mock.Anything, validActorURL).Return(fakeActor, nil)
+				_ = dic.Register[repository.ActorRepository](fakeActorRepo)
+
+				fakeInteractionRepo := repositorymocks.NewInteractionRepository(t)
+				fakeInteractionRepo.EXPECT().
+					DeleteByActivityID(mock.Anything, fakeActor, "https://another-instance.example.com/users/validactor#likes/1").
+					Return(true, nil)
+				_ = dic.Register[repository.InteractionRepository](fakeInteractionRepo)
+			},
+			marked: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := os.ReadFile(path.Join("testdata", "inbound", tt.inputFile+".json"))
+			require.NoError(t, err)
+			fakeActivity := &models.InboundActivity{
+				ID:      fakeInboundActivityID,
+				User:    "validuser",
+				Payload: payload,
+			}
+			if tt.processed {
+				fakeActivity.ProcessedAt = null.TimeFrom(time.Now())
+			}
+			fakeInboundActivityRepo := repositorymocks.NewInboundActivityRepository(t)
+			fakeInboundActivityRepo.EXPECT().Get(mock.Anything, fakeInboundActivityID).Return(fakeActivity, nil)
+			if tt.marked {
+				fakeInboundActivityRepo.EXPECT().
+					MarkProcessed(mock.Anything, fakeActivity, mock.MatchedBy(func(processErr error) bool {
+						return (processErr == nil) == (tt.err == "")
+					})).
+					Return(nil)
+			}
+			_ = dic.Register[repository.InboundActivityRepository](fakeInboundActivityRepo)
+
+			if tt.Mock != nil {
+				tt.Mock(t)
+			}
+			// Users accept to be bridged unless a case registered its own consent service
+			fakeConsentService := domainmocks.NewConsentService(t)
+			fakeConsentService.On("CheckUser", mock.Anything, mock.Anything).Return(nil).Maybe()
+			_ = dic.Register[domain.ConsentService](fakeConsentService)
+
+			dic_test.BuildTestContainer(t)
+			defer dic.ResetContainer()
+
+			taskPayload, err := json.Marshal(tasks.ProcessInboundActivityInput{ActivityID: fakeInboundActivityID})
+			require.NoError(t, err)
+			err = handlers.HandleProcessInboundActivity(
+				context.Background(),
+				asynq.NewTask(tasks.TypeProcessInboundActivity, taskPayload),
+			)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.err)
+			var taskErr taskerrors.TaskError
+			require.ErrorAs(t, err, &taskErr)
+			require.Equal(t, tt.skipRetry, taskErr.SkipRetry)
+		})
+	}
+}
+
+// TestHandleFollowOfOptedOutUser runs the follow of an user opted out through to the delivery of the reject.
+func TestHandleFollowOfOptedOutUser(t *testing.T) {
+	validActorURL, _ := url.Parse("https://another-instance.example.com/users/validactor")
+	validUser := &models.User{Username: "validuser"}
+	fakeActor := &models.Actor{
+		URL:   validActorURL.String(),
+		Inbox: null.StringFrom("https://another-instance.example.com/users/validactor/inbox"),
+	}
+	fakeFollower := &models.Follower{State: string(repository.FollowStatePending)}
+
+	payload, err := os.ReadFile(path.Join("testdata", "inbound", "valid_follow.json"))
+	require.NoError(t, err)
+	fakeActivity := &models.InboundActivity{
+		ID:      fakeInboundActivityID,
+		User:    "validuser",
+		Payload: payload,
+	}
+	fakeInboundActivityRepo := repositorymocks.NewInboundActivityRepository(t)
+	fakeInboundActivityRepo.EXPECT().Get(mock.Anything, fakeInboundActivityID).Return(fakeActivity, nil)
+	fakeInboundActivityRepo.EXPECT().MarkProcessed(mock.Anything, fakeActivity, mock.Anything).Return(nil)
+	_ = dic.Register[repository.InboundActivityRepository](fakeInboundActivityRepo)
+
+	fakeUserRepo := repositorymocks.NewUserRepository(t)
+	fakeUserRepo.EXPECT().Get(mock.Anything, "validuser").Return(validUser, nil)
+	_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+	fakeActorResolver := resolvermocks.NewActorResolver(t)
+	fakeActorResolver.EXPECT().Resolve(mock.Anything, validActorURL).Return(fakeActor, nil)
+	_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+
+	fakeConsentService := domainmocks.NewConsentService(t)
+	fakeConsentService.EXPECT().CheckUser(mock.Anything, "validuser").
+		Return(errors.WithStack(domain.ErrUserOptedOut))
+	_ = dic.Register[domain.ConsentService](fakeConsentService)
+
+	// The follower repository behaves like the database, the reject task reads the follow recorded by the inbox
+	fakeFollowerRepo := repositorymocks.NewFollowerRepository(t)
+	fakeFollowerRepo.EXPECT().Create(mock.Anything, validUser, fakeActor, fakeFollowID).Return(fakeFollower, nil)
+	fakeFollowerRepo.EXPECT().SetState(mock.Anything, fakeFollower, mock.Anything).
+		Run(func(_ context.Context, follower *models.Follower, state repository.FollowState) {
+			follower.State = string(state)
+		}).
+		Return(nil)
+	fakeFollowerRepo.EXPECT().Get(mock.Anything, validUser, fakeActor).Return(fakeFollower, nil)
+	fakeFollowerRepo.EXPECT().SetResponseDelivery(mock.Anything, fakeFollower, nil).Return(nil)
+	_ = dic.Register[repository.FollowerRepository](fakeFollowerRepo)
+
+	var rejectTask *asynq.Task
+	fakeWorker := clientmocks.NewBackgroundWorkerClient(t)
+	fakeWorker.EXPECT().Enqueue(mock.Anything).
+		Run(func(task *asynq.Task, _ ...asynq.Option) {
+			rejectTask = task
+		}).
+		Return(nil, nil)
+	_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
+
+	fakeActivityPubClient := activitypubclientmocks.NewActivityPubClient(t)
+	fakeActivityPubClient.EXPECT().PostInbox(
+		mock.Anything,
+		mock.MatchedBy(func(inbox *url.URL) bool { return inbox.String() == fakeActor.Inbox.String }),
+		validUser,
+		mock.Anything,
+	).Return(nil)
+	_ = dic.Register[activitypubclient.ActivityPubClient](fakeActivityPubClient)
+
+	dic_test.BuildTestContainer(t)
+	defer dic.ResetContainer()
+
+	taskPayload, err := json.Marshal(tasks.ProcessInboundActivityInput{ActivityID: fakeInboundActivityID})
+	require.NoError(t, err)
+	err = handlers.HandleProcessInboundActivity(
+		context.Background(),
+		asynq.NewTask(tasks.TypeProcessInboundActivity, taskPayload),
+	)
+	require.EqualError(t, err, "unable to process inbound activity: user refused to be bridged")
+	require.NotNil(t, rejectTask)
+	require.Equal(t, tasks.TypeRejectFollow, rejectTask.Type())
+	require.Equal(t, string(repository.FollowStateRejected), fakeFollower.State)
+
+	err = tasks.HandleRejectFollow(context.Background(), rejectTask)
+	require.NoError(t, err)
+}
@@ -0,0 +1,154 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/g8rswimmer/go-twitter/v2"
+	"github.com/go-fed/activity/streams"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/estrys/estrys/internal/activitypub"
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/logger"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/observability"
+	"github.com/estrys/estrys/internal/repository"
+	twittermodels "github.com/estrys/estrys/internal/twitter/models"
+	"github.com/estrys/estrys/internal/worker/client"
+	taskerrors "github.com/estrys/estrys/internal/worker/errors"
+	"github.com/estrys/estrys/internal/worker/queues"
+)
+
+// TweetSaver stores a tweet with its references, it is implemented by the tweet service of the domain
+// which cannot be imported from tasks.
+//
+//go:generate mockery --with-expecter --name=TweetSaver
+type TweetSaver interface {
+	SaveTweetAndReferences(context.Context, string) (*twittermodels.Tweet, error)
+}
+
+type IngestTweetInput struct {
+	TraceID string `json:"trace_id"`
+	From    string `json:"from"`
+	TweetID string `json:"tweet_id"`
+}
+
+func NewIngestTweet(
+	ctx context.Context,
+	user *models.User,
+	tweetID string,
+) (*asynq.Task, error) {
+	payload, err := json.Marshal(IngestTweetInput{
+		TraceID: observability.GetTraceIDFromContext(ctx),
+		From:    user.Username,
+		TweetID: tweetID,
+	})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	return asynq.NewTask(
+		TypeIngestTweet,
+		payload,
+		asynq.MaxRetry(5),
+		asynq.Timeout(1*time.Minute),
+		asynq.Queue(queues.QueueTweets),
+		asynq.Retention(1*time.Hour),
+	), nil
+}
+
+// HandleIngestTweet fetches and stores a tweet with its references, builds the activity once
+// and then schedules a lightweight delivery task for each follower inbox.
+func HandleIngestTweet(ctx context.Context, task *asynq.Task) error {
+	log := dic.GetService[logger.Logger]()
+	vocabService := dic.GetService[activitypub.VocabService]()
+	userRepo := dic.GetService[repository.UserRepository]()
+	tweetSaver := dic.GetService[TweetSaver]()
+	worker := dic.GetService[client.BackgroundWorkerClient]()
+
+	var input IngestTweetInput
+	if err := json.Unmarshal(task.Payload(), &input); err != nil {
+		log.WithError(err).Error("unable to deserialize task input")
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to deserialize task input"),
+		}
+	}
+
+	user, err := userRepo.Get(ctx, input.From)
+	if err != nil {
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to fetch user from database"),
+		}
+	}
+
+	// Tweets polled before the user moved are not delivered anymore
+	if user.MovedTo.Valid {
+		log.WithFields(logrus.Fields{
+			"from":  input.From,
+			"tweet": input.TweetID,
+		}).Info("user has moved, tweet ignored")
+		return nil
+	}
+
+	tweet, err := tweetSaver.SaveTweetAndReferences(ctx, input.TweetID)
+	if err != nil {
+		var errResponse *twitter.ErrorResponse
+		if errors.As(err, &errResponse) {
+			if errResponse.StatusCode == http.StatusTooManyRequests {
+				return taskerrors.TaskError{
+					Err: errors.Wrap(err, "got rate limited while fetching tweets amd references"),
+				}
+			}
+		}
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to save tweets and references"),
+		}
+	}
+
+	createNote, err := vocabService.GetCreateNoteFromTweet(user.Username, *tweet)
+	if err != nil {
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to create an create tweet activity"),
+		}
+	}
+	activity, err := streams.Serialize(createNote)
+	if err != nil {
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to serialize create tweet activity"),
+		}
+	}
+
+	actors, err := userRepo.GetFollowers(ctx, user)
+	if err != nil {
+		return taskerrors.TaskError{
+			Err: errors.Wrap(err, "unable to retrieve followers for user"),
+		}
+	}
+
+	deliveries, err := ScheduleDeliveries(worker, actors, func(sharedInbox string, actor *models.Actor) (*asynq.Task, error) {
+		if sharedInbox != "" {
+			return NewSendTweetToSharedInbox(ctx, user.Username, sharedInbox, tweet.ID, activity)
+		}
+		return NewSendTweet(ctx, user.Username, actor, tweet.ID, activity)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(logrus.Fields{
+		"from":       input.From,
+		"tweet":      tweet.ID,
+		"followers":  len(actors),
+		"deliveries": deliveries,
+	}).Info("tweet ingested")
+	return nil
+}
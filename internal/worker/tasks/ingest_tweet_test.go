@@ -0,0 +1,181 @@
+package tasks_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/g8rswimmer/go-twitter/v2"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	"github.com/estrys/estrys/internal/repository/mocks"
+	twittermodels "github.com/estrys/estrys/internal/twitter/models"
+	"github.com/estrys/estrys/internal/worker/client"
+	clientmocks "github.com/estrys/estrys/internal/worker/client/mocks"
+	taskerrors "github.com/estrys/estrys/internal/worker/errors"
+	"github.com/estrys/estrys/internal/worker/tasks"
+	tasksmocks "github.com/estrys/estrys/internal/worker/tasks/mocks"
+	dic_test "github.com/estrys/estrys/tests/dic"
+)
+
+func TestHandleIngestTweet(t *testing.T) {
+	fakeUser := &models.User{Username: "fake-username"}
+	fakeTweet := &twittermodels.Tweet{
+		ID:             "1337",
+		AuthorUsername: "fake-username",
+		Text:           "tweet content",
+	}
+	fakeFollowers := models.ActorSlice{
+		{
+			URL:         "https://another-instance.example.com/users/first",
+			SharedInbox: null.StringFrom("https://another-instance.example.com/inbox"),
+		},
+		{
+			URL:         "https://another-instance.example.com/users/second",
+			SharedInbox: null.StringFrom("https://another-instance.example.com/inbox"),
+		},
+		{URL: "https://small-instance.example.com/users/third"},
+	}
+
+	// registerTweet registers the user with its followers and the tweet to ingest.
+	registerTweet := func(t *testing.T, followers models.ActorSlice) {
+		t.Helper()
+		fakeUserRepo := mocks.NewUserRepository(t)
+		fakeUserRepo.EXPECT().Get(mock.Anything, "fake-username").Return(fakeUser, nil)
+		fakeUserRepo.EXPECT().GetFollowers(mock.Anything, fakeUser).Return(followers, nil)
+		_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+		fakeTweetSaver := tasksmocks.NewTweetSaver(t)
+		fakeTweetSaver.EXPECT().SaveTweetAndReferences(mock.Anything, "1337").Return(fakeTweet, nil)
+		_ = dic.Register[tasks.TweetSaver](fakeTweetSaver)
+	}
+
+	tests := []struct {
+		name      string
+		inputFile string
+		Mock      func(t *testing.T)
+		// deliveries are the recipients expected for each scheduled task, shared inbox or actor
+		deliveries []tasks.SendTweetInput
+		enqueueErr error
+		err        string
+		skipRetry  bool
+	}{
+		{
+			name:      "empty payload",
+			inputFile: "empty",
+			err:       "unable to deserialize task input: unexpected end of JSON input",
+			skipRetry: true,
+		},
+		{
+			name:      "user has moved",
+			inputFile: "ingest_tweet",
+			Mock: func(t *testing.T) {
+				fakeUserRepo := mocks.NewUserRepository(t)
+				fakeUserRepo.EXPECT().Get(mock.Anything, "fake-username").Return(&models.User{
+					Username: "fake-username",
+					MovedTo:  null.StringFrom("https://another-instance.example.com/users/fake-username"),
+				}, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+			},
+		},
+		{
+			name:      "rate limited while fetching the tweet",
+			inputFile: "ingest_tweet",
+			Mock: func(t *testing.T) {
+				fakeUserRepo := mocks.NewUserRepository(t)
+				fakeUserRepo.EXPECT().Get(mock.Anything, "fake-username").Return(fakeUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeTweetSaver := tasksmocks.NewTweetSaver(t)
+				fakeTweetSaver.EXPECT().SaveTweetAndReferences(mock.Anything, "1337").
+					Return(nil, &twitter.ErrorResponse{StatusCode: http.StatusTooManyRequests, Title: "Too Many Requests"})
+				_ = dic.Register[tasks.TweetSaver](fakeTweetSaver)
+			},
+			err: "got rate limited while fetching tweets amd references: twitter callout status 429 Too Many Requests:",
+		},
+		{
+			name:      "followers sharing an inbox get a single delivery",
+			inputFile: "ingest_tweet",
+			Mock: func(t *testing.T) {
+				registerTweet(t, fakeFollowers)
+			},
+			deliveries: []tasks.SendTweetInput{
+				{Inbox: "https://another-instance.example.com/inbox"},
+				{To: "https://small-instance.example.com/users/third"},
+			},
+		},
+		{
+			name:      "deliveries already scheduled by a previous attempt",
+			inputFile: "ingest_tweet",
+			Mock: func(t *testing.T) {
+				registerTweet(t, fakeFollowers)
+			},
+			deliveries: []tasks.SendTweetInput{
+				{Inbox: "https://another-instance.example.com/inbox"},
+				{To: "https://small-instance.example.com/users/third"},
+			},
+			enqueueErr: asynq.ErrTaskIDConflict,
+		},
+		{
+			name:      "unable to schedule a delivery",
+			inputFile: "ingest_tweet",
+			Mock: func(t *testing.T) {
+				registerTweet(t, fakeFollowers[2:])
+			},
+			deliveries: []tasks.SendTweetInput{
+				{To: "https://small-instance.example.com/users/third"},
+			},
+			enqueueErr: errors.New("redis is down"),
+			err:        "unable to schedule delivery task: redis is down",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.Mock != nil {
+				tt.Mock(t)
+			}
+			fakeWorker := clientmocks.NewBackgroundWorkerClient(t)
+			for _, delivery := range tt.deliveries {
+				delivery := delivery
+				fakeWorker.EXPECT().Enqueue(mock.MatchedBy(func(task *asynq.Task) bool {
+					var input tasks.SendTweetInput
+					require.NoError(t, json.Unmarshal(task.Payload(), &input))
+					return task.Type() == tasks.TypeSendTweet &&
+						input.From == "fake-username" &&
+						input.TweetID == "1337" &&
+						input.Activity["type"] == "Create" &&
+						input.Inbox == delivery.Inbox &&
+						input.To == delivery.To
+				})).Return(nil, tt.enqueueErr).Once()
+			}
+			_ = dic.Register[client.BackgroundWorkerClient](fakeWorker)
+
+			dic_test.BuildTestContainer(t)
+			defer dic.ResetContainer()
+
+			payload, err := os.ReadFile(path.Join("testdata/input", tt.inputFile+".json"))
+			require.NoError(t, err)
+			task := asynq.NewTask(tasks.TypeIngestTweet, payload)
+
+			err = tasks.HandleIngestTweet(context.TODO(), task)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.err)
+			var taskErr taskerrors.TaskError
+			require.True(t, errors.As(err, &taskErr))
+			require.Equal(t, tt.skipRetry, taskErr.SkipRetry)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/estrys/estrys/internal/twitter/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TweetSaver is an autogenerated mock type for the TweetSaver type
+type TweetSaver struct {
+	mock.Mock
+}
+
+type TweetSaver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TweetSaver) EXPECT() *TweetSaver_Expecter {
+	return &TweetSaver_Expecter{mock: &_m.Mock}
+}
+
+// SaveTweetAndReferences provides a mock function with given fields: _a0, _a1
+func (_m *TweetSaver) SaveTweetAndReferences(_a0 context.Context, _a1 string) (*models.Tweet, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *models.Tweet
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Tweet); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tweet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TweetSaver_SaveTweetAndReferences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveTweetAndReferences'
+type TweetSaver_SaveTweetAndReferences_Call struct {
+	*mock.Call
+}
+
+// SaveTweetAndReferences is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 string
+func (_e *TweetSaver_Expecter) SaveTweetAndReferences(_a0 interface{}, _a1 interface{}) *TweetSaver_SaveTweetAndReferences_Call {
+	return &TweetSaver_SaveTweetAndReferences_Call{Call: _e.mock.On("SaveTweetAndReferences", _a0, _a1)}
+}
+
+func (_c *TweetSaver_SaveTweetAndReferences_Call) Run(run func(_a0 context.Context, _a1 string)) *TweetSaver_SaveTweetAndReferences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TweetSaver_SaveTweetAndReferences_Call) Return(_a0 *models.Tweet, _a1 error) *TweetSaver_SaveTweetAndReferences_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+type mockConstructorTestingTNewTweetSaver interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTweetSaver creates a new instance of TweetSaver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTweetSaver(t mockConstructorTestingTNewTweetSaver) *TweetSaver {
+	mock := &TweetSaver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,37 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/observability"
+	"github.com/estrys/estrys/internal/worker/queues"
+)
+
+type ProcessInboundActivityInput struct {
+	TraceID    string `json:"trace_id,omitempty"`
+	ActivityID string `json:"activity_id"`
+}
+
+func NewProcessInboundActivityTask(ctx context.Context, activity *models.InboundActivity) (*asynq.Task, error) {
+	payload, err := json.Marshal(ProcessInboundActivityInput{
+		TraceID:    observability.GetTraceIDFromContext(ctx),
+		ActivityID: activity.ID,
+	})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	return asynq.NewTask(
+		TypeProcessInboundActivity,
+		payload,
+		asynq.TaskID(activity.ID),
+		asynq.MaxRetry(10),
+		asynq.Timeout(1*time.Minute),
+		asynq.Queue(queues.QueueInbox),
+		asynq.Retention(24*time.Hour),
+	), nil
+}
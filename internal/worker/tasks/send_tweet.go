@@ -3,34 +3,41 @@ package tasks
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/go-fed/activity/pub"
+	"github.com/go-fed/activity/streams"
 	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 
+	activitypubclient "github.com/estrys/estrys/internal/activitypub/client"
+	"github.com/estrys/estrys/internal/activitypub/resolver"
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/logger"
 	"github.com/estrys/estrys/internal/models"
 	"github.com/estrys/estrys/internal/observability"
+	"github.com/estrys/estrys/internal/repository"
+	taskerrors "github.com/estrys/estrys/internal/worker/errors"
 	"github.com/estrys/estrys/internal/worker/queues"
 )
 
 type SendTweetInput struct {
 	TraceID string `json:"trace_id"`
 	From    string `json:"from"`
-	To      string `json:"to"`
-	TweetID string `json:"tweet_id"`
+	// To is the actor the tweet is delivered to, it is only used when Inbox is not set
+	To string `json:"to,omitempty"`
+	// Inbox is the shared inbox the tweet is delivered to, on behalf of all followers from this server
+	Inbox    string         `json:"inbox,omitempty"`
+	TweetID  string         `json:"tweet_id"`
+	Activity map[string]any `json:"activity"`
 }
 
-func NewSendTweet(
-	ctx context.Context,
-	user *models.User,
-	actor *models.Actor,
-	tweetID string,
-) (*asynq.Task, error) {
-	payload, err := json.Marshal(SendTweetInput{
-		TraceID: observability.GetTraceIDFromContext(ctx),
-		From:    user.Username,
-		To:      actor.URL,
-		TweetID: tweetID,
-	})
+func newSendTweetTask(input SendTweetInput, recipient string) (*asynq.Task, error) {
+	payload, err := json.Marshal(input)
 	if err != nil {
 		return nil, err //nolint:wrapcheck
 	}
@@ -41,5 +48,142 @@ func NewSendTweet(
 		asynq.Timeout(10*time.Second),
 		asynq.Queue(queues.QueueTweets),
 		asynq.Retention(1*time.Hour),
+		// Avoid sending twice the same tweet to a recipient if the ingest task is retried
+		asynq.TaskID(strings.Join([]string{TypeSendTweet, input.TweetID, recipient}, ":")),
 	), nil
 }
+
+func NewSendTweet(
+	ctx context.Context,
+	from string,
+	actor *models.Actor,
+	tweetID string,
+	activity map[string]any,
+) (*asynq.Task, error) {
+	return newSendTweetTask(SendTweetInput{
+		TraceID:  observability.GetTraceIDFromContext(ctx),
+		From:     from,
+		To:       actor.URL,
+		TweetID:  tweetID,
+		Activity: activity,
+	}, actor.URL)
+}
+
+func NewSendTweetToSharedInbox(
+	ctx context.Context,
+	from string,
+	sharedInbox string,
+	tweetID string,
+	activity map[string]any,
+) (*asynq.Task, error) {
+	return newSendTweetTask(SendTweetInput{
+		TraceID:  observability.GetTraceIDFromContext(ctx),
+		From:     from,
+		Inbox:    sharedInbox,
+		TweetID:  tweetID,
+		Activity: activity,
+	}, sharedInbox)
+}
+
+// getDeliveryInbox returns the shared inbox of the task if any,
+// or the personal inbox of the recipient actor.
+func getDeliveryInbox(ctx context.Context, sharedInbox string, recipient string) (*url.URL, error) {
+	if sharedInbox != "" {
+		inbox, err := url.Parse(sharedInbox)
+		if err != nil {
+			return nil, taskerrors.TaskError{
+				SkipRetry: true,
+				Err:       errors.Wrap(err, "unable to parse shared inbox url"),
+			}
+		}
+		return inbox, nil
+	}
+
+	actorURL, err := url.Parse(recipient)
+	if err != nil {
+		return nil, taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to parse actor url"),
+		}
+	}
+	actor, err := dic.GetService[resolver.ActorResolver]().Resolve(ctx, actorURL)
+	if err != nil {
+		return nil, taskerrors.TaskError{
+			Err: errors.Wrap(err, "unable to resolve actor"),
+		}
+	}
+	inbox, err := activitypubclient.ActorInbox(actor)
+	if err != nil {
+		return nil, taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       err,
+		}
+	}
+	return inbox, nil
+}
+
+// HandleSendTweet only sign and post an already built activity to an inbox.
+func HandleSendTweet(ctx context.Context, task *asynq.Task) error {
+	log := dic.GetService[logger.Logger]()
+	userRepo := dic.GetService[repository.UserRepository]()
+	activityPubClient := dic.GetService[activitypubclient.ActivityPubClient]()
+
+	var input SendTweetInput
+	if err := json.Unmarshal(task.Payload(), &input); err != nil {
+		log.WithError(err).Error("unable to deserialize task input")
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to deserialize task input"),
+		}
+	}
+
+	vocabType, err := streams.ToType(ctx, input.Activity)
+	if err != nil {
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to decode activity"),
+		}
+	}
+	activity, isActivity := vocabType.(pub.Activity)
+	if !isActivity {
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Errorf("unsupported activity type %s", vocabType.GetTypeName()),
+		}
+	}
+
+	user, err := userRepo.Get(ctx, input.From)
+	if err != nil {
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to fetch user from database"),
+		}
+	}
+
+	inbox, err := getDeliveryInbox(ctx, input.Inbox, input.To)
+	if err != nil {
+		return err
+	}
+
+	err = activityPubClient.PostInbox(ctx, inbox, user, activity)
+	if err != nil {
+		var isNotAcceptedErr *activitypubclient.InboxNotAcceptedError
+		if errors.As(err, &isNotAcceptedErr) {
+			return taskerrors.TaskError{
+				// If the error is not on our side, let's retry
+				SkipRetry: isNotAcceptedErr.StatusCode < http.StatusInternalServerError,
+				Err:       errors.Wrap(err, "post to inbox was not accepted"),
+			}
+		}
+		return taskerrors.TaskError{
+			Err: errors.Wrap(err, "unable to send create tweet"),
+		}
+	}
+
+	log.WithFields(logrus.Fields{
+		"from":  input.From,
+		"inbox": inbox.String(),
+		"tweet": input.TweetID,
+	}).Info("tweet sent")
+	return nil
+}
@@ -0,0 +1,186 @@
+package tasks_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	activitypubclient "github.com/estrys/estrys/internal/activitypub/client"
+	activitypubclientmocks "github.com/estrys/estrys/internal/activitypub/client/mocks"
+	"github.com/estrys/estrys/internal/activitypub/resolver"
+	resolvermocks "github.com/estrys/estrys/internal/activitypub/resolver/mocks"
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	"github.com/estrys/estrys/internal/repository/mocks"
+	taskerrors "github.com/estrys/estrys/internal/worker/errors"
+	"github.com/estrys/estrys/internal/worker/tasks"
+	dic_test "github.com/estrys/estrys/tests/dic"
+)
+
+func TestHandleSendTweet(t *testing.T) {
+	fakeUser := &models.User{Username: "fake-username"}
+	fakeSharedInboxURL, _ := url.Parse("https://another-instance.example.com/inbox")
+	fakeInboxURL, _ := url.Parse("https://another-instance.example.com/users/fake-actor/inbox")
+	fakeActorURL, _ := url.Parse("https://another-instance.example.com/users/fake-actor")
+
+	tests := []struct {
+		name      string
+		inputFile string
+		Mock      func(t *testing.T)
+		err       string
+		skipRetry bool
+	}{
+		{
+			name:      "empty payload",
+			inputFile: "empty",
+			err:       "unable to deserialize task input: unexpected end of JSON input",
+			skipRetry: true,
+		},
+		{
+			name:      "not an activity",
+			inputFile: "send_tweet_not_activity",
+			err:       "unsupported activity type Note",
+			skipRetry: true,
+		},
+		{
+			name:      "unable to fetch user",
+			inputFile: "send_tweet_shared_inbox",
+			err:       "unable to fetch user from database: database is down",
+			skipRetry: true,
+			Mock: func(t *testing.T) {
+				fakeUserRepo := mocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "fake-username").
+					Return(nil, errors.New("database is down"))
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+			},
+		},
+		{
+			name:      "unable to resolve actor",
+			inputFile: "send_tweet_actor",
+			err:       "unable to resolve actor: actor does not exist",
+			Mock: func(t *testing.T) {
+				fakeUserRepo := mocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "fake-username").Return(fakeUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.On("Resolve", mock.Anything, fakeActorURL).
+					Return(nil, errors.New("actor does not exist"))
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+			},
+		},
+		{
+			name:      "remote server error is retried",
+			inputFile: "send_tweet_shared_inbox",
+			err:       "post to inbox was not accepted: error posting to inbox 503",
+			Mock: func(t *testing.T) {
+				fakeUserRepo := mocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "fake-username").Return(fakeUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActivityPubClient := activitypubclientmocks.NewActivityPubClient(t)
+				fakeActivityPubClient.On("PostInbox", mock.Anything, fakeSharedInboxURL, fakeUser, mock.Anything).
+					Return(&activitypubclient.InboxNotAcceptedError{StatusCode: http.StatusServiceUnavailable})
+				_ = dic.Register[activitypubclient.ActivityPubClient](fakeActivityPubClient)
+			},
+		},
+		{
+			name:      "rejected delivery is not retried",
+			inputFile: "send_tweet_shared_inbox",
+			err:       "post to inbox was not accepted: error posting to inbox 401",
+			skipRetry: true,
+			Mock: func(t *testing.T) {
+				fakeUserRepo := mocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "fake-username").Return(fakeUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActivityPubClient := activitypubclientmocks.NewActivityPubClient(t)
+				fakeActivityPubClient.On("PostInbox", mock.Anything, fakeSharedInboxURL, fakeUser, mock.Anything).
+					Return(&activitypubclient.InboxNotAcceptedError{StatusCode: http.StatusUnauthorized})
+				_ = dic.Register[activitypubclient.ActivityPubClient](fakeActivityPubClient)
+			},
+		},
+		{
+			name:      "ok to shared inbox",
+			inputFile: "send_tweet_shared_inbox",
+			Mock: func(t *testing.T) {
+				fakeUserRepo := mocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "fake-username").Return(fakeUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActivityPubClient := activitypubclientmocks.NewActivityPubClient(t)
+				fakeActivityPubClient.On(
+					"PostInbox",
+					mock.Anything,
+					fakeSharedInboxURL,
+					fakeUser,
+					mock.MatchedBy(func(act vocab.ActivityStreamsCreate) bool {
+						actualActivity, err := streams.Serialize(act)
+						assert.NoError(t, err)
+						assert.Equal(t, "https://example.com/users/fake-username/status/1337/activity", actualActivity["id"])
+						return true
+					})).Return(nil)
+				_ = dic.Register[activitypubclient.ActivityPubClient](fakeActivityPubClient)
+			},
+		},
+		{
+			name:      "ok to actor inbox",
+			inputFile: "send_tweet_actor",
+			Mock: func(t *testing.T) {
+				fakeUserRepo := mocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "fake-username").Return(fakeUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActorResolver := resolvermocks.NewActorResolver(t)
+				fakeActorResolver.On("Resolve", mock.Anything, fakeActorURL).
+					Return(&models.Actor{
+						URL:   fakeActorURL.String(),
+						Inbox: null.StringFrom(fakeInboxURL.String()),
+					}, nil)
+				_ = dic.Register[resolver.ActorResolver](fakeActorResolver)
+
+				fakeActivityPubClient := activitypubclientmocks.NewActivityPubClient(t)
+				fakeActivityPubClient.On("PostInbox", mock.Anything, fakeInboxURL, fakeUser, mock.Anything).
+					Return(nil)
+				_ = dic.Register[activitypubclient.ActivityPubClient](fakeActivityPubClient)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.Mock != nil {
+				tt.Mock(t)
+			}
+
+			dic_test.BuildTestContainer(t)
+			defer dic.ResetContainer()
+
+			payload, err := os.ReadFile(path.Join("testdata/input", tt.inputFile+".json"))
+			require.NoError(t, err)
+			task := asynq.NewTask(tasks.TypeSendTweet, payload)
+
+			err = tasks.HandleSendTweet(context.TODO(), task)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.err)
+			var taskErr taskerrors.TaskError
+			require.True(t, errors.As(err, &taskErr))
+			require.Equal(t, tt.skipRetry, taskErr.SkipRetry)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-fed/activity/pub"
+	"github.com/go-fed/activity/streams"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	activitypubclient "github.com/estrys/estrys/internal/activitypub/client"
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/logger"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/observability"
+	"github.com/estrys/estrys/internal/repository"
+	taskerrors "github.com/estrys/estrys/internal/worker/errors"
+	"github.com/estrys/estrys/internal/worker/queues"
+)
+
+// SendUserActivityInput describes the delivery to a follower of an activity about the user itself,
+// such as its move or its deletion.
+type SendUserActivityInput struct {
+	TraceID string `json:"trace_id"`
+	From    string `json:"from"`
+	// To is the follower the activity is delivered to, it is only used when Inbox is not set
+	To string `json:"to,omitempty"`
+	// Inbox is the shared inbox the activity is delivered to, on behalf of all followers from this server
+	Inbox    string         `json:"inbox,omitempty"`
+	Activity map[string]any `json:"activity"`
+}
+
+// userActivityTypes are the activity types delivered by each task type.
+var userActivityTypes = map[string]string{
+	TypeSendMove:   "Move",
+	TypeSendDelete: "Delete",
+}
+
+func newSendUserActivityTask(taskType string, input SendUserActivityInput, recipient string) (*asynq.Task, error) {
+	if _, supported := userActivityTypes[taskType]; !supported {
+		return nil, errors.Errorf("unsupported task type %s", taskType)
+	}
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	activityID, _ := input.Activity["id"].(string)
+	return asynq.NewTask(
+		taskType,
+		payload,
+		asynq.MaxRetry(10),
+		asynq.Timeout(30*time.Second),
+		asynq.Queue(queues.QueueFollows),
+		asynq.Retention(24*time.Hour),
+		// Scheduling the same activity again does not send it twice to a recipient
+		asynq.TaskID(strings.Join([]string{taskType, activityID, recipient}, ":")),
+	), nil
+}
+
+// NewSendUserActivity creates a task of the given type, TypeSendMove or TypeSendDelete, delivering the activity
+// to the inbox of the actor.
+func NewSendUserActivity(
+	ctx context.Context,
+	taskType string,
+	from string,
+	actor *models.Actor,
+	activity map[string]any,
+) (*asynq.Task, error) {
+	return newSendUserActivityTask(taskType, SendUserActivityInput{
+		TraceID:  observability.GetTraceIDFromContext(ctx),
+		From:     from,
+		To:       actor.URL,
+		Activity: activity,
+	}, actor.URL)
+}
+
+func NewSendUserActivityToSharedInbox(
+	ctx context.Context,
+	taskType string,
+	from string,
+	sharedInbox string,
+	activity map[string]any,
+) (*asynq.Task, error) {
+	return newSendUserActivityTask(taskType, SendUserActivityInput{
+		TraceID:  observability.GetTraceIDFromContext(ctx),
+		From:     from,
+		Inbox:    sharedInbox,
+		Activity: activity,
+	}, sharedInbox)
+}
+
+// HandleSendUserActivity posts an activity about the user, such as its move or its deletion,
+// to the inbox of its followers.
+func HandleSendUserActivity(ctx context.Context, task *asynq.Task) error {
+	log := dic.GetService[logger.Logger]()
+	userRepo := dic.GetService[repository.UserRepository]()
+	activityPubClient := dic.GetService[activitypubclient.ActivityPubClient]()
+
+	var input SendUserActivityInput
+	if err := json.Unmarshal(task.Payload(), &input); err != nil {
+		log.WithError(err).Error("unable to deserialize task input")
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to deserialize task input"),
+		}
+	}
+
+	vocabType, err := streams.ToType(ctx, input.Activity)
+	if err != nil {
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to decode activity"),
+		}
+	}
+	activity, isActivity := vocabType.(pub.Activity)
+	if !isActivity || vocabType.GetTypeName() != userActivityTypes[task.Type()] {
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Errorf("unsupported activity type %s", vocabType.GetTypeName()),
+		}
+	}
+
+	user, err := userRepo.Get(ctx, input.From)
+	if err != nil {
+		return taskerrors.TaskError{
+			SkipRetry: true,
+			Err:       errors.Wrap(err, "unable to fetch user from database"),
+		}
+	}
+
+	inbox, err := getDeliveryInbox(ctx, input.Inbox, input.To)
+	if err != nil {
+		return err
+	}
+
+	err = activityPubClient.PostInbox(ctx, inbox, user, activity)
+	if err != nil {
+		var isNotAcceptedErr *activitypubclient.InboxNotAcceptedError
+		if errors.As(err, &isNotAcceptedErr) {
+			return taskerrors.TaskError{
+				// If the error is not on our side, let's retry
+				SkipRetry: isNotAcceptedErr.StatusCode < http.StatusInternalServerError,
+				Err:       errors.Wrap(err, "post to inbox was not accepted"),
+			}
+		}
+		return taskerrors.TaskError{
+			Err: errors.Wrapf(err, "unable to send %s", strings.ToLower(vocabType.GetTypeName())),
+		}
+	}
+
+	log.WithFields(logrus.Fields{
+		"from":     input.From,
+		"inbox":    inbox.String(),
+		"activity": vocabType.GetTypeName(),
+	}).Info("user activity sent")
+	return nil
+}
@@ -0,0 +1,140 @@
+package tasks_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/hibiken/asynq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	activitypubclient "github.com/estrys/estrys/internal/activitypub/client"
+	activitypubclientmocks "github.com/estrys/estrys/internal/activitypub/client/mocks"
+	"github.com/estrys/estrys/internal/dic"
+	"github.com/estrys/estrys/internal/models"
+	"github.com/estrys/estrys/internal/repository"
+	"github.com/estrys/estrys/internal/repository/mocks"
+	taskerrors "github.com/estrys/estrys/internal/worker/errors"
+	"github.com/estrys/estrys/internal/worker/tasks"
+	dic_test "github.com/estrys/estrys/tests/dic"
+)
+
+func TestHandleSendUserActivity(t *testing.T) {
+	fakeUser := &models.User{Username: "fake-username"}
+	fakeSharedInboxURL, _ := url.Parse("https://another-instance.example.com/inbox")
+
+	tests := []struct {
+		name      string
+		taskType  string
+		inputFile string
+		Mock      func(t *testing.T)
+		err       string
+		skipRetry bool
+	}{
+		{
+			name:      "not a move",
+			taskType:  tasks.TypeSendMove,
+			inputFile: "send_tweet_shared_inbox",
+			err:       "unsupported activity type Create",
+			skipRetry: true,
+		},
+		{
+			name:      "move for a delete task",
+			taskType:  tasks.TypeSendDelete,
+			inputFile: "send_move_shared_inbox",
+			err:       "unsupported activity type Move",
+			skipRetry: true,
+		},
+		{
+			name:      "rejected delivery is not retried",
+			taskType:  tasks.TypeSendMove,
+			inputFile: "send_move_shared_inbox",
+			err:       "post to inbox was not accepted: error posting to inbox 401",
+			skipRetry: true,
+			Mock: func(t *testing.T) {
+				fakeUserRepo := mocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "fake-username").Return(fakeUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActivityPubClient := activitypubclientmocks.NewActivityPubClient(t)
+				fakeActivityPubClient.On("PostInbox", mock.Anything, fakeSharedInboxURL, fakeUser, mock.Anything).
+					Return(&activitypubclient.InboxNotAcceptedError{StatusCode: http.StatusUnauthorized})
+				_ = dic.Register[activitypubclient.ActivityPubClient](fakeActivityPubClient)
+			},
+		},
+		{
+			name:      "ok to shared inbox",
+			taskType:  tasks.TypeSendMove,
+			inputFile: "send_move_shared_inbox",
+			Mock: func(t *testing.T) {
+				fakeUserRepo := mocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "fake-username").Return(fakeUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActivityPubClient := activitypubclientmocks.NewActivityPubClient(t)
+				fakeActivityPubClient.On(
+					"PostInbox",
+					mock.Anything,
+					fakeSharedInboxURL,
+					fakeUser,
+					mock.MatchedBy(func(move vocab.ActivityStreamsMove) bool {
+						return move.GetActivityStreamsTarget().Begin().GetIRI().String() ==
+							"https://another-instance.example.com/users/fake-username"
+					})).Return(nil)
+				_ = dic.Register[activitypubclient.ActivityPubClient](fakeActivityPubClient)
+			},
+		},
+		{
+			name:      "delete ok to shared inbox",
+			taskType:  tasks.TypeSendDelete,
+			inputFile: "send_delete_shared_inbox",
+			Mock: func(t *testing.T) {
+				fakeUserRepo := mocks.NewUserRepository(t)
+				fakeUserRepo.On("Get", mock.Anything, "fake-username").Return(fakeUser, nil)
+				_ = dic.Register[repository.UserRepository](fakeUserRepo)
+
+				fakeActivityPubClient := activitypubclientmocks.NewActivityPubClient(t)
+				fakeActivityPubClient.On(
+					"PostInbox",
+					mock.Anything,
+					fakeSharedInboxURL,
+					fakeUser,
+					mock.MatchedBy(func(deleteActivity vocab.ActivityStreamsDelete) bool {
+						return deleteActivity.GetActivityStreamsObject().Begin().GetIRI().String() ==
+							"https://example.com/users/fake-username"
+					})).Return(nil)
+				_ = dic.Register[activitypubclient.ActivityPubClient](fakeActivityPubClient)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.Mock != nil {
+				tt.Mock(t)
+			}
+
+			dic_test.BuildTestContainer(t)
+			defer dic.ResetContainer()
+
+			payload, err := os.ReadFile(path.Join("testdata/input", tt.inputFile+".json"))
+			require.NoError(t, err)
+			task := asynq.NewTask(tt.taskType, payload)
+
+			err = tasks.HandleSendUserActivity(context.TODO(), task)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.err)
+			var taskErr taskerrors.TaskError
+			require.True(t, errors.As(err, &taskErr))
+			require.Equal(t, tt.skipRetry, taskErr.SkipRetry)
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package tasks
 
 const (
-	TypeAcceptFollow = "inbox:follow:accept"
-	TypeRejectFollow = "inbox:follow:reject"
-	TypeSendTweet    = "tweet:send"
+	TypeAcceptFollow           = "inbox:follow:accept"
+	TypeRejectFollow           = "inbox:follow:reject"
+	TypeProcessInboundActivity = "inbox:activity:process"
+	TypeIngestTweet            = "tweet:ingest"
+	TypeSendTweet              = "tweet:send"
+	TypeSendMove               = "user:move:send"
+	TypeSendDelete             = "user:delete:send"
 )
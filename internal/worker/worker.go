@@ -24,6 +24,7 @@ func StartBroker(ctx context.Context) error {
 			LogLevel:    asynq.InfoLevel,
 			Logger:      log,
 			Queues: map[string]int{
+				queues.QueueInbox:   1,
 				queues.QueueFollows: 1,
 				queues.QueueTweets:  1,
 			},
@@ -36,7 +37,14 @@ func StartBroker(ctx context.Context) error {
 
 	mux.HandleFunc(tasks.TypeAcceptFollow, ErrorHandler(TracingHandler(tasks.HandleAcceptFollow)))
 	mux.HandleFunc(tasks.TypeRejectFollow, ErrorHandler(TracingHandler(tasks.HandleRejectFollow)))
-	mux.HandleFunc(tasks.TypeSendTweet, ErrorHandler(TracingHandler(handlers.HandleSendTweet)))
+	mux.HandleFunc(
+		tasks.TypeProcessInboundActivity,
+		ErrorHandler(TracingHandler(handlers.HandleProcessInboundActivity)),
+	)
+	mux.HandleFunc(tasks.TypeIngestTweet, ErrorHandler(TracingHandler(tasks.HandleIngestTweet)))
+	mux.HandleFunc(tasks.TypeSendTweet, ErrorHandler(TracingHandler(tasks.HandleSendTweet)))
+	mux.HandleFunc(tasks.TypeSendMove, ErrorHandler(TracingHandler(tasks.HandleSendUserActivity)))
+	mux.HandleFunc(tasks.TypeSendDelete, ErrorHandler(TracingHandler(tasks.HandleSendUserActivity)))
 
 	log.Info("Starting worker")
 
@@ -0,0 +1,6 @@
+ALTER TABLE actors
+    DROP COLUMN inbox,
+    DROP COLUMN shared_inbox,
+    DROP COLUMN preferred_username,
+    DROP COLUMN public_key_id,
+    DROP COLUMN fetched_at
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE actors
+    ADD COLUMN inbox VARCHAR(2048),
+    ADD COLUMN shared_inbox VARCHAR(2048),
+    ADD COLUMN preferred_username VARCHAR(255),
+    ADD COLUMN public_key_id VARCHAR(2048),
+    ADD COLUMN fetched_at TIMESTAMP
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE instance_keys
\ No newline at end of file
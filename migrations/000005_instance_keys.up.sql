@@ -0,0 +1,5 @@
+CREATE TABLE instance_keys (
+    name VARCHAR(64) PRIMARY KEY,
+    private_key bytea NOT NULL,
+    created_at TIMESTAMP NOT NULL
+)
\ No newline at end of file
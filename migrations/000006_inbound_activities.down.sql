@@ -0,0 +1 @@
+DROP TABLE inbound_activities
\ No newline at end of file
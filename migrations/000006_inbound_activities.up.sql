@@ -0,0 +1,9 @@
+CREATE TABLE inbound_activities (
+    id uuid PRIMARY KEY,
+    activity_id VARCHAR(2048) NOT NULL UNIQUE,
+    "user" VARCHAR(15) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    payload jsonb NOT NULL,
+    received_at TIMESTAMP NOT NULL,
+    processed_at TIMESTAMP,
+    error TEXT
+)
\ No newline at end of file
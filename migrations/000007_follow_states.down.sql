@@ -0,0 +1,7 @@
+ALTER TABLE followers
+    DROP COLUMN follow_id,
+    DROP COLUMN state,
+    DROP COLUMN created_at,
+    DROP COLUMN updated_at,
+    DROP COLUMN response_delivered_at,
+    DROP COLUMN response_error
\ No newline at end of file
@@ -0,0 +1,8 @@
+ALTER TABLE followers
+    ADD COLUMN follow_id VARCHAR(2048) UNIQUE,
+    ADD COLUMN state VARCHAR(16) NOT NULL DEFAULT 'accepted'
+        CHECK (state IN ('pending', 'accepted', 'rejected', 'undone')),
+    ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    ADD COLUMN response_delivered_at TIMESTAMP,
+    ADD COLUMN response_error TEXT
\ No newline at end of file
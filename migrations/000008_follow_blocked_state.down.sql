@@ -0,0 +1,6 @@
+UPDATE followers SET state = 'undone' WHERE state = 'blocked';
+
+ALTER TABLE followers
+    DROP CONSTRAINT followers_state_check,
+    ADD CONSTRAINT followers_state_check
+        CHECK (state IN ('pending', 'accepted', 'rejected', 'undone'))
\ No newline at end of file
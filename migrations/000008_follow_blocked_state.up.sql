@@ -0,0 +1,4 @@
+ALTER TABLE followers
+    DROP CONSTRAINT followers_state_check,
+    ADD CONSTRAINT followers_state_check
+        CHECK (state IN ('pending', 'accepted', 'rejected', 'undone', 'blocked'))
\ No newline at end of file
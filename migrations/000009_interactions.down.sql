@@ -0,0 +1 @@
+DROP TABLE interactions
\ No newline at end of file
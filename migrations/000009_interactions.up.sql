@@ -0,0 +1,16 @@
+CREATE TABLE interactions (
+    id uuid PRIMARY KEY,
+    activity_id VARCHAR(2048) NOT NULL UNIQUE,
+    type VARCHAR(16) NOT NULL CHECK (type IN ('reply', 'like', 'share')),
+    "user" VARCHAR(15) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    tweet_id VARCHAR(32) NOT NULL,
+    actor uuid NOT NULL REFERENCES actors(id) ON DELETE CASCADE,
+    object_id VARCHAR(2048),
+    object_url VARCHAR(2048),
+    content TEXT,
+    published_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX interactions_tweet_id_type_idx ON interactions (tweet_id, type);
+CREATE INDEX interactions_object_id_idx ON interactions (object_id)
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE users
+    DROP COLUMN moved_to,
+    DROP COLUMN moved_at
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN moved_to VARCHAR(2048),
+    ADD COLUMN moved_at TIMESTAMP
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE fediverse_links
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE fediverse_links (
+    id uuid PRIMARY KEY,
+    "user" VARCHAR(15) NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    handle VARCHAR(512) NOT NULL,
+    actor_url VARCHAR(2048) NOT NULL,
+    verified_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE ("user", handle)
+);
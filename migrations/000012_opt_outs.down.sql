@@ -0,0 +1 @@
+DROP TABLE opt_outs
\ No newline at end of file
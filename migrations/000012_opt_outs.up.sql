@@ -0,0 +1,5 @@
+CREATE TABLE opt_outs (
+    username VARCHAR(15) PRIMARY KEY,
+    reason VARCHAR(16) NOT NULL CHECK (reason IN ('admin', 'marker')),
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
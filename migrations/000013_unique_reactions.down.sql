@@ -0,0 +1 @@
+DROP INDEX interactions_actor_tweet_id_type_idx
\ No newline at end of file
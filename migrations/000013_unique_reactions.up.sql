@@ -0,0 +1,11 @@
+-- Only the latest like or boost of an actor on a tweet is kept
+DELETE FROM interactions duplicate
+USING interactions latest
+WHERE duplicate.type IN ('like', 'share')
+  AND duplicate.type = latest.type
+  AND duplicate.actor = latest.actor
+  AND duplicate.tweet_id = latest.tweet_id
+  AND (duplicate.created_at, duplicate.id) < (latest.created_at, latest.id);
+
+CREATE UNIQUE INDEX interactions_actor_tweet_id_type_idx ON interactions (actor, tweet_id, type)
+WHERE type IN ('like', 'share')
\ No newline at end of file
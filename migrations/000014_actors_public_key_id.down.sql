@@ -0,0 +1 @@
+DROP INDEX actors_public_key_id_idx
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX actors_public_key_id_idx ON actors (public_key_id)
\ No newline at end of file
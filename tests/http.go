@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
@@ -57,6 +58,22 @@ func (r RequestContext) Value() any {
 	return r.Context
 }
 
+type RequestQuery struct {
+	Query url.Values
+}
+
+func (r RequestQuery) Value() any {
+	return r.Query
+}
+
+type RequestHeaders struct {
+	Headers http.Header
+}
+
+func (r RequestHeaders) Value() any {
+	return r.Headers
+}
+
 type HTTPTestCase struct {
 	Name           string
 	GoldenFile     string
@@ -119,6 +136,8 @@ func createFakeRequest(opts []RequestOption) *http.Request {
 	var params requestParams
 	var body io.ReadCloser
 	var ctx context.Context
+	requestURL := &url.URL{}
+	headers := http.Header{}
 	for _, opt := range opts {
 		if paramOp, ok := opt.(RequestParams); ok {
 			params = paramOp.Value().(requestParams)
@@ -134,12 +153,20 @@ func createFakeRequest(opts []RequestOption) *http.Request {
 		if bodyOp, ok := opt.(RequestBody); ok {
 			body = bodyOp.Value().(io.ReadCloser)
 		}
+		if queryOp, ok := opt.(RequestQuery); ok {
+			requestURL.RawQuery = queryOp.Value().(url.Values).Encode()
+		}
+		if headersOp, ok := opt.(RequestHeaders); ok {
+			headers = headersOp.Value().(http.Header)
+		}
 		if ctxOp, ok := opt.(RequestContext); ok {
 			ctx = ctxOp.Value().(context.Context)
 		}
 	}
 	req := &http.Request{
-		Body: body,
+		Body:   body,
+		URL:    requestURL,
+		Header: headers,
 	}
 	req = req.WithContext(context.Background())
 	if ctx != nil {